@@ -0,0 +1,513 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"html"
+	"image"
+	"slices"
+	"strings"
+
+	"goki.dev/ki/v2/bitflag"
+	"goki.dev/ki/v2/ki"
+	"goki.dev/ki/v2/kit"
+)
+
+// AccessRoles are the roles that nodes play for accessibility, i.e., for
+// assistive technologies such as screen readers, based on the WAI-ARIA roles.
+type AccessRoles int32
+
+const (
+	// AccessRoleNone means that the node is not itself exposed for
+	// accessibility, but its children are (e.g., most layouts).
+	AccessRoleNone AccessRoles = iota
+
+	// AccessRoleWindow is a top-level window
+	AccessRoleWindow
+
+	// AccessRoleDialog is a dialog window or popup
+	AccessRoleDialog
+
+	// AccessRoleGroup is a set of related elements, e.g., a named frame
+	AccessRoleGroup
+
+	// AccessRoleLabel is static text
+	AccessRoleLabel
+
+	// AccessRoleImage is an image or icon
+	AccessRoleImage
+
+	// AccessRoleButton is a push button
+	AccessRoleButton
+
+	// AccessRoleCheckBox is a checkable button
+	AccessRoleCheckBox
+
+	// AccessRoleTextField is an editable text field
+	AccessRoleTextField
+
+	// AccessRoleTextArea is a multi-line text editor
+	AccessRoleTextArea
+
+	// AccessRoleSlider is a slider for selecting a value within a range
+	AccessRoleSlider
+
+	// AccessRoleScrollBar is a scroll bar
+	AccessRoleScrollBar
+
+	// AccessRoleProgressBar is a progress indicator
+	AccessRoleProgressBar
+
+	// AccessRoleSpinBox is a numeric value entry with increment / decrement buttons
+	AccessRoleSpinBox
+
+	// AccessRoleComboBox is a drop-down list of items to choose from
+	AccessRoleComboBox
+
+	// AccessRoleMenuBar is a menu bar
+	AccessRoleMenuBar
+
+	// AccessRoleMenu is a popup menu
+	AccessRoleMenu
+
+	// AccessRoleMenuItem is an item within a menu or menu bar
+	AccessRoleMenuItem
+
+	// AccessRoleToolBar is a tool bar
+	AccessRoleToolBar
+
+	// AccessRoleTabList is the set of tabs in a tab view
+	AccessRoleTabList
+
+	// AccessRoleTab is one tab in a tab list
+	AccessRoleTab
+
+	// AccessRoleSplitter is a splitter for resizing panels
+	AccessRoleSplitter
+
+	// AccessRoleTree is a tree of items
+	AccessRoleTree
+
+	// AccessRoleTreeItem is an item in a tree
+	AccessRoleTreeItem
+
+	// AccessRoleList is a list of items
+	AccessRoleList
+
+	// AccessRoleTable is a table of rows and columns
+	AccessRoleTable
+
+	// AccessRoleRow is a row in a table
+	AccessRoleRow
+
+	// AccessRoleCell is a cell in a table row
+	AccessRoleCell
+
+	// AccessRoleColumnHeader is a header cell for a table column
+	AccessRoleColumnHeader
+
+	// AccessRoleTooltip is a tooltip popup
+	AccessRoleTooltip
+
+	AccessRolesN
+)
+
+var TypeAccessRoles = kit.Enums.AddEnumAltLower(AccessRolesN, kit.NotBitFlag, nil, "AccessRole")
+
+func (ev AccessRoles) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *AccessRoles) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// AccessStates are the accessibility state flags of a node, stored as
+// bit flags in AccessInfo.States
+type AccessStates int32
+
+const (
+	// AccessFocused means the node has keyboard focus
+	AccessFocused AccessStates = iota
+
+	// AccessSelected means the node is selected
+	AccessSelected
+
+	// AccessDisabled means the node is disabled and cannot be used
+	AccessDisabled
+
+	// AccessCheckable means the node can be checked
+	AccessCheckable
+
+	// AccessChecked means the node is checked
+	AccessChecked
+
+	// AccessExpandable means the node can be expanded to show more items
+	AccessExpandable
+
+	// AccessExpanded means the node is currently expanded
+	AccessExpanded
+
+	// AccessReadOnly means the value of the node cannot be edited
+	AccessReadOnly
+
+	// AccessProtected means the value of the node is hidden, e.g., a password
+	AccessProtected
+
+	AccessStatesN
+)
+
+// TypeAccessStates is not registered as a bit flag type, so that single
+// AccessStates values, as in AccessNode.States, are saved by name
+var TypeAccessStates = kit.Enums.AddEnumAltLower(AccessStatesN, kit.NotBitFlag, nil, "Access")
+
+func (ev AccessStates) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *AccessStates) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// AccessInfo is the accessibility information for a node
+type AccessInfo struct {
+
+	// role of the node
+	Role AccessRoles `desc:"role of the node"`
+
+	// accessible name of the node, e.g., the text of a button or label
+	Name string `desc:"accessible name of the node, e.g., the text of a button or label"`
+
+	// longer description of the node, e.g., from its tooltip
+	Desc string `desc:"longer description of the node, e.g., from its tooltip"`
+
+	// current value of the node, e.g., the text of a text field or the value of a slider
+	Value string `desc:"current value of the node, e.g., the text of a text field or the value of a slider"`
+
+	// state of the node, as bit flags of AccessStates
+	States int64 `desc:"state of the node, as bit flags of AccessStates"`
+}
+
+// SetState sets given state flag(s) according to given on value
+func (ai *AccessInfo) SetState(on bool, states ...AccessStates) {
+	for _, st := range states {
+		bitflag.SetState(&ai.States, on, int(st))
+	}
+}
+
+// HasState returns true if given state flag is set
+func (ai *AccessInfo) HasState(st AccessStates) bool {
+	return bitflag.Has(ai.States, int(st))
+}
+
+// Accessible is the interface for nodes that expose accessibility
+// information.  WidgetBase provides a default implementation
+// (see AccessInfoBase), which is then specialized by each widget type.
+type Accessible interface {
+	// AccessInfo returns the current accessibility info for this node
+	AccessInfo() AccessInfo
+}
+
+// AccessInfo returns the accessibility info for this widget: the default
+// has AccessRoleNone, so only its children are exposed.
+func (wb *WidgetBase) AccessInfo() AccessInfo {
+	return wb.AccessInfoBase(AccessRoleNone, "")
+}
+
+// AccessInfoBase returns the standard accessibility info for given role and
+// name, with the description from the Tooltip and the focused, selected and
+// disabled states from the node flags.  The "access-name" and "access-role"
+// properties, if set, override the name and role -- e.g., to name a button
+// that only has an icon.
+func (wb *WidgetBase) AccessInfoBase(role AccessRoles, name string) AccessInfo {
+	ai := AccessInfo{Role: role, Name: name, Desc: AccessText(wb.Tooltip)}
+	if nm, ok := wb.PropInherit("access-name", ki.NoInherit, ki.NoTypeProps); ok {
+		ai.Name = kit.ToString(nm)
+	}
+	if rl, ok := wb.PropInherit("access-role", ki.NoInherit, ki.NoTypeProps); ok {
+		var r AccessRoles
+		switch rv := rl.(type) {
+		case AccessRoles:
+			r = rv
+		default:
+			r.FromString(kit.ToString(rv))
+		}
+		ai.Role = r
+	}
+	ai.SetState(wb.HasFocus(), AccessFocused)
+	ai.SetState(wb.IsSelected(), AccessSelected)
+	ai.SetState(wb.IsDisabled(), AccessDisabled)
+	return ai
+}
+
+// AccessText returns plain text suitable for accessibility from given
+// text, which can contain HTML formatting, by removing any tags,
+// unescaping entities, and collapsing whitespace.
+func AccessText(txt string) string {
+	if txt == "" {
+		return ""
+	}
+	var sb strings.Builder
+	intag := false
+	for _, r := range txt {
+		switch {
+		case r == '<':
+			intag = true
+		case r == '>' && intag:
+			intag = false
+			sb.WriteRune(' ')
+		case !intag:
+			sb.WriteRune(r)
+		}
+	}
+	return strings.Join(strings.Fields(html.UnescapeString(sb.String())), " ")
+}
+
+/////////////////////////////////////////////////////////////////////
+//   Accessibility tree
+
+// AccessNode is a node in the accessibility tree of a window
+type AccessNode struct {
+
+	// unique path of the corresponding ki node within the window, which identifies this node
+	Path string `desc:"unique path of the corresponding ki node within the window, which identifies this node"`
+
+	// role of the node
+	Role AccessRoles `desc:"role of the node"`
+
+	// accessible name of the node
+	Name string `json:",omitempty" desc:"accessible name of the node"`
+
+	// longer description of the node
+	Desc string `json:",omitempty" desc:"longer description of the node"`
+
+	// current value of the node
+	Value string `json:",omitempty" desc:"current value of the node"`
+
+	// states of the node that are set
+	States []AccessStates `json:",omitempty" desc:"states of the node that are set"`
+
+	// bounding box of the node in window coordinates, in raw pixels
+	BBox image.Rectangle `desc:"bounding box of the node in window coordinates, in raw pixels"`
+
+	// children of this node in the accessibility tree
+	Kids []*AccessNode `json:",omitempty" desc:"children of this node in the accessibility tree"`
+}
+
+// NewAccessNode returns a new AccessNode for given ki node and info
+func NewAccessNode(k ki.Ki, ai AccessInfo) *AccessNode {
+	an := &AccessNode{Path: k.Path(), Role: ai.Role, Name: ai.Name, Desc: ai.Desc, Value: ai.Value}
+	for st := AccessFocused; st < AccessStatesN; st++ {
+		if ai.HasState(st) {
+			an.States = append(an.States, st)
+		}
+	}
+	if _, nb := KiToNode2D(k); nb != nil {
+		nb.BBoxMu.RLock()
+		an.BBox = nb.WinBBox
+		nb.BBoxMu.RUnlock()
+	}
+	return an
+}
+
+// HasState returns true if given state is set on this node
+func (an *AccessNode) HasState(st AccessStates) bool {
+	for _, s := range an.States {
+		if s == st {
+			return true
+		}
+	}
+	return false
+}
+
+// Equal returns true if this node has the same info as the other,
+// ignoring children
+func (an *AccessNode) Equal(on *AccessNode) bool {
+	if an.Path != on.Path || an.Role != on.Role || an.Name != on.Name || an.Desc != on.Desc || an.Value != on.Value || an.BBox != on.BBox || len(an.States) != len(on.States) {
+		return false
+	}
+	for i, st := range an.States {
+		if on.States[i] != st {
+			return false
+		}
+	}
+	return true
+}
+
+// FuncDown calls given function on this node and all of its descendants,
+// depth-first, stopping the traversal of any node's children for which
+// the function returns false.
+func (an *AccessNode) FuncDown(fun func(an *AccessNode) bool) {
+	if !fun(an) {
+		return
+	}
+	for _, kn := range an.Kids {
+		kn.FuncDown(fun)
+	}
+}
+
+// Find returns the first node in this tree (including this one) with given
+// role and name, or nil if not found.  An empty name matches any name.
+func (an *AccessNode) Find(role AccessRoles, name string) *AccessNode {
+	var fn *AccessNode
+	an.FuncDown(func(n *AccessNode) bool {
+		if fn != nil {
+			return false
+		}
+		if n.Role == role && (name == "" || n.Name == name) {
+			fn = n
+			return false
+		}
+		return true
+	})
+	return fn
+}
+
+// AccessChildren returns the accessibility nodes for the children of given
+// node: visible children that are Accessible with a role are returned
+// directly, with their own children, and the children of other nodes
+// (e.g., layouts) are included in their place.
+func AccessChildren(k ki.Ki) []*AccessNode {
+	var kids []*AccessNode
+	for _, kid := range *k.Children() {
+		kids = append(kids, AccessNodes(kid)...)
+	}
+	return kids
+}
+
+// AccessNodes returns the accessibility nodes for given node: if it is
+// visible and Accessible with a role, a single node with its children,
+// otherwise the nodes for its children (see AccessChildren).
+func AccessNodes(k ki.Ki) []*AccessNode {
+	if k == nil || k.This() == nil || k.IsDeleted() || k.IsDestroyed() {
+		return nil
+	}
+	if _, nb := KiToNode2D(k); nb != nil && nb.IsInvisible() {
+		return nil
+	}
+	if ac, ok := k.(Accessible); ok {
+		ai := ac.AccessInfo()
+		if ai.Role != AccessRoleNone {
+			an := NewAccessNode(k, ai)
+			if acn, ok := k.(AccessContainer); ok {
+				an.Kids = acn.AccessKids()
+			} else {
+				an.Kids = AccessChildren(k)
+			}
+			return []*AccessNode{an}
+		}
+	}
+	return AccessChildren(k)
+}
+
+// AccessContainer is an optional interface for Accessible nodes that
+// generate their own accessibility children, instead of using
+// AccessChildren -- e.g., to organize the cells of a table into rows.
+type AccessContainer interface {
+	// AccessKids returns the accessibility children of this node
+	AccessKids() []*AccessNode
+}
+
+// AccessTree returns the full accessibility tree for this window,
+// including any popups.
+func (w *Window) AccessTree() *AccessNode {
+	an := NewAccessNode(w.This(), AccessInfo{Role: AccessRoleWindow, Name: w.Title})
+	if w.Viewport != nil {
+		an.BBox = w.Viewport.Pixels.Bounds()
+		an.Kids = AccessChildren(w.Viewport)
+	}
+	w.PopMu.RLock()
+	pops := append([]ki.Ki{}, w.PopupStack...)
+	if w.Popup != nil {
+		pops = append(pops, w.Popup)
+	}
+	w.PopMu.RUnlock()
+	for _, pop := range pops {
+		gii, _ := KiToNode2D(pop)
+		if gii == nil {
+			continue
+		}
+		vp := gii.AsViewport2D()
+		if vp == nil {
+			continue
+		}
+		role := AccessRoleDialog
+		switch {
+		case vp.IsMenu() || vp.IsCompleter() || vp.IsCorrector():
+			role = AccessRoleMenu
+		case vp.IsTooltip():
+			role = AccessRoleTooltip
+		}
+		pn := NewAccessNode(vp.This(), AccessInfo{Role: role, Name: vp.Nm})
+		pn.Kids = AccessChildren(vp.This())
+		an.Kids = append(an.Kids, pn)
+	}
+	return an
+}
+
+/////////////////////////////////////////////////////////////////////
+//   Bridge
+
+// AccessBridge is the interface for delivering accessibility information
+// to assistive technologies.  When TheAccessBridge is set, each window
+// tracks its accessibility tree as events are processed, and calls the
+// bridge methods when it changes.  See AccessJSON for a simple
+// implementation that records everything in memory, as JSON.
+type AccessBridge interface {
+	// AccessTreeChanged is called with the full accessibility tree of given
+	// window when it is first generated, and when its structure changes
+	// (nodes are added or removed).
+	AccessTreeChanged(w *Window, tree *AccessNode)
+
+	// AccessNodeChanged is called when the info for a node changes,
+	// without any change in the tree structure.
+	AccessNodeChanged(w *Window, an *AccessNode)
+
+	// AccessFocusChanged is called when the focus node changes,
+	// with nil if no node has focus.
+	AccessFocusChanged(w *Window, an *AccessNode)
+
+	// AccessWindowClosed is called when given window is closed
+	AccessWindowClosed(w *Window)
+}
+
+// TheAccessBridge is the current accessibility bridge -- if nil (the default),
+// accessibility trees are only generated on demand via Window.AccessTree.
+var TheAccessBridge AccessBridge
+
+// accessState is the accessibility state tracked for a window
+type accessState struct {
+	nodes map[string]*AccessNode // last nodes by path
+	order []string               // last node paths in tree order
+	focus string                 // last focus path
+}
+
+// AccessUpdate updates the accessibility tree for the window and notifies
+// TheAccessBridge of any changes since the last update.  Called by the
+// event loop after each event, when TheAccessBridge is set.
+func (w *Window) AccessUpdate() {
+	ab := TheAccessBridge
+	if ab == nil || w.IsClosed() {
+		return
+	}
+	tree := w.AccessTree()
+	nodes := make(map[string]*AccessNode)
+	var order []string
+	focus := ""
+	var focusNode *AccessNode
+	tree.FuncDown(func(an *AccessNode) bool {
+		nodes[an.Path] = an
+		order = append(order, an.Path)
+		if focusNode == nil && an.HasState(AccessFocused) {
+			focus = an.Path
+			focusNode = an
+		}
+		return true
+	})
+	last := w.accessState
+	w.accessState = &accessState{nodes: nodes, order: order, focus: focus}
+	if last == nil || !slices.Equal(order, last.order) {
+		ab.AccessTreeChanged(w, tree)
+	} else {
+		for _, p := range order {
+			if !nodes[p].Equal(last.nodes[p]) {
+				ab.AccessNodeChanged(w, nodes[p])
+			}
+		}
+	}
+	if last == nil || focus != last.focus {
+		ab.AccessFocusChanged(w, focusNode)
+	}
+}
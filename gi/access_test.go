@@ -0,0 +1,210 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+// accessTestWin returns a window without an OSWin, with a viewport holding:
+//
+//	Frame form (access-role group)
+//	  Label title
+//	  Button ok (disabled)
+//	  CheckBox check (checked)
+//	  TextField name
+//	  TextField pass (NoEcho)
+func accessTestWin() *Window {
+	w := &Window{}
+	w.InitName(w, "win")
+	w.Title = "Access"
+	vp := NewViewport2D(200, 200)
+	vp.InitName(vp, "vp")
+	vp.Win = w
+	w.Viewport = vp
+	fr := AddNewFrame(vp, "form", LayoutVert)
+	fr.SetProp("access-role", AccessRoleGroup)
+	fr.SetProp("access-name", "Form")
+	AddNewLabel(fr, "title", "Hello <b>World</b> &amp; all")
+	ok := AddNewButton(fr, "ok")
+	ok.Text = "OK"
+	ok.Tooltip = "<i>accept</i> the form"
+	ok.SetDisabledState(true)
+	cb := AddNewCheckBox(fr, "check")
+	cb.Text = "Remember"
+	cb.SetCheckable(true)
+	cb.SetChecked(true)
+	tf := AddNewTextField(fr, "name")
+	tf.Placeholder = "Name"
+	tf.EditTxt = []rune("Ann")
+	pw := AddNewTextField(fr, "pass")
+	pw.Placeholder = "Password"
+	pw.NoEcho = true
+	pw.EditTxt = []rune("secret")
+	return w
+}
+
+// accessTestStates returns the states of given node, for comparison
+func accessTestStates(an *AccessNode) []AccessStates {
+	if an == nil {
+		return nil
+	}
+	return an.States
+}
+
+func TestAccessTree(t *testing.T) {
+	defer useNoIconMgr()()
+	w := accessTestWin()
+	tree := w.AccessTree()
+	if tree.Role != AccessRoleWindow || tree.Name != "Access" {
+		t.Errorf("root = %v %q, want window Access", tree.Role, tree.Name)
+	}
+	if len(tree.Kids) != 1 || tree.Kids[0].Role != AccessRoleGroup || tree.Kids[0].Name != "Form" {
+		t.Fatalf("root kids = %v, want only the Form group", tree.Kids)
+	}
+	tests := []struct {
+		role   AccessRoles
+		name   string
+		desc   string
+		value  string
+		states []AccessStates
+	}{
+		{AccessRoleLabel, "Hello World & all", "", "", nil},
+		{AccessRoleButton, "OK", "accept the form", "", []AccessStates{AccessDisabled}},
+		{AccessRoleCheckBox, "Remember", "", "", []AccessStates{AccessCheckable, AccessChecked}},
+		{AccessRoleTextField, "Name", "", "Ann", nil},
+		{AccessRoleTextField, "Password", "", "", []AccessStates{AccessProtected}},
+	}
+	kids := tree.Kids[0].Kids
+	if len(kids) != len(tests) {
+		t.Fatalf("form has %d kids, want %d", len(kids), len(tests))
+	}
+	for i, tt := range tests {
+		an := kids[i]
+		if an.Role != tt.role || an.Name != tt.name || an.Desc != tt.desc || an.Value != tt.value {
+			t.Errorf("kid %d = %v %q %q %q, want %v %q %q %q", i, an.Role, an.Name, an.Desc, an.Value, tt.role, tt.name, tt.desc, tt.value)
+		}
+		if len(an.States) != len(tt.states) {
+			t.Errorf("kid %d %q: states %v, want %v", i, an.Name, an.States, tt.states)
+			continue
+		}
+		for _, st := range tt.states {
+			if !an.HasState(st) {
+				t.Errorf("kid %d %q: states %v, want %v", i, an.Name, an.States, tt.states)
+			}
+		}
+	}
+	if an := tree.Find(AccessRoleTextField, "Password"); an == nil || an.Path != "/vp/form/pass" {
+		t.Errorf("Find password field = %v", an)
+	}
+	if an := tree.Find(AccessRoleSlider, ""); an != nil {
+		t.Errorf("Find slider = %v, want nil", an)
+	}
+
+	// invisible nodes are left out
+	w.Viewport.ChildByName("form", 0).ChildByName("pass", 0).(Node2D).AsNode2D().SetInvisible()
+	if an := w.AccessTree().Find(AccessRoleTextField, "Password"); an != nil {
+		t.Errorf("invisible field in tree: %v", an)
+	}
+}
+
+func TestAccessJSON(t *testing.T) {
+	defer useNoIconMgr()()
+	defer func(ab AccessBridge) { TheAccessBridge = ab }(TheAccessBridge)
+	aj := NewAccessJSON()
+	TheAccessBridge = aj
+	w := accessTestWin()
+	form := w.Viewport.ChildByName("form", 0)
+	cb := form.ChildByName("check", 0).(*CheckBox)
+	tf := form.ChildByName("name", 0).(*TextField)
+
+	events := func() []string {
+		aj.Mu.Lock()
+		defer aj.Mu.Unlock()
+		var evs []string
+		for _, ev := range aj.Events {
+			nm := ""
+			if ev.Node != nil {
+				nm = ev.Node.Path
+			}
+			evs = append(evs, ev.Type+" "+nm)
+		}
+		aj.Events = nil
+		return evs
+	}
+	check := func(step string, want ...string) {
+		t.Helper()
+		evs := events()
+		if len(evs) != len(want) {
+			t.Errorf("%s: events %q, want %q", step, evs, want)
+			return
+		}
+		for i := range evs {
+			if evs[i] != want[i] {
+				t.Errorf("%s: events %q, want %q", step, evs, want)
+				return
+			}
+		}
+	}
+
+	w.AccessUpdate()
+	check("first update", "TreeChanged /win", "FocusChanged ")
+	w.AccessUpdate()
+	check("no change")
+
+	cb.SetChecked(false)
+	w.AccessUpdate()
+	check("uncheck", "NodeChanged /vp/form/check")
+	if an := aj.Tree("win").Find(AccessRoleCheckBox, "Remember"); an == nil || an.HasState(AccessChecked) {
+		t.Errorf("tree not updated for uncheck: states %v", accessTestStates(an))
+	}
+
+	tf.SetFocusState(true)
+	w.AccessUpdate()
+	check("focus", "NodeChanged /vp/form/name", "FocusChanged /vp/form/name")
+	tf.EditTxt = []rune("Bob")
+	w.AccessUpdate()
+	check("edit", "NodeChanged /vp/form/name")
+	tf.SetFocusState(false)
+	w.AccessUpdate()
+	check("unfocus", "NodeChanged /vp/form/name", "FocusChanged ")
+
+	AddNewLabel(form, "note", "Note")
+	w.AccessUpdate()
+	check("add label", "TreeChanged /win")
+	if an := aj.Tree("win").Find(AccessRoleLabel, "Note"); an == nil {
+		t.Errorf("added label not in tree")
+	}
+
+	var buf bytes.Buffer
+	if err := aj.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var rj AccessJSON
+	if err := json.Unmarshal(buf.Bytes(), &rj); err != nil {
+		t.Fatal(err)
+	}
+	an := rj.Trees["win"].Find(AccessRoleTextField, "Name")
+	if an == nil || an.Value != "Bob" {
+		t.Errorf("JSON round trip: name field = %v, want value Bob", an)
+	}
+	if an := rj.Trees["win"].Find(AccessRoleButton, "OK"); an == nil || !an.HasState(AccessDisabled) {
+		t.Errorf("JSON round trip: OK button states = %v, want disabled", accessTestStates(an))
+	}
+
+	aj.AccessWindowClosed(w)
+	check("close", "WindowClosed ")
+	if aj.Tree("win") != nil {
+		t.Errorf("tree kept after window closed")
+	}
+
+	aj.MaxEvents = 2
+	for i := 0; i < 3; i++ {
+		aj.AccessFocusChanged(w, nil)
+	}
+	check("max events", "FocusChanged ", "FocusChanged ")
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// AccessEvent is one accessibility notification recorded by AccessJSON
+type AccessEvent struct {
+
+	// time of the notification
+	Time time.Time `desc:"time of the notification"`
+
+	// name of the window
+	Win string `desc:"name of the window"`
+
+	// type of notification: TreeChanged, NodeChanged, FocusChanged or WindowClosed
+	Type string `desc:"type of notification: TreeChanged, NodeChanged, FocusChanged or WindowClosed"`
+
+	// the node for the notification: the full tree for TreeChanged, and nil for WindowClosed or when nothing has focus
+	Node *AccessNode `json:",omitempty" desc:"the node for the notification: the full tree for TreeChanged, and nil for WindowClosed or when nothing has focus"`
+}
+
+// AccessJSON is an AccessBridge that keeps the latest accessibility tree
+// of each window, and a log of all notifications, in memory, which can be
+// written out as JSON.  It is useful for testing and debugging, and as a
+// starting point for real bridges.  Install it with:
+//
+//	aj := gi.NewAccessJSON()
+//	gi.TheAccessBridge = aj
+type AccessJSON struct {
+
+	// latest accessibility tree for each open window, by window name
+	Trees map[string]*AccessNode `desc:"latest accessibility tree for each open window, by window name"`
+
+	// log of all notifications received, in order
+	Events []*AccessEvent `desc:"log of all notifications received, in order"`
+
+	// if > 0, the maximum number of Events to keep -- older ones are dropped
+	MaxEvents int `desc:"if > 0, the maximum number of Events to keep -- older ones are dropped"`
+
+	// mutex protecting the trees and events
+	Mu sync.Mutex `json:"-" xml:"-" view:"-" desc:"mutex protecting the trees and events"`
+}
+
+// NewAccessJSON returns a new AccessJSON bridge
+func NewAccessJSON() *AccessJSON {
+	return &AccessJSON{Trees: make(map[string]*AccessNode)}
+}
+
+// addEvent adds a new event to the log -- must be called under Mu
+func (aj *AccessJSON) addEvent(w *Window, typ string, an *AccessNode) {
+	aj.Events = append(aj.Events, &AccessEvent{Time: time.Now(), Win: w.Nm, Type: typ, Node: an})
+	if aj.MaxEvents > 0 && len(aj.Events) > aj.MaxEvents {
+		aj.Events = aj.Events[len(aj.Events)-aj.MaxEvents:]
+	}
+}
+
+func (aj *AccessJSON) AccessTreeChanged(w *Window, tree *AccessNode) {
+	aj.Mu.Lock()
+	defer aj.Mu.Unlock()
+	aj.Trees[w.Nm] = tree
+	aj.addEvent(w, "TreeChanged", tree)
+}
+
+func (aj *AccessJSON) AccessNodeChanged(w *Window, an *AccessNode) {
+	aj.Mu.Lock()
+	defer aj.Mu.Unlock()
+	aj.addEvent(w, "NodeChanged", an)
+	// the tree is regenerated on each update, so just swap in the new node
+	if tree := aj.Trees[w.Nm]; tree != nil {
+		tree.FuncDown(func(n *AccessNode) bool {
+			for i, kn := range n.Kids {
+				if kn.Path == an.Path {
+					n.Kids[i] = an
+					return false
+				}
+			}
+			return true
+		})
+	}
+}
+
+func (aj *AccessJSON) AccessFocusChanged(w *Window, an *AccessNode) {
+	aj.Mu.Lock()
+	defer aj.Mu.Unlock()
+	aj.addEvent(w, "FocusChanged", an)
+}
+
+func (aj *AccessJSON) AccessWindowClosed(w *Window) {
+	aj.Mu.Lock()
+	defer aj.Mu.Unlock()
+	delete(aj.Trees, w.Nm)
+	aj.addEvent(w, "WindowClosed", nil)
+}
+
+// Tree returns the latest accessibility tree for window of given name,
+// or nil if none
+func (aj *AccessJSON) Tree(winName string) *AccessNode {
+	aj.Mu.Lock()
+	defer aj.Mu.Unlock()
+	return aj.Trees[winName]
+}
+
+// Reset clears the event log
+func (aj *AccessJSON) Reset() {
+	aj.Mu.Lock()
+	defer aj.Mu.Unlock()
+	aj.Events = nil
+}
+
+// WriteJSON writes the trees and events as indented JSON to given writer
+func (aj *AccessJSON) WriteJSON(w io.Writer) error {
+	aj.Mu.Lock()
+	defer aj.Mu.Unlock()
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(aj)
+}
+
+// SaveJSON saves the trees and events as indented JSON to given file
+func (aj *AccessJSON) SaveJSON(filename FileName) error {
+	f, err := os.Create(string(filename))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return aj.WriteJSON(f)
+}
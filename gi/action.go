@@ -202,6 +202,16 @@ func (ac *Action) Trigger() {
 	ac.ActionSig.Emit(ac.This(), 0, ac.Data)
 }
 
+// AccessInfo returns the accessibility info for the action, which is a
+// MenuItem within menus and menu bars, and otherwise a button.
+func (ac *Action) AccessInfo() AccessInfo {
+	ai := ac.ButtonBase.AccessInfo()
+	if ac.Type == ActionMenu || ac.Type == ActionMenuBar {
+		ai.Role = AccessRoleMenuItem
+	}
+	return ai
+}
+
 // ButtonRelease triggers action signal
 func (ac *Action) ButtonRelease() {
 	if ac.IsDisabled() {
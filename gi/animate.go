@@ -0,0 +1,213 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"sync"
+	"time"
+
+	"goki.dev/gi/v2/gist"
+	"goki.dev/gi/v2/oswin"
+)
+
+// WinAnimFPS is the frame rate, in frames per second, of the window
+// animation clock that drives style transitions and animations
+var WinAnimFPS = 60
+
+// StateTransitionDuration is the duration of the default style transitions
+// of the standard widgets (e.g., Button and TextField) between states, e.g.,
+// on hover or focus.  It is 0 by default, so state changes are immediate,
+// and set it to a positive duration, e.g., 150 * time.Millisecond, to turn
+// them on.  It must be set before the widgets are styled.  Transitions set
+// on any widget through styles or CSS work regardless of this setting.
+var StateTransitionDuration time.Duration
+
+// WidgetOpacity enables the opacity style property for widgets, which then
+// fades all of their colors (text, background, border and box shadows) by
+// it, including as set by transitions and animations.  It is off by default,
+// as it changes the rendering of any widgets that have an opacity set.
+var WidgetOpacity = false
+
+// winAnimTick is the Data of a CustomEvent sent by the window animation
+// clock, to step all of the animating widgets in the event loop.
+type winAnimTick struct{}
+
+// winAnim is the animation clock state of a window: the clock only runs
+// while there are widgets animating, registered with AddAnimation.
+type winAnim struct {
+	mu      sync.Mutex
+	nodes   map[Node2D]bool // value is true if the animation affects layout
+	ticking bool            // clock goroutine is running
+	pending bool            // tick event has been sent but not yet processed
+}
+
+// AddAnimation registers given node as animating, so that it is restyled
+// and re-rendered on the next tick of the window animation clock, starting
+// the clock if it is not already running.  Each node must register again on
+// each step to keep animating -- WidgetBase.AnimateStyle does this while any
+// style transitions or animations are running.  If layout is true, the
+// animation affects the layout, which is then also redone.
+func (w *Window) AddAnimation(ni Node2D, layout bool) {
+	wa := &w.anim
+	wa.mu.Lock()
+	defer wa.mu.Unlock()
+	if wa.nodes == nil {
+		wa.nodes = make(map[Node2D]bool)
+	}
+	wa.nodes[ni] = wa.nodes[ni] || layout
+	if !wa.ticking {
+		wa.ticking = true
+		go w.animClock()
+	}
+}
+
+// IsAnimating returns true if any nodes in the window are animating
+func (w *Window) IsAnimating() bool {
+	wa := &w.anim
+	wa.mu.Lock()
+	defer wa.mu.Unlock()
+	return len(wa.nodes) > 0
+}
+
+// animClock runs the animation clock, sending tick events to the
+// window until there is nothing left to animate
+func (w *Window) animClock() {
+	tick := time.NewTicker(time.Second / time.Duration(WinAnimFPS))
+	defer tick.Stop()
+	wa := &w.anim
+	for range tick.C {
+		wa.mu.Lock()
+		if len(wa.nodes) == 0 || w.IsClosed() || w.OSWin == nil || w.OSWin.IsClosed() {
+			wa.nodes = nil
+			wa.ticking = false
+			wa.pending = false
+			wa.mu.Unlock()
+			return
+		}
+		send := !wa.pending // don't pile up ticks if rendering is slow
+		wa.pending = true
+		wa.mu.Unlock()
+		if send {
+			oswin.SendCustomEvent(w.OSWin, winAnimTick{})
+		}
+	}
+}
+
+// animStep restyles and re-renders all the nodes that are animating,
+// in response to an animation clock tick event
+func (w *Window) animStep() {
+	wa := &w.anim
+	wa.mu.Lock()
+	nodes := wa.nodes
+	wa.nodes = nil
+	wa.pending = false
+	wa.mu.Unlock()
+	for ni, layout := range nodes {
+		if ni.This() == nil || ni.IsDeleted() || ni.IsDestroyed() {
+			continue
+		}
+		ni.AsGiNode().SetNeedsStyle()
+		if layout {
+			ni.AsGiNode().SetFullReRender()
+		}
+		ni.UpdateSig()
+	}
+}
+
+// styleAnim is the state of the style transitions and animations of a widget
+type styleAnim struct {
+	target    gist.Style             // the style without any animation, from the last styling pass
+	cur       gist.Style             // the style as last animated, before opacity
+	trans     map[string]*styleTrans // running transitions, by property
+	anim      gist.Animation         // running animation
+	animStart time.Time              // start time of the running animation
+}
+
+// styleTrans is a running transition of one style property
+type styleTrans struct {
+	from  gist.Style // style that the transition started from
+	start time.Time  // time the transition started
+	tr    gist.Transition
+}
+
+// AnimateStyle applies the style transitions and animations of this widget
+// to its Style, which has just been set to its target values by the style
+// funcs and CSS.  When the target value of a property with a Transition
+// changes, it is interpolated from its current value, and the Animation
+// keyframes are interpolated over time.  While anything is running, the
+// widget is registered with the window animation clock (see AddAnimation),
+// which restyles it on the next frame.  Finally, the Font.Opacity is applied
+// if WidgetOpacity is on.  It is called in Style2DWidget after ApplyCSS.
+func (wb *WidgetBase) AnimateStyle() {
+	st := &wb.Style
+	sa := wb.styleAnim
+	if sa == nil && len(st.Transitions) == 0 && st.Animation.Name == "" {
+		if WidgetOpacity {
+			st.ApplyOpacity()
+		}
+		return
+	}
+	now := time.Now()
+	if sa == nil { // first styling pass: nothing to transition from
+		sa = &styleAnim{target: *st, cur: *st}
+		wb.styleAnim = sa
+	}
+	target := *st
+	for _, tr := range st.Transitions {
+		if tr.Duration <= 0 {
+			continue
+		}
+		for _, prop := range tr.Props() {
+			if target.PropEqual(prop, &sa.target) {
+				continue
+			}
+			if sa.trans == nil {
+				sa.trans = make(map[string]*styleTrans)
+			}
+			sa.trans[prop] = &styleTrans{from: sa.cur, start: now, tr: tr}
+		}
+	}
+	animating, layout := false, false
+	for prop, str := range sa.trans {
+		el := now.Sub(str.start) - str.tr.Delay
+		if el >= str.tr.Duration {
+			delete(sa.trans, prop)
+			continue
+		}
+		t := float32(0)
+		if el > 0 {
+			t = str.tr.Easing.Apply(float32(el) / float32(str.tr.Duration))
+		}
+		st.Lerp(prop, &str.from, &target, t)
+		animating = true
+		layout = layout || gist.TransitionAffectsLayout(prop)
+	}
+	if an := target.Animation; an.Name != "" {
+		if an != sa.anim {
+			sa.anim = an
+			sa.animStart = now
+		}
+		if kfs, ok := gist.KeyframesByName(an.Name); ok {
+			if pos, running := an.Progress(now.Sub(sa.animStart)); running {
+				base := *st
+				kfs.Apply(st, &base, pos, an.Easing, wb.Viewport)
+				animating = true
+				layout = layout || kfs.AffectsLayout()
+			}
+		}
+	} else {
+		sa.anim = gist.Animation{}
+	}
+	sa.target = target
+	sa.cur = *st
+	if WidgetOpacity {
+		st.ApplyOpacity()
+	}
+	if animating {
+		if w := wb.ParentWindow(); w != nil {
+			w.AddAnimation(wb.This().(Node2D), layout)
+		}
+	}
+}
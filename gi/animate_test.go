@@ -0,0 +1,81 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"image/color"
+	"testing"
+	"time"
+)
+
+func TestAnimateStyleOpacity(t *testing.T) {
+	defer func(wo bool) { WidgetOpacity = wo }(WidgetOpacity)
+	red := color.RGBA{200, 0, 0, 255}
+	tests := []struct {
+		opacity bool
+		want    color.RGBA
+	}{
+		{false, red},
+		{true, color.RGBA{100, 0, 0, 127}},
+	}
+	for _, tt := range tests {
+		WidgetOpacity = tt.opacity
+		wb := &WidgetBase{}
+		wb.InitName(wb, "wb")
+		wb.Style.Defaults()
+		wb.Style.Color = red
+		wb.Style.Font.Opacity = 0.5
+		wb.AnimateStyle()
+		if wb.Style.Color != tt.want {
+			t.Errorf("WidgetOpacity %v: color = %v, want %v", tt.opacity, wb.Style.Color, tt.want)
+		}
+	}
+}
+
+func TestStateTransitions(t *testing.T) {
+	defer func(d time.Duration) { StateTransitionDuration = d }(StateTransitionDuration)
+	tests := []struct {
+		dur  time.Duration
+		ntrs int
+	}{
+		{0, 0},
+		{100 * time.Millisecond, 2},
+	}
+	for _, tt := range tests {
+		StateTransitionDuration = tt.dur
+		bt := &Button{}
+		bt.InitName(bt, "bt")
+		tf := &TextField{}
+		tf.InitName(tf, "tf")
+		for _, wb := range []*WidgetBase{bt.AsWidget(), tf.AsWidget()} {
+			wb.Style.Defaults()
+			wb.RunStyleFuncs()
+			if n := len(wb.Style.Transitions); n != tt.ntrs {
+				t.Errorf("%v with StateTransitionDuration %v: %d transitions, want %d", wb.Nm, tt.dur, n, tt.ntrs)
+			}
+			for _, tr := range wb.Style.Transitions {
+				if tr.Duration != tt.dur {
+					t.Errorf("%v: transition %v duration %v, want %v", wb.Nm, tr.Property, tr.Duration, tt.dur)
+				}
+			}
+		}
+	}
+}
+
+func TestAnimateStyleNoTransitions(t *testing.T) {
+	// without transitions or animations, no animation state is kept
+	wb := &WidgetBase{}
+	wb.InitName(wb, "wb")
+	wb.Style.Defaults()
+	wb.Style.BackgroundColor.SetColor(color.RGBA{0, 0, 200, 255})
+	st := wb.Style
+	wb.AnimateStyle()
+	if wb.styleAnim != nil {
+		t.Errorf("animation state created without transitions")
+	}
+	if wb.Style.BackgroundColor.Color != st.BackgroundColor.Color {
+		t.Errorf("background color changed: %v, want %v", wb.Style.BackgroundColor.Color, st.BackgroundColor.Color)
+	}
+}
@@ -52,6 +52,11 @@ func (mb *MenuBar) CopyFieldsFrom(frm any) {
 	mb.MainMenu = fr.MainMenu
 }
 
+// AccessInfo returns the accessibility info for the menu bar
+func (mb *MenuBar) AccessInfo() AccessInfo {
+	return mb.AccessInfoBase(AccessRoleMenuBar, "")
+}
+
 var MenuBarProps = ki.Props{
 	ki.EnumTypeFlag: TypeNodeFlags,
 }
@@ -338,6 +343,11 @@ func (tb *ToolBar) CopyFieldsFrom(frm any) {
 	tb.Layout.CopyFieldsFrom(&fr.Layout)
 }
 
+// AccessInfo returns the accessibility info for the toolbar
+func (tb *ToolBar) AccessInfo() AccessInfo {
+	return tb.AccessInfoBase(AccessRoleToolBar, "")
+}
+
 var ToolBarProps = ki.Props{
 	ki.EnumTypeFlag: TypeNodeFlags,
 }
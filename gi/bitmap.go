@@ -72,6 +72,16 @@ func (bm *Bitmap) CopyFieldsFrom(frm any) {
 	bm.Filename = fr.Filename
 }
 
+// AccessInfo returns the accessibility info for the bitmap, named by the
+// base name of its file, if any
+func (bm *Bitmap) AccessInfo() AccessInfo {
+	nm := ""
+	if bm.Filename != "" {
+		nm = filepath.Base(string(bm.Filename))
+	}
+	return bm.AccessInfoBase(AccessRoleImage, nm)
+}
+
 // SetSize sets size of the bitmap image.
 // This does not resize any existing image, just makes a new image
 // if the size is different
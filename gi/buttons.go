@@ -369,7 +369,7 @@ func (bb *ButtonBase) KeyChordEvent() {
 		if KeyEventTrace {
 			fmt.Printf("Button KeyChordEvent: %v\n", bbb.Path())
 		}
-		kf := KeyFun(kt.Chord())
+		kf := KeyFunFor(bbb.This(), kt.Chord())
 		if kf == KeyFunEnter || kt.Rune == ' ' {
 			if !(kt.Rune == ' ' && bbb.Viewport.IsCompleter()) {
 				kt.SetProcessed()
@@ -440,6 +440,25 @@ func (bb *ButtonBase) AsButtonBase() *ButtonBase {
 	return bb
 }
 
+// AccessInfo returns the accessibility info for the button: a CheckBox role
+// if it is checkable, and otherwise a Button, named by its text, or its icon
+// if it has no text.
+func (bb *ButtonBase) AccessInfo() AccessInfo {
+	nm := AccessText(bb.Text)
+	if nm == "" {
+		nm = string(bb.Icon)
+	}
+	role := AccessRoleButton
+	if bb.IsCheckable() {
+		role = AccessRoleCheckBox
+	}
+	ai := bb.AccessInfoBase(role, nm)
+	ai.SetState(bb.IsCheckable(), AccessCheckable)
+	ai.SetState(bb.IsChecked(), AccessChecked)
+	ai.SetState(bb.HasMenu(), AccessExpandable)
+	return ai
+}
+
 func (bb *ButtonBase) Init2D() {
 	bb.Init2DWidget()
 	// bb.State = ButtonActive
@@ -638,6 +657,12 @@ func (bt *Button) OnInit() {
 			s.Padding.Right.SetEm(1 * Prefs.DensityMul())
 		}
 		s.Text.Align = gist.AlignCenter
+		if StateTransitionDuration > 0 {
+			s.Transitions = []gist.Transition{
+				{Property: "box-shadow", Duration: StateTransitionDuration},
+				{Property: "background-color", Duration: StateTransitionDuration},
+			}
+		}
 		switch bt.Type {
 		case ButtonFilled:
 			s.BackgroundColor.SetSolid(ColorScheme.Primary)
@@ -327,6 +327,17 @@ func (cb *ComboBox) TextField() (*TextField, bool) {
 	return tff.(*TextField), true
 }
 
+// AccessInfo returns the accessibility info for the combobox, with the
+// current item as the value
+func (cb *ComboBox) AccessInfo() AccessInfo {
+	ai := cb.AccessInfoBase(AccessRoleComboBox, "")
+	if cb.CurVal != nil {
+		ai.Value = ToLabel(cb.CurVal)
+	}
+	ai.SetState(true, AccessExpandable)
+	return ai
+}
+
 // MakeItems makes sure the Items list is made, and if not, or reset is true,
 // creates one with the given capacity
 func (cb *ComboBox) MakeItems(reset bool, capacity int) {
@@ -604,7 +615,7 @@ func (cb *ComboBox) KeyChordEvent() {
 		if KeyEventTrace {
 			fmt.Printf("ComboBox KeyChordEvent: %v\n", cbb.Path())
 		}
-		kf := KeyFun(kt.Chord())
+		kf := KeyFunFor(cbb.This(), kt.Chord())
 		switch {
 		case kf == KeyFunMoveUp:
 			kt.SetProcessed()
@@ -6,18 +6,21 @@ package gi
 
 import (
 	"log"
+	"strings"
 
 	"github.com/aymerick/douceur/css"
 	"github.com/aymerick/douceur/parser"
 
 	// 	"github.com/benbjohnson/css" // this was too low-level
+	"goki.dev/gi/v2/gist"
 	"goki.dev/ki/v2/ki"
 	"goki.dev/ki/v2/kit"
 )
 
 // StyleSheet is a Node2D node that contains a stylesheet -- property values
 // contained in this sheet can be transformed into ki.Props and set in CSS
-// field of appropriate node
+// field of appropriate node, where they apply to all nodes below it that
+// match their selectors (see CSSSelector)
 type StyleSheet struct {
 	Node2DBase
 	Sheet *css.Stylesheet
@@ -41,7 +44,8 @@ func (ss *StyleSheet) CopyFieldsFrom(frm any) {
 }
 
 // ParseString parses the string into a StyleSheet of rules, which can then be
-// used for extracting properties
+// used for extracting properties.  Any @keyframes rules are added to the
+// global gist.StyleKeyframes, for use in animations.
 func (ss *StyleSheet) ParseString(str string) error {
 	pss, err := parser.Parse(str)
 	if err != nil {
@@ -49,11 +53,42 @@ func (ss *StyleSheet) ParseString(str string) error {
 		return err
 	}
 	ss.Sheet = pss
+	ss.AddKeyframes()
 	return nil
 }
 
+// AddKeyframes adds the @keyframes rules in this style sheet to the
+// global gist.StyleKeyframes, for use in animations
+func (ss *StyleSheet) AddKeyframes() {
+	if ss.Sheet == nil {
+		return
+	}
+	for _, r := range ss.Sheet.Rules {
+		if r.Kind != css.AtRule || r.Name != "@keyframes" || r.Prelude == "" {
+			continue
+		}
+		var kfs gist.Keyframes
+		for _, kr := range r.Rules {
+			props := make(ki.Props, len(kr.Declarations))
+			for _, de := range kr.Declarations {
+				props[de.Property] = de.Value
+			}
+			for _, sel := range kr.Selectors {
+				pos, err := gist.ParseKeyframePos(sel)
+				if err != nil {
+					log.Printf("gi.StyleSheet AddKeyframes: %v\n", err)
+					continue
+				}
+				kfs = append(kfs, gist.Keyframe{Pos: pos, Props: props})
+			}
+		}
+		gist.AddKeyframes(strings.TrimSpace(r.Prelude), kfs)
+	}
+}
+
 // CSSProps returns the properties for each of the rules in this style sheet,
-// suitable for setting the CSS value of a node -- returns nil if empty sheet
+// suitable for setting the CSS value of a node -- returns nil if empty sheet.
+// The source order of each rule is recorded in its CSSOrderKey property.
 func (ss *StyleSheet) CSSProps() ki.Props {
 	if ss.Sheet == nil {
 		return nil
@@ -71,13 +106,35 @@ func (ss *StyleSheet) CSSProps() ki.Props {
 		if nd == 0 {
 			continue
 		}
+		order := NextCSSOrder()
 		for _, sel := range r.Selectors {
-			sp := make(ki.Props, nd)
+			// later rules for the same selector add to and override earlier
+			// ones, and move it to their place in the cascade
+			sp, has := pr[sel].(ki.Props)
+			if !has {
+				sp = make(ki.Props, nd+1)
+				pr[sel] = sp
+			}
 			for _, de := range r.Declarations {
 				sp[de.Property] = de.Value
 			}
-			pr[sel] = sp
+			sp[CSSOrderKey] = order
 		}
 	}
 	return pr
 }
+
+// ParseCSS parses given CSS style sheet text, and adds its rules to the CSS
+// of this node, so that they apply to it and all of the nodes below it that
+// match their selectors -- e.g., call on the main window viewport to style
+// an entire app from one style sheet.
+func (nb *NodeBase) ParseCSS(str string) error {
+	ss := &StyleSheet{}
+	if err := ss.ParseString(str); err != nil {
+		return err
+	}
+	if cp := ss.CSSProps(); cp != nil {
+		AggCSS(&nb.CSS, cp)
+	}
+	return nil
+}
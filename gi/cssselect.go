@@ -0,0 +1,966 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"goki.dev/ki/v2/ki"
+	"goki.dev/ki/v2/kit"
+)
+
+// CSS selectors are matched against the ki tree of nodes, as follows:
+//
+//   - type selectors match the type name of the node, case-insensitively
+//     (e.g., Button or button), and * matches any node.
+//   - .class matches any of the space-separated names in the node Class,
+//     and #name matches the node Name, both case-insensitively.
+//   - [attr] matches nodes with the given property set, and [attr=val],
+//     [attr~=val], [attr|=val], [attr^=val], [attr$=val] and [attr*=val]
+//     match its string value, per standard CSS.  If no such property is
+//     set, the name and class attributes match the node Name and Class.
+//   - the :hover, :active, :focus, :focus-within, :disabled, :enabled,
+//     :selected, :checked, :first-child, :last-child, :only-child,
+//     :nth-child(an+b), :nth-last-child(an+b), :empty and :root pseudo-classes,
+//     and :not(compound) for a compound selector without combinators.
+//   - the descendant (space), child (>), adjacent sibling (+)
+//     and general sibling (~) combinators.
+//
+// Within a CSS set of properties (e.g., the NodeBase CSS and CSSAgg fields),
+// keys are selectors, possibly comma-separated, with values that are ki.Props
+// of the style properties to apply to the matching nodes.  Properties are
+// applied in cascade order of increasing specificity and then source order,
+// so that more specific and later selectors override less specific and
+// earlier ones (see CSSMatchProps).
+
+// CSSCombinators are the ways in which the compound selectors
+// of a CSS selector are combined.
+type CSSCombinators int32
+
+const (
+	// CSSDescendant matches any descendant of the prior compound (a space)
+	CSSDescendant CSSCombinators = iota
+
+	// CSSChild matches a direct child of the prior compound (>)
+	CSSChild
+
+	// CSSAdjacent matches the sibling immediately after the prior compound (+)
+	CSSAdjacent
+
+	// CSSSibling matches any later sibling of the prior compound (~)
+	CSSSibling
+
+	CSSCombinatorsN
+)
+
+var TypeCSSCombinators = kit.Enums.AddEnum(CSSCombinatorsN, kit.NotBitFlag, nil)
+
+// CSSSpecificity is the specificity of a CSS selector, as the number of
+// (id, class / attribute / pseudo-class, type) selectors it contains.
+type CSSSpecificity [3]int
+
+// Less returns true if this specificity is lower than the other
+func (sp CSSSpecificity) Less(osp CSSSpecificity) bool {
+	for i := range sp {
+		if sp[i] != osp[i] {
+			return sp[i] < osp[i]
+		}
+	}
+	return false
+}
+
+// Add adds the other specificity to this one
+func (sp *CSSSpecificity) Add(osp CSSSpecificity) {
+	for i := range sp {
+		sp[i] += osp[i]
+	}
+}
+
+// CSSAttr is an attribute selector, matching node properties
+type CSSAttr struct {
+
+	// name of the attribute (property)
+	Name string `desc:"name of the attribute (property)"`
+
+	// match operator: empty for presence only, or =, ~=, |=, ^=, $= or *=
+	Op string `desc:"match operator: empty for presence only, or =, ~=, |=, ^=, $= or *="`
+
+	// value to match
+	Value string `desc:"value to match"`
+}
+
+// Matches returns true if the attribute selector matches given node
+func (at *CSSAttr) Matches(k ki.Ki) bool {
+	var val string
+	if pv, ok := k.PropInherit(at.Name, ki.NoInherit, ki.NoTypeProps); ok {
+		val = kit.ToString(pv)
+	} else {
+		switch {
+		case at.Name == "name":
+			val = k.Name()
+		case at.Name == "class":
+			nb := cssNode(k)
+			if nb == nil || nb.Class == "" {
+				return false
+			}
+			val = nb.Class
+		default:
+			return false
+		}
+	}
+	switch at.Op {
+	case "":
+		return true
+	case "=":
+		return val == at.Value
+	case "~=":
+		for _, f := range strings.Fields(val) {
+			if f == at.Value {
+				return true
+			}
+		}
+		return false
+	case "|=":
+		return val == at.Value || strings.HasPrefix(val, at.Value+"-")
+	case "^=":
+		return at.Value != "" && strings.HasPrefix(val, at.Value)
+	case "$=":
+		return at.Value != "" && strings.HasSuffix(val, at.Value)
+	case "*=":
+		return at.Value != "" && strings.Contains(val, at.Value)
+	}
+	return false
+}
+
+// CSSPseudo is a pseudo-class selector, e.g., :hover or :nth-child(2n+1)
+type CSSPseudo struct {
+
+	// name of the pseudo-class, without the :
+	Name string `desc:"name of the pseudo-class, without the :"`
+
+	// for nth-child pseudo-classes, the a in an+b
+	A int `desc:"for nth-child pseudo-classes, the a in an+b"`
+
+	// for nth-child pseudo-classes, the b in an+b
+	B int `desc:"for nth-child pseudo-classes, the b in an+b"`
+
+	// for :not, the compound selector that must not match
+	Not *CSSCompound `desc:"for :not, the compound selector that must not match"`
+}
+
+// Matches returns true if the pseudo-class selector matches given node
+func (ps *CSSPseudo) Matches(k ki.Ki) bool {
+	nb := cssNode(k)
+	switch ps.Name {
+	case "first-child":
+		return nthChild(k, false) == 1
+	case "last-child":
+		return nthChild(k, true) == 1
+	case "only-child":
+		return k.Parent() != nil && k.Parent().NumChildren() == 1
+	case "nth-child":
+		return nthMatches(ps.A, ps.B, nthChild(k, false))
+	case "nth-last-child":
+		return nthMatches(ps.A, ps.B, nthChild(k, true))
+	case "empty":
+		return !k.HasChildren()
+	case "root":
+		return k.Parent() == nil
+	case "not":
+		return !ps.Not.Matches(k)
+	}
+	if nb == nil {
+		return false
+	}
+	switch ps.Name {
+	case "hover":
+		return nb.IsHovered()
+	case "active":
+		return nb.IsActive()
+	case "focus":
+		return nb.HasFocus()
+	case "focus-within":
+		return nb.HasFocusWithin()
+	case "disabled":
+		return nb.IsDisabled()
+	case "enabled":
+		return !nb.IsDisabled()
+	case "selected":
+		return nb.IsSelected()
+	case "checked":
+		if ck, ok := k.(interface{ IsChecked() bool }); ok {
+			return ck.IsChecked()
+		}
+	}
+	return false
+}
+
+// cssNode returns the NodeBase for given node, or nil if it is not a Node
+func cssNode(k ki.Ki) *NodeBase {
+	if gn, ok := k.(Node); ok {
+		return gn.AsGiNode()
+	}
+	return nil
+}
+
+// nthChild returns the 1-based index of given node in its parent,
+// counting from the end if fromEnd, or 0 if it has no parent
+func nthChild(k ki.Ki, fromEnd bool) int {
+	idx, ok := k.IndexInParent()
+	if !ok {
+		return 0
+	}
+	if fromEnd {
+		return k.Parent().NumChildren() - idx
+	}
+	return idx + 1
+}
+
+// nthMatches returns true if the 1-based index idx is a*n+b for some n >= 0
+func nthMatches(a, b, idx int) bool {
+	if idx < 1 {
+		return false
+	}
+	if a == 0 {
+		return idx == b
+	}
+	d := idx - b
+	return d%a == 0 && d/a >= 0
+}
+
+// CSSCompound is a compound CSS selector, e.g., Button.primary:hover,
+// which matches a single node
+type CSSCompound struct {
+
+	// how this compound is combined with the prior one in the selector -- not used for the first one
+	Comb CSSCombinators `desc:"how this compound is combined with the prior one in the selector -- not used for the first one"`
+
+	// type name to match, or empty (or *) for any type
+	Type string `desc:"type name to match, or empty (or *) for any type"`
+
+	// name to match (#name), if non-empty
+	ID string `desc:"name to match (#name), if non-empty"`
+
+	// class names that must all be present (.class)
+	Classes []string `desc:"class names that must all be present (.class)"`
+
+	// attribute selectors that must all match
+	Attrs []CSSAttr `desc:"attribute selectors that must all match"`
+
+	// pseudo-class selectors that must all match
+	Pseudos []CSSPseudo `desc:"pseudo-class selectors that must all match"`
+}
+
+// Matches returns true if the compound selector matches given node
+func (cp *CSSCompound) Matches(k ki.Ki) bool {
+	if cp.Type != "" && cp.Type != "*" && !strings.EqualFold(cp.Type, ki.Type(k).Name()) {
+		return false
+	}
+	if cp.ID != "" && !strings.EqualFold(cp.ID, k.Name()) {
+		return false
+	}
+	if len(cp.Classes) > 0 {
+		nb := cssNode(k)
+		if nb == nil {
+			return false
+		}
+		cls := strings.Fields(nb.Class)
+		for _, c := range cp.Classes {
+			has := false
+			for _, nc := range cls {
+				if strings.EqualFold(c, nc) {
+					has = true
+					break
+				}
+			}
+			if !has {
+				return false
+			}
+		}
+	}
+	for i := range cp.Attrs {
+		if !cp.Attrs[i].Matches(k) {
+			return false
+		}
+	}
+	for i := range cp.Pseudos {
+		if !cp.Pseudos[i].Matches(k) {
+			return false
+		}
+	}
+	return true
+}
+
+// Dynamic returns true if the compound selector depends on the state of
+// the node (e.g., :hover) or its attributes (see CSSSelector.Dynamic)
+func (cp *CSSCompound) Dynamic() bool {
+	if len(cp.Attrs) > 0 {
+		return true
+	}
+	for i := range cp.Pseudos {
+		ps := &cp.Pseudos[i]
+		switch ps.Name {
+		case "hover", "active", "focus", "focus-within", "disabled", "enabled", "selected", "checked":
+			return true
+		case "not":
+			if ps.Not.Dynamic() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Specificity returns the specificity of the compound selector
+func (cp *CSSCompound) Specificity() CSSSpecificity {
+	var sp CSSSpecificity
+	if cp.ID != "" {
+		sp[0]++
+	}
+	sp[1] += len(cp.Classes) + len(cp.Attrs)
+	for i := range cp.Pseudos {
+		ps := &cp.Pseudos[i]
+		if ps.Not != nil {
+			sp.Add(ps.Not.Specificity()) // :not itself does not count
+		} else {
+			sp[1]++
+		}
+	}
+	if cp.Type != "" && cp.Type != "*" {
+		sp[2]++
+	}
+	return sp
+}
+
+// CSSSelector is a parsed CSS selector, consisting of compound selectors
+// joined by combinators, e.g., Frame.toolbar > Button:hover
+type CSSSelector struct {
+
+	// the compound selectors, in order from left to right
+	Compounds []*CSSCompound `desc:"the compound selectors, in order from left to right"`
+
+	// specificity of the selector
+	Spec CSSSpecificity `desc:"specificity of the selector"`
+
+	// whether the selector depends on the state of nodes (e.g., :hover) or their attributes, not just on the tree and classes, so its matches cannot be cached
+	Dynamic bool `desc:"whether the selector depends on the state of nodes (e.g., :hover) or their attributes, not just on the tree and classes, so its matches cannot be cached"`
+}
+
+// String returns the selector in standard CSS format
+func (sel *CSSSelector) String() string {
+	var sb strings.Builder
+	for i, cp := range sel.Compounds {
+		if i > 0 {
+			sb.WriteString([]string{" ", " > ", " + ", " ~ "}[cp.Comb])
+		}
+		sb.WriteString(cp.String())
+	}
+	return sb.String()
+}
+
+// String returns the compound selector in standard CSS format
+func (cp *CSSCompound) String() string {
+	var sb strings.Builder
+	sb.WriteString(cp.Type)
+	if cp.ID != "" {
+		sb.WriteString("#" + cp.ID)
+	}
+	for _, c := range cp.Classes {
+		sb.WriteString("." + c)
+	}
+	for _, at := range cp.Attrs {
+		if at.Op == "" {
+			sb.WriteString("[" + at.Name + "]")
+		} else {
+			sb.WriteString("[" + at.Name + at.Op + strconv.Quote(at.Value) + "]")
+		}
+	}
+	for _, ps := range cp.Pseudos {
+		switch {
+		case ps.Not != nil:
+			sb.WriteString(":not(" + ps.Not.String() + ")")
+		case ps.Name == "nth-child" || ps.Name == "nth-last-child":
+			sb.WriteString(fmt.Sprintf(":%s(%dn%+d)", ps.Name, ps.A, ps.B))
+		default:
+			sb.WriteString(":" + ps.Name)
+		}
+	}
+	if sb.Len() == 0 {
+		return "*"
+	}
+	return sb.String()
+}
+
+// Matches returns true if the selector matches given node, in the context
+// of its ancestors and siblings in the ki tree
+func (sel *CSSSelector) Matches(k ki.Ki) bool {
+	if len(sel.Compounds) == 0 {
+		return false
+	}
+	return sel.matchesAt(len(sel.Compounds)-1, k)
+}
+
+// matchesAt returns true if the compounds up to and including index ci
+// match, with the one at ci matching given node
+func (sel *CSSSelector) matchesAt(ci int, k ki.Ki) bool {
+	cp := sel.Compounds[ci]
+	if !cp.Matches(k) {
+		return false
+	}
+	if ci == 0 {
+		return true
+	}
+	switch cp.Comb {
+	case CSSChild:
+		par := k.Parent()
+		return par != nil && sel.matchesAt(ci-1, par)
+	case CSSDescendant:
+		for par := k.Parent(); par != nil; par = par.Parent() {
+			if sel.matchesAt(ci-1, par) {
+				return true
+			}
+		}
+	case CSSAdjacent:
+		idx, ok := k.IndexInParent()
+		return ok && idx > 0 && sel.matchesAt(ci-1, k.Parent().Child(idx-1))
+	case CSSSibling:
+		idx, ok := k.IndexInParent()
+		if !ok {
+			return false
+		}
+		par := k.Parent()
+		for i := idx - 1; i >= 0; i-- {
+			if sel.matchesAt(ci-1, par.Child(i)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+/////////////////////////////////////////////////////////////////////
+//   Parsing
+
+// ParseCSSSelectors parses given comma-separated list of CSS selectors
+func ParseCSSSelectors(str string) ([]*CSSSelector, error) {
+	var sels []*CSSSelector
+	for _, s := range splitCSSSelectors(str) {
+		sel, err := ParseCSSSelector(s)
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, sel)
+	}
+	if len(sels) == 0 {
+		return nil, fmt.Errorf("gi.ParseCSSSelectors: empty selector: %q", str)
+	}
+	return sels, nil
+}
+
+// splitCSSSelectors splits given selector list at top-level commas
+func splitCSSSelectors(str string) []string {
+	var sels []string
+	depth := 0
+	var quote rune
+	st := 0
+	for i, r := range str {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '(' || r == '[':
+			depth++
+		case r == ')' || r == ']':
+			depth--
+		case r == ',' && depth == 0:
+			sels = append(sels, str[st:i])
+			st = i + 1
+		}
+	}
+	sels = append(sels, str[st:])
+	res := sels[:0]
+	for _, s := range sels {
+		if s = strings.TrimSpace(s); s != "" {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+// cssParser is the state for parsing a CSS selector
+type cssParser struct {
+	str string
+	pos int
+}
+
+func (cp *cssParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("gi.ParseCSSSelector: %q at position %d: %s", cp.str, cp.pos, fmt.Sprintf(format, args...))
+}
+
+func (cp *cssParser) eof() bool {
+	return cp.pos >= len(cp.str)
+}
+
+func (cp *cssParser) peek() byte {
+	if cp.eof() {
+		return 0
+	}
+	return cp.str[cp.pos]
+}
+
+func (cp *cssParser) skipSpace() bool {
+	st := cp.pos
+	for !cp.eof() && strings.IndexByte(" \t\n\r\f", cp.str[cp.pos]) >= 0 {
+		cp.pos++
+	}
+	return cp.pos > st
+}
+
+func isCSSIdentChar(c byte) bool {
+	return c == '-' || c == '_' || c >= 0x80 || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func (cp *cssParser) ident() (string, error) {
+	st := cp.pos
+	for !cp.eof() && isCSSIdentChar(cp.str[cp.pos]) {
+		cp.pos++
+	}
+	if cp.pos == st {
+		return "", cp.errorf("expected name")
+	}
+	return cp.str[st:cp.pos], nil
+}
+
+// ParseCSSSelector parses a single CSS selector (without commas)
+func ParseCSSSelector(str string) (*CSSSelector, error) {
+	cp := &cssParser{str: str}
+	sel := &CSSSelector{}
+	cp.skipSpace()
+	comb := CSSDescendant
+	for {
+		cmp, err := cp.compound()
+		if err != nil {
+			return nil, err
+		}
+		cmp.Comb = comb
+		sel.Compounds = append(sel.Compounds, cmp)
+		sel.Spec.Add(cmp.Specificity())
+		sel.Dynamic = sel.Dynamic || cmp.Dynamic()
+		sp := cp.skipSpace()
+		if cp.eof() {
+			break
+		}
+		switch cp.peek() {
+		case '>':
+			comb = CSSChild
+		case '+':
+			comb = CSSAdjacent
+		case '~':
+			comb = CSSSibling
+		default:
+			if !sp {
+				return nil, cp.errorf("unexpected character: %q", cp.peek())
+			}
+			comb = CSSDescendant
+			continue
+		}
+		cp.pos++
+		cp.skipSpace()
+	}
+	return sel, nil
+}
+
+// compound parses a compound selector
+func (cp *cssParser) compound() (*CSSCompound, error) {
+	cmp := &CSSCompound{}
+	st := cp.pos
+	if cp.peek() == '*' {
+		cp.pos++
+		cmp.Type = "*"
+	} else if !cp.eof() && isCSSIdentChar(cp.peek()) {
+		cmp.Type, _ = cp.ident()
+	}
+	for !cp.eof() {
+		var err error
+		switch cp.peek() {
+		case '#':
+			cp.pos++
+			cmp.ID, err = cp.ident()
+		case '.':
+			cp.pos++
+			var cls string
+			cls, err = cp.ident()
+			cmp.Classes = append(cmp.Classes, cls)
+		case '[':
+			cp.pos++
+			var at CSSAttr
+			at, err = cp.attr()
+			cmp.Attrs = append(cmp.Attrs, at)
+		case ':':
+			cp.pos++
+			var ps CSSPseudo
+			ps, err = cp.pseudo()
+			cmp.Pseudos = append(cmp.Pseudos, ps)
+		default:
+			if cp.pos == st {
+				return nil, cp.errorf("unexpected character: %q", cp.peek())
+			}
+			return cmp, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cp.pos == st {
+		return nil, cp.errorf("expected selector")
+	}
+	return cmp, nil
+}
+
+// attr parses an attribute selector, after the [
+func (cp *cssParser) attr() (CSSAttr, error) {
+	var at CSSAttr
+	cp.skipSpace()
+	nm, err := cp.ident()
+	if err != nil {
+		return at, err
+	}
+	at.Name = nm
+	cp.skipSpace()
+	if cp.peek() == ']' {
+		cp.pos++
+		return at, nil
+	}
+	if c := cp.peek(); strings.IndexByte("~|^$*", c) >= 0 {
+		at.Op = string(c)
+		cp.pos++
+	}
+	if cp.peek() != '=' {
+		return at, cp.errorf("expected = in attribute selector")
+	}
+	cp.pos++
+	at.Op += "="
+	cp.skipSpace()
+	if q := cp.peek(); q == '"' || q == '\'' {
+		end := strings.IndexByte(cp.str[cp.pos+1:], q)
+		if end < 0 {
+			return at, cp.errorf("unterminated string")
+		}
+		at.Value = cp.str[cp.pos+1 : cp.pos+1+end]
+		cp.pos += end + 2
+	} else {
+		at.Value, err = cp.ident()
+		if err != nil {
+			return at, err
+		}
+	}
+	cp.skipSpace()
+	if cp.peek() != ']' {
+		return at, cp.errorf("expected ] to end attribute selector")
+	}
+	cp.pos++
+	return at, nil
+}
+
+// pseudo parses a pseudo-class selector, after the :
+func (cp *cssParser) pseudo() (CSSPseudo, error) {
+	var ps CSSPseudo
+	if cp.peek() == ':' {
+		return ps, cp.errorf("pseudo-elements are not supported")
+	}
+	nm, err := cp.ident()
+	if err != nil {
+		return ps, err
+	}
+	ps.Name = strings.ToLower(nm)
+	switch ps.Name {
+	case "hover", "active", "focus", "focus-within", "disabled", "enabled", "selected", "checked",
+		"first-child", "last-child", "only-child", "empty", "root":
+		return ps, nil
+	case "nth-child", "nth-last-child", "not":
+	default:
+		return ps, cp.errorf("unsupported pseudo-class: %q", nm)
+	}
+	if cp.peek() != '(' {
+		return ps, cp.errorf("expected ( after :%s", ps.Name)
+	}
+	end := closeParen(cp.str[cp.pos:])
+	if end < 0 {
+		return ps, cp.errorf("expected ) after :%s", ps.Name)
+	}
+	arg := strings.TrimSpace(cp.str[cp.pos+1 : cp.pos+end])
+	if ps.Name == "not" {
+		ncp := &cssParser{str: arg}
+		ps.Not, err = ncp.compound()
+		if err == nil && !ncp.eof() {
+			err = ncp.errorf("only compound selectors are supported in :not")
+		}
+		if err != nil {
+			return ps, err
+		}
+	} else {
+		ps.A, ps.B, err = parseNth(arg)
+		if err != nil {
+			return ps, cp.errorf("%v", err)
+		}
+	}
+	cp.pos += end + 1
+	return ps, nil
+}
+
+// closeParen returns the index of the ) matching the ( at the start of
+// given string, skipping over nested parentheses and quoted strings,
+// or -1 if there is none
+func closeParen(str string) int {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// parseNth parses the an+b argument of :nth-child, including odd and even
+func parseNth(arg string) (a, b int, err error) {
+	arg = strings.ToLower(strings.ReplaceAll(arg, " ", ""))
+	switch arg {
+	case "odd":
+		return 2, 1, nil
+	case "even":
+		return 2, 0, nil
+	}
+	ni := strings.IndexByte(arg, 'n')
+	if ni < 0 {
+		b, err = strconv.Atoi(arg)
+		return 0, b, err
+	}
+	switch as := arg[:ni]; as {
+	case "", "+":
+		a = 1
+	case "-":
+		a = -1
+	default:
+		if a, err = strconv.Atoi(as); err != nil {
+			return
+		}
+	}
+	if bs := arg[ni+1:]; bs != "" {
+		if bs[0] != '+' && bs[0] != '-' {
+			return 0, 0, errors.New("invalid nth-child argument: " + arg)
+		}
+		b, err = strconv.Atoi(bs)
+	}
+	return
+}
+
+/////////////////////////////////////////////////////////////////////
+//   Cascade
+
+// cssSelCache caches parsed selectors by selector string -- invalid
+// selectors are stored as nil, so they are only reported once
+var cssSelCache sync.Map
+
+// CSSSelectorsCached returns the parsed selectors for given comma-separated
+// selector list, using a cache.  Returns nil for invalid selectors, which
+// are logged the first time they are encountered.
+func CSSSelectorsCached(str string) []*CSSSelector {
+	if sv, ok := cssSelCache.Load(str); ok {
+		return sv.([]*CSSSelector)
+	}
+	sels, err := ParseCSSSelectors(str)
+	if err != nil {
+		log.Println(err)
+		sels = nil
+	}
+	cssSelCache.Store(str, sels)
+	return sels
+}
+
+// CSSOrderKey is the key of the property in the properties of each CSS
+// rule that records the source order of the rule, as set by
+// StyleSheet.CSSProps, and used by CSSMatchProps to order rules of equal
+// specificity.  It starts with _ so it is ignored in styling.
+const CSSOrderKey = "_css-order"
+
+// cssOrder is the last source order assigned to a CSS rule -- it increases
+// across all style sheets, so that rules parsed later come later in the
+// cascade
+var cssOrder int64
+
+// NextCSSOrder returns the next source order for a CSS rule (see CSSOrderKey)
+func NextCSSOrder() int64 {
+	return atomic.AddInt64(&cssOrder, 1)
+}
+
+// CSSMatchProps returns the properties of all the rules in given CSS set of
+// properties (see NodeBase CSS) whose selectors match given node, in cascade
+// order: increasing specificity, and then increasing source order (see
+// CSSOrderKey), such that applying them in order results in the more
+// specific and later rules taking precedence.  Rules without a source order
+// (e.g., set directly in code) come before those with one, ordered by their
+// selector strings.
+func CSSMatchProps(css ki.Props, k ki.Ki) []ki.Props {
+	return cssMatch(cssRules(css, k), k)
+}
+
+// cssRule is a rule of a CSS set of properties that can match a node,
+// with its static selectors already matched (see cssRules)
+type cssRule struct {
+	pr     ki.Props
+	order  int64
+	static bool           // whether any static selector matches
+	spec   CSSSpecificity // highest specificity of the matching static selectors
+	dyn    []*CSSSelector // the Dynamic selectors, to match each time
+}
+
+// cssRules returns the rules in given CSS set of properties that can match
+// given node: those with a static selector that matches it, or with any
+// Dynamic selector, in the order of their selector strings
+func cssRules(css ki.Props, k ki.Ki) []cssRule {
+	keys := make([]string, 0, len(css))
+	for key := range css {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var rules []cssRule
+	for _, key := range keys {
+		pr, ok := css[key].(ki.Props)
+		if !ok || key == "" {
+			continue
+		}
+		rl := cssRule{pr: pr, order: -1}
+		for _, sel := range CSSSelectorsCached(key) {
+			if sel.Dynamic {
+				rl.dyn = append(rl.dyn, sel)
+				continue
+			}
+			if sel.Matches(k) && (!rl.static || rl.spec.Less(sel.Spec)) {
+				rl.spec = sel.Spec
+				rl.static = true
+			}
+		}
+		if !rl.static && len(rl.dyn) == 0 {
+			continue
+		}
+		if ov, ok := pr[CSSOrderKey]; ok {
+			if o, ok := kit.ToInt(ov); ok {
+				rl.order = o
+			}
+		}
+		rules = append(rules, rl)
+	}
+	return rules
+}
+
+// cssMatch returns the properties of the given rules (see cssRules) that
+// match given node, matching their Dynamic selectors, in cascade order
+// (see CSSMatchProps)
+func cssMatch(rules []cssRule, k ki.Ki) []ki.Props {
+	var ms []cssRule
+	for _, rl := range rules {
+		for _, sel := range rl.dyn {
+			if sel.Matches(k) && (!rl.static || rl.spec.Less(sel.Spec)) {
+				rl.spec = sel.Spec
+				rl.static = true
+			}
+		}
+		if rl.static {
+			ms = append(ms, rl)
+		}
+	}
+	if len(ms) == 0 {
+		return nil
+	}
+	sort.SliceStable(ms, func(i, j int) bool {
+		if ms[i].spec != ms[j].spec {
+			return ms[i].spec.Less(ms[j].spec)
+		}
+		return ms[i].order < ms[j].order
+	})
+	prs := make([]ki.Props, len(ms))
+	for i, m := range ms {
+		prs[i] = m.pr
+	}
+	return prs
+}
+
+// cssClassGen is incremented on each change of the classes of a node with
+// SetClass or AddClass, as a change of the classes of one node can change
+// the matches of selectors for the nodes below and after it
+var cssClassGen int64
+
+// cssClassChanged records a change of the classes of a node (see cssClassGen)
+func cssClassChanged() {
+	atomic.AddInt64(&cssClassGen, 1)
+}
+
+// cssMatchCache caches the rules of the aggregated CSS of a widget that can
+// match it, for WidgetBase.ApplyCSS.  It is valid for the same rules, the
+// same Class of the widget and the same cssClassGen, and must be dropped
+// on any change in the tree.
+type cssMatchCache struct {
+	css   ki.Props // the CSS that the rules are from
+	class string   // the Class of the widget
+	gen   int64    // cssClassGen
+	rules []cssRule
+}
+
+// newCSSMatchCache returns a new cache of the rules in given CSS set of
+// properties that can match given node, with given class
+func newCSSMatchCache(css ki.Props, k ki.Ki, class string) *cssMatchCache {
+	mc := &cssMatchCache{css: css, class: class, gen: atomic.LoadInt64(&cssClassGen)}
+	mc.rules = cssRules(css, k)
+	return mc
+}
+
+// valid returns true if the cache is valid for given CSS set of properties,
+// which has the same rules (by identity) as the cached one, and given class
+func (mc *cssMatchCache) valid(css ki.Props, class string) bool {
+	if mc == nil || mc.class != class || mc.gen != atomic.LoadInt64(&cssClassGen) || len(mc.css) != len(css) {
+		return false
+	}
+	for key, val := range css {
+		cv, ok := mc.css[key]
+		if !ok || cssRuleID(cv) != cssRuleID(val) {
+			return false
+		}
+	}
+	return true
+}
+
+// cssRuleID returns the identity of the properties of a CSS rule,
+// or 0 if it is not a ki.Props
+func cssRuleID(val any) uintptr {
+	if pr, ok := val.(ki.Props); ok {
+		return reflect.ValueOf(pr).Pointer()
+	}
+	return 0
+}
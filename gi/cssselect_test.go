@@ -0,0 +1,307 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"testing"
+
+	"goki.dev/ki/v2/ki"
+)
+
+func TestParseCSSSelector(t *testing.T) {
+	tests := []struct {
+		sel  string
+		str  string
+		spec CSSSpecificity
+	}{
+		{"Button", "Button", CSSSpecificity{0, 0, 1}},
+		{"*", "*", CSSSpecificity{0, 0, 0}},
+		{"#ok", "#ok", CSSSpecificity{1, 0, 0}},
+		{".primary.big", ".primary.big", CSSSpecificity{0, 2, 0}},
+		{"Button.primary:hover", "Button.primary:hover", CSSSpecificity{0, 2, 1}},
+		{"[tooltip]", "[tooltip]", CSSSpecificity{0, 1, 0}},
+		{"[lang|='en']", `[lang|="en"]`, CSSSpecificity{0, 1, 0}},
+		{"Frame > Button", "Frame > Button", CSSSpecificity{0, 0, 2}},
+		{"Frame   Label + Button ~ Label", "Frame Label + Button ~ Label", CSSSpecificity{0, 0, 4}},
+		{"Button:nth-child(2n+1)", "Button:nth-child(2n+1)", CSSSpecificity{0, 1, 1}},
+		{":nth-last-child(odd)", ":nth-last-child(2n+1)", CSSSpecificity{0, 1, 0}},
+		{":nth-child(3)", ":nth-child(0n+3)", CSSSpecificity{0, 1, 0}},
+		{"Button:not(.primary)", "Button:not(.primary)", CSSSpecificity{0, 1, 1}},
+		{"Button:not(:nth-child(2))", "Button:not(:nth-child(0n+2))", CSSSpecificity{0, 1, 1}},
+		{"Label:not([title=')'])", `Label:not([title=")"])`, CSSSpecificity{0, 1, 1}},
+	}
+	for _, tt := range tests {
+		sel, err := ParseCSSSelector(tt.sel)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.sel, err)
+			continue
+		}
+		if str := sel.String(); str != tt.str {
+			t.Errorf("%q: String() = %q, want %q", tt.sel, str, tt.str)
+		}
+		if sel.Spec != tt.spec {
+			t.Errorf("%q: specificity = %v, want %v", tt.sel, sel.Spec, tt.spec)
+		}
+	}
+}
+
+func TestParseCSSSelectorErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"Button >",
+		"Button::before",
+		":unknown",
+		":nth-child(2",
+		":nth-child(x)",
+		":not(Frame Button)",
+		":not(:nth-child(2)",
+		"[attr",
+		"[attr='x]",
+		"Button!",
+	}
+	for _, str := range tests {
+		if _, err := ParseCSSSelectors(str); err == nil {
+			t.Errorf("%q: expected error", str)
+		}
+	}
+}
+
+func TestSplitCSSSelectors(t *testing.T) {
+	tests := []struct {
+		str  string
+		sels []string
+	}{
+		{"Button", []string{"Button"}},
+		{"Button, Label", []string{"Button", "Label"}},
+		{":not(.a, .b), [title='x,y'] , Label", []string{":not(.a, .b)", "[title='x,y']", "Label"}},
+		{" , Button,", []string{"Button"}},
+	}
+	for _, tt := range tests {
+		sels := splitCSSSelectors(tt.str)
+		if len(sels) != len(tt.sels) {
+			t.Errorf("%q: got %q, want %q", tt.str, sels, tt.sels)
+			continue
+		}
+		for i := range sels {
+			if sels[i] != tt.sels[i] {
+				t.Errorf("%q: got %q, want %q", tt.str, sels, tt.sels)
+				break
+			}
+		}
+	}
+}
+
+// cssTestTree returns a tree for testing selectors:
+//
+//	Frame root
+//	  Label title
+//	  Frame row
+//	    Button ok (.primary)
+//	    Button cancel
+//	    Label note (title=hint)
+func cssTestTree() *Frame {
+	root := &Frame{}
+	root.InitName(root, "root")
+	AddNewLabel(root, "title", "Title")
+	row := AddNewFrame(root, "row", LayoutHoriz)
+	ok := AddNewButton(row, "ok")
+	ok.Class = "primary"
+	AddNewButton(row, "cancel")
+	note := AddNewLabel(row, "note", "Note")
+	note.SetProp("title", "hint")
+	return root
+}
+
+func TestCSSSelectorMatches(t *testing.T) {
+	root := cssTestTree()
+	all := []string{"root", "title", "row", "ok", "cancel", "note"}
+	tests := []struct {
+		sel   string
+		match []string
+	}{
+		{"Button", []string{"ok", "cancel"}},
+		{"button", []string{"ok", "cancel"}},
+		{"*", all},
+		{"#ok", []string{"ok"}},
+		{".primary", []string{"ok"}},
+		{"Button:not(.primary)", []string{"cancel"}},
+		{"Frame > Label", []string{"title", "note"}},
+		{"#row > Label", []string{"note"}},
+		{"#root > Frame Button", []string{"ok", "cancel"}},
+		{"Label + Frame", []string{"row"}},
+		{"Button + Button", []string{"cancel"}},
+		{"#ok ~ Label", []string{"note"}},
+		{":first-child", []string{"title", "ok"}},
+		{":last-child", []string{"row", "note"}},
+		{":nth-child(2)", []string{"row", "cancel"}},
+		{":nth-last-child(2)", []string{"title", "cancel"}},
+		{"Button:not(:nth-child(2))", []string{"ok"}},
+		{":root", []string{"root"}},
+		{":empty", []string{"title", "ok", "cancel", "note"}},
+		{"[title]", []string{"note"}},
+		{"[title^=hi]", []string{"note"}},
+		{"[title=hi]", nil},
+		{"[name=cancel]", []string{"cancel"}},
+		{"[class~=primary]", []string{"ok"}},
+		{"Frame Frame Frame", nil},
+	}
+	for _, tt := range tests {
+		sel, err := ParseCSSSelector(tt.sel)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.sel, err)
+			continue
+		}
+		var got []string
+		var walk func(k ki.Ki)
+		walk = func(k ki.Ki) {
+			if sel.Matches(k) {
+				got = append(got, k.Name())
+			}
+			for _, kid := range *k.Children() {
+				walk(kid)
+			}
+		}
+		walk(root)
+		if len(got) != len(tt.match) {
+			t.Errorf("%q: matched %v, want %v", tt.sel, got, tt.match)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.match[i] {
+				t.Errorf("%q: matched %v, want %v", tt.sel, got, tt.match)
+				break
+			}
+		}
+	}
+}
+
+func TestCSSMatchProps(t *testing.T) {
+	root := cssTestTree()
+	ok := root.ChildByName("row", 0).ChildByName("ok", 0)
+	ss := &StyleSheet{}
+	err := ss.ParseString(`
+		Button { color: red; }
+		.primary { color: green; }
+		Label { color: gray; }
+		Button.primary { color: blue; }
+		Frame > * { color: black; }
+		Button { background-color: white; }
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	css := ss.CSSProps()
+	// Button and Frame > * have equal specificity, so source order wins,
+	// and the second Button rule moves Button to its place
+	want := []string{"black", "red", "green", "blue"}
+	for i := 0; i < 10; i++ { // map order must not matter
+		prs := CSSMatchProps(css, ok)
+		if len(prs) != len(want) {
+			t.Fatalf("got %d matching rules, want %d", len(prs), len(want))
+		}
+		for j, pr := range prs {
+			if pr["color"] != want[j] {
+				t.Fatalf("rule %d: color %v, want %v", j, pr["color"], want[j])
+			}
+		}
+	}
+	if bg := CSSMatchProps(css, ok)[1]["background-color"]; bg != "white" {
+		t.Errorf("Button rules were not merged: background-color = %v", bg)
+	}
+}
+
+func TestCSSSelectorDynamic(t *testing.T) {
+	tests := []struct {
+		sel     string
+		dynamic bool
+	}{
+		{"Button.primary", false},
+		{"#row > Frame:first-child", false},
+		{"Button:not(:nth-child(2))", false},
+		{"Button:hover", true},
+		{"Frame:focus-within Label", true},
+		{"Button:not(:disabled)", true},
+		{"[title]", true},
+	}
+	for _, tt := range tests {
+		sel, err := ParseCSSSelector(tt.sel)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.sel, err)
+			continue
+		}
+		if sel.Dynamic != tt.dynamic {
+			t.Errorf("%q: got %v, want %v", tt.sel, sel.Dynamic, tt.dynamic)
+		}
+	}
+}
+
+func TestApplyCSSCache(t *testing.T) {
+	vp := NewViewport2D(200, 200)
+	vp.InitName(vp, "vp")
+	root := AddNewFrame(vp, "root", LayoutVert)
+	row := AddNewFrame(root, "row", LayoutHoriz)
+	a := AddNewFrame(row, "a", LayoutVert)
+	a.Class = "wide"
+	b := AddNewFrame(row, "b", LayoutVert)
+	err := root.ParseCSS(`
+		.wide { width: 100px; }
+		Frame:hover { height: 30px; }
+		.dark Frame { min-width: 5px; }
+		#row > Frame:first-child { min-height: 7px; }
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root.Init2DTree()
+	// frames have a min-width and min-height of 2px by default
+	type sizes struct{ w, h, minW, minH float32 }
+	check := func(step string, fr *Frame, want sizes) {
+		t.Helper()
+		root.Style2DTree()
+		st := &fr.Style
+		if got := (sizes{st.Width.Val, st.Height.Val, st.MinWidth.Val, st.MinHeight.Val}); got != want {
+			t.Errorf("%s: %s: got %v, want %v", step, fr.Nm, got, want)
+		}
+	}
+
+	check("first", a, sizes{100, 0, 2, 7})
+	check("first", b, sizes{0, 0, 2, 2})
+	mc := a.cssMatches
+	if mc == nil {
+		t.Fatalf("no css cache after styling")
+	}
+	check("again", a, sizes{100, 0, 2, 7})
+	if a.cssMatches != mc {
+		t.Errorf("css cache not reused")
+	}
+
+	// dynamic selectors are matched again with the cache
+	a.SetHovered()
+	check("hover", a, sizes{100, 30, 2, 7})
+	a.SetHoveredState(false)
+	check("no hover", a, sizes{100, 0, 2, 7})
+	if a.cssMatches != mc {
+		t.Errorf("css cache not reused for a change of state")
+	}
+
+	// class changes
+	b.Class = "wide"
+	check("own class", b, sizes{100, 0, 2, 2})
+	row.SetClass("dark")
+	check("parent class", a, sizes{100, 0, 5, 7})
+	check("parent class", b, sizes{100, 0, 5, 2})
+
+	// tree changes are followed by Init2D
+	z := row.InsertNewChild(TypeFrame, 0, "z").(*Frame)
+	row.Init2DTree()
+	check("insert", a, sizes{100, 0, 5, 2})
+	check("insert", z, sizes{0, 0, 5, 7})
+
+	// rule changes
+	if err := root.ParseCSS(`#b { height: 40px; }`); err != nil {
+		t.Fatal(err)
+	}
+	check("new rule", b, sizes{100, 40, 5, 2})
+}
@@ -82,6 +82,9 @@ type Dialog struct {
 
 	// [view: -] the main data element represented by this window -- used for Recycle* methods for windows that represent a given data element -- prevents redundant windows
 	Data any `json:"-" xml:"-" view:"-" desc:"the main data element represented by this window -- used for Recycle* methods for windows that represent a given data element -- prevents redundant windows"`
+
+	// [view: -] optional function that validates the contents of the dialog -- while it returns an error, the dialog cannot be accepted and its Ok button is disabled -- call UpdateValid when the contents change
+	ValidFunc func() error `json:"-" xml:"-" view:"-" desc:"optional function that validates the contents of the dialog -- while it returns an error, the dialog cannot be accepted and its Ok button is disabled -- call UpdateValid when the contents change"`
 }
 
 var TypeDialog = kit.Types.AddType(&Dialog{}, DialogProps)
@@ -217,7 +220,7 @@ func (dlg *Dialog) Open(x, y int, avp *Viewport2D, cfgFunc func()) bool {
 		if KeyEventTrace {
 			fmt.Printf("gi.Dialog LowPri KeyInput: %v\n", ddlg.Path())
 		}
-		kf := KeyFun(kt.Chord())
+		kf := KeyFunFor(ddlg.This(), kt.Chord())
 		switch kf {
 		case KeyFunAbort:
 			ddlg.Cancel()
@@ -230,7 +233,7 @@ func (dlg *Dialog) Open(x, y int, avp *Viewport2D, cfgFunc func()) bool {
 		if KeyEventTrace {
 			fmt.Printf("gi.Dialog LowPriRaw KeyInput: %v\n", ddlg.Path())
 		}
-		kf := KeyFun(kt.Chord())
+		kf := KeyFunFor(ddlg.This(), kt.Chord())
 		switch kf {
 		case KeyFunAccept:
 			ddlg.Accept()
@@ -289,11 +292,15 @@ func (dlg *Dialog) Close() {
 	}
 }
 
-// Accept accepts the dialog, activated by the default Ok button
+// Accept accepts the dialog, activated by the default Ok button -- does
+// nothing if the contents are not valid according to ValidFunc
 func (dlg *Dialog) Accept() {
 	if dlg == nil {
 		return
 	}
+	if !dlg.UpdateValid() {
+		return
+	}
 	dlg.State = DialogAccepted
 	if dlg.SigVal >= 0 {
 		dlg.DialogSig.Emit(dlg.This(), dlg.SigVal, nil)
@@ -303,6 +310,39 @@ func (dlg *Dialog) Accept() {
 	dlg.Close()
 }
 
+// UpdateValid checks the contents of the dialog using ValidFunc, if set,
+// disabling the Ok button while they are not valid, with the error in its
+// tooltip -- returns true if they are valid
+func (dlg *Dialog) UpdateValid() bool {
+	if dlg.ValidFunc == nil {
+		return true
+	}
+	err := dlg.ValidFunc()
+	if !dlg.HasChildren() {
+		return err == nil
+	}
+	bb, _ := dlg.ButtonBox(dlg.Frame())
+	if bb == nil {
+		return err == nil
+	}
+	okk := bb.ChildByName("ok", 0)
+	if okk == nil {
+		return err == nil
+	}
+	okb := okk.Embed(TypeButton).(*Button)
+	okb.Tooltip = ""
+	if err != nil {
+		okb.Tooltip = err.Error()
+	}
+	if okb.IsDisabled() != (err != nil) {
+		updt := okb.UpdateStart()
+		okb.SetDisabledState(err != nil)
+		okb.SetFullReRender()
+		okb.UpdateEnd(updt)
+	}
+	return err == nil
+}
+
 // Cancel cancels the dialog, activated by the default Cancel button
 func (dlg *Dialog) Cancel() {
 	if dlg == nil {
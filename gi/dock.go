@@ -0,0 +1,969 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"encoding/json"
+	"image"
+	"image/draw"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"reflect"
+	"sync"
+
+	"goki.dev/colors"
+	"goki.dev/gi/v2/gist"
+	"goki.dev/gi/v2/oswin"
+	"goki.dev/gi/v2/oswin/dnd"
+	"goki.dev/ki/v2/ki"
+	"goki.dev/ki/v2/kit"
+	"goki.dev/mat32/v2"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////
+//    Dock
+
+// Dock is an IDE-style docking workspace, which arranges panels in areas
+// docked at the left, center, right and bottom, using SplitView's for the
+// areas and a TabView within each area to stack its panels as tabs.
+// Panels are registered with a unique id (see AddPanel), and users
+// rearrange them by dragging their tabs: dropping on an area docks the panel
+// there (a preview of the area is shown while dragging), and dropping
+// outside of the window floats it in its own window, from which it returns
+// to the area where it was last docked when that window is closed.  Areas
+// without panels are hidden, and areas can be collapsed (see CollapseArea).
+// The arrangement can be saved and restored per app (see SaveState,
+// RestoreState and DockMgr).
+type Dock struct {
+	Layout
+
+	// name under which the arrangement is saved in DockMgr -- defaults to the name of the dock
+	StateName string `desc:"name under which the arrangement is saved in DockMgr -- defaults to the name of the dock"`
+
+	// the panels in the dock, by id
+	Panels map[string]*DockPanel `json:"-" xml:"-" desc:"the panels in the dock, by id"`
+
+	// proportion of space allocated to each area when it is visible, within its split view -- the center area gets the rest of the space along the bottom
+	AreaSplits [DockAreasN]float32 `desc:"proportion of space allocated to each area when it is visible, within its split view -- the center area gets the rest of the space along the bottom"`
+
+	// whether each area is collapsed by the user
+	Collapsed [DockAreasN]bool `desc:"whether each area is collapsed by the user"`
+
+	// tab views of the floating panels, each in their own window
+	Floats []*TabView `json:"-" xml:"-" view:"-" desc:"tab views of the floating panels, each in their own window"`
+
+	// signal for dock events -- see DockSignals for the types
+	DockSig ki.Signal `json:"-" xml:"-" view:"-" desc:"signal for dock events -- see DockSignals for the types"`
+
+	// whether each area was shown in the last update of the splits
+	shown [DockAreasN]bool
+
+	// area currently previewed for the drop of a dragged panel, or -1 if none
+	previewArea DockAreas
+}
+
+var TypeDock = kit.Types.AddType(&Dock{}, DockProps)
+
+// AddNewDock adds a new dock to given parent node, with given name.
+func AddNewDock(parent ki.Ki, name string) *Dock {
+	return parent.AddNewChild(TypeDock, name).(*Dock)
+}
+
+func (dk *Dock) OnInit() {
+	dk.previewArea = -1
+	dk.AreaSplits = [DockAreasN]float32{DockLeft: .2, DockCenter: .6, DockRight: .2, DockBottom: .25}
+	dk.AddStyler(func(w *WidgetBase, s *gist.Style) {
+		s.MaxWidth.SetPx(-1)
+		s.MaxHeight.SetPx(-1)
+		s.Margin.Set()
+		s.Padding.Set()
+	})
+}
+
+func (dk *Dock) CopyFieldsFrom(frm any) {
+	fr := frm.(*Dock)
+	dk.Layout.CopyFieldsFrom(&fr.Layout)
+	dk.StateName = fr.StateName
+	dk.AreaSplits = fr.AreaSplits
+	dk.Collapsed = fr.Collapsed
+}
+
+var DockProps = ki.Props{
+	ki.EnumTypeFlag: TypeNodeFlags,
+}
+
+// DockAreas are the areas of a Dock in which panels are docked
+type DockAreas int32
+
+const (
+	// DockLeft is the area at the left of the center area
+	DockLeft DockAreas = iota
+
+	// DockCenter is the main area in the center
+	DockCenter
+
+	// DockRight is the area at the right of the center area
+	DockRight
+
+	// DockBottom is the area at the bottom, spanning the other areas
+	DockBottom
+
+	// DockFloat is for panels floating in their own window
+	DockFloat
+
+	DockAreasN
+)
+
+var TypeDockAreas = kit.Enums.AddEnumAltLower(DockAreasN, kit.NotBitFlag, nil, "Dock")
+
+func (ev DockAreas) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *DockAreas) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// DockSignals are signals that a Dock can send
+type DockSignals int64
+
+const (
+	// DockPanelMoved indicates that a panel was docked in another area,
+	// reordered within its area, or floated, by the user or the app -- data
+	// is the id of the panel
+	DockPanelMoved DockSignals = iota
+
+	// DockAreaCollapsed indicates that an area was collapsed -- data is the
+	// DockAreas
+	DockAreaCollapsed
+
+	// DockAreaExpanded indicates that a collapsed area was expanded -- data
+	// is the DockAreas
+	DockAreaExpanded
+
+	DockSignalsN
+)
+
+// DockPanel is a panel registered in a Dock
+type DockPanel struct {
+
+	// unique id of the panel, which is also the name of its widget
+	Id string `desc:"unique id of the panel, which is also the name of its widget"`
+
+	// label shown on the tab of the panel
+	Label string `desc:"label shown on the tab of the panel"`
+
+	// area in which the panel was last docked, where it returns to when its floating window is closed
+	Area DockAreas `desc:"area in which the panel was last docked, where it returns to when its floating window is closed"`
+
+	// the widget of the panel
+	Widget Node2D `json:"-" xml:"-" desc:"the widget of the panel"`
+}
+
+// Config configures the split views and tab views of the areas, if not
+// already done
+func (dk *Dock) Config() {
+	if len(dk.Kids) != 0 {
+		return
+	}
+	updt := dk.UpdateStart()
+	dk.Lay = LayoutVert
+	vs := AddNewSplitView(dk, "dock-vsplit")
+	vs.Dim = mat32.Y
+	hs := AddNewSplitView(vs, "dock-hsplit")
+	hs.Dim = mat32.X
+	for a := DockLeft; a < DockFloat; a++ {
+		par := hs
+		if a == DockBottom {
+			par = vs
+		}
+		tv := AddNewTabView(par, "dock-"+kit.Enums.EnumIfaceToAltString(a))
+		dk.ConfigTabView(tv)
+	}
+	dk.UpdateEnd(updt)
+}
+
+// ConfigTabView configures given tab view for holding panels of the dock
+func (dk *Dock) ConfigTabView(tv *TabView) {
+	tv.NoDeleteTabs = true
+	tv.SetStretchMax()
+	tv.TabViewSig.Connect(dk.This(), func(recv, send ki.Ki, sig int64, data any) {
+		dkk := recv.Embed(TypeDock).(*Dock)
+		dkk.TabViewSigRecv(send.Embed(TypeTabView).(*TabView), TabViewSignals(sig), data)
+	})
+}
+
+// SplitViews returns the vertical split view, between the bottom area and the
+// rest, and the horizontal split view, between the left, center and right
+func (dk *Dock) SplitViews() (vs, hs *SplitView) {
+	dk.Config()
+	vs = dk.Child(0).(*SplitView)
+	hs = vs.Child(0).(*SplitView)
+	return
+}
+
+// AreaTabView returns the tab view holding the panels of given area, which
+// must not be DockFloat
+func (dk *Dock) AreaTabView(area DockAreas) *TabView {
+	vs, hs := dk.SplitViews()
+	if area == DockBottom {
+		return vs.Child(1).(*TabView)
+	}
+	return hs.Child(int(area)).(*TabView)
+}
+
+// TabViewArea returns the area of given tab view: DockFloat if it is
+// one of the Floats, and -1 if it is not one of the dock
+func (dk *Dock) TabViewArea(tv *TabView) DockAreas {
+	for a := DockLeft; a < DockFloat; a++ {
+		if dk.AreaTabView(a) == tv {
+			return a
+		}
+	}
+	for _, ft := range dk.Floats {
+		if ft == tv {
+			return DockFloat
+		}
+	}
+	return -1
+}
+
+// AddPanel adds given widget as a panel with given unique id, which
+// becomes the name of the widget, and given tab label, docked in given area
+// (or floated for DockFloat)
+func (dk *Dock) AddPanel(id, label string, widg Node2D, area DockAreas) *DockPanel {
+	widg.SetName(id)
+	p := &DockPanel{Id: id, Label: label, Area: area, Widget: widg}
+	if area == DockFloat {
+		p.Area = DockCenter
+	}
+	if dk.Panels == nil {
+		dk.Panels = make(map[string]*DockPanel)
+	}
+	dk.Panels[id] = p
+	dk.AreaTabView(p.Area).AddTab(widg, label)
+	if area == DockFloat {
+		dk.FloatPanel(id)
+	} else {
+		dk.UpdateSplits()
+	}
+	return p
+}
+
+// AddNewPanel adds a new widget of given type as a panel with given unique
+// id and tab label, docked in given area (or floated for DockFloat), and
+// returns the new widget
+func (dk *Dock) AddNewPanel(typ reflect.Type, id, label string, area DockAreas) Node2D {
+	widg := ki.NewOfType(typ).(Node2D)
+	widg.InitName(widg, id)
+	dk.AddPanel(id, label, widg, area)
+	return widg
+}
+
+// PanelTabView returns the tab view holding the panel with given id, and
+// its index there -- nil if not found
+func (dk *Dock) PanelTabView(id string) (*TabView, int) {
+	p, ok := dk.Panels[id]
+	if !ok || p.Widget.Parent() == nil || p.Widget.Parent().Parent() == nil {
+		return nil, -1
+	}
+	tv, ok := p.Widget.Parent().Parent().Embed(TypeTabView).(*TabView)
+	if !ok {
+		return nil, -1
+	}
+	idx, _ := p.Widget.IndexInParent()
+	return tv, idx
+}
+
+// PanelArea returns the area where the panel with given id currently is,
+// or -1 if not found
+func (dk *Dock) PanelArea(id string) DockAreas {
+	tv, _ := dk.PanelTabView(id)
+	if tv == nil {
+		return -1
+	}
+	return dk.TabViewArea(tv)
+}
+
+// MovePanel docks the panel with given id in given area (or floats it for
+// DockFloat), at given tab index there (clamped to the number of tabs),
+// expanding the area if collapsed, and sends DockPanelMoved -- returns
+// false if not found
+func (dk *Dock) MovePanel(id string, area DockAreas, idx int) bool {
+	if area == DockFloat {
+		return dk.FloatPanel(id)
+	}
+	if !dk.movePanel(id, dk.AreaTabView(area), idx) {
+		return false
+	}
+	dk.PanelMoved(id)
+	return true
+}
+
+// movePanel moves the panel with given id to given index in given tab view
+func (dk *Dock) movePanel(id string, to *TabView, idx int) bool {
+	tv, i := dk.PanelTabView(id)
+	if tv == nil {
+		return false
+	}
+	if a := dk.TabViewArea(to); a >= 0 && a < DockFloat && dk.Collapsed[a] {
+		dk.captureSplits()
+		dk.Collapsed[a] = false
+	}
+	return tv.MoveTabTo(i, to, idx)
+}
+
+// FloatPanel moves the panel with given id into a new window of its own,
+// and sends DockPanelMoved -- returns false if not found
+func (dk *Dock) FloatPanel(id string) bool {
+	tv, i := dk.PanelTabView(id)
+	if tv == nil {
+		return false
+	}
+	if dk.TabViewArea(tv) == DockFloat {
+		return true
+	}
+	var pos image.Point
+	if win := dk.ParentWindow(); win != nil {
+		pos = win.OSWin.Position().Add(image.Point{40, 40})
+	}
+	if wgp := WinGeomMgr.Pref(tv.Nm+"-"+dk.Panels[id].Label, nil); wgp != nil {
+		pos = wgp.Pos()
+	}
+	ntv, ok := tv.DetachTab(i, pos)
+	if !ok {
+		return false
+	}
+	dk.AddFloat(ntv)
+	dk.PanelMoved(id)
+	return true
+}
+
+// AddFloat adds given tab view, in its own window, to the Floats: its panels
+// return to the area where they were last docked when its window is closed
+func (dk *Dock) AddFloat(tv *TabView) {
+	if dk.TabViewArea(tv) >= 0 {
+		return
+	}
+	dk.Floats = append(dk.Floats, tv)
+	dk.ConfigTabView(tv)
+	win := tv.ParentWindow()
+	if win == nil {
+		return
+	}
+	win.SetCloseReqFunc(func(w *Window) {
+		if dk.This() != nil {
+			dk.DockFloat(tv)
+		}
+		w.Close()
+	})
+}
+
+// DockFloat docks all the panels of given floating tab view back in the
+// area where they were last docked -- its window is then closed
+func (dk *Dock) DockFloat(tv *TabView) {
+	for tv.NTabs() > 0 {
+		widg, _, ok := tv.TabAtIndex(0)
+		if !ok {
+			break
+		}
+		p, has := dk.Panels[widg.Name()]
+		if !has {
+			tv.DeleteTabIndex(0, true)
+			continue
+		}
+		to := dk.AreaTabView(p.Area)
+		dk.movePanel(p.Id, to, to.NTabs())
+	}
+	dk.PanelMoved("")
+}
+
+// PanelMoved updates the dock after the panel with given id has been moved
+// (if not empty): records its area, closes the windows of any Floats
+// without panels left, and updates the splits -- sends DockPanelMoved
+func (dk *Dock) PanelMoved(id string) {
+	if a := dk.PanelArea(id); a >= 0 && a < DockFloat {
+		dk.Panels[id].Area = a
+	}
+	for i := len(dk.Floats) - 1; i >= 0; i-- {
+		ft := dk.Floats[i]
+		if ft.This() != nil && ft.NTabs() > 0 {
+			continue
+		}
+		dk.Floats = append(dk.Floats[:i], dk.Floats[i+1:]...)
+		if ft.This() == nil {
+			continue
+		}
+		if win := ft.ParentWindow(); win != nil && !win.IsClosed() {
+			win.Close()
+		}
+	}
+	dk.UpdateSplits()
+	if id != "" {
+		dk.DockSig.Emit(dk.This(), int64(DockPanelMoved), id)
+	}
+}
+
+// TabViewSigRecv handles the signals from the tab views of the dock, for
+// panels moved by the user
+func (dk *Dock) TabViewSigRecv(tv *TabView, sig TabViewSignals, data any) {
+	switch sig {
+	case TabMoved:
+		mv := data.(TabMove)
+		if tv != mv.To && dk.TabViewArea(mv.To) >= 0 {
+			return // both tab views send it: only handle once
+		}
+		if widg, _, ok := mv.To.TabAtIndex(mv.ToIdx); ok {
+			dk.PanelMoved(widg.Name())
+		}
+	case TabDetached:
+		mv := data.(TabMove)
+		dk.AddFloat(mv.To)
+		if widg, _, ok := mv.To.TabAtIndex(mv.ToIdx); ok {
+			dk.PanelMoved(widg.Name())
+		}
+	}
+}
+
+// ShowPanel selects the panel with given id in its area, expanding the area
+// if collapsed, or raises its window if floating -- returns false if not found
+func (dk *Dock) ShowPanel(id string) bool {
+	tv, i := dk.PanelTabView(id)
+	if tv == nil {
+		return false
+	}
+	a := dk.TabViewArea(tv)
+	switch {
+	case a == DockFloat:
+		if win := tv.ParentWindow(); win != nil {
+			win.Raise()
+		}
+	case a >= 0 && dk.Collapsed[a]:
+		dk.ExpandArea(a)
+	}
+	tv.SelectTabIndex(i)
+	return true
+}
+
+// IsCollapsed returns true if given area has been collapsed, by
+// CollapseArea or by the user with its splitter
+func (dk *Dock) IsCollapsed(area DockAreas) bool {
+	_, coll := dk.curSplits()
+	return coll[area]
+}
+
+// areaSplitView returns the split view holding given area, which must not be
+// DockFloat, and the index of the area within it
+func (dk *Dock) areaSplitView(area DockAreas) (*SplitView, int) {
+	vs, hs := dk.SplitViews()
+	if area == DockBottom {
+		return vs, 1
+	}
+	return hs, int(area)
+}
+
+// CollapseArea collapses given area, hiding its panels, and sends
+// DockAreaCollapsed -- the splits are saved in its split view, and restored
+// by ExpandArea (see SplitView.SaveSplits)
+func (dk *Dock) CollapseArea(area DockAreas) {
+	if dk.IsCollapsed(area) {
+		return
+	}
+	dk.captureSplits()
+	sv, _ := dk.areaSplitView(area)
+	sv.SaveSplits()
+	dk.Collapsed[area] = true
+	dk.UpdateSplits()
+	dk.DockSig.Emit(dk.This(), int64(DockAreaCollapsed), area)
+}
+
+// ExpandArea expands given collapsed area, and sends DockAreaExpanded --
+// the splits saved when it was collapsed are restored if the same areas are
+// shown (see SplitView.RestoreSplits)
+func (dk *Dock) ExpandArea(area DockAreas) {
+	if !dk.IsCollapsed(area) {
+		return
+	}
+	dk.captureSplits()
+	dk.Collapsed[area] = false
+	sv, _ := dk.areaSplitView(area)
+	if !dk.savedSplitsShown(sv) {
+		dk.UpdateSplits()
+	} else {
+		updt := dk.UpdateStart()
+		sv.RestoreSplits()
+		dk.shown[area] = dk.AreaVisible(area)
+		dk.captureSplits()
+		dk.UpdateEnd(updt)
+	}
+	dk.DockSig.Emit(dk.This(), int64(DockAreaExpanded), area)
+}
+
+// savedSplitsShown returns true if the splits saved in given split view of
+// the dock show the children that are now to be shown (see AreaVisible)
+func (dk *Dock) savedSplitsShown(sv *SplitView) bool {
+	vs, _ := dk.SplitViews()
+	var shown []bool
+	if sv == vs {
+		main := dk.AreaVisible(DockLeft) || dk.AreaVisible(DockCenter) || dk.AreaVisible(DockRight)
+		shown = []bool{main, dk.AreaVisible(DockBottom)}
+	} else {
+		shown = []bool{dk.AreaVisible(DockLeft), dk.AreaVisible(DockCenter), dk.AreaVisible(DockRight)}
+	}
+	if len(sv.SavedSplits) != len(shown) {
+		return false
+	}
+	for i, sp := range sv.SavedSplits {
+		if (sp >= 0.01) != shown[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// AreaVisible returns true if given area is to be shown: it has panels and
+// is not collapsed
+func (dk *Dock) AreaVisible(area DockAreas) bool {
+	return !dk.Collapsed[area] && dk.AreaTabView(area).NTabs() > 0
+}
+
+// curSplits returns AreaSplits and Collapsed updated with the current splits
+// of the areas that were last shown, keeping the proportions of the other
+// areas -- an area the user has collapsed with its splitter is collapsed
+func (dk *Dock) curSplits() (splits [DockAreasN]float32, collapsed [DockAreasN]bool) {
+	splits, collapsed = dk.AreaSplits, dk.Collapsed
+	vs, hs := dk.SplitViews()
+	if len(hs.Splits) == len(hs.Kids) {
+		prv, cur := float32(0), float32(0)
+		for a := DockLeft; a <= DockRight; a++ {
+			if dk.shown[a] {
+				prv += dk.AreaSplits[a]
+				cur += hs.Splits[a]
+			}
+		}
+		if cur > 0 {
+			for a := DockLeft; a <= DockRight; a++ {
+				if !dk.shown[a] {
+					continue
+				}
+				if hs.IsCollapsed(int(a)) {
+					collapsed[a] = true
+				} else {
+					splits[a] = hs.Splits[a] * prv / cur
+				}
+			}
+		}
+	}
+	if len(vs.Splits) == len(vs.Kids) && dk.shown[DockBottom] && vs.Splits[0] > 0 {
+		if vs.IsCollapsed(1) {
+			collapsed[DockBottom] = true
+		} else {
+			splits[DockBottom] = vs.Splits[1] / (vs.Splits[0] + vs.Splits[1])
+		}
+	}
+	return
+}
+
+// captureSplits records the current splits of the areas into AreaSplits and
+// Collapsed (see curSplits)
+func (dk *Dock) captureSplits() {
+	dk.AreaSplits, dk.Collapsed = dk.curSplits()
+}
+
+// UpdateSplits updates the splits of the split views to show the areas
+// that are visible (see AreaVisible) at their AreaSplits
+func (dk *Dock) UpdateSplits() {
+	dk.captureSplits()
+	vs, hs := dk.SplitViews()
+	hsp := make([]float32, 3)
+	main := false
+	for a := DockLeft; a < DockFloat; a++ {
+		dk.shown[a] = dk.AreaVisible(a)
+		if a <= DockRight && dk.shown[a] {
+			hsp[a] = dk.AreaSplits[a]
+			main = true
+		}
+	}
+	vsp := []float32{1, 0}
+	if dk.shown[DockBottom] {
+		vsp = []float32{1 - dk.AreaSplits[DockBottom], dk.AreaSplits[DockBottom]}
+		if !main {
+			vsp[0] = 0
+		}
+	}
+	updt := dk.UpdateStart()
+	if main {
+		hs.SetSplits(hsp...)
+	}
+	vs.SetSplits(vsp...)
+	dk.UpdateEnd(updt)
+	if vp := dk.ViewportSafe(); vp != nil {
+		vp.SetNeedsFullRender()
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//    Dragging panels
+
+// DockZoneFrac is the proportion of the size of a Dock, at its left, right
+// and bottom edges, within which dropping a panel docks it in the area at
+// that edge, when that area is not visible
+var DockZoneFrac = float32(0.2)
+
+// DockPreviewSpriteName is the sprite name used for the semi-transparent box
+// rendered over the area where a panel being dragged would be docked
+var DockPreviewSpriteName = "gi.Dock.Preview"
+
+// DragPanel returns the tab button of the panel of this dock being dragged,
+// or nil if none
+func (dk *Dock) DragPanel() *TabButton {
+	win := dk.ParentWindow()
+	if win == nil || win.EventMgr.DNDSource == nil || !win.EventMgr.DNDData.HasType(TabMimeType) {
+		return nil
+	}
+	tb, ok := win.EventMgr.DNDSource.(*TabButton)
+	if !ok || tb.TabView() == nil || dk.TabViewArea(tb.TabView()) < 0 {
+		return nil
+	}
+	return tb
+}
+
+// zoneBBox returns the box within the window at the edge of the dock for
+// given area, used for docking in areas that are not visible
+func (dk *Dock) zoneBBox(area DockAreas) image.Rectangle {
+	bb := dk.WinBBox
+	sz := mat32.NewVec2FmPoint(bb.Size()).MulScalar(DockZoneFrac)
+	switch area {
+	case DockLeft:
+		bb.Max.X = bb.Min.X + int(sz.X)
+	case DockRight:
+		bb.Min.X = bb.Max.X - int(sz.X)
+	case DockBottom:
+		bb.Min.Y = bb.Max.Y - int(sz.Y)
+	}
+	return bb
+}
+
+// DropArea returns the area in which a panel dropped at given position
+// within the window is docked, and the box of that area within the window:
+// that of the visible area at that position, or of the zone at the edge of
+// the dock for an area that is not visible (see DockZoneFrac)
+func (dk *Dock) DropArea(pos image.Point) (DockAreas, image.Rectangle) {
+	for _, a := range []DockAreas{DockLeft, DockRight, DockBottom} {
+		if zb := dk.zoneBBox(a); !dk.shown[a] && pos.In(zb) {
+			return a, zb
+		}
+	}
+	for a := DockLeft; a < DockFloat; a++ {
+		if tv := dk.AreaTabView(a); dk.shown[a] && tv.PosInWinBBox(pos) {
+			return a, tv.WinBBox
+		}
+	}
+	return DockCenter, dk.AreaTabView(DockCenter).WinBBox
+}
+
+// PreviewDrop shows a preview of the area where a panel dropped at given
+// position within the window is docked
+func (dk *Dock) PreviewDrop(pos image.Point) {
+	win := dk.ParentWindow()
+	if win == nil {
+		return
+	}
+	a, bb := dk.DropArea(pos)
+	if a == dk.previewArea {
+		return
+	}
+	dk.previewArea = a
+	win.DeleteSprite(DockPreviewSpriteName)
+	sp := NewSprite(DockPreviewSpriteName, bb.Size(), bb.Min)
+	if sp.Pixels == nil {
+		return
+	}
+	draw.Draw(sp.Pixels, sp.Pixels.Bounds(), &image.Uniform{colors.SetAF32(ColorScheme.Primary, 0.3)}, image.Point{}, draw.Src)
+	win.AddSprite(sp)
+	win.ActivateSprite(DockPreviewSpriteName)
+	win.UpdateSig()
+}
+
+// ClearPreview removes any preview of the area where a panel is docked
+func (dk *Dock) ClearPreview() {
+	dk.previewArea = -1
+	if win := dk.ParentWindow(); win != nil && win.DeleteSprite(DockPreviewSpriteName) {
+		win.UpdateSig()
+	}
+}
+
+// DropPanel docks the panel being dragged in the area where it is dropped:
+// drops on the tabs of an area are left to its tab view, to insert it there
+func (dk *Dock) DropPanel(de *dnd.Event) {
+	dk.ClearPreview()
+	tb := dk.DragPanel()
+	if tb == nil || de.Source != tb.This() {
+		return
+	}
+	a, _ := dk.DropArea(de.Where)
+	to := dk.AreaTabView(a)
+	if dk.shown[a] && to.Tabs().PosInWinBBox(de.Where) {
+		return
+	}
+	widg, _, ok := tb.TabView().TabAtIndex(tb.Data.(int))
+	if !ok {
+		return
+	}
+	de.Target = dk.This()
+	de.SetProcessed()
+	if win := dk.ParentWindow(); win != nil {
+		win.FinalizeDragNDrop(dnd.DropMove) // before moving, which deletes the source
+	}
+	dk.MovePanel(widg.Name(), a, to.NTabs())
+}
+
+// DockEvents connects the events for dragging panels -- at high priority,
+// to get them before the tab views
+func (dk *Dock) DockEvents() {
+	dk.ConnectEvent(oswin.DNDMoveEvent, HiPri, func(recv, send ki.Ki, sig int64, d any) {
+		de := d.(*dnd.MoveEvent)
+		dkk := recv.Embed(TypeDock).(*Dock)
+		if dkk.DragPanel() != nil {
+			dkk.PreviewDrop(de.Where)
+		}
+	})
+	dk.ConnectEvent(oswin.DNDEvent, HiPri, func(recv, send ki.Ki, sig int64, d any) {
+		de := d.(*dnd.Event)
+		dkk := recv.Embed(TypeDock).(*Dock)
+		if de.Action == dnd.DropOnTarget {
+			dkk.DropPanel(de)
+		}
+	})
+	dk.ConnectEvent(oswin.DNDFocusEvent, HiPri, func(recv, send ki.Ki, sig int64, d any) {
+		de := d.(*dnd.FocusEvent)
+		dkk := recv.Embed(TypeDock).(*Dock)
+		if de.Action == dnd.Exit {
+			dkk.ClearPreview()
+		}
+	})
+}
+
+func (dk *Dock) ConnectEvents2D() {
+	dk.Layout.ConnectEvents2D()
+	dk.DockEvents()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//    State
+
+// DockAreaState is the saved state of an area of a Dock, or of the tab view
+// of floating panels in one window
+type DockAreaState struct {
+
+	// the area, or DockFloat for floating panels
+	Area DockAreas `desc:"the area, or DockFloat for floating panels"`
+
+	// ids of the panels, in tab order
+	Panels []string `desc:"ids of the panels, in tab order"`
+
+	// id of the selected panel
+	Cur string `desc:"id of the selected panel"`
+
+	// proportion of space allocated to the area when visible (see Dock.AreaSplits)
+	Split float32 `desc:"proportion of space allocated to the area when visible (see Dock.AreaSplits)"`
+
+	// whether the area is collapsed
+	Collapsed bool `desc:"whether the area is collapsed"`
+}
+
+// DockState is the saved arrangement of the panels of a Dock
+type DockState struct {
+
+	// state of each area, followed by that of each window of floating panels
+	Areas []DockAreaState `desc:"state of each area, followed by that of each window of floating panels"`
+}
+
+// tabViewState returns the state of given tab view
+func tabViewState(tv *TabView, area DockAreas) DockAreaState {
+	as := DockAreaState{Area: area}
+	for i, sz := 0, tv.NTabs(); i < sz; i++ {
+		if widg, _, ok := tv.TabAtIndex(i); ok {
+			as.Panels = append(as.Panels, widg.Name())
+		}
+	}
+	if widg, _, ok := tv.CurTab(); ok {
+		as.Cur = widg.Name()
+	}
+	return as
+}
+
+// State returns the current arrangement of the panels
+func (dk *Dock) State() DockState {
+	splits, coll := dk.curSplits()
+	st := DockState{}
+	for a := DockLeft; a < DockFloat; a++ {
+		as := tabViewState(dk.AreaTabView(a), a)
+		as.Split = splits[a]
+		as.Collapsed = coll[a]
+		st.Areas = append(st.Areas, as)
+	}
+	for _, ft := range dk.Floats {
+		if ft.This() != nil {
+			st.Areas = append(st.Areas, tabViewState(ft, DockFloat))
+		}
+	}
+	return st
+}
+
+// SetState restores given arrangement of the panels: panels not in it are
+// left where they are, and ids of panels not in the dock are ignored
+func (dk *Dock) SetState(st DockState) {
+	updt := dk.UpdateStart()
+	for _, as := range st.Areas {
+		if as.Area < 0 || as.Area >= DockFloat {
+			continue
+		}
+		to := dk.AreaTabView(as.Area)
+		for i, id := range as.Panels {
+			if dk.movePanel(id, to, i) {
+				dk.Panels[id].Area = as.Area
+			}
+		}
+	}
+	for _, as := range st.Areas {
+		if as.Area != DockFloat || len(as.Panels) == 0 || !dk.FloatPanel(as.Panels[0]) {
+			continue
+		}
+		ftv, _ := dk.PanelTabView(as.Panels[0])
+		for i, id := range as.Panels[1:] {
+			dk.movePanel(id, ftv, i+1)
+		}
+	}
+	for _, as := range st.Areas {
+		if tv, i := dk.PanelTabView(as.Cur); tv != nil {
+			tv.SelectTabIndex(i)
+		}
+	}
+	// the splits are set after the moves, which capture the current ones,
+	// and none are shown, so that they are not captured again
+	for _, as := range st.Areas {
+		if as.Area >= 0 && as.Area < DockFloat {
+			dk.AreaSplits[as.Area] = as.Split
+			dk.Collapsed[as.Area] = as.Collapsed
+		}
+	}
+	dk.shown = [DockAreasN]bool{}
+	dk.PanelMoved("")
+	dk.UpdateEnd(updt)
+}
+
+// StateNm returns the name under which the arrangement is saved in DockMgr
+func (dk *Dock) StateNm() string {
+	if dk.StateName != "" {
+		return dk.StateName
+	}
+	return dk.Nm
+}
+
+// SaveState saves the current arrangement of the panels in DockMgr, for
+// the current app, under StateNm
+func (dk *Dock) SaveState() error {
+	return DockMgr.SetState(dk.StateNm(), dk.State())
+}
+
+// RestoreState restores the arrangement of the panels saved in DockMgr for
+// the current app under StateNm -- returns false if none was saved
+func (dk *Dock) RestoreState() bool {
+	st, ok := DockMgr.State(dk.StateNm())
+	if ok {
+		dk.SetState(st)
+	}
+	return ok
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//    DockStatePrefsMgr
+
+// DockMgr is the manager of dock state preferences
+var DockMgr = DockStatePrefsMgr{}
+
+// DockStatePrefs is the data structure for recording the arrangements of
+// docks by app name, dock name
+type DockStatePrefs map[string]map[string]DockState
+
+// DockStatePrefsMgr is the manager of dock state preferences.  Records the
+// arrangements of docks in a persistent file in the GoGi prefs directory,
+// alongside the window geometry (see WinGeomPrefsMgr).
+type DockStatePrefsMgr struct {
+
+	// the full set of dock states
+	States DockStatePrefs `desc:"the full set of dock states"`
+
+	// base name of the preferences file in GoGi prefs directory
+	FileName string `desc:"base name of the preferences file in GoGi prefs directory"`
+
+	// mutex that protects updating of States
+	Mu sync.Mutex `desc:"mutex that protects updating of States"`
+}
+
+// Init does initialization if not yet initialized
+func (mgr *DockStatePrefsMgr) Init() {
+	if mgr.States == nil {
+		mgr.States = make(DockStatePrefs)
+		mgr.FileName = "dock_state_prefs"
+	}
+}
+
+// Open opens dock state preferences from GoGi standard prefs directory --
+// called under mutex
+func (mgr *DockStatePrefsMgr) Open() error {
+	mgr.Init()
+	pdir := oswin.TheApp.GoGiPrefsDir()
+	pnm := filepath.Join(pdir, mgr.FileName+".json")
+	b, err := ioutil.ReadFile(pnm)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(b, &mgr.States)
+	if err != nil {
+		log.Println(err)
+	}
+	return err
+}
+
+// Save saves dock state preferences to GoGi standard prefs directory --
+// called under mutex
+func (mgr *DockStatePrefsMgr) Save() error {
+	if mgr.States == nil {
+		return nil
+	}
+	pdir := oswin.TheApp.GoGiPrefsDir()
+	pnm := filepath.Join(pdir, mgr.FileName+".json")
+	b, err := json.MarshalIndent(mgr.States, "", "\t")
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	err = ioutil.WriteFile(pnm, b, 0644)
+	if err != nil {
+		log.Println(err)
+	}
+	return err
+}
+
+// State returns the dock state saved for the current app under given dock name
+func (mgr *DockStatePrefsMgr) State(dockName string) (DockState, bool) {
+	mgr.Mu.Lock()
+	defer mgr.Mu.Unlock()
+	mgr.Open()
+	st, ok := mgr.States[AppName()][dockName]
+	return st, ok
+}
+
+// SetState saves given dock state for the current app under given dock
+// name, and saves the preferences file
+func (mgr *DockStatePrefsMgr) SetState(dockName string, st DockState) error {
+	mgr.Mu.Lock()
+	defer mgr.Mu.Unlock()
+	mgr.Open() // get any states saved by other apps
+	app := AppName()
+	if mgr.States[app] == nil {
+		mgr.States[app] = make(map[string]DockState)
+	}
+	mgr.States[app][dockName] = st
+	return mgr.Save()
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"reflect"
+	"testing"
+
+	"goki.dev/ki/v2/ki"
+)
+
+// dockTest returns a new dock with panels a and b at the left, c in the
+// center and d at the bottom -- call useNoIconMgr first
+func dockTest() *Dock {
+	vp := NewViewport2D(400, 300)
+	vp.InitName(vp, "vp")
+	dk := AddNewDock(vp, "dock")
+	dk.AddNewPanel(TypeFrame, "a", "A", DockLeft)
+	dk.AddNewPanel(TypeFrame, "b", "B", DockLeft)
+	dk.AddNewPanel(TypeFrame, "c", "C", DockCenter)
+	dk.AddNewPanel(TypeFrame, "d", "D", DockBottom)
+	return dk
+}
+
+func TestDockMovePanel(t *testing.T) {
+	defer useNoIconMgr()()
+	dk := dockTest()
+	var moved []string
+	dk.DockSig.Connect(dk.This(), func(recv, send ki.Ki, sig int64, data any) {
+		if DockSignals(sig) == DockPanelMoved {
+			moved = append(moved, data.(string))
+		}
+	})
+	if !dk.AreaVisible(DockLeft) || dk.AreaVisible(DockRight) {
+		t.Errorf("visible left, right = %v, %v, want true, false", dk.AreaVisible(DockLeft), dk.AreaVisible(DockRight))
+	}
+	if !dk.MovePanel("a", DockRight, 0) {
+		t.Fatalf("MovePanel failed")
+	}
+	if a := dk.PanelArea("a"); a != DockRight || dk.Panels["a"].Area != DockRight {
+		t.Errorf("panel area %v, recorded %v, want %v", a, dk.Panels["a"].Area, DockRight)
+	}
+	if !dk.AreaVisible(DockRight) {
+		t.Errorf("right area not visible after move")
+	}
+	dk.MovePanel("b", DockCenter, 0)
+	if dk.AreaVisible(DockLeft) {
+		t.Errorf("left area visible without panels")
+	}
+	if _, hs := dk.SplitViews(); hs.Splits[DockLeft] != 0 {
+		t.Errorf("left split %v, want 0", hs.Splits[DockLeft])
+	}
+	if tv, idx := dk.PanelTabView("b"); tv != dk.AreaTabView(DockCenter) || idx != 0 {
+		t.Errorf("panel b at %v %d, want center 0", tv.Nm, idx)
+	}
+	if dk.MovePanel("nope", DockLeft, 0) {
+		t.Errorf("MovePanel of unknown panel succeeded")
+	}
+	if !reflect.DeepEqual(moved, []string{"a", "b"}) {
+		t.Errorf("moved signals %v, want [a b]", moved)
+	}
+}
+
+func TestDockCollapse(t *testing.T) {
+	defer useNoIconMgr()()
+	dk := dockTest()
+	var sigs []DockSignals
+	dk.DockSig.Connect(dk.This(), func(recv, send ki.Ki, sig int64, data any) {
+		sigs = append(sigs, DockSignals(sig))
+	})
+	_, hs := dk.SplitViews()
+	before := append([]float32{}, hs.Splits...)
+
+	dk.CollapseArea(DockLeft)
+	if !dk.IsCollapsed(DockLeft) || dk.AreaVisible(DockLeft) || !hs.IsCollapsed(int(DockLeft)) {
+		t.Errorf("left area not collapsed")
+	}
+	dk.CollapseArea(DockLeft) // no-op
+	dk.ExpandArea(DockLeft)
+	if dk.IsCollapsed(DockLeft) || !dk.AreaVisible(DockLeft) {
+		t.Errorf("left area not expanded")
+	}
+	if !reflect.DeepEqual(hs.Splits, before) {
+		t.Errorf("splits %v after expand, want %v", hs.Splits, before)
+	}
+	want := []DockSignals{DockAreaCollapsed, DockAreaExpanded}
+	if !reflect.DeepEqual(sigs, want) {
+		t.Errorf("signals %v, want %v", sigs, want)
+	}
+}
+
+func TestDockSplitterCollapse(t *testing.T) {
+	defer useNoIconMgr()()
+	dk := dockTest()
+	vs, hs := dk.SplitViews()
+	before := append([]float32{}, vs.Splits...)
+	splits := dk.AreaSplits
+
+	// collapsed by the user with the splitter
+	vs.CollapseChild(true, 1)
+	for i := 0; i < 2; i++ { // querying does not change the state
+		if !dk.IsCollapsed(DockBottom) {
+			t.Errorf("bottom area not collapsed by splitter")
+		}
+		if dk.Collapsed[DockBottom] || dk.AreaSplits != splits {
+			t.Errorf("IsCollapsed changed the dock state")
+		}
+	}
+	if st := dk.State(); !st.Areas[DockBottom].Collapsed || dk.Collapsed[DockBottom] {
+		t.Errorf("State: bottom collapsed %v, changed %v", st.Areas[DockBottom].Collapsed, dk.Collapsed[DockBottom])
+	}
+	dk.ExpandArea(DockBottom)
+	if dk.IsCollapsed(DockBottom) || !reflect.DeepEqual(vs.Splits, before) {
+		t.Errorf("splits %v after expand, want %v", vs.Splits, before)
+	}
+
+	// the user resizes the areas with the splitters
+	hs.SetSplits(.3, .7, 0)
+	if st := dk.State(); st.Areas[DockLeft].Split == dk.AreaSplits[DockLeft] {
+		t.Errorf("State: left split %v not updated", st.Areas[DockLeft].Split)
+	}
+	dk.UpdateSplits()
+	if r := dk.AreaSplits[DockLeft] / dk.AreaSplits[DockCenter]; r < .42 || r > .44 {
+		t.Errorf("left / center splits %v, want %v", r, .3/.7)
+	}
+}
+
+func TestDockState(t *testing.T) {
+	defer useNoIconMgr()()
+	dk := dockTest()
+	dk.MovePanel("c", DockRight, 0)
+	dk.MovePanel("a", DockCenter, 0)
+	dk.AreaTabView(DockLeft).SelectTabIndex(0)
+	dk.CollapseArea(DockBottom)
+	st := dk.State()
+	want := []DockAreaState{
+		{Area: DockLeft, Panels: []string{"b"}, Cur: "b", Split: dk.AreaSplits[DockLeft]},
+		{Area: DockCenter, Panels: []string{"a"}, Cur: "a", Split: dk.AreaSplits[DockCenter]},
+		{Area: DockRight, Panels: []string{"c"}, Cur: "c", Split: dk.AreaSplits[DockRight]},
+		{Area: DockBottom, Panels: []string{"d"}, Cur: "d", Split: dk.AreaSplits[DockBottom], Collapsed: true},
+	}
+	if !reflect.DeepEqual(st.Areas, want) {
+		t.Fatalf("state %+v, want %+v", st.Areas, want)
+	}
+
+	nd := dockTest()
+	nd.SetState(st)
+	if nst := nd.State(); !reflect.DeepEqual(nst, st) {
+		t.Errorf("restored state %+v, want %+v", nst.Areas, st.Areas)
+	}
+	if nd.AreaVisible(DockBottom) || !nd.AreaVisible(DockRight) {
+		t.Errorf("restored visible bottom, right = %v, %v, want false, true", nd.AreaVisible(DockBottom), nd.AreaVisible(DockRight))
+	}
+}
+
+// dockStatesNear returns true if the splits of given states differ by less
+// than .001, with the same panels and collapsed areas
+func dockStatesNear(a, b DockState) bool {
+	if len(a.Areas) != len(b.Areas) {
+		return false
+	}
+	for i, aa := range a.Areas {
+		ba := b.Areas[i]
+		if d := aa.Split - ba.Split; d < -.001 || d > .001 {
+			return false
+		}
+		aa.Split, ba.Split = 0, 0
+		if !reflect.DeepEqual(aa, ba) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestDockStateSplits(t *testing.T) {
+	defer useNoIconMgr()()
+	dk := dockTest()
+	dk.MovePanel("c", DockRight, 0)
+	dk.MovePanel("a", DockCenter, 0)
+	vs, hs := dk.SplitViews()
+	hs.SetSplits(.2, .5, .3)
+	vs.SetSplits(.6, .4)
+	dk.CollapseArea(DockRight)
+	vs.CollapseChild(true, 1) // by the user with the splitter
+	st := dk.State()
+	if !st.Areas[DockRight].Collapsed || !st.Areas[DockBottom].Collapsed {
+		t.Errorf("right, bottom collapsed = %v, %v, want true, true", st.Areas[DockRight].Collapsed, st.Areas[DockBottom].Collapsed)
+	}
+
+	nd := dockTest()
+	nd.SetState(st)
+	if nst := nd.State(); !dockStatesNear(nst, st) {
+		t.Errorf("restored state %+v, want %+v", nst.Areas, st.Areas)
+	}
+	nvs, nhs := nd.SplitViews()
+	if nd.AreaVisible(DockRight) || nhs.Splits[DockRight] != 0 || nd.AreaVisible(DockBottom) || nvs.Splits[1] != 0 {
+		t.Errorf("restored splits %v, %v, want right and bottom collapsed", nhs.Splits, nvs.Splits)
+	}
+	if l := nhs.Splits[DockLeft] / nhs.Splits[DockCenter]; l < .399 || l > .401 {
+		t.Errorf("restored left / center split %v, want .4", l)
+	}
+	// expanding gives the same splits as in the original dock
+	for _, d := range []*Dock{dk, nd} {
+		d.ExpandArea(DockRight)
+		d.ExpandArea(DockBottom)
+	}
+	if !dockStatesNear(nd.State(), dk.State()) {
+		t.Errorf("state after expand %+v, want %+v", nd.State().Areas, dk.State().Areas)
+	}
+	for _, svs := range [][2]*SplitView{{nhs, hs}, {nvs, vs}} {
+		for i, sp := range svs[0].Splits {
+			if d := sp - svs[1].Splits[i]; d < -.001 || d > .001 {
+				t.Errorf("splits after expand %v, want %v", svs[0].Splits, svs[1].Splits)
+				break
+			}
+		}
+	}
+}
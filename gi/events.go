@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"image"
 	"log"
+	"math"
 	"sort"
 	"sync"
 	"time"
@@ -116,7 +117,13 @@ type EventMgr struct {
 	LagSkipDeltaPos image.Point `desc:"change in position accumulated from skipped-over laggy mouse move events"`
 
 	// true if last event was skipped due to lag
-	LagLastSkipped  bool `desc:"true if last event was skipped due to lag"`
+	LagLastSkipped bool `desc:"true if last event was skipped due to lag"`
+
+	// pending prefix of a multi-key chord sequence (e.g., Control+X while waiting for Control+S) -- see Window.KeySeqEvent
+	KeySeqPrefix key.Chord `desc:"pending prefix of a multi-key chord sequence (e.g., Control+X while waiting for Control+S) -- see Window.KeySeqEvent"`
+
+	keySeqTimer     *time.Timer
+	keySeqGen       int // incremented on each change of KeySeqPrefix, to ignore stale timeouts
 	startDrag       *mouse.DragEvent
 	dragStarted     bool
 	startDND        *mouse.DragEvent
@@ -156,13 +163,18 @@ func (we *WinEventRecv) Call(send ki.Ki, sig int64, data any) {
 
 type WinEventRecvList []WinEventRecv
 
+// EventTopDepth is the depth of the receiver that gets events first no
+// matter where it is, e.g., the one dragging -- above any EventZDepth
+const EventTopDepth = math.MaxInt32
+
 func (wl *WinEventRecvList) Add(recv ki.Ki, fun ki.RecvFunc, data int) {
 	rr := WinEventRecv{recv, fun, data}
 	*wl = append(*wl, rr)
 }
 
+// AddDepth adds given receiver with its EventZDepth below given top node
 func (wl *WinEventRecvList) AddDepth(recv ki.Ki, fun ki.RecvFunc, par ki.Ki) {
-	wl.Add(recv, fun, recv.ParentLevel(par))
+	wl.Add(recv, fun, EventZDepth(recv, par))
 }
 
 // ConnectEvent adds a Signal connection for given event type and
@@ -325,7 +337,7 @@ func (em *EventMgr) SendEventSignalFunc(evi oswin.Event, popup bool, rvs *WinEve
 					if EventTrace {
 						fmt.Printf("Event: dragging top pri: %v\n", recv.Path())
 					}
-					rvs.Add(recv, fun, 10000)
+					rvs.Add(recv, fun, EventTopDepth)
 					return ki.Break
 				} else {
 					return ki.Continue
@@ -343,7 +355,7 @@ func (em *EventMgr) SendEventSignalFunc(evi oswin.Event, popup bool, rvs *WinEve
 					if EventTrace {
 						fmt.Printf("Event: scrolling top pri: %v\n", recv.Path())
 					}
-					rvs.Add(recv, fun, 10000)
+					rvs.Add(recv, fun, EventTopDepth)
 				} else {
 					return ki.Continue
 				}
@@ -359,7 +371,7 @@ func (em *EventMgr) SendEventSignalFunc(evi oswin.Event, popup bool, rvs *WinEve
 				if EventTrace {
 					fmt.Printf("Event: dragging, non drag top pri: %v\n", recv.Path())
 				}
-				rvs.Add(recv, fun, 10000) // top priority -- can't steal!
+				rvs.Add(recv, fun, EventTopDepth) // top priority -- can't steal!
 				return ki.Break
 			}
 			if !gn.PosInWinBBox(pos) {
@@ -884,16 +896,42 @@ func (em *EventMgr) SendKeyFunEvent(kf KeyFuns, popup bool) {
 	if chord == "" {
 		return
 	}
-	r, mods, err := chord.Decode()
+	em.SendKeyChord(chord, popup)
+}
+
+// SendKeyChord sends a KeyChord event for given chord, which can be either
+// a single rune or a named key code, e.g., "Control+ReturnEnter".
+// If popup is true, then only items on popup are in scope, otherwise items
+// NOT on popup are in scope (if no popup, everything is in scope).
+func (em *EventMgr) SendKeyChord(chord key.Chord, popup bool) {
+	ke, err := NewKeyChordEvent(chord)
 	if err != nil {
 		return
 	}
-	ke := key.ChordEvent{}
+	em.SendEventSignal(ke, popup)
+}
+
+// NewKeyChordEvent returns a new key Press ChordEvent for given chord,
+// which can be either a single rune or a named key code,
+// e.g., "Control+ReturnEnter", or a multi-key sequence of those, for
+// which the event is for its final chord, with the sequence as its Seq.
+// The event time is set to now.
+func NewKeyChordEvent(chord key.Chord) (*key.ChordEvent, error) {
+	chs := chord.Chords()
+	r, code, mods, err := chs[len(chs)-1].DecodeCode()
+	if err != nil {
+		return nil, err
+	}
+	ke := &key.ChordEvent{}
+	if chord.IsSeq() {
+		ke.Seq = chord
+	}
 	ke.SetTime()
 	ke.Modifiers = mods
 	ke.Rune = r
+	ke.Code = code
 	ke.Action = key.Press
-	em.SendEventSignal(&ke, popup)
+	return ke, nil
 }
 
 // CurFocus gets the current focus node under mutex protection
@@ -1281,7 +1319,7 @@ func (em *EventMgr) ManagerKeyChordEvents(e *key.ChordEvent) {
 		return
 	}
 	cs := e.Chord()
-	kf := KeyFun(cs)
+	kf := KeyFunFor(em.CurFocus(), cs)
 	switch kf {
 	case KeyFunFocusNext: // tab
 		em.FocusNext(em.CurFocus())
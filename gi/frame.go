@@ -77,7 +77,7 @@ func (fr *Frame) FrameStdRender() {
 
 	fr.RenderStdBox(st)
 
-	if fr.Lay == LayoutGrid && fr.Stripes != NoStripes && Prefs.Params.ZebraStripeWeight != 0 {
+	if (fr.Lay == LayoutGrid || fr.Lay == LayoutGridIrreg) && fr.Stripes != NoStripes && Prefs.Params.ZebraStripeWeight != 0 {
 		fr.RenderStripes()
 	}
 }
@@ -135,6 +135,12 @@ func (fr *Frame) Render2D() {
 		return
 	}
 	if fr.PushBounds() {
+		if fr.NeedsStyle() { // e.g., for style animations
+			fr.StyMu.Lock()
+			fr.Style2DWidget()
+			fr.StyMu.Unlock()
+			fr.ClearNeedsStyle()
+		}
 		fr.FrameStdRender()
 		fr.This().(Node2D).ConnectEvents2D()
 		fr.RenderScrolls()
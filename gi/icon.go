@@ -73,6 +73,11 @@ func (ic *Icon) CopyFieldsFrom(frm any) {
 	ic.Filename = fr.Filename
 }
 
+// AccessInfo returns the accessibility info for the icon, named by the icon
+func (ic *Icon) AccessInfo() AccessInfo {
+	return ic.AccessInfoBase(AccessRoleImage, string(ic.IconNm))
+}
+
 var IconProps = ki.Props{
 	ki.EnumTypeFlag: TypeNodeFlags,
 }
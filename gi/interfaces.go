@@ -5,6 +5,7 @@
 package gi
 
 import (
+	"image"
 	"reflect"
 
 	"goki.dev/gi/v2/oswin/dnd"
@@ -120,3 +121,24 @@ type DragNDropper interface {
 	// will be nil.
 	DropExternal(md mimedata.Mimes, mod dnd.DropMods)
 }
+
+// DragNDropOutsider is an optional interface for the source of a drag-n-drop
+// that can be dropped outside of the window where it started, e.g., onto
+// another window or the desktop, as for the tabs of a TabView (see
+// Window.DNDDropOutside)
+type DragNDropOutsider interface {
+	// DropOutside is called on the source when it is dropped outside of its
+	// window, at given position in OS window manager screen coordinates
+	// (see Window.ScreenToWinPos, WindowList.AtScreenPos)
+	DropOutside(md mimedata.Mimes, scrPos image.Point)
+}
+
+// KeyChordRecorder is an optional interface for widgets that record the raw
+// key chords typed into them, e.g., to edit the chords of a KeyMap -- while
+// recording, multi-key sequence prefixes are not intercepted by the window
+// (see Window.KeySeqEvent)
+type KeyChordRecorder interface {
+	// IsRecordingKeys returns true if the widget is currently recording
+	// key chords
+	IsRecordingKeys() bool
+}
@@ -100,9 +100,10 @@ const (
 	KeyFunMenuOpenAlt2 // alternative version (e.g., alt)
 	KeyFunMenuSave
 	KeyFunMenuSaveAs
-	KeyFunMenuSaveAlt   // another alt (e.g., alt)
-	KeyFunMenuCloseAlt1 // alternative version (e.g., shift)
-	KeyFunMenuCloseAlt2 // alternative version (e.g., alt)
+	KeyFunMenuSaveAlt    // another alt (e.g., alt)
+	KeyFunMenuCloseAlt1  // alternative version (e.g., shift)
+	KeyFunMenuCloseAlt2  // alternative version (e.g., alt)
+	KeyFunCommandPalette // search and run all the commands of the window
 	KeyFunsN
 )
 
@@ -114,6 +115,10 @@ func (kf *KeyFuns) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(
 // KeyMap is a map between a key sequence (chord) and a specific KeyFun
 // function.  This mapping must be unique, in that each chord has unique
 // KeyFun, but multiple chords can trigger the same function.
+// A key can also be a multi-key sequence of chords separated by spaces,
+// e.g., "Control+X Control+S" -- typing the leading chords of a sequence
+// puts the window into a pending prefix state (see Window.KeySeqEvent),
+// and a prefix takes precedence over any single-chord binding of the same chord.
 type KeyMap map[key.Chord]KeyFuns
 
 // ActiveKeyMap points to the active map -- users can set this to an
@@ -129,6 +134,13 @@ func SetActiveKeyMap(km *KeyMap, kmName KeyMapName) {
 	km.Update(kmName)
 	ActiveKeyMap = km
 	ActiveKeyMapName = kmName
+	ActiveKeyScopes = nil
+	for _, it := range AvailKeyMaps {
+		if it.Name == string(kmName) {
+			ActiveKeyScopes = it.Scopes
+			break
+		}
+	}
 }
 
 // SetActiveKeyMapName sets the current ActiveKeyMap by name from those
@@ -154,7 +166,8 @@ func SetActiveKeyMapName(mapnm KeyMapName) {
 }
 
 // KeyFun translates chord into keyboard function -- use oswin key.Chord
-// to get chord
+// to get chord, which is the full sequence for the final chord of a
+// multi-key sequence (see key.ChordEvent.Seq)
 func KeyFun(chord key.Chord) KeyFuns {
 	kf := KeyFunNil
 	if chord != "" {
@@ -187,14 +200,34 @@ func (km *KeyMap) ToSlice() []KeyMapItem {
 	return kms
 }
 
-// ChordForFun returns first key chord trigger for given KeyFun in map
+// IsSeqPrefix returns true if the given chord or chord sequence is the
+// leading part of at least one multi-key sequence in the map
+func (km *KeyMap) IsSeqPrefix(seq key.Chord) bool {
+	if seq == "" {
+		return false
+	}
+	pfx := string(seq) + key.SeqSep
+	for ch := range *km {
+		if strings.HasPrefix(string(ch), pfx) {
+			return true
+		}
+	}
+	return false
+}
+
+// ChordForFun returns first key chord trigger for given KeyFun in map,
+// preferring a single chord over a multi-key sequence
 func (km *KeyMap) ChordForFun(kf KeyFuns) key.Chord {
+	seq := key.Chord("")
 	for key, fun := range *km {
 		if fun == kf {
-			return key
+			if !key.IsSeq() {
+				return key
+			}
+			seq = key
 		}
 	}
-	return ""
+	return seq
 }
 
 // ShortcutForFun returns OS-specific formatted shortcut for first key chord
@@ -281,8 +314,11 @@ type KeyMapsItem struct {
 	// description of keymap -- good idea to include source it was derived from
 	Desc string `desc:"description of keymap -- good idea to include source it was derived from"`
 
-	// to edit key sequence click button and type new key combination; to edit function mapped to key sequence choose from menu
-	Map KeyMap `desc:"to edit key sequence click button and type new key combination; to edit function mapped to key sequence choose from menu"`
+	// to edit key sequence click button and type new key combination, or several in a row for a multi-key sequence such as Control+X Control+S; to edit function mapped to key sequence choose from menu
+	Map KeyMap `desc:"to edit key sequence click button and type new key combination, or several in a row for a multi-key sequence such as Control+X Control+S; to edit function mapped to key sequence choose from menu"`
+
+	// overrides of scoped key binding layers, by scope name (e.g., TextView) -- entries take precedence over the bindings the widget declares, and an empty command removes a binding from the layer
+	Scopes map[string]KeyCmds `desc:"overrides of scoped key binding layers, by scope name (e.g., TextView) -- entries take precedence over the bindings the widget declares, and an empty command removes a binding from the layer"`
 }
 
 // Label satisfies the Labeler interface
@@ -605,6 +641,7 @@ var StdKeyMaps = KeyMaps{
 		"Shift+Control+G":         KeyFunWinSnapshot,
 		"Control+Alt+I":           KeyFunGoGiEditor,
 		"Shift+Control+I":         KeyFunGoGiEditor,
+		"Shift+Meta+P":            KeyFunCommandPalette,
 		"Meta+N":                  KeyFunMenuNew,
 		"Shift+Meta+N":            KeyFunMenuNewAlt1,
 		"Alt+Meta+N":              KeyFunMenuNewAlt2,
@@ -616,7 +653,7 @@ var StdKeyMaps = KeyMaps{
 		"Alt+Meta+S":              KeyFunMenuSaveAlt,
 		"Shift+Meta+W":            KeyFunMenuCloseAlt1,
 		"Alt+Meta+W":              KeyFunMenuCloseAlt2,
-	}},
+	}, nil},
 	{"MacEmacs", "Mac with emacs-style navigation -- emacs wins in conflicts", KeyMap{
 		"UpArrow":                 KeyFunMoveUp,
 		"Shift+UpArrow":           KeyFunMoveUp,
@@ -724,6 +761,8 @@ var StdKeyMaps = KeyMaps{
 		"Control+.":               KeyFunComplete,
 		"Control+,":               KeyFunLookup,
 		"Control+S":               KeyFunSearch,
+		"Control+X Control+S":     KeyFunMenuSave,
+		"Control+X Control+W":     KeyFunMenuSaveAs,
 		"Meta+F":                  KeyFunFind,
 		"Meta+R":                  KeyFunReplace,
 		"Control+R":               KeyFunReplace,
@@ -739,6 +778,7 @@ var StdKeyMaps = KeyMaps{
 		"Shift+Control+G":         KeyFunWinSnapshot,
 		"Control+Alt+I":           KeyFunGoGiEditor,
 		"Shift+Control+I":         KeyFunGoGiEditor,
+		"Shift+Meta+P":            KeyFunCommandPalette,
 		"Meta+N":                  KeyFunMenuNew,
 		"Shift+Meta+N":            KeyFunMenuNewAlt1,
 		"Alt+Meta+N":              KeyFunMenuNewAlt2,
@@ -750,7 +790,7 @@ var StdKeyMaps = KeyMaps{
 		"Alt+Meta+S":              KeyFunMenuSaveAlt,
 		"Shift+Meta+W":            KeyFunMenuCloseAlt1,
 		"Alt+Meta+W":              KeyFunMenuCloseAlt2,
-	}},
+	}, nil},
 	{"LinuxEmacs", "Linux with emacs-style navigation -- emacs wins in conflicts", KeyMap{
 		"UpArrow":                 KeyFunMoveUp,
 		"Shift+UpArrow":           KeyFunMoveUp,
@@ -846,6 +886,8 @@ var StdKeyMaps = KeyMaps{
 		"Control+.":               KeyFunComplete,
 		"Control+,":               KeyFunLookup,
 		"Control+S":               KeyFunSearch,
+		"Control+X Control+S":     KeyFunMenuSave,
+		"Control+X Control+W":     KeyFunMenuSaveAs,
 		"Alt+F":                   KeyFunFind,
 		"Control+R":               KeyFunReplace,
 		"Control+J":               KeyFunJump,
@@ -858,6 +900,7 @@ var StdKeyMaps = KeyMaps{
 		"Shift+Control+G":         KeyFunWinSnapshot,
 		"Control+Alt+I":           KeyFunGoGiEditor,
 		"Shift+Control+I":         KeyFunGoGiEditor,
+		"Shift+Alt+P":             KeyFunCommandPalette,
 		"Alt+N":                   KeyFunMenuNew, // ctrl keys conflict..
 		"Shift+Alt+N":             KeyFunMenuNewAlt1,
 		"Control+Alt+N":           KeyFunMenuNewAlt2,
@@ -869,7 +912,7 @@ var StdKeyMaps = KeyMaps{
 		"Control+Alt+S":           KeyFunMenuSaveAlt,
 		"Shift+Alt+W":             KeyFunMenuCloseAlt1,
 		"Control+Alt+W":           KeyFunMenuCloseAlt2,
-	}},
+	}, nil},
 	{"LinuxStd", "Standard Linux KeyMap", KeyMap{
 		"UpArrow":                 KeyFunMoveUp,
 		"Shift+UpArrow":           KeyFunMoveUp,
@@ -947,6 +990,7 @@ var StdKeyMaps = KeyMaps{
 		"Control+Alt+G":           KeyFunWinSnapshot,
 		"Shift+Control+G":         KeyFunWinSnapshot,
 		"Shift+Control+I":         KeyFunGoGiEditor,
+		"Shift+Alt+P":             KeyFunCommandPalette,
 		"Shift+Control+N":         KeyFunMenuNewAlt1,
 		"Control+Alt+N":           KeyFunMenuNewAlt2,
 		"Control+O":               KeyFunMenuOpen,
@@ -957,7 +1001,7 @@ var StdKeyMaps = KeyMaps{
 		"Control+Alt+S":           KeyFunMenuSaveAlt,
 		"Shift+Control+W":         KeyFunMenuCloseAlt1,
 		"Control+Alt+W":           KeyFunMenuCloseAlt2,
-	}},
+	}, nil},
 	{"WindowsStd", "Standard Windows KeyMap", KeyMap{
 		"UpArrow":                 KeyFunMoveUp,
 		"Shift+UpArrow":           KeyFunMoveUp,
@@ -1034,6 +1078,7 @@ var StdKeyMaps = KeyMaps{
 		"Control+Alt+G":           KeyFunWinSnapshot,
 		"Shift+Control+G":         KeyFunWinSnapshot,
 		"Shift+Control+I":         KeyFunGoGiEditor,
+		"Shift+Alt+P":             KeyFunCommandPalette,
 		"Control+N":               KeyFunMenuNew,
 		"Shift+Control+N":         KeyFunMenuNewAlt1,
 		"Control+Alt+N":           KeyFunMenuNewAlt2,
@@ -1045,7 +1090,7 @@ var StdKeyMaps = KeyMaps{
 		"Control+Alt+S":           KeyFunMenuSaveAlt,
 		"Shift+Control+W":         KeyFunMenuCloseAlt1,
 		"Control+Alt+W":           KeyFunMenuCloseAlt2,
-	}},
+	}, nil},
 	{"ChromeStd", "Standard chrome-browser and linux-under-chrome bindings", KeyMap{
 		"UpArrow":                 KeyFunMoveUp,
 		"Shift+UpArrow":           KeyFunMoveUp,
@@ -1122,6 +1167,7 @@ var StdKeyMaps = KeyMaps{
 		"Control+Alt+G":           KeyFunWinSnapshot,
 		"Shift+Control+G":         KeyFunWinSnapshot,
 		"Shift+Control+I":         KeyFunGoGiEditor,
+		"Shift+Alt+P":             KeyFunCommandPalette,
 		"Control+N":               KeyFunMenuNew,
 		"Shift+Control+N":         KeyFunMenuNewAlt1,
 		"Control+Alt+N":           KeyFunMenuNewAlt2,
@@ -1133,5 +1179,5 @@ var StdKeyMaps = KeyMaps{
 		"Control+Alt+S":           KeyFunMenuSaveAlt,
 		"Shift+Control+W":         KeyFunMenuCloseAlt1,
 		"Control+Alt+W":           KeyFunMenuCloseAlt2,
-	}},
+	}, nil},
 }
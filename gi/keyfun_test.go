@@ -0,0 +1,109 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"testing"
+
+	"goki.dev/gi/v2/oswin/key"
+)
+
+func TestKeyMapSeq(t *testing.T) {
+	km := KeyMap{
+		"Control+S":           KeyFunSearch,
+		"Control+X Control+S": KeyFunMenuSave,
+		"Control+X Control+W": KeyFunMenuSaveAs,
+		"Control+X":           KeyFunCut,
+	}
+	tests := []struct {
+		seq    key.Chord
+		prefix bool
+	}{
+		{"Control+X", true},
+		{"Control+S", false},
+		{"Control+X Control+S", false},
+		{"Control+C", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if pfx := km.IsSeqPrefix(tt.seq); pfx != tt.prefix {
+			t.Errorf("IsSeqPrefix(%q) = %v, want %v", tt.seq, pfx, tt.prefix)
+		}
+	}
+	if ch := km.ChordForFun(KeyFunMenuSave); ch != "Control+X Control+S" {
+		t.Errorf("ChordForFun(MenuSave) = %q", ch)
+	}
+	km["Control+Alt+S"] = KeyFunMenuSave
+	if ch := km.ChordForFun(KeyFunMenuSave); ch != "Control+Alt+S" {
+		t.Errorf("ChordForFun(MenuSave) = %q, want the single chord", ch)
+	}
+}
+
+func TestNewKeyChordEventSeq(t *testing.T) {
+	defer func(km *KeyMap) { ActiveKeyMap = km }(ActiveKeyMap)
+	ActiveKeyMap = &KeyMap{
+		"Control+S":           KeyFunSearch,
+		"Control+X Control+S": KeyFunMenuSave,
+	}
+	tests := []struct {
+		chord key.Chord
+		last  key.Chord
+		kf    KeyFuns
+	}{
+		{"Control+S", "Control+S", KeyFunSearch},
+		{"Control+X Control+S", "Control+S", KeyFunMenuSave},
+	}
+	for _, tt := range tests {
+		ke, err := NewKeyChordEvent(tt.chord)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.chord, err)
+		}
+		if ch := ke.Event.Chord(); ch != tt.last {
+			t.Errorf("%q: event for chord %q, want %q", tt.chord, ch, tt.last)
+		}
+		// the lookup only depends on the event
+		if kf := KeyFun(ke.Chord()); kf != tt.kf {
+			t.Errorf("%q: KeyFun = %v, want %v", tt.chord, kf, tt.kf)
+		}
+	}
+	if _, err := NewKeyChordEvent("Control+X Control+NotAKey"); err == nil {
+		t.Errorf("expected error for unknown key in sequence")
+	}
+}
+
+func TestKeyFunFor(t *testing.T) {
+	defer func(km *KeyMap) { ActiveKeyMap = km }(ActiveKeyMap)
+	ActiveKeyMap = &KeyMap{
+		"Control+A": KeyFunSelectAll,
+		"Control+U": KeyFunMoveUp,
+		"Control+K": KeyFunKill,
+	}
+	fr := &Frame{}
+	fr.InitName(fr, "fr")
+	bt := AddNewButton(fr, "bt")
+	SetKeyCmds(fr, "", KeyCmds{
+		"Control+A": "CommentRegion", // not a KeyFun
+		"Control+D": "MoveDown",
+		"Control+K": "KeyFunMoveUp",
+	})
+	tests := []struct {
+		chord key.Chord
+		kf    KeyFuns
+	}{
+		{"Control+A", KeyFunSelectAll},
+		{"Control+D", KeyFunMoveDown},
+		{"Control+K", KeyFunMoveUp},
+		{"Control+U", KeyFunMoveUp},
+		{"Control+Z", KeyFunNil},
+	}
+	for _, tt := range tests {
+		if kf := KeyFunFor(bt, tt.chord); kf != tt.kf {
+			t.Errorf("KeyFunFor(%q) = %v, want %v", tt.chord, kf, tt.kf)
+		}
+	}
+	if cmd, scope, ok := KeyCmdFor(bt, "Control+A"); !ok || cmd != "CommentRegion" || scope != "fr" {
+		t.Errorf("KeyCmdFor(Control+A) = %q, %q, %v", cmd, scope, ok)
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"strings"
+
+	"goki.dev/gi/v2/oswin/key"
+	"goki.dev/ki/v2/ki"
+	"goki.dev/ki/v2/kit"
+)
+
+// KeyCmds is a scoped key binding layer: a map between key chords (or
+// multi-key sequences, see KeyMap) and named commands.  A widget type
+// declares its layer in its type properties, and a widget instance in its
+// own properties, under KeyCmdsProp.  A command that is the name of a
+// KeyFun (e.g., "MoveUp" or "KeyFunMoveUp") is translated into that KeyFun
+// by KeyFunFor, so a layer can also remap the standard key functions
+// within its scope.
+type KeyCmds map[key.Chord]string
+
+// KeyCmdsProp is the name of the property holding the KeyCmds layer
+// declared by a widget type (in its type properties) or widget instance
+const KeyCmdsProp = "key-cmds"
+
+// KeyScopeProp is the name of the property holding the scope name of the
+// KeyCmds layer of a widget instance, used for overriding it in the key map
+// preferences -- defaults to the name of the widget
+const KeyScopeProp = "key-scope"
+
+// ActiveKeyScopes are the user overrides of the scoped key binding layers
+// for the ActiveKeyMap, by scope name (see KeyMapsItem.Scopes)
+var ActiveKeyScopes map[string]KeyCmds
+
+// SetKeyCmds sets the KeyCmds layer of the given widget instance, with
+// given scope name for overriding it in the key map preferences
+// (empty = the name of the widget)
+func SetKeyCmds(k ki.Ki, scope string, cmds KeyCmds) {
+	k.SetProp(KeyCmdsProp, cmds)
+	if scope != "" {
+		k.SetProp(KeyScopeProp, scope)
+	}
+}
+
+// KeyTypeScope returns the scope name of the type layer of given node,
+// which is the name of its type without the package, e.g., TextView
+func KeyTypeScope(k ki.Ki) string {
+	return ki.Type(k).Name()
+}
+
+// KeyInstScope returns the scope name of the instance layer of given node
+func KeyInstScope(k ki.Ki) string {
+	if sp := k.Prop(KeyScopeProp); sp != nil {
+		return kit.ToString(sp)
+	}
+	return k.Name()
+}
+
+// keyLayerCmd looks up the chord in one layer: first in the user
+// overrides for its scope, and then in its declared bindings.  An empty
+// override command removes the binding.
+func keyLayerCmd(scope string, decl any, chord key.Chord) (string, bool) {
+	if ov, ok := ActiveKeyScopes[scope]; ok {
+		if cmd, ok := ov[chord]; ok {
+			return cmd, cmd != ""
+		}
+	}
+	if kc, ok := decl.(KeyCmds); ok {
+		cmd, ok := kc[chord]
+		return cmd, ok && cmd != ""
+	}
+	return "", false
+}
+
+// KeyCmdFor returns the named command bound to the given chord for the
+// given (typically focused) node, walking from the node up through its
+// ancestors to the window, and checking the instance layer and then the
+// type layer of each.  Also returns the scope name of the layer where it
+// was found.  Chords that are not bound in any layer return false, and
+// should be looked up in the global ActiveKeyMap (see KeyFunFor).
+func KeyCmdFor(k ki.Ki, chord key.Chord) (cmd, scope string, ok bool) {
+	if chord == "" {
+		return
+	}
+	scope, ok = keyLayersWalk(k, func(scope string, decl any) bool {
+		cmd, ok = keyLayerCmd(scope, decl, chord)
+		return ok
+	})
+	if !ok {
+		return "", "", false
+	}
+	return
+}
+
+// keyLayersWalk calls fun on each of the KeyCmds layers of given node and
+// its ancestors, in lookup order, with the scope name and declared
+// bindings (nil if none) of each, until fun returns true, returning the
+// scope of that layer and true, or false if fun never returns true
+func keyLayersWalk(k ki.Ki, fun func(scope string, decl any) bool) (string, bool) {
+	for n := k; n != nil && !n.IsDeleted(); n = n.Parent() {
+		scope := KeyInstScope(n)
+		if fun(scope, n.Prop(KeyCmdsProp)) {
+			return scope, true
+		}
+		decl, _ := kit.Types.Prop(ki.Type(n), KeyCmdsProp)
+		scope = KeyTypeScope(n)
+		if fun(scope, decl) {
+			return scope, true
+		}
+	}
+	return "", false
+}
+
+// KeyCmdsSeqPrefix returns true if the given chord or chord sequence is
+// the leading part of a multi-key sequence in any of the KeyCmds layers of
+// given node and its ancestors (see KeyCmdFor)
+func KeyCmdsSeqPrefix(k ki.Ki, seq key.Chord) bool {
+	if k == nil || seq == "" {
+		return false
+	}
+	pfx := string(seq) + key.SeqSep
+	has := func(kc KeyCmds) bool {
+		for ch, cmd := range kc {
+			if cmd != "" && strings.HasPrefix(string(ch), pfx) {
+				return true
+			}
+		}
+		return false
+	}
+	_, found := keyLayersWalk(k, func(scope string, decl any) bool {
+		if kc, ok := decl.(KeyCmds); ok && has(kc) {
+			return true
+		}
+		return has(ActiveKeyScopes[scope])
+	})
+	return found
+}
+
+// KeyFunByName returns the KeyFun with given name, with or without the
+// KeyFun prefix, e.g., "MoveUp" or "KeyFunMoveUp"
+func KeyFunByName(nm string) (KeyFuns, bool) {
+	kf := KeyFunNil
+	if err := kf.FromString(nm); err == nil {
+		return kf, true
+	}
+	if err := kf.FromString("KeyFun" + strings.TrimPrefix(nm, "KeyFun")); err == nil {
+		return kf, true
+	}
+	return KeyFunNil, false
+}
+
+// KeyFunFor translates chord into keyboard function for the given
+// (typically focused) node, using the scoped KeyCmds layers of the node
+// and its ancestors (see KeyCmdFor) ahead of the global ActiveKeyMap.  A
+// chord that is bound in a layer to a command that is not a KeyFun falls
+// through to the global ActiveKeyMap -- widgets handle such commands via
+// KeyCmdFor, ahead of the key functions.
+func KeyFunFor(k ki.Ki, chord key.Chord) KeyFuns {
+	if k != nil {
+		if cmd, _, ok := KeyCmdFor(k, chord); ok {
+			if kf, ok := KeyFunByName(cmd); ok {
+				return kf
+			}
+		}
+	}
+	return KeyFun(chord)
+}
@@ -237,6 +237,11 @@ func (lb *Label) Disconnect() {
 	lb.LinkSig.DisconnectAll()
 }
 
+// AccessInfo returns the accessibility info for the label, named by its text
+func (lb *Label) AccessInfo() AccessInfo {
+	return lb.AccessInfoBase(AccessRoleLabel, AccessText(lb.Text))
+}
+
 var LabelProps = ki.Props{
 	ki.EnumTypeFlag: TypeNodeFlags,
 }
@@ -145,7 +145,7 @@ var LayoutFocusNameTabMSec = 2000
 // For a Grid layout, the 'columns' property should generally be set
 // to the desired number of columns, from which the number of rows
 // is computed -- otherwise it uses the square root of number of
-// elements.
+// elements.  Use LayoutGridIrreg for grids with row and column spans.
 type Layout struct {
 	WidgetBase
 
@@ -179,8 +179,11 @@ type Layout struct {
 	// grid data for rows in [0] and cols in [1]
 	GridData [RowColN][]GridData `copy:"-" json:"-" xml:"-" desc:"grid data for rows in [0] and cols in [1]"`
 
-	// line breaks for flow layout
-	FlowBreaks []int `copy:"-" json:"-" xml:"-" desc:"line breaks for flow layout"`
+	// placement of each child in a LayoutGridIrreg grid, computed during Size2D pass
+	gridPlaces []gridPlace
+
+	// line breaks for flow layout, and wrapping flex layout
+	FlowBreaks []int `copy:"-" json:"-" xml:"-" desc:"line breaks for flow layout, and wrapping flex layout"`
 
 	// true if this layout got a redo = true on previous iteration -- otherwise it just skips any re-layout on subsequent iteration
 	NeedsRedo bool `copy:"-" json:"-" xml:"-" desc:"true if this layout got a redo = true on previous iteration -- otherwise it just skips any re-layout on subsequent iteration"`
@@ -240,9 +243,6 @@ const (
 	// LayoutGrid arranges items according to a regular grid
 	LayoutGrid
 
-	// todo: add LayoutGridIrreg that deals with irregular grids with spans etc -- keep
-	// the basic grid for fully regular cases -- need high performance for large grids
-
 	// LayoutHorizFlow arranges items horizontally across a row, overflowing
 	// vertically as needed.  Ballpark target width or height props should be set
 	// to generate initial first-pass sizing estimates.
@@ -262,6 +262,28 @@ const (
 	// parent wants to take over the job of the layout
 	LayoutNil
 
+	// LayoutGridIrreg arranges items according to an irregular grid, where
+	// items can span multiple rows and columns (row-span, col-span), and the
+	// column and row tracks can have fixed, fractional or auto sizes
+	// (grid-template-columns, grid-template-rows), with separate column-gap and
+	// row-gap spacing.  Items with a row or col set are placed there, and the
+	// others fill the remaining cells in order.  LayoutGrid is faster
+	// for large, fully regular grids.
+	LayoutGridIrreg
+
+	// LayoutHorizFlex arranges items horizontally across a row as in a CSS
+	// flexbox (flex-direction: row): items start at their flex-basis size,
+	// and then grow (flex-grow) into any extra space or shrink (flex-shrink)
+	// to fit, and are distributed along the row by justify-content and
+	// aligned within it by align-items, with column-gap spacing between
+	// them.  If flex-wrap is set, items wrap onto multiple rows, separated
+	// by the row-gap.
+	LayoutHorizFlex
+
+	// LayoutVertFlex arranges items vertically down a column as in a CSS
+	// flexbox (flex-direction: column) -- see LayoutHorizFlex.
+	LayoutVertFlex
+
 	LayoutsN
 )
 
@@ -270,6 +292,11 @@ var TypeLayouts = kit.Enums.AddEnumAltLower(LayoutsN, kit.NotBitFlag, gist.Style
 func (ev Layouts) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
 func (ev *Layouts) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
 
+// IsFlex returns true if this is a flex layout (LayoutHorizFlex or LayoutVertFlex)
+func (ev Layouts) IsFlex() bool {
+	return ev == LayoutHorizFlex || ev == LayoutVertFlex
+}
+
 // row / col for grid data
 type RowCol int32
 
@@ -569,7 +596,7 @@ func (ly *Layout) Layout2DChildren(iter int) bool {
 		redo := false
 		for _, kid := range ly.Kids {
 			nii, _ := KiToNode2D(kid)
-			if nii.Layout2D(cbb, iter) {
+			if nii.Layout2D(ly.kidBBox(nii.AsWidget(), cbb), iter) {
 				redo = true
 			}
 		}
@@ -577,7 +604,7 @@ func (ly *Layout) Layout2DChildren(iter int) bool {
 	}
 }
 
-// render the children
+// render the children, in their ZOrder
 func (ly *Layout) Render2DChildren() {
 	if ly.Lay == LayoutStacked {
 		for i, kid := range ly.Kids {
@@ -591,7 +618,8 @@ func (ly *Layout) Render2DChildren() {
 		}
 		// note: all nodes need to render to disconnect b/c of invisible
 	}
-	for _, kid := range ly.Kids {
+	for _, i := range ly.ZOrder() {
+		kid := ly.Kids[i]
 		if kid == nil {
 			continue
 		}
@@ -615,7 +643,8 @@ func (ly *Layout) Move2DChildren(delta image.Point) {
 		for _, kid := range ly.Kids {
 			nii, _ := KiToNode2D(kid)
 			if nii != nil {
-				nii.Move2D(delta, cbb)
+				ni := nii.AsWidget()
+				nii.Move2D(ly.kidDelta(ni, delta), ly.kidBBox(ni, cbb))
 			}
 		}
 	}
@@ -881,6 +910,10 @@ func (ly *Layout) FocusNextChild(updn bool) bool {
 	nxti := idx + 1
 	if ly.Lay == LayoutGrid && updn {
 		nxti = idx + ly.Style.Columns
+	} else if ly.Lay == LayoutGridIrreg && updn {
+		if nxti = ly.GridIrregNeighbor(idx, true); nxti < 0 {
+			return false
+		}
 	}
 	did := false
 	if nxti < sz {
@@ -910,6 +943,10 @@ func (ly *Layout) FocusPrevChild(updn bool) bool {
 	nxti := idx - 1
 	if ly.Lay == LayoutGrid && updn {
 		nxti = idx - ly.Style.Columns
+	} else if ly.Lay == LayoutGridIrreg && updn {
+		if nxti = ly.GridIrregNeighbor(idx, false); nxti < 0 {
+			return false
+		}
 	}
 	did := false
 	if nxti >= 0 {
@@ -932,8 +969,8 @@ func (ly *Layout) LayoutKeys(kt *key.ChordEvent) {
 	if KeyEventTrace {
 		fmt.Printf("Layout KeyInput: %v\n", ly.Path())
 	}
-	kf := KeyFun(kt.Chord())
-	if ly.Lay == LayoutHoriz || ly.Lay == LayoutGrid || ly.Lay == LayoutHorizFlow {
+	kf := KeyFunFor(ly.This(), kt.Chord())
+	if ly.Lay == LayoutHoriz || ly.Lay == LayoutGrid || ly.Lay == LayoutGridIrreg || ly.Lay == LayoutHorizFlow || ly.Lay == LayoutHorizFlex {
 		switch kf {
 		case KeyFunMoveRight:
 			if ly.FocusNextChild(false) { // allow higher layers to try..
@@ -947,7 +984,7 @@ func (ly *Layout) LayoutKeys(kt *key.ChordEvent) {
 			return
 		}
 	}
-	if ly.Lay == LayoutVert || ly.Lay == LayoutGrid || ly.Lay == LayoutVertFlow {
+	if ly.Lay == LayoutVert || ly.Lay == LayoutGrid || ly.Lay == LayoutGridIrreg || ly.Lay == LayoutVertFlow || ly.Lay == LayoutVertFlex {
 		switch kf {
 		case KeyFunMoveDown:
 			if ly.FocusNextChild(true) {
@@ -998,7 +1035,7 @@ func (ly *Layout) FocusOnName(kt *key.ChordEvent) bool {
 	if KeyEventTrace {
 		fmt.Printf("Layout FocusOnName: %v\n", ly.Path())
 	}
-	kf := KeyFun(kt.Chord())
+	kf := KeyFunFor(ly.This(), kt.Chord())
 	delayMs := int(kt.Time().Sub(ly.FocusNameTime) / time.Millisecond)
 	ly.FocusNameTime = kt.Time()
 	if kf == KeyFunFocusNext { // tab means go to next match -- don't worry about time
@@ -1178,10 +1215,7 @@ func (ly *Layout) StyleLayout() {
 	// pr := prof.Start("StyleLayout")
 	// defer pr.End()
 
-	hasTempl, saveTempl := ly.Style.FromTemplate()
-	if !hasTempl || saveTempl {
-		ly.Style2DWidget()
-	}
+	// "lay" comes first, as the style defaults depend on it
 	ly.StyleFromProps(ly.Props, ly.Viewport)           // does "lay" and "spacing", in layoutstyles.go
 	tprops := *kit.Types.Properties(ki.Type(ly), true) // true = makeNew
 	if len(tprops) > 0 {
@@ -1189,6 +1223,10 @@ func (ly *Layout) StyleLayout() {
 		ly.StyleFromProps(tprops, ly.Viewport)
 		kit.TypesMu.RUnlock()
 	}
+	hasTempl, saveTempl := ly.Style.FromTemplate()
+	if !hasTempl || saveTempl {
+		ly.Style2DWidget()
+	}
 	ly.StyleToDots(&ly.Style.UnContext)
 	if hasTempl && saveTempl {
 		ly.Style.SaveTemplate()
@@ -1209,6 +1247,10 @@ func (ly *Layout) Size2D(iter int) {
 		GatherSizesFlow(ly, iter)
 	case LayoutGrid:
 		GatherSizesGrid(ly)
+	case LayoutGridIrreg:
+		GatherSizesGridIrreg(ly)
+	case LayoutHorizFlex, LayoutVertFlex:
+		GatherSizesFlex(ly, iter)
 	default:
 		GatherSizes(ly)
 	}
@@ -1232,6 +1274,8 @@ func (ly *Layout) Layout2D(parBBox image.Rectangle, iter int) bool {
 		LayoutSharedDim(ly, mat32.X)
 	case LayoutGrid:
 		LayoutGridLay(ly)
+	case LayoutGridIrreg:
+		LayoutGridIrregLay(ly)
 	case LayoutStacked:
 		LayoutSharedDim(ly, mat32.X)
 		LayoutSharedDim(ly, mat32.Y)
@@ -1239,9 +1283,14 @@ func (ly *Layout) Layout2D(parBBox image.Rectangle, iter int) bool {
 		redo = LayoutFlow(ly, mat32.X, iter)
 	case LayoutVertFlow:
 		redo = LayoutFlow(ly, mat32.Y, iter)
+	case LayoutHorizFlex:
+		redo = LayoutFlex(ly, mat32.X, iter)
+	case LayoutVertFlex:
+		redo = LayoutFlex(ly, mat32.Y, iter)
 	case LayoutNil:
 		// nothing
 	}
+	LayoutPositioned(ly)
 	ly.FinalizeLayout()
 	if redo && iter == 0 {
 		ly.NeedsRedo = true
@@ -1335,6 +1384,7 @@ func (st *Stretch) OnInit() {
 	st.AddStyler(func(w *WidgetBase, s *gist.Style) {
 		s.MaxWidth.SetPx(-1)
 		s.MaxHeight.SetPx(-1)
+		s.FlexGrow = 1 // also stretches in flex layouts
 	})
 }
 
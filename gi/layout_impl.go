@@ -6,6 +6,7 @@ package gi
 
 import (
 	"fmt"
+	"image"
 
 	"goki.dev/gi/v2/gist"
 	"goki.dev/ki/v2/ints"
@@ -67,10 +68,7 @@ func GatherSizesSumMax(ly *Layout) (sumPref, sumNeed, maxPref, maxNeed mat32.Vec
 	}
 
 	for _, c := range ly.Kids {
-		if c == nil {
-			continue
-		}
-		ni := c.(Node2D).AsWidget()
+		ni := layKid(c)
 		if ni == nil {
 			continue
 		}
@@ -89,7 +87,7 @@ func GatherSizesSumMax(ly *Layout) (sumPref, sumNeed, maxPref, maxNeed mat32.Vec
 
 // GatherSizes is size first pass: gather the size information from the children
 func GatherSizes(ly *Layout) {
-	sz := len(ly.Kids)
+	sz := ly.NumInFlow()
 	if sz == 0 {
 		return
 	}
@@ -159,7 +157,7 @@ func (ly *Layout) ChildrenUpdateSizes() {
 
 // GatherSizesFlow is size first pass: gather the size information from the children
 func GatherSizesFlow(ly *Layout, iter int) {
-	sz := len(ly.Kids)
+	sz := ly.NumInFlow()
 	if sz == 0 {
 		return
 	}
@@ -250,7 +248,7 @@ func GatherSizesFlow(ly *Layout, iter int) {
 	}
 }
 
-// todo: grid does not process spans at all yet -- assumes = 1
+// note: the regular grid does not process spans -- assumes = 1 -- see LayoutGridIrreg
 
 // GatherSizesGrid is size first pass: gather the size information from the
 // children, grid version
@@ -262,13 +260,10 @@ func GatherSizesGrid(ly *Layout) {
 	cols := ly.Style.Columns
 	rows := 0
 
-	sz := len(ly.Kids)
+	sz := ly.NumInFlow()
 	// collect overall size
 	for _, c := range ly.Kids {
-		if c == nil {
-			continue
-		}
-		ni := c.(Node2D).AsWidget()
+		ni := layKid(c)
 		if ni == nil {
 			continue
 		}
@@ -317,10 +312,7 @@ func GatherSizesGrid(ly *Layout) {
 	col := 0
 	row := 0
 	for _, c := range ly.Kids {
-		if c == nil {
-			continue
-		}
-		ni := c.(Node2D).AsWidget()
+		ni := layKid(c)
 		if ni == nil {
 			continue
 		}
@@ -538,10 +530,7 @@ func LayoutSharedDim(ly *Layout, dim mat32.Dims) {
 	spc := ly.BoxSpace()
 	avail := ly.LayState.Alloc.Size.Dim(dim) - spc.Size().Dim(dim)
 	for i, c := range ly.Kids {
-		if c == nil {
-			continue
-		}
-		ni := c.(Node2D).AsWidget()
+		ni := layKid(c)
 		if ni == nil {
 			continue
 		}
@@ -563,7 +552,7 @@ func LayoutSharedDim(ly *Layout, dim mat32.Dims) {
 // LayoutAlongDim lays out all children along given dim -- only affects that dim --
 // e.g., use LayoutSharedDim for other dim.
 func LayoutAlongDim(ly *Layout, dim mat32.Dims) {
-	sz := len(ly.Kids)
+	sz := ly.NumInFlow()
 	if sz == 0 {
 		return
 	}
@@ -593,10 +582,7 @@ func LayoutAlongDim(ly *Layout, dim mat32.Dims) {
 	addSpace := false           // apply extra toward spacing -- for justify
 	if usePref && extra > 0.0 { // have some stretch extra
 		for _, c := range ly.Kids {
-			if c == nil {
-				continue
-			}
-			ni := c.(Node2D).AsWidget()
+			ni := layKid(c)
 			if ni == nil {
 				continue
 			}
@@ -610,10 +596,7 @@ func LayoutAlongDim(ly *Layout, dim mat32.Dims) {
 		}
 	} else if extra > 0.0 { // extra relative to Need
 		for _, c := range ly.Kids {
-			if c == nil {
-				continue
-			}
-			ni := c.(Node2D).AsWidget()
+			ni := layKid(c)
 			if ni == nil {
 				continue
 			}
@@ -647,10 +630,7 @@ func LayoutAlongDim(ly *Layout, dim mat32.Dims) {
 	}
 
 	for i, c := range ly.Kids {
-		if c == nil {
-			continue
-		}
-		ni := c.(Node2D).AsWidget()
+		ni := layKid(c)
 		if ni == nil {
 			continue
 		}
@@ -685,7 +665,7 @@ func LayoutAlongDim(ly *Layout, dim mat32.Dims) {
 // returns true if needs another iteration (only if iter == 0)
 func LayoutFlow(ly *Layout, dim mat32.Dims, iter int) bool {
 	ly.FlowBreaks = nil
-	sz := len(ly.Kids)
+	sz := ly.NumInFlow()
 	if sz == 0 {
 		return false
 	}
@@ -700,10 +680,7 @@ func LayoutFlow(ly *Layout, dim mat32.Dims, iter int) bool {
 	// SidesTODO: might be odim
 	pos := spc.Pos().Dim(dim)
 	for i, c := range ly.Kids {
-		if c == nil {
-			continue
-		}
-		ni := c.(Node2D).AsWidget()
+		ni := layKid(c)
 		if ni == nil {
 			continue
 		}
@@ -731,10 +708,7 @@ func LayoutFlow(ly *Layout, dim mat32.Dims, iter int) bool {
 		rmax := float32(0)
 		for i := ci; i < bi; i++ {
 			c := ly.Kids[i]
-			if c == nil {
-				continue
-			}
-			ni := c.(Node2D).AsWidget()
+			ni := layKid(c)
 			if ni == nil {
 				continue
 			}
@@ -882,15 +856,12 @@ func LayoutGridLay(ly *Layout) {
 	cols := ly.GridSize.X
 	rows := ly.GridSize.Y
 
-	if cols*rows != ly.NumChildren() {
+	if cols*rows != ly.NumInFlow() {
 		GatherSizesGrid(ly)
 	}
 
 	for _, c := range ly.Kids {
-		if c == nil {
-			continue
-		}
-		ni := c.(Node2D).AsWidget()
+		ni := layKid(c)
 		if ni == nil {
 			continue
 		}
@@ -946,6 +917,707 @@ func LayoutGridLay(ly *Layout) {
 	}
 }
 
+////////////////////////////////////////////////////////////////////////////////////////
+//     Irregular Grid
+
+// gridPlace is the placement of a child in a LayoutGridIrreg grid
+type gridPlace struct {
+	ni   *WidgetBase
+	idx  int         // index of the child
+	pos  image.Point // column (X) and row (Y) of the first cell
+	span image.Point // number of columns (X) and rows (Y)
+}
+
+// covers returns true if the placement covers given cell
+func (gp *gridPlace) covers(cell image.Point) bool {
+	return cell.In(image.Rectangle{Min: gp.pos, Max: gp.pos.Add(gp.span)})
+}
+
+// Gap returns the gap between tracks or items along given dim for a
+// LayoutGridIrreg grid or flex layout: the column-gap (X) or row-gap (Y)
+// style, or the Spacing if that is 0
+func (ly *Layout) Gap(dim mat32.Dims) float32 {
+	gap := ly.Style.ColumnGap.Dots
+	if dim == mat32.Y {
+		gap = ly.Style.RowGap.Dots
+	}
+	if gap > 0 {
+		return gap
+	}
+	return ly.Spacing.Dots
+}
+
+// GridTracks returns the track sizes for the rows or columns of a
+// LayoutGridIrreg grid, from grid-template-rows / columns
+func (ly *Layout) GridTracks(rowcol RowCol) []gist.GridTrack {
+	if rowcol == Row {
+		return ly.Style.GridTemplateRows
+	}
+	return ly.Style.GridTemplateColumns
+}
+
+// PlaceGridIrreg places all the children in a LayoutGridIrreg grid,
+// setting the GridSize.  Children with a row or col set (>= 0) are placed
+// there first, with the other one defaulting to 0, and then the others
+// (with row and col both -1) fill the remaining free cells in order,
+// across each row.  The number of columns is given by the
+// grid-template-columns, or else the columns style, and is extended to
+// fit any explicitly placed children.
+func PlaceGridIrreg(ly *Layout) {
+	ly.gridPlaces = ly.gridPlaces[:0]
+	cols := len(ly.Style.GridTemplateColumns)
+	if cols == 0 {
+		cols = ly.Style.Columns
+	}
+	rows := len(ly.Style.GridTemplateRows)
+	nauto := 0
+	for i, c := range ly.Kids {
+		ni := layKid(c)
+		if ni == nil {
+			continue
+		}
+		ni.StyMu.RLock()
+		st := &ni.Style
+		gp := gridPlace{ni: ni, idx: i, span: image.Point{ints.MaxInt(st.ColSpan, 1), ints.MaxInt(st.RowSpan, 1)}}
+		if st.Col >= 0 || st.Row >= 0 {
+			gp.pos = image.Point{ints.MaxInt(st.Col, 0), ints.MaxInt(st.Row, 0)}
+			cols = ints.MaxInt(cols, gp.pos.X+gp.span.X)
+		} else {
+			gp.pos = image.Point{-1, -1}
+			nauto++
+		}
+		ni.StyMu.RUnlock()
+		ly.gridPlaces = append(ly.gridPlaces, gp)
+	}
+	if cols == 0 {
+		cols = ints.MaxInt(int(mat32.Sqrt(float32(nauto))), 1) // as in LayoutGrid
+	}
+
+	used := make(map[image.Point]bool)
+	mark := func(gp *gridPlace) {
+		for r := 0; r < gp.span.Y; r++ {
+			for c := 0; c < gp.span.X; c++ {
+				used[gp.pos.Add(image.Point{c, r})] = true
+			}
+		}
+		rows = ints.MaxInt(rows, gp.pos.Y+gp.span.Y)
+	}
+	free := func(pos, span image.Point) bool {
+		for r := 0; r < span.Y; r++ {
+			for c := 0; c < span.X; c++ {
+				if used[pos.Add(image.Point{c, r})] {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	for i := range ly.gridPlaces {
+		if gp := &ly.gridPlaces[i]; gp.pos.X >= 0 {
+			mark(gp)
+		}
+	}
+	cur := image.Point{}
+	for i := range ly.gridPlaces {
+		gp := &ly.gridPlaces[i]
+		if gp.pos.X >= 0 {
+			continue
+		}
+		gp.span.X = ints.MinInt(gp.span.X, cols)
+		for {
+			if cur.X+gp.span.X > cols {
+				cur.X = 0
+				cur.Y++
+				continue
+			}
+			if free(cur, gp.span) {
+				break
+			}
+			cur.X++
+		}
+		gp.pos = cur
+		mark(gp)
+		cur.X += gp.span.X
+	}
+	ly.GridSize = image.Point{cols, rows}
+}
+
+// GridIrregNeighbor returns the index of the child in the next (down) or
+// previous row of a LayoutGridIrreg grid from the child at given index,
+// in the same column as its first column, or -1 if there is none
+func (ly *Layout) GridIrregNeighbor(idx int, down bool) int {
+	var from *gridPlace
+	for i := range ly.gridPlaces {
+		if ly.gridPlaces[i].idx == idx {
+			from = &ly.gridPlaces[i]
+			break
+		}
+	}
+	if from == nil {
+		return -1
+	}
+	cell := image.Point{from.pos.X, from.pos.Y - 1}
+	if down {
+		cell.Y = from.pos.Y + from.span.Y
+	}
+	for cell.Y >= 0 && cell.Y < ly.GridSize.Y {
+		for i := range ly.gridPlaces {
+			if gp := &ly.gridPlaces[i]; gp.covers(cell) {
+				return gp.idx
+			}
+		}
+		if down {
+			cell.Y++
+		} else {
+			cell.Y--
+		}
+	}
+	return -1
+}
+
+// GatherSizesGridIrreg is size first pass: gather the size information from
+// the children, irregular grid version.  Fixed tracks get their size, and
+// the others get the sizes of the children in them, with any additional
+// space needed by children spanning multiple tracks spread across the
+// non-fixed tracks that they span (the fraction tracks if any).
+func GatherSizesGridIrreg(ly *Layout) {
+	if len(ly.Kids) == 0 {
+		ly.gridPlaces = nil
+		return
+	}
+	PlaceGridIrreg(ly)
+	sizes := [RowColN]int{ly.GridSize.Y, ly.GridSize.X}
+	dims := [RowColN]mat32.Dims{mat32.Y, mat32.X}
+	for rc := Row; rc < RowColN; rc++ {
+		if len(ly.GridData[rc]) != sizes[rc] {
+			ly.GridData[rc] = make([]GridData, sizes[rc])
+		}
+		tracks := ly.GridTracks(rc)
+		for i := range ly.GridData[rc] {
+			gd := &ly.GridData[rc][i]
+			*gd = GridData{}
+			switch gt := gist.GridTrackAt(tracks, i); gt.Type {
+			case gist.GridTrackFixed:
+				gd.SizeNeed = gt.Size.Dots
+				gd.SizePref = gt.Size.Dots
+				gd.SizeMax = gt.Size.Dots
+			case gist.GridTrackFr:
+				gd.SizeMax = -1
+			}
+		}
+	}
+
+	for i := range ly.gridPlaces {
+		ly.gridPlaces[i].ni.LayState.UpdateSizes()
+	}
+	// single-track children first, so spanning children only add what is missing
+	for pass := 0; pass < 2; pass++ {
+		for i := range ly.gridPlaces {
+			gp := &ly.gridPlaces[i]
+			ls := &gp.ni.LayState
+			for rc := Row; rc < RowColN; rc++ {
+				dim := dims[rc]
+				st, span := gp.pos.Y, gp.span.Y
+				if rc == Col {
+					st, span = gp.pos.X, gp.span.X
+				}
+				if (span > 1) != (pass == 1) {
+					continue
+				}
+				gridIrregAddSizes(ly.GridData[rc][st:st+span], ly.GridTracks(rc)[ints.MinInt(st, len(ly.GridTracks(rc))):],
+					ls.Size.Need.Dim(dim), ls.Size.Pref.Dim(dim), ls.Size.Max.Dim(dim), ly.Gap(dim))
+			}
+		}
+	}
+
+	spc := ly.BoxSpace()
+	for rc := Row; rc < RowColN; rc++ {
+		dim := dims[rc]
+		gaps := float32(sizes[rc]-1) * ly.Gap(dim)
+		sumNeed, sumPref := gaps, gaps
+		for _, gd := range ly.GridData[rc] {
+			sumNeed += gd.SizeNeed
+			sumPref += gd.SizePref
+		}
+		if ly.LayState.Size.Pref.Dim(dim) == 0 {
+			ly.LayState.Size.Need.SetDim(dim, mat32.Max(ly.LayState.Size.Need.Dim(dim), sumNeed))
+			ly.LayState.Size.Pref.SetDim(dim, mat32.Max(ly.LayState.Size.Pref.Dim(dim), sumPref))
+		} else { // use target size from style otherwise
+			ly.LayState.Size.Need.SetDim(dim, ly.LayState.Size.Pref.Dim(dim))
+		}
+	}
+	ly.LayState.Size.Need.SetAdd(spc.Size())
+	ly.LayState.Size.Pref.SetAdd(spc.Size())
+	ly.LayState.UpdateSizes() // enforce max and normal ordering, etc
+	if Layout2DTrace {
+		fmt.Printf("Size:   %v gather sizes irregular grid: %v need: %v, pref: %v\n", ly.Path(), ly.GridSize, ly.LayState.Size.Need, ly.LayState.Size.Pref)
+	}
+}
+
+// gridIrregAddSizes adds the sizes of a child to the grid tracks that it
+// spans, which start at the start of given tracks (which can be shorter)
+func gridIrregAddSizes(gds []GridData, tracks []gist.GridTrack, need, pref, max, gap float32) {
+	if len(gds) == 1 {
+		if gist.GridTrackAt(tracks, 0).Type == gist.GridTrackFixed {
+			return
+		}
+		gd := &gds[0]
+		mat32.SetMax(&gd.SizeNeed, need)
+		mat32.SetMax(&gd.SizePref, pref)
+		if gd.SizeMax >= 0 { // any -1 stretch dominates, else accumulate any max
+			if max < 0 {
+				gd.SizeMax = -1
+			} else {
+				mat32.SetMax(&gd.SizeMax, max)
+			}
+		}
+		return
+	}
+	// spread any missing size over the fraction tracks, or else the auto tracks
+	var flex []int
+	for _, typ := range []gist.GridTrackTypes{gist.GridTrackFr, gist.GridTrackAuto} {
+		for i := range gds {
+			if gist.GridTrackAt(tracks, i).Type == typ {
+				flex = append(flex, i)
+			}
+		}
+		if len(flex) > 0 {
+			break
+		}
+	}
+	if len(flex) == 0 {
+		return
+	}
+	gaps := float32(len(gds)-1) * gap
+	sumNeed, sumPref := gaps, gaps
+	for _, gd := range gds {
+		sumNeed += gd.SizeNeed
+		sumPref += gd.SizePref
+	}
+	addNeed := mat32.Max(need-sumNeed, 0) / float32(len(flex))
+	addPref := mat32.Max(pref-sumPref, 0) / float32(len(flex))
+	for _, i := range flex {
+		gd := &gds[i]
+		gd.SizeNeed += addNeed
+		gd.SizePref += addPref
+		if max < 0 {
+			gd.SizeMax = -1
+		}
+	}
+}
+
+// LayoutGridIrregDim lays out the tracks of a LayoutGridIrreg grid along
+// given dimension (row, Y; col, X).  Tracks get their preferred sizes if they
+// fit, and otherwise their needed sizes.  Any extra space goes to the
+// fraction tracks, in proportion to their fraction but never less than their
+// own size, or else to the auto tracks that stretch, in proportion to their
+// preferred size, or else it is used for the alignment of the grid.
+func LayoutGridIrregDim(ly *Layout, rowcol RowCol, dim mat32.Dims) {
+	gds := ly.GridData[rowcol]
+	sz := len(gds)
+	if sz == 0 {
+		return
+	}
+	tracks := ly.GridTracks(rowcol)
+	gap := ly.Gap(dim)
+	spc := ly.BoxSpace()
+	avail := ly.LayState.Alloc.Size.Dim(dim) - spc.Size().Dim(dim) - float32(sz-1)*gap
+
+	sumPref := float32(0)
+	for _, gd := range gds {
+		sumPref += gd.SizePref
+	}
+	usePref := sumPref <= avail+0.1
+	sizes := make([]float32, sz)
+	sum := float32(0)
+	for i, gd := range gds {
+		sizes[i] = gd.SizeNeed
+		if usePref {
+			sizes[i] = gd.SizePref
+		}
+		sum += sizes[i]
+	}
+	extra := mat32.Max(avail-sum, 0)
+
+	flex := make(map[int]bool)
+	frTot := float32(0)
+	for i := range gds {
+		if gt := gist.GridTrackAt(tracks, i); gt.Type == gist.GridTrackFr && gt.Fr > 0 {
+			flex[i] = true
+			frTot += gt.Fr
+		}
+	}
+	al := ly.Style.AlignDim(dim)
+	extraSpace := float32(0)
+	pos := spc.Pos().Dim(dim)
+	switch {
+	case extra > 0 && frTot > 0:
+		// fraction tracks whose own size is more than their share keep their
+		// size, and the rest share what is left, until that is stable
+		var unit float32
+		for {
+			free := avail
+			for i := range gds {
+				if !flex[i] {
+					free -= sizes[i]
+				}
+			}
+			unit = free / frTot
+			changed := false
+			for i := range flex {
+				if fr := gist.GridTrackAt(tracks, i).Fr; sizes[i] > unit*fr {
+					delete(flex, i)
+					frTot -= fr
+					changed = true
+				}
+			}
+			if !changed || frTot <= 0 {
+				break
+			}
+		}
+		for i := range flex {
+			sizes[i] = unit * gist.GridTrackAt(tracks, i).Fr
+		}
+	case extra > 0:
+		stretchTot := float32(0)
+		for i, gd := range gds {
+			if gd.SizeMax < 0 {
+				stretchTot += sizes[i]
+			}
+		}
+		if stretchTot > 0 {
+			for i, gd := range gds {
+				if gd.SizeMax < 0 {
+					sizes[i] += extra * (sizes[i] / stretchTot)
+				}
+			}
+		} else if gist.IsAlignMiddle(al) {
+			pos += 0.5 * extra
+		} else if gist.IsAlignEnd(al) {
+			pos += extra
+		} else if al == gist.AlignJustify && sz > 1 {
+			extraSpace = extra / float32(sz-1)
+		}
+	}
+
+	if Layout2DTrace {
+		fmt.Printf("Layout Irregular Grid Dim: %v All on dim %v, avail: %v pref: %v, usePref: %v, extra %v\n", ly.Path(), dim, avail, sumPref, usePref, extra)
+	}
+	for i := range gds {
+		gd := &gds[i]
+		gd.AllocSize = sizes[i]
+		gd.AllocPosRel = pos
+		pos += sizes[i] + gap + extraSpace
+	}
+}
+
+// LayoutGridIrregLay manages overall irregular grid layout of children:
+// each child is laid out within the area of the cells that it spans
+func LayoutGridIrregLay(ly *Layout) {
+	if len(ly.Kids) == 0 {
+		return
+	}
+	if len(ly.gridPlaces) == 0 {
+		GatherSizesGridIrreg(ly)
+	}
+	LayoutGridIrregDim(ly, Row, mat32.Y)
+	LayoutGridIrregDim(ly, Col, mat32.X)
+
+	for i := range ly.gridPlaces {
+		gp := &ly.gridPlaces[i]
+		ni := gp.ni
+		for _, dim := range []mat32.Dims{mat32.X, mat32.Y} {
+			gds := ly.GridData[Col][gp.pos.X : gp.pos.X+gp.span.X]
+			if dim == mat32.Y {
+				gds = ly.GridData[Row][gp.pos.Y : gp.pos.Y+gp.span.Y]
+			}
+			first, last := gds[0], gds[len(gds)-1]
+			avail := last.AllocPosRel + last.AllocSize - first.AllocPosRel
+			ni.StyMu.RLock()
+			al := ni.Style.AlignDim(dim)
+			ni.StyMu.RUnlock()
+			pref := ni.LayState.Size.Pref.Dim(dim)
+			need := ni.LayState.Size.Need.Dim(dim)
+			max := ni.LayState.Size.Max.Dim(dim)
+			pos, size := LayoutSharedDimImpl(ly, avail, need, pref, max, gist.SideFloats{}, al)
+			ni.LayState.Alloc.Size.SetDim(dim, size)
+			ni.LayState.Alloc.PosRel.SetDim(dim, pos+first.AllocPosRel)
+		}
+		if Layout2DTrace {
+			fmt.Printf("Layout: %v irregular grid cell: %v span: %v pos: %v size: %v\n", ly.Path(), gp.pos, gp.span, ni.LayState.Alloc.PosRel, ni.LayState.Alloc.Size)
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//     Flex
+
+// flexItem is the flex layout state of one child along the main dimension
+type flexItem struct {
+	ni     *WidgetBase
+	base   float32 // flex base size: flex-basis, or pref if auto
+	need   float32 // minimum size
+	max    float32 // maximum size, if > 0
+	grow   float32
+	shrink float32
+	size   float32 // resolved size
+	frozen bool
+}
+
+// hypo returns the hypothetical size of the item, clamped to its min and max
+func (fi *flexItem) hypo() float32 {
+	sz := mat32.Max(fi.base, fi.need)
+	if fi.max > 0 {
+		sz = mat32.Max(mat32.Min(sz, fi.max), fi.need)
+	}
+	return sz
+}
+
+// flexItems returns the flex items for the children of given flex layout,
+// along given main dimension
+func flexItems(ly *Layout, dim mat32.Dims) []flexItem {
+	fis := make([]flexItem, 0, len(ly.Kids))
+	for _, c := range ly.Kids {
+		ni := layKid(c)
+		if ni == nil {
+			continue
+		}
+		ni.LayState.UpdateSizes()
+		ni.StyMu.RLock()
+		fi := flexItem{ni: ni, base: ni.Style.FlexBasis.Dots, grow: ni.Style.FlexGrow, shrink: ni.Style.FlexShrink}
+		ni.StyMu.RUnlock()
+		if fi.base < 0 { // auto
+			fi.base = ni.LayState.Size.Pref.Dim(dim)
+		}
+		fi.need = ni.LayState.Size.Need.Dim(dim)
+		fi.max = ni.LayState.Size.Max.Dim(dim)
+		fis = append(fis, fi)
+	}
+	return fis
+}
+
+// GatherSizesFlex is size first pass: gather the size information from the
+// children, flex version.  Along the main dimension, the need is the sum of
+// the needs of the children (or the largest one if wrapping), and the pref is
+// the sum of their flex base sizes.  Across, it is the max of the children.
+// Wrapping layouts are redone in a second iteration with the actual size,
+// as for the flow layouts.
+func GatherSizesFlex(ly *Layout, iter int) {
+	if len(ly.Kids) == 0 {
+		return
+	}
+	if iter > 0 && ly.Style.FlexWrap {
+		GatherSizesFlow(ly, iter) // same fixed size from first iteration
+		return
+	}
+	dim := LaySummedDim(ly.Lay)
+	odim := mat32.OtherDim(dim)
+	fis := flexItems(ly, dim)
+	gaps := float32(ints.MaxInt(len(fis)-1, 0)) * ly.Gap(dim)
+	sumNeed, sumPref, maxNeed := gaps, gaps, float32(0)
+	var oNeed, oPref float32
+	for i := range fis {
+		fi := &fis[i]
+		sumNeed += fi.need
+		sumPref += fi.hypo()
+		maxNeed = mat32.Max(maxNeed, fi.need)
+		ls := &fi.ni.LayState
+		oNeed = mat32.Max(oNeed, ls.Size.Need.Dim(odim))
+		oPref = mat32.Max(oPref, ls.Size.Pref.Dim(odim))
+	}
+	if ly.Style.FlexWrap {
+		sumNeed = maxNeed // can always wrap down to one item per line
+	}
+	ly.LayState.Size.Need.SetMaxDim(dim, sumNeed)
+	ly.LayState.Size.Pref.SetMaxDim(dim, sumPref)
+	ly.LayState.Size.Need.SetMaxDim(odim, oNeed)
+	ly.LayState.Size.Pref.SetMaxDim(odim, oPref)
+
+	spc := ly.BoxSpace()
+	ly.LayState.Size.Need.SetAdd(spc.Size())
+	ly.LayState.Size.Pref.SetAdd(spc.Size())
+	ly.LayState.UpdateSizes() // enforce max and normal ordering, etc
+	if Layout2DTrace {
+		fmt.Printf("Size:   %v gather sizes flex need: %v, pref: %v\n", ly.Path(), ly.LayState.Size.Need, ly.LayState.Size.Pref)
+	}
+}
+
+// flexResolve resolves the sizes of the items in one line of a flex layout,
+// growing or shrinking them to fill the available space, as in CSS: items
+// that hit their min or max size are frozen there, and the remaining space
+// is distributed again over the others.
+func flexResolve(fis []flexItem, avail float32) {
+	sum := float32(0)
+	for i := range fis {
+		fi := &fis[i]
+		fi.size = fi.hypo()
+		fi.frozen = false
+		sum += fi.size
+	}
+	growing := avail > sum
+	for i := range fis {
+		fi := &fis[i]
+		if (growing && fi.grow <= 0) || (!growing && fi.shrink <= 0) {
+			fi.frozen = true
+		}
+	}
+	for {
+		free := avail
+		tot := float32(0)
+		for i := range fis {
+			fi := &fis[i]
+			if fi.frozen {
+				free -= fi.size
+				continue
+			}
+			free -= fi.base
+			if growing {
+				tot += fi.grow
+			} else {
+				tot += fi.shrink * fi.base
+			}
+		}
+		if tot <= 0 {
+			return
+		}
+		changed := false
+		for i := range fis {
+			fi := &fis[i]
+			if fi.frozen {
+				continue
+			}
+			if growing {
+				fi.size = fi.base + free*fi.grow/tot
+			} else {
+				fi.size = fi.base + free*fi.shrink*fi.base/tot
+			}
+			if fi.max > 0 && fi.size > fi.max {
+				fi.size = fi.max
+				fi.frozen = true
+				changed = true
+			}
+			if fi.size < fi.need {
+				fi.size = fi.need
+				fi.frozen = true
+				changed = true
+			}
+		}
+		if !changed {
+			return
+		}
+	}
+}
+
+// LayoutFlex manages the flex layout along given main dimension,
+// returning true if it needs another iteration (only if iter == 0),
+// when the items wrap onto multiple lines
+func LayoutFlex(ly *Layout, dim mat32.Dims, iter int) bool {
+	ly.FlowBreaks = nil
+	fis := flexItems(ly, dim)
+	if len(fis) == 0 {
+		return false
+	}
+	odim := mat32.OtherDim(dim)
+	gap := ly.Gap(dim)
+	ogap := ly.Gap(odim)
+	spc := ly.BoxSpace()
+	avail := ly.LayState.Alloc.Size.Dim(dim) - spc.Size().Dim(dim)
+	oavail := ly.LayState.Alloc.Size.Dim(odim) - spc.Size().Dim(odim)
+
+	// break into lines
+	if ly.Style.FlexWrap {
+		pos := float32(0)
+		for i := range fis {
+			sz := fis[i].hypo()
+			if i > 0 && pos+sz > avail {
+				ly.FlowBreaks = append(ly.FlowBreaks, i)
+				pos = 0
+			}
+			pos += sz + gap
+		}
+	}
+	ly.FlowBreaks = append(ly.FlowBreaks, len(fis))
+	nlines := len(ly.FlowBreaks)
+
+	// cross size of each line: the largest item, with any extra space shared
+	lsizes := make([]float32, nlines)
+	st := 0
+	ltot := float32(nlines-1) * ogap
+	for li, bi := range ly.FlowBreaks {
+		for i := st; i < bi; i++ {
+			ls := &fis[i].ni.LayState
+			lsizes[li] = mat32.Max(lsizes[li], mat32.Max(ls.Size.Pref.Dim(odim), ls.Size.Need.Dim(odim)))
+		}
+		ltot += lsizes[li]
+		st = bi
+	}
+	if extra := oavail - ltot; extra > 0 {
+		for li := range lsizes {
+			lsizes[li] += extra / float32(nlines)
+		}
+	}
+
+	jc := ly.Style.JustifyContent
+	ai := ly.Style.AlignItems
+	st = 0
+	opos := spc.Pos().Dim(odim)
+	for li, bi := range ly.FlowBreaks {
+		line := fis[st:bi]
+		n := len(line)
+		gaps := float32(n-1) * gap
+		flexResolve(line, avail-gaps)
+		sum := gaps
+		for i := range line {
+			sum += line[i].size
+		}
+		extra := mat32.Max(avail-sum, 0)
+		pos := spc.Pos().Dim(dim)
+		between := gap
+		switch {
+		case gist.IsAlignMiddle(jc):
+			pos += 0.5 * extra
+		case gist.IsAlignEnd(jc):
+			pos += extra
+		case jc == gist.AlignJustify && n > 1:
+			between += extra / float32(n-1)
+		case jc == gist.AlignSpaceAround:
+			pos += 0.5 * extra / float32(n)
+			between += extra / float32(n)
+		case jc == gist.AlignSpaceEvenly:
+			pos += extra / float32(n+1)
+			between += extra / float32(n+1)
+		}
+		for i := range line {
+			fi := &line[i]
+			ls := &fi.ni.LayState
+			ls.Alloc.Size.SetDim(dim, fi.size)
+			ls.Alloc.PosRel.SetDim(dim, pos)
+			pos += fi.size + between
+
+			need := ls.Size.Need.Dim(odim)
+			pref := ls.Size.Pref.Dim(odim)
+			max := ls.Size.Max.Dim(odim)
+			var op, osz float32
+			if ai == gist.AlignStretch {
+				osz = lsizes[li]
+				if max > 0 {
+					osz = mat32.Max(mat32.Min(osz, max), need)
+				}
+			} else {
+				op, osz = LayoutSharedDimImpl(ly, lsizes[li], need, pref, max, gist.SideFloats{}, ai)
+			}
+			ls.Alloc.Size.SetDim(odim, osz)
+			ls.Alloc.PosRel.SetDim(odim, opos+op)
+			if Layout2DTrace {
+				fmt.Printf("Layout: %v Flex Child: %v, line: %v, pos: %v, size: %v\n", ly.Path(), fi.ni.Nm, li, ls.Alloc.PosRel, ls.Alloc.Size)
+			}
+		}
+		opos += lsizes[li] + ogap
+		st = bi
+	}
+	return iter == 0 && nlines > 1
+}
+
 // FinalizeLayout is final pass through children to finalize the layout,
 // computing summary size stats
 func (ly *Layout) FinalizeLayout() {
@@ -964,10 +1636,7 @@ func (ly *Layout) FinalizeLayout() {
 		return
 	}
 	for _, c := range ly.Kids {
-		if c == nil {
-			continue
-		}
-		ni := c.(Node2D).AsWidget()
+		ni := layKid(c)
 		if ni == nil {
 			continue
 		}
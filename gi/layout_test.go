@@ -0,0 +1,248 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"image"
+	"testing"
+
+	"goki.dev/gi/v2/gist"
+	"goki.dev/gi/v2/units"
+	"goki.dev/ki/v2/ki"
+	"goki.dev/mat32/v2"
+)
+
+func TestPlaceGridIrreg(t *testing.T) {
+	type kid struct {
+		row, col, rowSpan, colSpan int
+	}
+	const auto = -1
+	tests := []struct {
+		name string
+		cols int
+		kids []kid
+		pos  []image.Point
+		size image.Point
+	}{
+		{"all auto", 2,
+			[]kid{{auto, auto, 0, 0}, {auto, auto, 0, 0}, {auto, auto, 0, 0}},
+			[]image.Point{{0, 0}, {1, 0}, {0, 1}}, image.Point{2, 2}},
+		{"explicit row and col 0", 3,
+			[]kid{{auto, auto, 0, 0}, {0, 0, 0, 0}, {auto, auto, 0, 0}},
+			[]image.Point{{1, 0}, {0, 0}, {2, 0}}, image.Point{3, 1}},
+		{"col only", 2,
+			[]kid{{auto, auto, 0, 0}, {auto, 1, 0, 0}},
+			[]image.Point{{0, 0}, {1, 0}}, image.Point{2, 1}},
+		{"row only", 2,
+			[]kid{{2, auto, 0, 0}, {auto, auto, 0, 0}},
+			[]image.Point{{0, 2}, {0, 0}}, image.Point{2, 3}},
+		{"spans", 3,
+			[]kid{{0, 0, 2, 1}, {auto, auto, 0, 2}, {auto, auto, 0, 2}, {auto, auto, 0, 0}},
+			[]image.Point{{0, 0}, {1, 0}, {1, 1}, {0, 2}}, image.Point{3, 3}},
+		{"extends cols", 2,
+			[]kid{{0, 3, 0, 0}, {auto, auto, 0, 0}},
+			[]image.Point{{3, 0}, {0, 0}}, image.Point{4, 1}},
+	}
+	for _, tt := range tests {
+		ly := &Layout{}
+		ly.InitName(ly, "grid")
+		ly.Lay = LayoutGridIrreg
+		ly.Style.Defaults()
+		ly.Style.Columns = tt.cols
+		for i, k := range tt.kids {
+			fr := AddNewFrame(ly, "kid", LayoutVert)
+			fr.SetName(fr.Nm + string(rune('a'+i)))
+			fr.Style.Defaults()
+			if fr.Style.Row != auto || fr.Style.Col != auto {
+				t.Fatalf("default row, col = %d, %d, want auto", fr.Style.Row, fr.Style.Col)
+			}
+			fr.Style.Row, fr.Style.Col = k.row, k.col
+			fr.Style.RowSpan, fr.Style.ColSpan = k.rowSpan, k.colSpan
+		}
+		PlaceGridIrreg(ly)
+		if ly.GridSize != tt.size {
+			t.Errorf("%s: grid size = %v, want %v", tt.name, ly.GridSize, tt.size)
+		}
+		for _, gp := range ly.gridPlaces {
+			if gp.pos != tt.pos[gp.idx] {
+				t.Errorf("%s: kid %d at %v, want %v", tt.name, gp.idx, gp.pos, tt.pos[gp.idx])
+			}
+		}
+	}
+}
+
+// TestLayoutGridIrreg lays out a grid with columns of fixed 30px, auto, 1fr,
+// 2fr and fixed 20px, and rows of 1fr each, with a column gap of 10 and a
+// row gap of 5:
+//
+//	a  b  c  c  f
+//	d  d  .  e  f
+func TestLayoutGridIrreg(t *testing.T) {
+	fixed := func(px float32) gist.GridTrack {
+		v := units.Px(px)
+		v.Dots = px
+		return gist.GridTrackFixedSize(v)
+	}
+	ly := &Layout{}
+	ly.InitName(ly, "grid")
+	ly.Lay = LayoutGridIrreg
+	ly.Style.Defaults()
+	ly.Style.GridTemplateColumns = []gist.GridTrack{fixed(30), gist.GridTrackAutoSize(), gist.GridTrackFraction(1), gist.GridTrackFraction(2), fixed(20)}
+	ly.Style.GridTemplateRows = []gist.GridTrack{gist.GridTrackFraction(1), gist.GridTrackFraction(1)}
+	ly.Style.ColumnGap.Dots = 10
+	ly.Style.RowGap.Dots = 5
+
+	kids := []struct {
+		name                       string
+		row, col, rowSpan, colSpan int
+		size                       mat32.Vec2
+		maxX                       float32
+		alH, alV                   gist.Align
+		pos, alloc                 mat32.Vec2
+	}{
+		{"a", 0, 0, 0, 0, mat32.Vec2{20, 10}, 0, gist.AlignLeft, gist.AlignTop, mat32.Vec2{0, 0}, mat32.Vec2{20, 10}},
+		{"b", 0, 1, 0, 0, mat32.Vec2{40, 20}, 0, gist.AlignCenter, gist.AlignTop, mat32.Vec2{50, 0}, mat32.Vec2{40, 20}},
+		{"c", 0, 2, 0, 2, mat32.Vec2{90, 15}, -1, gist.AlignLeft, gist.AlignTop, mat32.Vec2{110, 0}, mat32.Vec2{310, 15}},
+		{"d", 1, 0, 0, 2, mat32.Vec2{100, 30}, 0, gist.AlignLeft, gist.AlignTop, mat32.Vec2{0, 35}, mat32.Vec2{100, 30}},
+		{"e", 1, 3, 0, 0, mat32.Vec2{30, 25}, 0, gist.AlignLeft, gist.AlignTop, mat32.Vec2{220, 35}, mat32.Vec2{30, 25}},
+		{"f", 0, 4, 2, 0, mat32.Vec2{15, 65}, 0, gist.AlignLeft, gist.AlignBottom, mat32.Vec2{430, 5}, mat32.Vec2{15, 65}},
+	}
+	for _, k := range kids {
+		fr := AddNewFrame(ly, k.name, LayoutVert)
+		fr.Style.Defaults()
+		fr.Style.Row, fr.Style.Col = k.row, k.col
+		fr.Style.RowSpan, fr.Style.ColSpan = k.rowSpan, k.colSpan
+		fr.Style.AlignH, fr.Style.AlignV = k.alH, k.alV
+		fr.LayState.Size.Need = k.size
+		fr.LayState.Size.Pref = k.size
+		fr.LayState.Size.Max = mat32.Vec2{k.maxX, 0}
+	}
+
+	GatherSizesGridIrreg(ly)
+	// the spanning c only adds what e does not already give to the 2fr
+	// column, and d adds what it misses to the auto column
+	gathered := []struct {
+		rowcol RowCol
+		pref   []float32
+	}{
+		{Col, []float32{30, 60, 25, 55, 20}},
+		{Row, []float32{25, 35}},
+	}
+	for _, tt := range gathered {
+		for i, gd := range ly.GridData[tt.rowcol] {
+			if gd.SizePref != tt.pref[i] {
+				t.Errorf("%v %d: pref %v, want %v", tt.rowcol, i, gd.SizePref, tt.pref[i])
+			}
+		}
+	}
+	if want := (mat32.Vec2{230, 65}); ly.LayState.Size.Pref != want {
+		t.Errorf("grid pref %v, want %v", ly.LayState.Size.Pref, want)
+	}
+
+	// the 1fr and 2fr columns share 300 of extra space, and the 1fr row
+	// keeping its own size leaves the other one the rest
+	ly.LayState.Alloc.Size = mat32.Vec2{450, 70}
+	LayoutGridIrregLay(ly)
+	tracks := []struct {
+		rowcol    RowCol
+		pos, size []float32
+	}{
+		{Col, []float32{0, 40, 110, 220, 430}, []float32{30, 60, 100, 200, 20}},
+		{Row, []float32{0, 35}, []float32{30, 35}},
+	}
+	for _, tt := range tracks {
+		for i, gd := range ly.GridData[tt.rowcol] {
+			if gd.AllocPosRel != tt.pos[i] || gd.AllocSize != tt.size[i] {
+				t.Errorf("%v %d: got %v, %v, want %v, %v", tt.rowcol, i, gd.AllocPosRel, gd.AllocSize, tt.pos[i], tt.size[i])
+			}
+		}
+	}
+	for i, k := range kids {
+		ls := &ly.Kids[i].(*Frame).LayState
+		if ls.Alloc.PosRel != k.pos || ls.Alloc.Size != k.alloc {
+			t.Errorf("%s: got %v, %v, want %v, %v", k.name, ls.Alloc.PosRel, ls.Alloc.Size, k.pos, k.alloc)
+		}
+	}
+}
+
+func TestFlexStyleDefaults(t *testing.T) {
+	tests := []struct {
+		lay   Layouts
+		props ki.Props
+		want  gist.Align
+	}{
+		{LayoutVert, nil, gist.AlignLeft},
+		{LayoutHorizFlex, nil, gist.AlignStretch},
+		{LayoutVertFlex, nil, gist.AlignStretch},
+		{LayoutVert, ki.Props{"lay": LayoutHorizFlex}, gist.AlignStretch},
+	}
+	for _, tt := range tests {
+		vp := NewViewport2D(100, 100)
+		vp.InitName(vp, "vp")
+		ly := AddNewLayout(vp, "ly", tt.lay)
+		for k, v := range tt.props {
+			ly.SetProp(k, v)
+		}
+		ly.Style2D()
+		if ly.Style.JustifyContent != gist.AlignFlexStart {
+			t.Errorf("%v %v: justify-content = %v, want %v", tt.lay, tt.props, ly.Style.JustifyContent, gist.AlignFlexStart)
+		}
+		if ly.Style.AlignItems != tt.want {
+			t.Errorf("%v %v: align-items = %v, want %v", tt.lay, tt.props, ly.Style.AlignItems, tt.want)
+		}
+	}
+}
+
+func TestFlexStyleOverride(t *testing.T) {
+	vp := NewViewport2D(100, 100)
+	vp.InitName(vp, "vp")
+	ly := AddNewLayout(vp, "ly", LayoutHorizFlex)
+	ly.AddStyler(func(w *WidgetBase, s *gist.Style) {
+		s.AlignItems = gist.AlignCenter
+	})
+	ly.Style2D()
+	if ly.Style.AlignItems != gist.AlignCenter {
+		t.Errorf("align-items = %v, want %v", ly.Style.AlignItems, gist.AlignCenter)
+	}
+}
+
+func TestFlexResolve(t *testing.T) {
+	type item struct {
+		base, need, max, grow, shrink float32
+	}
+	tests := []struct {
+		name  string
+		items []item
+		avail float32
+		want  []float32
+	}{
+		{"grow equal", []item{{100, 0, 0, 1, 1}, {100, 0, 0, 1, 1}}, 300, []float32{150, 150}},
+		{"grow weighted", []item{{50, 0, 0, 1, 1}, {50, 0, 0, 3, 1}}, 300, []float32{100, 200}},
+		{"no grow", []item{{100, 0, 0, 0, 1}, {100, 0, 0, 0, 1}}, 300, []float32{100, 100}},
+		{"grow one", []item{{100, 0, 0, 0, 1}, {100, 0, 0, 1, 1}}, 300, []float32{100, 200}},
+		{"grow to max", []item{{50, 0, 80, 1, 1}, {50, 0, 0, 1, 1}}, 300, []float32{80, 220}},
+		{"all at max", []item{{50, 0, 80, 1, 1}, {50, 0, 60, 1, 1}}, 300, []float32{80, 60}},
+		{"exact fit", []item{{100, 0, 0, 1, 1}, {200, 0, 0, 1, 1}}, 300, []float32{100, 200}},
+		{"shrink by base", []item{{200, 0, 0, 0, 1}, {100, 0, 0, 0, 1}}, 240, []float32{160, 80}},
+		{"shrink weighted", []item{{100, 0, 0, 0, 3}, {100, 0, 0, 0, 1}}, 160, []float32{70, 90}},
+		{"shrink to min", []item{{200, 0, 0, 0, 1}, {100, 90, 0, 0, 1}}, 240, []float32{150, 90}},
+		{"no shrink", []item{{200, 0, 0, 1, 0}, {100, 0, 0, 1, 0}}, 240, []float32{200, 100}},
+		{"shrink one", []item{{200, 0, 0, 0, 0}, {100, 0, 0, 0, 1}}, 240, []float32{200, 40}},
+		{"need over base", []item{{50, 80, 0, 0, 0}, {100, 0, 0, 1, 1}}, 300, []float32{80, 220}},
+		{"need over max", []item{{100, 60, 40, 0, 0}}, 300, []float32{60}},
+	}
+	for _, tt := range tests {
+		fis := make([]flexItem, len(tt.items))
+		for i, it := range tt.items {
+			fis[i] = flexItem{base: it.base, need: it.need, max: it.max, grow: it.grow, shrink: it.shrink}
+		}
+		flexResolve(fis, tt.avail)
+		for i, fi := range fis {
+			if mat32.Abs(fi.size-tt.want[i]) > 0.01 {
+				t.Errorf("%s: item %d: got %v, want %v", tt.name, i, fi.size, tt.want[i])
+			}
+		}
+	}
+}
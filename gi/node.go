@@ -481,6 +481,15 @@ func (nb *NodeBase) AddClass(cls string) {
 	} else {
 		nb.Class += " " + cls
 	}
+	cssClassChanged()
+}
+
+// SetClass sets the CSS class name(s), separated by spaces, so that the
+// CSS selectors of this node and of the nodes below and after it that
+// depend on them are matched again on the next styling
+func (nb *NodeBase) SetClass(cls string) {
+	nb.Class = cls
+	cssClassChanged()
 }
 
 // HasClass returns whether the node has the given class name
@@ -543,7 +552,7 @@ func SetStdXMLAttr(ni Node, name, val string) bool {
 		nb.SetName(val)
 		return true
 	case "class":
-		nb.Class = val
+		nb.SetClass(val)
 		return true
 	case "style":
 		gist.SetStylePropsXML(val, &nb.Props)
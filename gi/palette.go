@@ -0,0 +1,372 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/iancoleman/strcase"
+	"goki.dev/gi/v2/oswin"
+	"goki.dev/gi/v2/oswin/key"
+	"goki.dev/gi/v2/units"
+	"goki.dev/ki/v2/ki"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////
+//    Command Palette
+
+// PaletteCmd is a command listed in the command palette of a window: an
+// Action from the main menu, a toolbar or the window Shortcuts, or a KeyFun
+// of the ActiveKeyMap
+type PaletteCmd struct {
+
+	// label of the command, including the menus it is in for menu items -- used for matching, and for recording recent commands
+	Label string `desc:"label of the command, including the menus it is in for menu items -- used for matching, and for recording recent commands"`
+
+	// shortcut for the command, if any
+	Shortcut key.Chord `desc:"shortcut for the command, if any"`
+
+	// tooltip of the action, if any
+	Tooltip string `desc:"tooltip of the action, if any"`
+
+	// the action to trigger -- nil for a key function
+	Action *Action `desc:"the action to trigger -- nil for a key function"`
+
+	// the key function to perform, for commands without an action
+	KeyFun KeyFuns `desc:"the key function to perform, for commands without an action"`
+}
+
+// Run runs the command in given window: triggers its action, or sends the
+// key chord for its key function to the window
+func (pc *PaletteCmd) Run(w *Window) {
+	if pc.Action != nil {
+		pc.Action.Trigger()
+		return
+	}
+	ke, err := NewKeyChordEvent(ActiveKeyMap.ChordForFun(pc.KeyFun))
+	if err != nil {
+		log.Printf("gi.PaletteCmd: %v: %v\n", pc.Label, err)
+		return
+	}
+	w.OSWin.Send(ke)
+}
+
+// PaletteMaxItems is the maximum number of commands shown in the command
+// palette at once
+var PaletteMaxItems = 50
+
+// PaletteRecentsMax is the maximum number of recent commands remembered by
+// the command palette
+var PaletteRecentsMax = 20
+
+// PaletteRecents are the labels of the commands most recently run from the
+// command palette, per app (see AppName), most recent first -- saved in the
+// GoGi prefs directory
+var PaletteRecents map[string][]string
+
+// PrefsPaletteRecentsFileName is the name of the file in the GoGi prefs
+// directory for saving PaletteRecents
+var PrefsPaletteRecentsFileName = "palette_recents_prefs.json"
+
+// OpenPaletteRecents opens PaletteRecents from the GoGi prefs directory
+func OpenPaletteRecents() error {
+	pdir := oswin.TheApp.GoGiPrefsDir()
+	b, err := ioutil.ReadFile(filepath.Join(pdir, PrefsPaletteRecentsFileName))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, &PaletteRecents)
+}
+
+// SavePaletteRecents saves PaletteRecents to the GoGi prefs directory
+func SavePaletteRecents() error {
+	pdir := oswin.TheApp.GoGiPrefsDir()
+	b, err := json.MarshalIndent(PaletteRecents, "", "  ")
+	if err != nil {
+		log.Println(err) // unlikely
+		return err
+	}
+	err = ioutil.WriteFile(filepath.Join(pdir, PrefsPaletteRecentsFileName), b, 0644)
+	if err != nil {
+		log.Println(err)
+	}
+	return err
+}
+
+// AddPaletteRecent records the command with given label as the most recent
+// one run for the current app, and saves PaletteRecents
+func AddPaletteRecent(label string) {
+	if PaletteRecents == nil {
+		PaletteRecents = make(map[string][]string)
+	}
+	rc := PaletteRecents[AppName()]
+	StringsInsertFirstUnique(&rc, label, PaletteRecentsMax)
+	PaletteRecents[AppName()] = rc
+	SavePaletteRecents()
+}
+
+// FuzzyMatch returns whether all the runes of given pattern appear in order
+// in given string, ignoring case, along with a score for sorting matches:
+// higher for runes matched in runs and at the start of words, and for
+// shorter strings
+func FuzzyMatch(pat, str string) (int, bool) {
+	pr := []rune(strings.ToLower(pat))
+	if len(pr) == 0 {
+		return 0, true
+	}
+	sr := []rune(str)
+	score := 0
+	pi := 0
+	last := -2
+	for si := 0; si < len(sr) && pi < len(pr); si++ {
+		if unicode.ToLower(sr[si]) != pr[pi] {
+			continue
+		}
+		switch {
+		case si == last+1:
+			score += 5
+		case si == 0 || !unicode.IsLetter(sr[si-1]) && !unicode.IsDigit(sr[si-1]) || unicode.IsUpper(sr[si]) && unicode.IsLower(sr[si-1]):
+			score += 3 // start of word
+		default:
+			score++
+		}
+		last = si
+		pi++
+	}
+	if pi < len(pr) {
+		return 0, false
+	}
+	return 100*score - len(sr), true
+}
+
+// actionLabel returns the label to use for given action in the palette
+func actionLabel(ac *Action) string {
+	switch {
+	case ac.Text != "":
+		return ac.Text
+	case ac.Tooltip != "":
+		return ac.Tooltip
+	}
+	return ac.Nm
+}
+
+// PaletteCmds returns all the commands of the window for the command
+// palette: the actions of the main menu and its sub-menus, then those of
+// the toolbars and the remaining window Shortcuts, and then the KeyFuns of
+// the ActiveKeyMap, except for the command palette itself
+func (w *Window) PaletteCmds() []*PaletteCmd {
+	var cmds []*PaletteCmd
+	has := map[*Action]bool{}
+	addAction := func(ac *Action, label string) {
+		if has[ac] || ac.IsDestroyed() {
+			return
+		}
+		has[ac] = true
+		cmds = append(cmds, &PaletteCmd{Label: label, Shortcut: ac.Shortcut, Tooltip: ac.Tooltip, Action: ac})
+	}
+	var addMenu func(m Menu, path string)
+	addMenu = func(m Menu, path string) {
+		for _, mi := range m {
+			ac, ok := mi.Embed(TypeAction).(*Action)
+			if !ok {
+				continue
+			}
+			if ac.MakeMenuFunc != nil {
+				ac.MakeMenuFunc(ac.This(), &ac.Menu)
+			}
+			if ac.HasMenu() {
+				addMenu(ac.Menu, path+actionLabel(ac)+": ")
+				continue
+			}
+			ac.UpdateActions()
+			addAction(ac, path+actionLabel(ac))
+		}
+	}
+	if w.MainMenu != nil {
+		addMenu(Menu(w.MainMenu.Kids), "")
+	}
+	if w.Viewport != nil {
+		w.Viewport.FuncDownMeFirst(0, nil, func(k ki.Ki, level int, d any) bool {
+			if !ki.TypeEmbeds(k, TypeToolBar) {
+				return ki.Continue
+			}
+			addMenu(Menu(*k.Children()), "")
+			return ki.Break
+		})
+	}
+	var scs []*Action
+	for _, ac := range w.Shortcuts {
+		scs = append(scs, ac)
+	}
+	sort.Slice(scs, func(i, j int) bool { return actionLabel(scs[i]) < actionLabel(scs[j]) })
+	for _, ac := range scs {
+		ac.UpdateActions()
+		addAction(ac, actionLabel(ac))
+	}
+	for kf := KeyFunNil + 1; kf < KeyFunsN; kf++ {
+		ch := ActiveKeyMap.ChordForFun(kf)
+		if kf == KeyFunCommandPalette || ch == "" || strings.HasPrefix(string(ch), "- Not Set") {
+			continue
+		}
+		lbl := strcase.ToDelimited(strings.TrimPrefix(kf.String(), "KeyFun"), ' ')
+		lbl = "Key: " + strings.ToUpper(lbl[:1]) + lbl[1:]
+		cmds = append(cmds, &PaletteCmd{Label: lbl, Shortcut: ch.OSShortcut(), KeyFun: kf})
+	}
+	return cmds
+}
+
+// PaletteMatches returns the commands among given ones that match given
+// pattern (see FuzzyMatch), sorted by score, and then by how recently they
+// were run from the command palette
+func PaletteMatches(cmds []*PaletteCmd, pat string) []*PaletteCmd {
+	if PaletteRecents == nil {
+		OpenPaletteRecents()
+	}
+	rank := map[string]int{}
+	for i, lbl := range PaletteRecents[AppName()] {
+		rank[lbl] = i + 1
+	}
+	recent := func(lbl string) int { // lower is more recent
+		if r, ok := rank[lbl]; ok {
+			return r
+		}
+		return len(rank) + 1
+	}
+	var mts []*PaletteCmd
+	scores := map[*PaletteCmd]int{}
+	for _, pc := range cmds {
+		if sc, ok := FuzzyMatch(pat, pc.Label); ok {
+			mts = append(mts, pc)
+			scores[pc] = sc
+		}
+	}
+	sort.SliceStable(mts, func(i, j int) bool {
+		si, sj := scores[mts[i]], scores[mts[j]]
+		if si != sj {
+			return si > sj
+		}
+		return recent(mts[i].Label) < recent(mts[j].Label)
+	})
+	return mts
+}
+
+// cmdPalette is the state of an open command palette
+type cmdPalette struct {
+	win  *Window
+	dlg  *Dialog
+	list *Frame
+	cmds []*PaletteCmd
+	mts  []*PaletteCmd
+	sel  int
+}
+
+// Filter updates the list of commands for given pattern
+func (pl *cmdPalette) Filter(pat string) {
+	pl.mts = PaletteMatches(pl.cmds, pat)
+	if len(pl.mts) > PaletteMaxItems {
+		pl.mts = pl.mts[:PaletteMaxItems]
+	}
+	pl.sel = 0
+	updt := pl.list.UpdateStart()
+	pl.list.SetFullReRender()
+	pl.list.DeleteChildren(ki.DestroyKids)
+	var m Menu
+	for i, pc := range pl.mts {
+		ac := m.AddAction(ActOpts{Label: pc.Label, Tooltip: pc.Tooltip, Data: i},
+			pl.dlg.This(), func(recv, send ki.Ki, sig int64, data any) {
+				pl.Run(data.(int))
+			})
+		ac.Shortcut = pc.Shortcut
+		ac.SetDisabledState(pc.Action != nil && pc.Action.IsDisabled())
+		ac.SetSelectedState(i == pl.sel)
+		pl.list.AddChild(ac)
+	}
+	pl.list.UpdateEnd(updt)
+}
+
+// MoveSel moves the selected command by given delta
+func (pl *cmdPalette) MoveSel(del int) {
+	if len(pl.mts) == 0 {
+		return
+	}
+	updt := pl.list.UpdateStart()
+	pl.list.Child(pl.sel).(*Action).SetSelectedState(false)
+	pl.sel = (pl.sel + del + len(pl.mts)) % len(pl.mts)
+	ac := pl.list.Child(pl.sel).(*Action)
+	ac.SetSelectedState(true)
+	ac.ScrollToMe()
+	pl.list.UpdateEnd(updt)
+}
+
+// Run closes the palette and runs the command at given index in the list
+func (pl *cmdPalette) Run(idx int) {
+	if idx < 0 || idx >= len(pl.mts) {
+		return
+	}
+	pc := pl.mts[idx]
+	if pc.Action != nil && pc.Action.IsDisabled() {
+		return
+	}
+	AddPaletteRecent(pc.Label)
+	pl.dlg.Accept()
+	pc.Run(pl.win)
+}
+
+// CommandPalette opens the command palette for this window: a dialog
+// listing all its commands (see PaletteCmds) with their shortcuts, filtered
+// by fuzzy matching of what is typed (see FuzzyMatch), with the most recently
+// run ones first -- up and down move the selection, and Enter or clicking
+// runs a command.  Opened by KeyFunCommandPalette.
+func (w *Window) CommandPalette() *Dialog {
+	dlg := NewStdDialog(DlgOpts{Title: "Command Palette"}, NoOk, NoCancel)
+	dlg.Modal = true
+	frame := dlg.Frame()
+	_, prIdx := dlg.PromptWidget(frame)
+	tf := frame.InsertNewChild(TypeTextField, prIdx+1, "search").(*TextField)
+	tf.Placeholder = "Search commands"
+	tf.SetStretchMaxWidth()
+	tf.SetMinPrefWidth(units.Ch(60))
+	lst := frame.InsertNewChild(TypeFrame, prIdx+2, "cmds").(*Frame)
+	lst.Lay = LayoutVert
+	lst.SetStretchMax()
+	lst.SetMinPrefHeight(units.Em(20))
+
+	pl := &cmdPalette{win: w, dlg: dlg, list: lst, cmds: w.PaletteCmds()}
+	pl.Filter("")
+	tf.TextFieldSig.Connect(dlg.This(), func(recv, send ki.Ki, sig int64, data any) {
+		switch TextFieldSignals(sig) {
+		case TextFieldInsert, TextFieldBackspace, TextFieldDelete, TextFieldCleared:
+			pl.Filter(string(tf.EditTxt)) // data is not always the edited text
+		}
+	})
+	dlg.UpdateEndNoSig(true)
+	dlg.Open(0, 0, w.Viewport, func() {
+		// HiPri, to get these before the text field
+		dlg.Win.EventMgr.ConnectEvent(dlg.This(), oswin.KeyChordEvent, HiPri, func(recv, send ki.Ki, sig int64, d any) {
+			kt := d.(*key.ChordEvent)
+			switch KeyFun(kt.Chord()) {
+			case KeyFunMoveUp:
+				kt.SetProcessed()
+				pl.MoveSel(-1)
+			case KeyFunMoveDown:
+				kt.SetProcessed()
+				pl.MoveSel(1)
+			case KeyFunEnter, KeyFunAccept:
+				kt.SetProcessed()
+				pl.Run(pl.sel)
+			case KeyFunAbort:
+				kt.SetProcessed()
+				dlg.Cancel()
+			}
+		})
+	})
+	return dlg
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"testing"
+
+	"goki.dev/gi/v2/oswin/key"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		pat, str string
+		ok       bool
+	}{
+		{"", "anything", true},
+		{"save", "File / Save", true},
+		{"SAVE", "File / save as", true},
+		{"fsa", "File / Save As", true},
+		{"fs", "Save File", false},
+		{"saved", "File / Save", false},
+		{"zoom", "Zoom In", true},
+		{"é", "Café", true},
+	}
+	for _, tt := range tests {
+		if _, ok := FuzzyMatch(tt.pat, tt.str); ok != tt.ok {
+			t.Errorf("FuzzyMatch(%q, %q) = %v, want %v", tt.pat, tt.str, ok, tt.ok)
+		}
+	}
+}
+
+func TestFuzzyMatchScore(t *testing.T) {
+	// each pattern scores higher for the first string than for the second
+	tests := []struct {
+		pat, better, worse string
+	}{
+		{"save", "Save", "Sxaxvxe"},              // runs
+		{"sa", "File / Save As", "File / Sxxxa"}, // start of words
+		{"sa", "Save As", "SubmitAnother"},       // runs beat word starts
+		{"sa", "SaveAs", "Saxxxxxxxxxxx"},        // camel case word start vs. shorter
+		{"new", "New", "New Window"},             // shorter
+	}
+	for _, tt := range tests {
+		bs, bok := FuzzyMatch(tt.pat, tt.better)
+		ws, wok := FuzzyMatch(tt.pat, tt.worse)
+		if !bok || !wok {
+			t.Errorf("%q: no match for %q (%v) or %q (%v)", tt.pat, tt.better, bok, tt.worse, wok)
+			continue
+		}
+		if bs <= ws {
+			t.Errorf("%q: score %d for %q, not higher than %d for %q", tt.pat, bs, tt.better, ws, tt.worse)
+		}
+	}
+}
+
+func TestCommandPaletteKeyMaps(t *testing.T) {
+	// the command palette does not take over other key functions
+	tests := []struct {
+		keymap  KeyMapName
+		palette key.Chord
+		prefs   key.Chord
+	}{
+		{"MacStd", "Shift+Meta+P", "Control+Alt+P"},
+		{"MacEmacs", "Shift+Meta+P", "Control+Alt+P"},
+		{"LinuxEmacs", "Shift+Alt+P", "Control+Alt+P"},
+		{"LinuxStd", "Shift+Alt+P", "Shift+Control+P"},
+		{"WindowsStd", "Shift+Alt+P", "Shift+Control+P"},
+		{"ChromeStd", "Shift+Alt+P", "Shift+Control+P"},
+	}
+	for _, tt := range tests {
+		km, _, ok := StdKeyMaps.MapByName(tt.keymap)
+		if !ok {
+			t.Fatalf("no key map %v", tt.keymap)
+		}
+		if kf := (*km)[tt.palette]; kf != KeyFunCommandPalette {
+			t.Errorf("%v: %v is %v, want %v", tt.keymap, tt.palette, kf, KeyFunCommandPalette)
+		}
+		if kf := (*km)[tt.prefs]; kf != KeyFunPrefs {
+			t.Errorf("%v: %v is %v, want %v", tt.keymap, tt.prefs, kf, KeyFunPrefs)
+		}
+	}
+}
@@ -0,0 +1,353 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"image"
+	"sort"
+
+	"goki.dev/gi/v2/gist"
+	"goki.dev/ki/v2/ki"
+	"goki.dev/mat32/v2"
+)
+
+// EventZDepthFactor is the amount added to the depth of event receivers
+// within a positioned element, per level of ZIndex (starting at 1 for
+// z-index 0), so that elements rendered on top get events first
+var EventZDepthFactor = 1000
+
+// IsOutOfFlow returns true if this widget is taken out of the layout of its
+// parent (position absolute or fixed), and placed by its Inset instead
+func (wb *WidgetBase) IsOutOfFlow() bool {
+	wb.StyMu.RLock()
+	defer wb.StyMu.RUnlock()
+	return wb.Style.IsOutOfFlow()
+}
+
+// layKid returns the widget for given child of a layout, or nil if it is not
+// a widget or is taken out of the flow of the layout (see IsOutOfFlow)
+func layKid(c ki.Ki) *WidgetBase {
+	if c == nil {
+		return nil
+	}
+	ni := c.(Node2D).AsWidget()
+	if ni == nil || ni.IsOutOfFlow() {
+		return nil
+	}
+	return ni
+}
+
+// NumInFlow returns the number of children that are in the flow of
+// the layout, i.e., not absolute or fixed
+func (ly *Layout) NumInFlow() int {
+	n := 0
+	for _, c := range ly.Kids {
+		if layKid(c) != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// PositionedAncestor returns the nearest positioned (non-static) widget
+// at or above this one, stopping at the viewport (returns nil if none)
+func (wb *WidgetBase) PositionedAncestor() *WidgetBase {
+	for k := wb.This(); k != nil; k = k.Parent() {
+		nii, ok := k.(Node2D)
+		if !ok || nii.AsViewport2D() != nil {
+			return nil
+		}
+		pw := nii.AsWidget()
+		if pw == nil {
+			return nil
+		}
+		pw.StyMu.RLock()
+		pos := pw.Style.IsPositioned()
+		pw.StyMu.RUnlock()
+		if pos {
+			return pw
+		}
+	}
+	return nil
+}
+
+// ContainingBlock returns the containing block for given out-of-flow child
+// of this layout: the content box of its nearest positioned ancestor for
+// absolute (or the viewport if none), and the viewport for fixed, in the
+// canonical (unscrolled) layout coordinates.  Also returns the node whose
+// ChildrenBBox2D clips the child.
+func (ly *Layout) ContainingBlock(ni *WidgetBase) (pos, size mat32.Vec2, clip Node2D) {
+	ni.StyMu.RLock()
+	fixed := ni.Style.Position == gist.PositionFixed
+	ni.StyMu.RUnlock()
+	if !fixed {
+		if pw := ly.PositionedAncestor(); pw != nil {
+			spc := pw.BoxSpace()
+			pos = pw.LayState.Alloc.PosOrig.Add(spc.Pos())
+			size = pw.LayState.Alloc.Size.Sub(spc.Size())
+			return pos, size, pw.This().(Node2D)
+		}
+	}
+	mvp := ly.ViewportSafe()
+	if mvp == nil {
+		return
+	}
+	pos = mvp.LayState.Alloc.PosOrig
+	size = mat32.NewVec2FmPoint(mvp.This().(Node2D).ChildrenBBox2D().Size())
+	return pos, size, mvp.This().(Node2D)
+}
+
+// insetPosSize returns the position and size along given dimension of an
+// element with given inset sides set, within given containing block start
+// and size.  If both insets are set, the element fills the space between
+// them, otherwise it keeps its preferred size, and is placed at whichever
+// is set, or at the start if neither (its static position).
+func insetPosSize(st, csz, pref float32, ins [2]float32, set [2]bool) (pos, size float32) {
+	size = pref
+	switch {
+	case set[0] && set[1]:
+		size = mat32.Max(csz-ins[0]-ins[1], 0)
+		pos = st + ins[0]
+	case set[0]:
+		pos = st + ins[0]
+	case set[1]:
+		pos = st + csz - ins[1] - size
+	default:
+		pos = st
+	}
+	return
+}
+
+// insetDim returns the insets and whether they are set along given dimension
+// (left, right for X, top, bottom for Y)
+func insetDim(s *gist.Style, dim mat32.Dims) (ins [2]float32, set [2]bool) {
+	if dim == mat32.X {
+		return [2]float32{s.Inset.Left.Dots, s.Inset.Right.Dots}, [2]bool{s.InsetSides.Left, s.InsetSides.Right}
+	}
+	return [2]float32{s.Inset.Top.Dots, s.Inset.Bottom.Dots}, [2]bool{s.InsetSides.Top, s.InsetSides.Bottom}
+}
+
+// posStyle is a copy of the positioning fields of a Style, so they can be
+// used in the layout without holding the StyMu lock
+type posStyle struct {
+	pos gist.Positions
+	ins [2][2]float32 // insets along each dimension, as from insetDim
+	set [2][2]bool    // whether the insets are set along each dimension
+}
+
+// positionStyle returns a copy of the positioning fields of the style of
+// given widget, taken under its StyMu lock
+func positionStyle(ni *WidgetBase) posStyle {
+	ni.StyMu.RLock()
+	defer ni.StyMu.RUnlock()
+	ps := posStyle{pos: ni.Style.Position}
+	for d := mat32.X; d <= mat32.Y; d++ {
+		ps.ins[d], ps.set[d] = insetDim(&ni.Style, d)
+	}
+	return ps
+}
+
+// LayoutPositioned places the positioned children of the layout, after the
+// other children have been laid out: absolute and fixed children are placed
+// by their Inset within their ContainingBlock, and relative children are
+// offset by their Inset from where the layout placed them.
+func LayoutPositioned(ly *Layout) {
+	for _, c := range ly.Kids {
+		if c == nil {
+			continue
+		}
+		ni := c.(Node2D).AsWidget()
+		if ni == nil {
+			continue
+		}
+		ps := positionStyle(ni)
+		switch ps.pos {
+		case gist.PositionRelative:
+			for d := mat32.X; d <= mat32.Y; d++ {
+				ins, set := ps.ins[d], ps.set[d]
+				off := float32(0)
+				if set[0] {
+					off = ins[0]
+				} else if set[1] {
+					off = -ins[1]
+				}
+				ni.LayState.Alloc.PosRel.SetDim(d, ni.LayState.Alloc.PosRel.Dim(d)+off)
+			}
+		case gist.PositionAbsolute, gist.PositionFixed:
+			ni.LayState.UpdateSizes()
+			cpos, csz, _ := ly.ContainingBlock(ni)
+			for d := mat32.X; d <= mat32.Y; d++ {
+				ins, set := ps.ins[d], ps.set[d]
+				pref := mat32.Max(ni.LayState.Size.Pref.Dim(d), ni.LayState.Size.Need.Dim(d))
+				pos, size := insetPosSize(cpos.Dim(d), csz.Dim(d), pref, ins, set)
+				ni.LayState.Alloc.Size.SetDim(d, size)
+				ni.LayState.Alloc.PosRel.SetDim(d, pos-ly.LayState.Alloc.PosOrig.Dim(d))
+			}
+			if Layout2DTrace {
+				fmt.Printf("Layout: %v Positioned Child: %v, pos: %v, size: %v\n", ly.Path(), ni.Nm, ni.LayState.Alloc.PosRel, ni.LayState.Alloc.Size)
+			}
+		}
+	}
+}
+
+// kidBBox returns the parent bbox to use for given child in the Layout2D and
+// Move2D passes: out-of-flow children are clipped by their containing block
+// instead of this layout
+func (ly *Layout) kidBBox(ni *WidgetBase, cbb image.Rectangle) image.Rectangle {
+	if ni == nil || !ni.IsOutOfFlow() {
+		return cbb
+	}
+	_, _, clip := ly.ContainingBlock(ni)
+	if clip == nil {
+		return cbb
+	}
+	return clip.ChildrenBBox2D()
+}
+
+// kidDelta returns the delta to use for moving given child in the Move2D
+// pass: fixed children do not move with their scrolling parents, and sticky
+// children are kept within their scrolling layout at their Inset
+func (ly *Layout) kidDelta(ni *WidgetBase, delta image.Point) image.Point {
+	if ni == nil {
+		return delta
+	}
+	ps := positionStyle(ni)
+	switch ps.pos {
+	case gist.PositionFixed:
+		return image.Point{}
+	case gist.PositionSticky:
+		return ly.stickyDelta(ni, &ps, delta)
+	}
+	return delta
+}
+
+// stickyDelta returns the delta for moving given sticky child: if it would
+// be scrolled out past its Inset from the visible area of the nearest
+// scrolling layout, it is shifted back in, but not outside of this layout
+func (ly *Layout) stickyDelta(ni *WidgetBase, ps *posStyle, delta image.Point) image.Point {
+	var view image.Rectangle
+	for k := ly.This(); k != nil; k = k.Parent() {
+		nii, ok := k.(Node2D)
+		if !ok {
+			break
+		}
+		if vp := nii.AsViewport2D(); vp != nil {
+			view = vp.This().(Node2D).ChildrenBBox2D()
+			break
+		}
+		if sl := nii.AsLayout2D(); sl != nil && sl.HasAnyScroll() {
+			view = sl.This().(Node2D).ChildrenBBox2D()
+			break
+		}
+	}
+	if view == (image.Rectangle{}) {
+		return delta
+	}
+	ls := &ni.LayState
+	pos := ls.Alloc.PosOrig.Add(mat32.NewVec2FmPoint(delta))
+	// extent of our content, which the sticky child stays within
+	lst := ly.LayState.Alloc.PosOrig.Add(mat32.NewVec2FmPoint(delta))
+	lsz := ly.LayState.Alloc.Size.Max(ly.ChildSize)
+	vmin := mat32.NewVec2FmPoint(view.Min)
+	vmax := mat32.NewVec2FmPoint(view.Max)
+	for d := mat32.X; d <= mat32.Y; d++ {
+		ins, set := ps.ins[d], ps.set[d]
+		p := pos.Dim(d)
+		sz := ls.Alloc.Size.Dim(d)
+		np := p
+		if set[0] {
+			np = mat32.Max(np, vmin.Dim(d)+ins[0])
+		}
+		if set[1] {
+			np = mat32.Min(np, vmax.Dim(d)-ins[1]-sz)
+		}
+		np = mat32.Min(np, lst.Dim(d)+lsz.Dim(d)-sz)
+		np = mat32.Max(np, lst.Dim(d))
+		if !set[0] && !set[1] {
+			np = p
+		}
+		if d == mat32.X {
+			delta.X += int(np - p)
+		} else {
+			delta.Y += int(np - p)
+		}
+	}
+	return delta
+}
+
+// ZOrder returns the indexes of the children in the order in which they are
+// rendered: positioned children with a negative ZIndex first, then the static
+// children, then the other positioned children by increasing ZIndex, keeping
+// the order of the children otherwise.  Events go in the reverse order.
+func (ly *Layout) ZOrder() []int {
+	idxs := make([]int, len(ly.Kids))
+	keys := make([]int, len(ly.Kids))
+	zs := make([]int, len(ly.Kids))
+	sorted := true
+	for i, c := range ly.Kids {
+		idxs[i] = i
+		keys[i] = 1 // static
+		if c == nil {
+			continue
+		}
+		nii, ok := c.(Node2D)
+		if !ok {
+			continue
+		}
+		ni := nii.AsWidget()
+		if ni == nil {
+			continue
+		}
+		ni.StyMu.RLock()
+		if ni.Style.IsPositioned() {
+			zs[i] = ni.Style.ZIndex
+			if zs[i] < 0 {
+				keys[i] = 0
+			} else {
+				keys[i] = 2
+			}
+		}
+		ni.StyMu.RUnlock()
+		if i > 0 && (keys[i] < keys[i-1] || (keys[i] == keys[i-1] && zs[i] < zs[i-1])) {
+			sorted = false
+		}
+	}
+	if !sorted {
+		sort.SliceStable(idxs, func(i, j int) bool {
+			a, b := idxs[i], idxs[j]
+			if keys[a] != keys[b] {
+				return keys[a] < keys[b]
+			}
+			return zs[a] < zs[b]
+		})
+	}
+	return idxs
+}
+
+// EventZDepth returns the depth of given event receiver below given top
+// node, used to order the receivers of positional events: the deepest ones
+// get the events first, and each positioned element at or above the receiver
+// adds EventZDepthFactor per level of its ZIndex above -1, so that elements
+// rendered on top of others get their events first.
+func EventZDepth(recv, top ki.Ki) int {
+	depth := recv.ParentLevel(top)
+	for k := recv; k != nil && k != top; k = k.Parent() {
+		nii, ok := k.(Node2D)
+		if !ok {
+			continue
+		}
+		ni := nii.AsWidget()
+		if ni == nil {
+			continue
+		}
+		ni.StyMu.RLock()
+		if ni.Style.IsPositioned() {
+			depth += (ni.Style.ZIndex + 1) * EventZDepthFactor
+		}
+		ni.StyMu.RUnlock()
+	}
+	return depth
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"image"
+	"testing"
+
+	"goki.dev/gi/v2/gist"
+	"goki.dev/mat32/v2"
+)
+
+func TestInsetPosSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		st, csz   float32
+		pref      float32
+		ins       [2]float32
+		set       [2]bool
+		pos, size float32
+	}{
+		{"static", 10, 100, 30, [2]float32{5, 7}, [2]bool{}, 10, 30},
+		{"start", 10, 100, 30, [2]float32{5, 7}, [2]bool{true, false}, 15, 30},
+		{"end", 10, 100, 30, [2]float32{5, 7}, [2]bool{false, true}, 73, 30},
+		{"both", 10, 100, 30, [2]float32{5, 7}, [2]bool{true, true}, 15, 88},
+		{"both too large", 10, 10, 30, [2]float32{5, 7}, [2]bool{true, true}, 15, 0},
+		{"negative", 0, 100, 30, [2]float32{-5, -5}, [2]bool{true, true}, -5, 110},
+	}
+	for _, tt := range tests {
+		pos, size := insetPosSize(tt.st, tt.csz, tt.pref, tt.ins, tt.set)
+		if pos != tt.pos || size != tt.size {
+			t.Errorf("%s: got %v, %v, want %v, %v", tt.name, pos, size, tt.pos, tt.size)
+		}
+	}
+}
+
+func TestStickyDelta(t *testing.T) {
+	tests := []struct {
+		name  string
+		ins   [2]float32
+		set   [2]bool
+		y     float32
+		delta image.Point
+		want  image.Point
+	}{
+		{"not set", [2]float32{}, [2]bool{}, 50, image.Pt(0, -100), image.Pt(0, -100)},
+		{"top in view", [2]float32{}, [2]bool{true, false}, 50, image.Pt(0, -40), image.Pt(0, -40)},
+		{"top scrolled out", [2]float32{}, [2]bool{true, false}, 50, image.Pt(0, -100), image.Pt(0, -50)},
+		{"top inset", [2]float32{10, 0}, [2]bool{true, false}, 50, image.Pt(0, -100), image.Pt(0, -40)},
+		{"top end of layout", [2]float32{}, [2]bool{true, false}, 50, image.Pt(0, -990), image.Pt(0, -60)},
+		{"bottom", [2]float32{0, 10}, [2]bool{false, true}, 300, image.Pt(0, 0), image.Pt(0, -130)},
+		{"bottom in view", [2]float32{0, 10}, [2]bool{false, true}, 100, image.Pt(0, 0), image.Pt(0, 0)},
+		{"x unchanged", [2]float32{}, [2]bool{true, false}, 50, image.Pt(-30, -100), image.Pt(-30, -50)},
+	}
+	vp := NewViewport2D(200, 200)
+	vp.InitName(vp, "vp")
+	ly := AddNewLayout(vp, "ly", LayoutVert)
+	ly.LayState.Alloc.Size = mat32.Vec2{200, 1000}
+	kid := AddNewFrame(ly, "kid", LayoutVert)
+	kid.LayState.Alloc.Size = mat32.Vec2{200, 20}
+	for _, tt := range tests {
+		kid.LayState.Alloc.PosOrig = mat32.Vec2{0, tt.y}
+		ps := posStyle{pos: gist.PositionSticky}
+		ps.ins[mat32.Y], ps.set[mat32.Y] = tt.ins, tt.set
+		if got := ly.stickyDelta(&kid.WidgetBase, &ps, tt.delta); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestZOrder(t *testing.T) {
+	type kid struct {
+		pos gist.Positions
+		z   int
+	}
+	tests := []struct {
+		name string
+		kids []kid
+		want []int
+	}{
+		{"static", []kid{{gist.PositionStatic, 0}, {gist.PositionStatic, 5}}, []int{0, 1}},
+		{"positioned last", []kid{{gist.PositionRelative, 0}, {gist.PositionStatic, 0}}, []int{1, 0}},
+		{"negative first", []kid{{gist.PositionStatic, 0}, {gist.PositionAbsolute, -1}}, []int{1, 0}},
+		{"by z", []kid{{gist.PositionAbsolute, 2}, {gist.PositionAbsolute, 1}, {gist.PositionStatic, 0}, {gist.PositionAbsolute, 1}}, []int{2, 1, 3, 0}},
+		{"stable", []kid{{gist.PositionFixed, 1}, {gist.PositionSticky, 1}, {gist.PositionAbsolute, -2}, {gist.PositionAbsolute, -2}}, []int{2, 3, 0, 1}},
+	}
+	for _, tt := range tests {
+		ly := &Layout{}
+		ly.InitName(ly, "ly")
+		for i, k := range tt.kids {
+			fr := AddNewFrame(ly, fmt.Sprintf("kid%d", i), LayoutVert)
+			fr.Style.Position = k.pos
+			fr.Style.ZIndex = k.z
+		}
+		if got := ly.ZOrder(); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEventZDepth(t *testing.T) {
+	defer func(f int) { EventZDepthFactor = f }(EventZDepthFactor)
+	EventZDepthFactor = 1000
+	vp := NewViewport2D(200, 200)
+	vp.InitName(vp, "vp")
+	ly := AddNewLayout(vp, "ly", LayoutVert)
+	pos := AddNewFrame(ly, "pos", LayoutVert)
+	inner := AddNewFrame(pos, "inner", LayoutVert)
+	neg := AddNewFrame(ly, "neg", LayoutVert)
+	neg.Style.Position = gist.PositionAbsolute
+	neg.Style.ZIndex = -1
+
+	tests := []struct {
+		name string
+		pos  gist.Positions
+		z    int
+		want int
+	}{
+		{"static", gist.PositionStatic, 3, 2},
+		{"relative", gist.PositionRelative, 0, 1002},
+		{"absolute z 2", gist.PositionAbsolute, 2, 3002},
+		{"fixed z -1", gist.PositionFixed, -1, 2},
+	}
+	for _, tt := range tests {
+		pos.Style.Position = tt.pos
+		pos.Style.ZIndex = tt.z
+		if got := EventZDepth(inner.This(), vp.This()); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if got := EventZDepth(neg.This(), vp.This()); got != 1 {
+		t.Errorf("z -1: got %v, want 1", got)
+	}
+	if got := EventZDepth(inner.This(), pos.This()); got != 0 {
+		t.Errorf("below top: got %v, want 0", got)
+	}
+}
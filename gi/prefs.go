@@ -1014,6 +1014,9 @@ type PrefsDetailed struct {
 	// [def: 50] [min: 0] [max: 1000] [step: 1] the maximum number of pixels that mouse can move and still register a Hover event
 	HoverMaxPix int `def:"50" min:"0" max:"1000" step:"1" desc:"the maximum number of pixels that mouse can move and still register a Hover event"`
 
+	// [def: 2000] [min: 100] [max: 10000] [step: 100] the number of milliseconds to wait for the next chord of a multi-key sequence (e.g., Control+X Control+S) before abandoning the pending prefix
+	KeySeqTimeoutMSec int `def:"2000" min:"100" max:"10000" step:"100" desc:"the number of milliseconds to wait for the next chord of a multi-key sequence (e.g., Control+X Control+S) before abandoning the pending prefix"`
+
 	// [def: 0] [min: 0] [max: 10000] [step: 10] the number of milliseconds to wait before offering completions
 	CompleteWaitMSec int `def:"0" min:"0" max:"10000" step:"10" desc:"the number of milliseconds to wait before offering completions"`
 
@@ -1116,6 +1119,7 @@ func (pf *PrefsDetailed) Defaults() {
 	pf.DNDStartPix = DNDStartPix
 	pf.HoverStartMSec = HoverStartMSec
 	pf.HoverMaxPix = HoverMaxPix
+	pf.KeySeqTimeoutMSec = KeySeqTimeoutMSec
 	pf.CompleteWaitMSec = CompleteWaitMSec
 	pf.CompleteMaxItems = CompleteMaxItems
 	pf.CursorBlinkMSec = CursorBlinkMSec
@@ -1144,6 +1148,9 @@ func (pf *PrefsDetailed) Apply() {
 	DNDStartPix = pf.DNDStartPix
 	HoverStartMSec = pf.HoverStartMSec
 	HoverMaxPix = pf.HoverMaxPix
+	if pf.KeySeqTimeoutMSec > 0 {
+		KeySeqTimeoutMSec = pf.KeySeqTimeoutMSec
+	}
 	CompleteWaitMSec = pf.CompleteWaitMSec
 	CompleteMaxItems = pf.CompleteMaxItems
 	CursorBlinkMSec = pf.CursorBlinkMSec
@@ -1229,6 +1236,9 @@ type PrefsDebug struct {
 	// WinGeomTrace records window geometry saving / loading functions
 	WinGeomTrace *bool `desc:"WinGeomTrace records window geometry saving / loading functions"`
 
+	// records all window events to a JSON file in the app prefs events directory, saved when the window is closed or this is turned off -- use ReplayEvents to replay
+	WinEventRecord *bool `desc:"records all window events to a JSON file in the app prefs events directory, saved when the window is closed or this is turned off -- use ReplayEvents to replay"`
+
 	// reports trace of keyboard events (printfs to stdout)
 	KeyEventTrace *bool `desc:"reports trace of keyboard events (printfs to stdout)"`
 
@@ -1263,6 +1273,18 @@ var PrefsDebugProps = ki.Props{
 			"desc": "Toggle profiling of program on or off -- does both targeted and global CPU and Memory profiling.",
 			"icon": icons.LabProfile,
 		}},
+		{"ReplayEvents", ki.Props{
+			"desc": "Replay events recorded by WinEventRecord into the window they were recorded from (if open) or the current focus window.",
+			"icon": icons.Replay,
+			"Args": ki.PropSlice{
+				{"Event File Name", ki.Props{
+					"ext": ".json",
+				}},
+				{"Real Time", ki.Props{
+					"desc": "replay events at their recorded times, instead of as fast as possible",
+				}},
+			},
+		}},
 	},
 }
 
@@ -1275,6 +1297,7 @@ func (pf *PrefsDebug) Connect() {
 	pf.WinPublishTrace = &WinPublishTrace
 	pf.WinDrawTrace = &WinDrawTrace
 	pf.WinGeomTrace = &WinGeomTrace
+	pf.WinEventRecord = &WinEventRecord
 	pf.KeyEventTrace = &KeyEventTrace
 	pf.EventTrace = &EventTrace
 	pf.DNDTrace = &DNDTrace
@@ -1287,3 +1310,27 @@ func (pf *PrefsDebug) Connect() {
 func (pf *PrefsDebug) Profile() {
 	ProfileToggle()
 }
+
+// ReplayEvents replays the events in given file, recorded via WinEventRecord,
+// into the window they were recorded from if it is open, and otherwise
+// the current focus window (see Window.Replay).
+func (pf *PrefsDebug) ReplayEvents(filename FileName, realTime bool) {
+	rec, err := OpenEventRecording(filename)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	win, ok := AllWindows.FindName(rec.WinName)
+	if !ok {
+		win = WindowInFocus()
+	}
+	if win == nil {
+		log.Printf("gi.PrefsDebug: no window to replay events into\n")
+		return
+	}
+	go func() {
+		if err := win.Replay(rec, realTime); err != nil {
+			log.Println(err)
+		}
+	}()
+}
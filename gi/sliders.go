@@ -233,6 +233,14 @@ func (sb *SliderBase) SnapValue() {
 	}
 }
 
+// AccessInfoSlider returns the accessibility info for a slider with
+// given role, with the current value
+func (sb *SliderBase) AccessInfoSlider(role AccessRoles) AccessInfo {
+	ai := sb.AccessInfoBase(role, "")
+	ai.Value = fmt.Sprintf("%g", sb.Value)
+	return ai
+}
+
 // SetSliderState sets the slider state to given state, updates style
 func (sb *SliderBase) SetSliderState(state SliderStates) {
 	prev := sb.State
@@ -438,7 +446,7 @@ func (sb *SliderBase) KeyInput(kt *key.ChordEvent) {
 	if KeyEventTrace {
 		fmt.Printf("SliderBase KeyInput: %v\n", sb.Path())
 	}
-	kf := KeyFun(kt.Chord())
+	kf := KeyFunFor(sb.This(), kt.Chord())
 	switch kf {
 	case KeyFunMoveUp:
 		sb.SetValueAction(sb.Value - sb.Step)
@@ -769,6 +777,11 @@ func (sr *Slider) CopyFieldsFrom(frm any) {
 	sr.SliderBase.CopyFieldsFrom(&fr.SliderBase)
 }
 
+// AccessInfo returns the accessibility info for the slider
+func (sr *Slider) AccessInfo() AccessInfo {
+	return sr.AccessInfoSlider(AccessRoleSlider)
+}
+
 var SliderProps = ki.Props{
 	ki.EnumTypeFlag: TypeNodeFlags,
 }
@@ -942,6 +955,11 @@ func (sb *ScrollBar) CopyFieldsFrom(frm any) {
 	sb.SliderBase.CopyFieldsFrom(&fr.SliderBase)
 }
 
+// AccessInfo returns the accessibility info for the scrollbar
+func (sb *ScrollBar) AccessInfo() AccessInfo {
+	return sb.AccessInfoSlider(AccessRoleScrollBar)
+}
+
 var ScrollBarProps = ki.Props{
 	ki.EnumTypeFlag: TypeNodeFlags,
 }
@@ -1087,6 +1105,11 @@ func (pb *ProgressBar) CopyFieldsFrom(frm any) {
 	pb.SliderBase.CopyFieldsFrom(&fr.SliderBase)
 }
 
+// AccessInfo returns the accessibility info for the progress bar
+func (pb *ProgressBar) AccessInfo() AccessInfo {
+	return pb.AccessInfoSlider(AccessRoleProgressBar)
+}
+
 func ProgressDefaultInc(max int) int {
 	switch {
 	case max > 50000:
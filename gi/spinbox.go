@@ -302,6 +302,14 @@ func (sb *SpinBox) ValToString(val float32) string {
 	return fmt.Sprintf(sb.Format, val)
 }
 
+// AccessInfo returns the accessibility info for the spinbox, with the
+// formatted value
+func (sb *SpinBox) AccessInfo() AccessInfo {
+	ai := sb.AccessInfoBase(AccessRoleSpinBox, "")
+	ai.Value = sb.ValToString(sb.Value)
+	return ai
+}
+
 // StringToVal converts the string field back to float value
 func (sb *SpinBox) StringToVal(str string) (float32, error) {
 	var fval float32
@@ -365,7 +373,7 @@ func (sb *SpinBox) KeyChordEvent() {
 		if KeyEventTrace {
 			fmt.Printf("SpinBox KeyChordEvent: %v\n", sbb.Path())
 		}
-		kf := KeyFun(kt.Chord())
+		kf := KeyFunFor(sbb.This(), kt.Chord())
 		switch {
 		case kf == KeyFunMoveUp:
 			kt.SetProcessed()
@@ -152,7 +152,15 @@ func (sv *SplitView) SetSplitsList(splits []float32) {
 // child entirely -- does full rebuild at level of viewport
 func (sv *SplitView) SetSplitsAction(splits ...float32) {
 	sv.SetSplits(splits...)
-	sv.ViewportSafe().SetNeedsFullRender()
+	sv.needsFullRender()
+}
+
+// needsFullRender sets the viewport to do a full render, which splits
+// typically require -- nothing is done if not yet in a viewport
+func (sv *SplitView) needsFullRender() {
+	if vp := sv.ViewportSafe(); vp != nil {
+		vp.SetNeedsFullRender()
+	}
 }
 
 // SaveSplits saves the current set of splits in SavedSplits, for a later RestoreSplits
@@ -190,7 +198,7 @@ func (sv *SplitView) CollapseChild(save bool, idxs ...int) {
 		}
 	}
 	sv.UpdateSplits()
-	sv.ViewportSafe().SetNeedsFullRender() // splits typically require full rebuild
+	sv.needsFullRender()
 	sv.UpdateEnd(updt)
 }
 
@@ -204,7 +212,7 @@ func (sv *SplitView) RestoreChild(idxs ...int) {
 		}
 	}
 	sv.UpdateSplits()
-	sv.ViewportSafe().SetNeedsFullRender() // splits typically require full rebuild
+	sv.needsFullRender()
 	sv.UpdateEnd(updt)
 }
 
@@ -256,7 +264,7 @@ func (sv *SplitView) SetSplitAction(idx int, nwval float32) {
 	// fmt.Printf("splits: %v value: %v  splts: %v\n", idx, nwval, sv.Splits)
 	sv.UpdateSplits()
 	// fmt.Printf("splits: %v\n", sv.Splits)
-	sv.ViewportSafe().SetNeedsFullRender() // splits typically require full rebuild
+	sv.needsFullRender()
 }
 
 func (sv *SplitView) Init2D() {
@@ -631,6 +639,12 @@ func (sr *Splitter) SplitView() *SplitView {
 	return svi.(*SplitView)
 }
 
+// AccessInfo returns the accessibility info for the splitter, with its
+// position as the value
+func (sr *Splitter) AccessInfo() AccessInfo {
+	return sr.AccessInfoSlider(AccessRoleSplitter)
+}
+
 func (sr *Splitter) MouseEvent() {
 	sr.ConnectEvent(oswin.MouseEvent, RegPri, func(recv, send ki.Ki, sig int64, d any) {
 		me := d.(*mouse.Event)
@@ -1,4 +1,4 @@
-// Code generated by "stringer -output stringer.go -type=ActionTypes,ButtonFlags,ButtonSignals,ButtonTypes,ComboBoxTypes,CompleteSignals,DialogState,EventPris,DNDStages,Stripes,KeyFuns,LabelTypes,Layouts,RowCol,NodeFlags,FocusChanges,Densities,SliderSignals,SliderStates,SpellSignals,TabViewSignals,TextFieldTypes,TextFieldSignals,VpFlags,WidgetSignals,WinFlags"; DO NOT EDIT.
+// Code generated by "stringer -output stringer.go -type=ActionTypes,ButtonFlags,ButtonSignals,ButtonTypes,ComboBoxTypes,CompleteSignals,DialogState,EventPris,DNDStages,Stripes,KeyFuns,LabelTypes,Layouts,RowCol,NodeFlags,FocusChanges,Densities,SliderSignals,SliderStates,SpellSignals,TabViewSignals,TextFieldTypes,TextFieldSignals,VpFlags,WidgetSignals,WinFlags,AccessRoles,AccessStates,CSSCombinators,DockAreas,DockSignals,ToastLevels"; DO NOT EDIT.
 
 package gi
 
@@ -531,12 +531,13 @@ func _() {
 	_ = x[KeyFunMenuSaveAlt-63]
 	_ = x[KeyFunMenuCloseAlt1-64]
 	_ = x[KeyFunMenuCloseAlt2-65]
-	_ = x[KeyFunsN-66]
+	_ = x[KeyFunCommandPalette-66]
+	_ = x[KeyFunsN-67]
 }
 
-const _KeyFuns_name = "KeyFunNilKeyFunMoveUpKeyFunMoveDownKeyFunMoveRightKeyFunMoveLeftKeyFunPageUpKeyFunPageDownKeyFunHomeKeyFunEndKeyFunDocHomeKeyFunDocEndKeyFunWordRightKeyFunWordLeftKeyFunFocusNextKeyFunFocusPrevKeyFunEnterKeyFunAcceptKeyFunCancelSelectKeyFunSelectModeKeyFunSelectAllKeyFunAbortKeyFunCopyKeyFunCutKeyFunPasteKeyFunPasteHistKeyFunBackspaceKeyFunBackspaceWordKeyFunDeleteKeyFunDeleteWordKeyFunKillKeyFunDuplicateKeyFunTransposeKeyFunTransposeWordKeyFunUndoKeyFunRedoKeyFunInsertKeyFunInsertAfterKeyFunZoomOutKeyFunZoomInKeyFunPrefsKeyFunRefreshKeyFunRecenterKeyFunCompleteKeyFunLookupKeyFunSearchKeyFunFindKeyFunReplaceKeyFunJumpKeyFunHistPrevKeyFunHistNextKeyFunMenuKeyFunWinFocusNextKeyFunWinCloseKeyFunWinSnapshotKeyFunGoGiEditorKeyFunMenuNewKeyFunMenuNewAlt1KeyFunMenuNewAlt2KeyFunMenuOpenKeyFunMenuOpenAlt1KeyFunMenuOpenAlt2KeyFunMenuSaveKeyFunMenuSaveAsKeyFunMenuSaveAltKeyFunMenuCloseAlt1KeyFunMenuCloseAlt2KeyFunsN"
+const _KeyFuns_name = "KeyFunNilKeyFunMoveUpKeyFunMoveDownKeyFunMoveRightKeyFunMoveLeftKeyFunPageUpKeyFunPageDownKeyFunHomeKeyFunEndKeyFunDocHomeKeyFunDocEndKeyFunWordRightKeyFunWordLeftKeyFunFocusNextKeyFunFocusPrevKeyFunEnterKeyFunAcceptKeyFunCancelSelectKeyFunSelectModeKeyFunSelectAllKeyFunAbortKeyFunCopyKeyFunCutKeyFunPasteKeyFunPasteHistKeyFunBackspaceKeyFunBackspaceWordKeyFunDeleteKeyFunDeleteWordKeyFunKillKeyFunDuplicateKeyFunTransposeKeyFunTransposeWordKeyFunUndoKeyFunRedoKeyFunInsertKeyFunInsertAfterKeyFunZoomOutKeyFunZoomInKeyFunPrefsKeyFunRefreshKeyFunRecenterKeyFunCompleteKeyFunLookupKeyFunSearchKeyFunFindKeyFunReplaceKeyFunJumpKeyFunHistPrevKeyFunHistNextKeyFunMenuKeyFunWinFocusNextKeyFunWinCloseKeyFunWinSnapshotKeyFunGoGiEditorKeyFunMenuNewKeyFunMenuNewAlt1KeyFunMenuNewAlt2KeyFunMenuOpenKeyFunMenuOpenAlt1KeyFunMenuOpenAlt2KeyFunMenuSaveKeyFunMenuSaveAsKeyFunMenuSaveAltKeyFunMenuCloseAlt1KeyFunMenuCloseAlt2KeyFunCommandPaletteKeyFunsN"
 
-var _KeyFuns_index = [...]uint16{0, 9, 21, 35, 50, 64, 76, 90, 100, 109, 122, 134, 149, 163, 178, 193, 204, 216, 234, 250, 265, 276, 286, 295, 306, 321, 336, 355, 367, 383, 393, 408, 423, 442, 452, 462, 474, 491, 504, 516, 527, 540, 554, 568, 580, 592, 602, 615, 625, 639, 653, 663, 681, 695, 712, 728, 741, 758, 775, 789, 807, 825, 839, 855, 872, 891, 910, 918}
+var _KeyFuns_index = [...]uint16{0, 9, 21, 35, 50, 64, 76, 90, 100, 109, 122, 134, 149, 163, 178, 193, 204, 216, 234, 250, 265, 276, 286, 295, 306, 321, 336, 355, 367, 383, 393, 408, 423, 442, 452, 462, 474, 491, 504, 516, 527, 540, 554, 568, 580, 592, 602, 615, 625, 639, 653, 663, 681, 695, 712, 728, 741, 758, 775, 789, 807, 825, 839, 855, 872, 891, 910, 930, 938}
 
 func (i KeyFuns) String() string {
 	if i < 0 || i >= KeyFuns(len(_KeyFuns_index)-1) {
@@ -623,6 +624,7 @@ var _KeyFuns_descMap = map[KeyFuns]string{
 	64: ``,
 	65: ``,
 	66: ``,
+	67: ``,
 }
 
 func (i KeyFuns) Desc() string {
@@ -710,12 +712,15 @@ func _() {
 	_ = x[LayoutVertFlow-4]
 	_ = x[LayoutStacked-5]
 	_ = x[LayoutNil-6]
-	_ = x[LayoutsN-7]
+	_ = x[LayoutGridIrreg-7]
+	_ = x[LayoutHorizFlex-8]
+	_ = x[LayoutVertFlex-9]
+	_ = x[LayoutsN-10]
 }
 
-const _Layouts_name = "LayoutHorizLayoutVertLayoutGridLayoutHorizFlowLayoutVertFlowLayoutStackedLayoutNilLayoutsN"
+const _Layouts_name = "LayoutHorizLayoutVertLayoutGridLayoutHorizFlowLayoutVertFlowLayoutStackedLayoutNilLayoutGridIrregLayoutHorizFlexLayoutVertFlexLayoutsN"
 
-var _Layouts_index = [...]uint8{0, 11, 21, 31, 46, 60, 73, 82, 90}
+var _Layouts_index = [...]uint8{0, 11, 21, 31, 46, 60, 73, 82, 97, 112, 126, 134}
 
 func (i Layouts) String() string {
 	if i < 0 || i >= Layouts(len(_Layouts_index)-1) {
@@ -735,14 +740,17 @@ func (i *Layouts) FromString(s string) error {
 }
 
 var _Layouts_descMap = map[Layouts]string{
-	0: `LayoutHoriz arranges items horizontally across a row`,
-	1: `LayoutVert arranges items vertically in a column`,
-	2: `LayoutGrid arranges items according to a regular grid`,
-	3: `LayoutHorizFlow arranges items horizontally across a row, overflowing vertically as needed. Ballpark target width or height props should be set to generate initial first-pass sizing estimates.`,
-	4: `LayoutVertFlow arranges items vertically within a column, overflowing horizontally as needed. Ballpark target width or height props should be set to generate initial first-pass sizing estimates.`,
-	5: `LayoutStacked arranges items stacked on top of each other -- Top index indicates which to show -- overall size accommodates largest in each dimension`,
-	6: `LayoutNil is a nil layout -- doesn&#39;t do anything -- for cases when a parent wants to take over the job of the layout`,
-	7: ``,
+	0:  `LayoutHoriz arranges items horizontally across a row`,
+	1:  `LayoutVert arranges items vertically in a column`,
+	2:  `LayoutGrid arranges items according to a regular grid`,
+	3:  `LayoutHorizFlow arranges items horizontally across a row, overflowing vertically as needed.  Ballpark target width or height props should be set to generate initial first-pass sizing estimates.`,
+	4:  `LayoutVertFlow arranges items vertically within a column, overflowing horizontally as needed.  Ballpark target width or height props should be set to generate initial first-pass sizing estimates.`,
+	5:  `LayoutStacked arranges items stacked on top of each other -- Top index indicates which to show -- overall size accommodates largest in each dimension`,
+	6:  `LayoutNil is a nil layout -- doesn't do anything -- for cases when a parent wants to take over the job of the layout`,
+	7:  `LayoutGridIrreg arranges items according to an irregular grid, where items can span multiple rows and columns (row-span, col-span), and the column and row tracks can have fixed, fractional or auto sizes (grid-template-columns, grid-template-rows), with separate column-gap and row-gap spacing.  Items with a row or col set are placed there, and the others fill the remaining cells in order.  LayoutGrid is faster for large, fully regular grids.`,
+	8:  `LayoutHorizFlex arranges items horizontally across a row as in a CSS flexbox (flex-direction: row): items start at their flex-basis size, and then grow (flex-grow) into any extra space or shrink (flex-shrink) to fit, and are distributed along the row by justify-content and aligned within it by align-items, with column-gap spacing between them.  If flex-wrap is set, items wrap onto multiple rows, separated by the row-gap.`,
+	9:  `LayoutVertFlex arranges items vertically down a column as in a CSS flexbox (flex-direction: column) -- see LayoutHorizFlex.`,
+	10: ``,
 }
 
 func (i Layouts) Desc() string {
@@ -1101,12 +1109,14 @@ func _() {
 	_ = x[TabSelected-0]
 	_ = x[TabAdded-1]
 	_ = x[TabDeleted-2]
-	_ = x[TabViewSignalsN-3]
+	_ = x[TabMoved-3]
+	_ = x[TabDetached-4]
+	_ = x[TabViewSignalsN-5]
 }
 
-const _TabViewSignals_name = "TabSelectedTabAddedTabDeletedTabViewSignalsN"
+const _TabViewSignals_name = "TabSelectedTabAddedTabDeletedTabMovedTabDetachedTabViewSignalsN"
 
-var _TabViewSignals_index = [...]uint8{0, 11, 19, 29, 44}
+var _TabViewSignals_index = [...]uint8{0, 11, 19, 29, 37, 48, 63}
 
 func (i TabViewSignals) String() string {
 	if i < 0 || i >= TabViewSignals(len(_TabViewSignals_index)-1) {
@@ -1129,7 +1139,9 @@ var _TabViewSignals_descMap = map[TabViewSignals]string{
 	0: `TabSelected indicates tab was selected -- data is the tab index`,
 	1: `TabAdded indicates tab was added -- data is the tab index`,
 	2: `TabDeleted indicates tab was deleted -- data is the tab name`,
-	3: ``,
+	3: `TabMoved indicates tab was moved by the user, within this tab view or to or from another one -- emitted by both tab views in that case -- data is a TabMove`,
+	4: `TabDetached indicates tab was detached by the user into a new window -- data is a TabMove, with the tab view in the new window as To`,
+	5: ``,
 }
 
 func (i TabViewSignals) Desc() string {
@@ -1191,12 +1203,13 @@ func _() {
 	_ = x[TextFieldInsert-4]
 	_ = x[TextFieldBackspace-5]
 	_ = x[TextFieldDelete-6]
-	_ = x[TextFieldSignalsN-7]
+	_ = x[TextFieldInvalid-7]
+	_ = x[TextFieldSignalsN-8]
 }
 
-const _TextFieldSignals_name = "TextFieldDoneTextFieldDeFocusedTextFieldSelectedTextFieldClearedTextFieldInsertTextFieldBackspaceTextFieldDeleteTextFieldSignalsN"
+const _TextFieldSignals_name = "TextFieldDoneTextFieldDeFocusedTextFieldSelectedTextFieldClearedTextFieldInsertTextFieldBackspaceTextFieldDeleteTextFieldInvalidTextFieldSignalsN"
 
-var _TextFieldSignals_index = [...]uint8{0, 13, 31, 48, 64, 79, 97, 112, 129}
+var _TextFieldSignals_index = [...]uint8{0, 13, 31, 48, 64, 79, 97, 112, 128, 145}
 
 func (i TextFieldSignals) String() string {
 	if i < 0 || i >= TextFieldSignals(len(_TextFieldSignals_index)-1) {
@@ -1223,7 +1236,8 @@ var _TextFieldSignals_descMap = map[TextFieldSignals]string{
 	4: `TextFieldInsert is emitted when a character is inserted into the textfield`,
 	5: `TextFieldBackspace is emitted when a character before cursor is deleted`,
 	6: `TextFieldDelete is emitted when a character after cursor is deleted`,
-	7: ``,
+	7: `TextFieldInvalid is emitted when the text fails validation, by the Validator or Mask, when editing is done. data is the error.`,
+	8: ``,
 }
 
 func (i TextFieldSignals) Desc() string {
@@ -1398,3 +1412,346 @@ func (i WinFlags) Desc() string {
 	}
 	return "WinFlags(" + strconv.FormatInt(int64(i), 10) + ")"
 }
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[AccessRoleNone-0]
+	_ = x[AccessRoleWindow-1]
+	_ = x[AccessRoleDialog-2]
+	_ = x[AccessRoleGroup-3]
+	_ = x[AccessRoleLabel-4]
+	_ = x[AccessRoleImage-5]
+	_ = x[AccessRoleButton-6]
+	_ = x[AccessRoleCheckBox-7]
+	_ = x[AccessRoleTextField-8]
+	_ = x[AccessRoleTextArea-9]
+	_ = x[AccessRoleSlider-10]
+	_ = x[AccessRoleScrollBar-11]
+	_ = x[AccessRoleProgressBar-12]
+	_ = x[AccessRoleSpinBox-13]
+	_ = x[AccessRoleComboBox-14]
+	_ = x[AccessRoleMenuBar-15]
+	_ = x[AccessRoleMenu-16]
+	_ = x[AccessRoleMenuItem-17]
+	_ = x[AccessRoleToolBar-18]
+	_ = x[AccessRoleTabList-19]
+	_ = x[AccessRoleTab-20]
+	_ = x[AccessRoleSplitter-21]
+	_ = x[AccessRoleTree-22]
+	_ = x[AccessRoleTreeItem-23]
+	_ = x[AccessRoleList-24]
+	_ = x[AccessRoleTable-25]
+	_ = x[AccessRoleRow-26]
+	_ = x[AccessRoleCell-27]
+	_ = x[AccessRoleColumnHeader-28]
+	_ = x[AccessRoleTooltip-29]
+	_ = x[AccessRolesN-30]
+}
+
+const _AccessRoles_name = "AccessRoleNoneAccessRoleWindowAccessRoleDialogAccessRoleGroupAccessRoleLabelAccessRoleImageAccessRoleButtonAccessRoleCheckBoxAccessRoleTextFieldAccessRoleTextAreaAccessRoleSliderAccessRoleScrollBarAccessRoleProgressBarAccessRoleSpinBoxAccessRoleComboBoxAccessRoleMenuBarAccessRoleMenuAccessRoleMenuItemAccessRoleToolBarAccessRoleTabListAccessRoleTabAccessRoleSplitterAccessRoleTreeAccessRoleTreeItemAccessRoleListAccessRoleTableAccessRoleRowAccessRoleCellAccessRoleColumnHeaderAccessRoleTooltipAccessRolesN"
+
+var _AccessRoles_index = [...]uint16{0, 14, 30, 46, 61, 76, 91, 107, 125, 144, 162, 178, 197, 218, 235, 253, 270, 284, 302, 319, 336, 349, 367, 381, 399, 413, 428, 441, 455, 477, 494, 506}
+
+func (i AccessRoles) String() string {
+	if i < 0 || i >= AccessRoles(len(_AccessRoles_index)-1) {
+		return "AccessRoles(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _AccessRoles_name[_AccessRoles_index[i]:_AccessRoles_index[i+1]]
+}
+
+func (i *AccessRoles) FromString(s string) error {
+	for j := 0; j < len(_AccessRoles_index)-1; j++ {
+		if s == _AccessRoles_name[_AccessRoles_index[j]:_AccessRoles_index[j+1]] {
+			*i = AccessRoles(j)
+			return nil
+		}
+	}
+	return errors.New("String: " + s + " is not a valid option for type: AccessRoles")
+}
+
+var _AccessRoles_descMap = map[AccessRoles]string{
+	0:  `AccessRoleNone means that the node is not itself exposed for accessibility, but its children are (e.g., most layouts).`,
+	1:  `AccessRoleWindow is a top-level window`,
+	2:  `AccessRoleDialog is a dialog window or popup`,
+	3:  `AccessRoleGroup is a set of related elements, e.g., a named frame`,
+	4:  `AccessRoleLabel is static text`,
+	5:  `AccessRoleImage is an image or icon`,
+	6:  `AccessRoleButton is a push button`,
+	7:  `AccessRoleCheckBox is a checkable button`,
+	8:  `AccessRoleTextField is an editable text field`,
+	9:  `AccessRoleTextArea is a multi-line text editor`,
+	10: `AccessRoleSlider is a slider for selecting a value within a range`,
+	11: `AccessRoleScrollBar is a scroll bar`,
+	12: `AccessRoleProgressBar is a progress indicator`,
+	13: `AccessRoleSpinBox is a numeric value entry with increment / decrement buttons`,
+	14: `AccessRoleComboBox is a drop-down list of items to choose from`,
+	15: `AccessRoleMenuBar is a menu bar`,
+	16: `AccessRoleMenu is a popup menu`,
+	17: `AccessRoleMenuItem is an item within a menu or menu bar`,
+	18: `AccessRoleToolBar is a tool bar`,
+	19: `AccessRoleTabList is the set of tabs in a tab view`,
+	20: `AccessRoleTab is one tab in a tab list`,
+	21: `AccessRoleSplitter is a splitter for resizing panels`,
+	22: `AccessRoleTree is a tree of items`,
+	23: `AccessRoleTreeItem is an item in a tree`,
+	24: `AccessRoleList is a list of items`,
+	25: `AccessRoleTable is a table of rows and columns`,
+	26: `AccessRoleRow is a row in a table`,
+	27: `AccessRoleCell is a cell in a table row`,
+	28: `AccessRoleColumnHeader is a header cell for a table column`,
+	29: `AccessRoleTooltip is a tooltip popup`,
+	30: ``,
+}
+
+func (i AccessRoles) Desc() string {
+	if str, ok := _AccessRoles_descMap[i]; ok {
+		return str
+	}
+	return "AccessRoles(" + strconv.FormatInt(int64(i), 10) + ")"
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[AccessFocused-0]
+	_ = x[AccessSelected-1]
+	_ = x[AccessDisabled-2]
+	_ = x[AccessCheckable-3]
+	_ = x[AccessChecked-4]
+	_ = x[AccessExpandable-5]
+	_ = x[AccessExpanded-6]
+	_ = x[AccessReadOnly-7]
+	_ = x[AccessProtected-8]
+	_ = x[AccessStatesN-9]
+}
+
+const _AccessStates_name = "AccessFocusedAccessSelectedAccessDisabledAccessCheckableAccessCheckedAccessExpandableAccessExpandedAccessReadOnlyAccessProtectedAccessStatesN"
+
+var _AccessStates_index = [...]uint8{0, 13, 27, 41, 56, 69, 85, 99, 113, 128, 141}
+
+func (i AccessStates) String() string {
+	if i < 0 || i >= AccessStates(len(_AccessStates_index)-1) {
+		return "AccessStates(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _AccessStates_name[_AccessStates_index[i]:_AccessStates_index[i+1]]
+}
+
+func (i *AccessStates) FromString(s string) error {
+	for j := 0; j < len(_AccessStates_index)-1; j++ {
+		if s == _AccessStates_name[_AccessStates_index[j]:_AccessStates_index[j+1]] {
+			*i = AccessStates(j)
+			return nil
+		}
+	}
+	return errors.New("String: " + s + " is not a valid option for type: AccessStates")
+}
+
+var _AccessStates_descMap = map[AccessStates]string{
+	0: `AccessFocused means the node has keyboard focus`,
+	1: `AccessSelected means the node is selected`,
+	2: `AccessDisabled means the node is disabled and cannot be used`,
+	3: `AccessCheckable means the node can be checked`,
+	4: `AccessChecked means the node is checked`,
+	5: `AccessExpandable means the node can be expanded to show more items`,
+	6: `AccessExpanded means the node is currently expanded`,
+	7: `AccessReadOnly means the value of the node cannot be edited`,
+	8: `AccessProtected means the value of the node is hidden, e.g., a password`,
+	9: ``,
+}
+
+func (i AccessStates) Desc() string {
+	if str, ok := _AccessStates_descMap[i]; ok {
+		return str
+	}
+	return "AccessStates(" + strconv.FormatInt(int64(i), 10) + ")"
+}
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[CSSDescendant-0]
+	_ = x[CSSChild-1]
+	_ = x[CSSAdjacent-2]
+	_ = x[CSSSibling-3]
+	_ = x[CSSCombinatorsN-4]
+}
+
+const _CSSCombinators_name = "CSSDescendantCSSChildCSSAdjacentCSSSiblingCSSCombinatorsN"
+
+var _CSSCombinators_index = [...]uint8{0, 13, 21, 32, 42, 57}
+
+func (i CSSCombinators) String() string {
+	if i < 0 || i >= CSSCombinators(len(_CSSCombinators_index)-1) {
+		return "CSSCombinators(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _CSSCombinators_name[_CSSCombinators_index[i]:_CSSCombinators_index[i+1]]
+}
+
+func (i *CSSCombinators) FromString(s string) error {
+	for j := 0; j < len(_CSSCombinators_index)-1; j++ {
+		if s == _CSSCombinators_name[_CSSCombinators_index[j]:_CSSCombinators_index[j+1]] {
+			*i = CSSCombinators(j)
+			return nil
+		}
+	}
+	return errors.New("String: " + s + " is not a valid option for type: CSSCombinators")
+}
+
+var _CSSCombinators_descMap = map[CSSCombinators]string{
+	0: `CSSDescendant matches any descendant of the prior compound (a space)`,
+	1: `CSSChild matches a direct child of the prior compound (>)`,
+	2: `CSSAdjacent matches the sibling immediately after the prior compound (+)`,
+	3: `CSSSibling matches any later sibling of the prior compound (~)`,
+	4: ``,
+}
+
+func (i CSSCombinators) Desc() string {
+	if str, ok := _CSSCombinators_descMap[i]; ok {
+		return str
+	}
+	return "CSSCombinators(" + strconv.FormatInt(int64(i), 10) + ")"
+}
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[DockLeft-0]
+	_ = x[DockCenter-1]
+	_ = x[DockRight-2]
+	_ = x[DockBottom-3]
+	_ = x[DockFloat-4]
+	_ = x[DockAreasN-5]
+}
+
+const _DockAreas_name = "DockLeftDockCenterDockRightDockBottomDockFloatDockAreasN"
+
+var _DockAreas_index = [...]uint8{0, 8, 18, 27, 37, 46, 56}
+
+func (i DockAreas) String() string {
+	if i < 0 || i >= DockAreas(len(_DockAreas_index)-1) {
+		return "DockAreas(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _DockAreas_name[_DockAreas_index[i]:_DockAreas_index[i+1]]
+}
+
+func (i *DockAreas) FromString(s string) error {
+	for j := 0; j < len(_DockAreas_index)-1; j++ {
+		if s == _DockAreas_name[_DockAreas_index[j]:_DockAreas_index[j+1]] {
+			*i = DockAreas(j)
+			return nil
+		}
+	}
+	return errors.New("String: " + s + " is not a valid option for type: DockAreas")
+}
+
+var _DockAreas_descMap = map[DockAreas]string{
+	0: `DockLeft is the area at the left of the center area`,
+	1: `DockCenter is the main area in the center`,
+	2: `DockRight is the area at the right of the center area`,
+	3: `DockBottom is the area at the bottom, spanning the other areas`,
+	4: `DockFloat is for panels floating in their own window`,
+	5: ``,
+}
+
+func (i DockAreas) Desc() string {
+	if str, ok := _DockAreas_descMap[i]; ok {
+		return str
+	}
+	return "DockAreas(" + strconv.FormatInt(int64(i), 10) + ")"
+}
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[DockPanelMoved-0]
+	_ = x[DockAreaCollapsed-1]
+	_ = x[DockAreaExpanded-2]
+	_ = x[DockSignalsN-3]
+}
+
+const _DockSignals_name = "DockPanelMovedDockAreaCollapsedDockAreaExpandedDockSignalsN"
+
+var _DockSignals_index = [...]uint8{0, 14, 31, 47, 59}
+
+func (i DockSignals) String() string {
+	if i < 0 || i >= DockSignals(len(_DockSignals_index)-1) {
+		return "DockSignals(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _DockSignals_name[_DockSignals_index[i]:_DockSignals_index[i+1]]
+}
+
+func (i *DockSignals) FromString(s string) error {
+	for j := 0; j < len(_DockSignals_index)-1; j++ {
+		if s == _DockSignals_name[_DockSignals_index[j]:_DockSignals_index[j+1]] {
+			*i = DockSignals(j)
+			return nil
+		}
+	}
+	return errors.New("String: " + s + " is not a valid option for type: DockSignals")
+}
+
+var _DockSignals_descMap = map[DockSignals]string{
+	0: `DockPanelMoved indicates that a panel was docked in another area, reordered within its area, or floated, by the user or the app -- data is the id of the panel`,
+	1: `DockAreaCollapsed indicates that an area was collapsed -- data is the DockAreas`,
+	2: `DockAreaExpanded indicates that a collapsed area was expanded -- data is the DockAreas`,
+	3: ``,
+}
+
+func (i DockSignals) Desc() string {
+	if str, ok := _DockSignals_descMap[i]; ok {
+		return str
+	}
+	return "DockSignals(" + strconv.FormatInt(int64(i), 10) + ")"
+}
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[ToastInfo-0]
+	_ = x[ToastSuccess-1]
+	_ = x[ToastWarning-2]
+	_ = x[ToastError-3]
+	_ = x[ToastLevelsN-4]
+}
+
+const _ToastLevels_name = "ToastInfoToastSuccessToastWarningToastErrorToastLevelsN"
+
+var _ToastLevels_index = [...]uint8{0, 9, 21, 33, 43, 55}
+
+func (i ToastLevels) String() string {
+	if i < 0 || i >= ToastLevels(len(_ToastLevels_index)-1) {
+		return "ToastLevels(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _ToastLevels_name[_ToastLevels_index[i]:_ToastLevels_index[i+1]]
+}
+
+func (i *ToastLevels) FromString(s string) error {
+	for j := 0; j < len(_ToastLevels_index)-1; j++ {
+		if s == _ToastLevels_name[_ToastLevels_index[j]:_ToastLevels_index[j+1]] {
+			*i = ToastLevels(j)
+			return nil
+		}
+	}
+	return errors.New("String: " + s + " is not a valid option for type: ToastLevels")
+}
+
+var _ToastLevels_descMap = map[ToastLevels]string{
+	0: `ToastInfo is a neutral informational message`,
+	1: `ToastSuccess reports the successful completion of something`,
+	2: `ToastWarning reports a potential problem`,
+	3: `ToastError reports a failure`,
+	4: ``,
+}
+
+func (i ToastLevels) Desc() string {
+	if str, ok := _ToastLevels_descMap[i]; ok {
+		return str
+	}
+	return "ToastLevels(" + strconv.FormatInt(int64(i), 10) + ")"
+}
@@ -4,4 +4,4 @@
 
 package gi
 
-//go:generate stringer -output stringer.go -type=ActionTypes,ButtonFlags,ButtonSignals,ButtonTypes,ComboBoxTypes,CompleteSignals,DialogState,EventPris,DNDStages,Stripes,KeyFuns,LabelTypes,Layouts,RowCol,NodeFlags,FocusChanges,Densities,SliderSignals,SliderStates,SpellSignals,TabViewSignals,TextFieldTypes,TextFieldSignals,VpFlags,WidgetSignals,WinFlags
+//go:generate stringer -output stringer.go -type=ActionTypes,ButtonFlags,ButtonSignals,ButtonTypes,ComboBoxTypes,CompleteSignals,DialogState,EventPris,DNDStages,Stripes,KeyFuns,LabelTypes,Layouts,RowCol,NodeFlags,FocusChanges,Densities,SliderSignals,SliderStates,SpellSignals,TabViewSignals,TextFieldTypes,TextFieldSignals,VpFlags,WidgetSignals,WinFlags,AccessRoles,AccessStates,CSSCombinators,DockAreas,DockSignals,ToastLevels
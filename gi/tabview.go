@@ -6,6 +6,7 @@ package gi
 
 import (
 	"fmt"
+	"image"
 	"log"
 	"reflect"
 	"sync"
@@ -13,8 +14,12 @@ import (
 	"goki.dev/colors"
 	"goki.dev/gi/v2/gist"
 	"goki.dev/gi/v2/icons"
+	"goki.dev/gi/v2/oswin"
 	"goki.dev/gi/v2/oswin/cursor"
+	"goki.dev/gi/v2/oswin/dnd"
+	"goki.dev/gi/v2/oswin/mimedata"
 	"goki.dev/gi/v2/units"
+	"goki.dev/ki/v2/ints"
 	"goki.dev/ki/v2/ki"
 	"goki.dev/ki/v2/kit"
 )
@@ -41,6 +46,12 @@ type TabView struct {
 	// if true, tabs are not user-deleteable
 	NoDeleteTabs bool `desc:"if true, tabs are not user-deleteable"`
 
+	// if true, tabs cannot be dragged to reorder them or move them to or from another tab view
+	NoMoveTabs bool `desc:"if true, tabs cannot be dragged to reorder them or move them to or from another tab view"`
+
+	// if true, tabs cannot be detached into a new window by dragging them outside of any tab view
+	NoDetachTabs bool `desc:"if true, tabs cannot be detached into a new window by dragging them outside of any tab view"`
+
 	// type of widget to create in a new tab via new tab button -- Frame by default
 	NewTabType reflect.Type `desc:"type of widget to create in a new tab via new tab button -- Frame by default"`
 
@@ -105,6 +116,9 @@ func (tv *TabView) CopyFieldsFrom(frm any) {
 	tv.MaxChars = fr.MaxChars
 	tv.NewTabButton = fr.NewTabButton
 	tv.NewTabType = fr.NewTabType
+	tv.NoDeleteTabs = fr.NoDeleteTabs
+	tv.NoMoveTabs = fr.NoMoveTabs
+	tv.NoDetachTabs = fr.NoDetachTabs
 }
 
 func (tv *TabView) Disconnect() {
@@ -172,6 +186,10 @@ func (tv *TabView) InsertTabOnlyAt(widg Node2D, label string, idx int) {
 		tab.SetSelectedState(true)
 	} else {
 		widg.AsNode2D().SetInvisible() // new tab is invisible until selected
+		if idx <= fr.StackTop {
+			fr.StackTop++ // keep the same tab selected
+		}
+		tv.RenumberTabs()
 	}
 }
 
@@ -473,9 +491,230 @@ const (
 	// TabDeleted indicates tab was deleted -- data is the tab name
 	TabDeleted
 
+	// TabMoved indicates tab was moved by the user, within this tab view or
+	// to or from another one -- emitted by both tab views in that case --
+	// data is a TabMove
+	TabMoved
+
+	// TabDetached indicates tab was detached by the user into a new window
+	// -- data is a TabMove, with the tab view in the new window as To
+	TabDetached
+
 	TabViewSignalsN
 )
 
+// TabMove is the data for the TabMoved and TabDetached signals
+type TabMove struct {
+
+	// name (label) of the tab
+	Name string
+
+	// tab view the tab was moved from
+	From *TabView
+
+	// index of the tab in the From tab view
+	FromIdx int
+
+	// tab view the tab was moved to
+	To *TabView
+
+	// index of the tab in the To tab view
+	ToIdx int
+}
+
+// TabMimeType is the mime type of the drag-n-drop data for tabs
+// being dragged -- the data is the name of the tab
+const TabMimeType = "application/x-gogi-tab"
+
+// MoveTabIndex moves the tab at given index to given new index, keeping
+// the same tab selected -- returns false if either index is invalid
+func (tv *TabView) MoveTabIndex(from, to int) bool {
+	sz := tv.NTabs()
+	if from < 0 || from >= sz || to < 0 || to >= sz {
+		log.Printf("gi.TabView: MoveTabIndex: index %v or %v out of range for number of tabs: %v\n", from, to, sz)
+		return false
+	}
+	if from == to {
+		return true
+	}
+	tv.Mu.Lock()
+	fr := tv.Frame()
+	tb := tv.Tabs()
+	updt := tv.UpdateStart()
+	tv.SetFullReRender()
+	fr.Kids.Move(from, to)
+	tb.Kids.Move(from, to)
+	switch top := fr.StackTop; {
+	case top == from:
+		fr.StackTop = to
+	case from < top && to >= top:
+		fr.StackTop--
+	case from > top && to <= top:
+		fr.StackTop++
+	}
+	tv.RenumberTabs()
+	tv.Mu.Unlock()
+	tv.UpdateEnd(updt)
+	return true
+}
+
+// MoveTabIndexAction moves the tab at given index to given new index,
+// and emits the TabMoved signal
+func (tv *TabView) MoveTabIndexAction(from, to int) {
+	tnm := tv.TabName(from)
+	if tv.MoveTabIndex(from, to) && from != to {
+		tv.TabViewSig.Emit(tv.This(), int64(TabMoved), TabMove{Name: tnm, From: tv, FromIdx: from, To: tv, ToIdx: to})
+	}
+}
+
+// MoveTabTo moves the tab at given index to given index in given other tab
+// view (which can be this one), which could be in another window, and
+// selects it there -- returns false if the index is invalid
+func (tv *TabView) MoveTabTo(idx int, to *TabView, toIdx int) bool {
+	if to == tv {
+		return tv.MoveTabIndex(idx, ints.MinInt(toIdx, tv.NTabs()-1))
+	}
+	widg, _, ok := tv.TabAtIndex(idx)
+	if !ok {
+		return false
+	}
+	widg.AsNode2D().DisconnectAllEvents(AllPris) // may be going to another window
+	widg, tnm, ok := tv.DeleteTabIndex(idx, false)
+	if !ok {
+		return false
+	}
+	toIdx = ints.MaxInt(ints.MinInt(toIdx, to.NTabs()), 0)
+	to.InsertTab(widg, tnm, toIdx)
+	to.SelectTabIndex(toIdx)
+	return true
+}
+
+// MoveTabToAction moves the tab at given index to given index in given other
+// tab view, and emits the TabMoved signal from both tab views
+func (tv *TabView) MoveTabToAction(idx int, to *TabView, toIdx int) {
+	if to == tv {
+		tv.MoveTabIndexAction(idx, ints.MinInt(toIdx, tv.NTabs()-1))
+		return
+	}
+	tnm := tv.TabName(idx)
+	toIdx = ints.MaxInt(ints.MinInt(toIdx, to.NTabs()), 0)
+	if tv.MoveTabTo(idx, to, toIdx) {
+		mv := TabMove{Name: tnm, From: tv, FromIdx: idx, To: to, ToIdx: toIdx}
+		tv.TabViewSig.Emit(tv.This(), int64(TabMoved), mv)
+		to.TabViewSig.Emit(to.This(), int64(TabMoved), mv)
+	}
+}
+
+// DetachTab moves the tab at given index into a new TabView in a new main
+// window, placed at given position in screen coordinates (see
+// Window.WinToScreenPos) -- returns the new tab view, and false if the
+// index is invalid or the window could not be made
+func (tv *TabView) DetachTab(idx int, scrPos image.Point) (*TabView, bool) {
+	_, _, ok := tv.TabAtIndex(idx)
+	if !ok {
+		return nil, false
+	}
+	tnm := tv.TabName(idx)
+	sz := tv.LayState.Alloc.Size
+	if win := tv.ParentWindow(); win != nil {
+		sz = sz.MulScalar(96 / win.LogicalDPI()) // to standard pixels
+	}
+	nw := NewMainWindow(tv.Nm+"-"+tnm, tnm, ints.MaxInt(int(sz.X), 200), ints.MaxInt(int(sz.Y), 150))
+	if nw == nil {
+		return nil, false
+	}
+	vp := nw.WinViewport2D()
+	updt := vp.UpdateStart()
+	mfr := nw.SetMainFrame()
+	ntv := AddNewTabView(mfr, tv.Nm)
+	ntv.MaxChars = tv.MaxChars
+	ntv.NoDeleteTabs = tv.NoDeleteTabs
+	ntv.NoMoveTabs = tv.NoMoveTabs
+	ntv.NoDetachTabs = tv.NoDetachTabs
+	ntv.NewTabType = tv.NewTabType
+	ntv.SetStretchMax()
+	tv.MoveTabTo(idx, ntv, 0)
+	nw.OSWin.SetPos(scrPos)
+	vp.UpdateEndNoSig(updt)
+	nw.GoStartEventLoop()
+	return ntv, true
+}
+
+// DetachTabAction detaches the tab at given index into a new window (see
+// DetachTab), and emits the TabDetached signal
+func (tv *TabView) DetachTabAction(idx int, scrPos image.Point) {
+	tnm := tv.TabName(idx)
+	if ntv, ok := tv.DetachTab(idx, scrPos); ok {
+		tv.TabViewSig.Emit(tv.This(), int64(TabDetached), TabMove{Name: tnm, From: tv, FromIdx: idx, To: ntv, ToIdx: 0})
+	}
+}
+
+// TabIndexAtWinPos returns the index at which a tab dropped at given
+// position within the window is inserted: that of the tab at that position,
+// or the number of tabs if there is none
+func (tv *TabView) TabIndexAtWinPos(pos image.Point) int {
+	sz := tv.NTabs()
+	tbs := tv.Tabs()
+	for i := 0; i < sz; i++ {
+		if _, ni := KiToNode2D(tbs.Child(i)); ni != nil && ni.PosInWinBBox(pos) {
+			return i
+		}
+	}
+	return sz
+}
+
+// TabViewAtWinPos returns the innermost TabView in given window that
+// contains given position within the window, or nil if none
+func TabViewAtWinPos(w *Window, pos image.Point) *TabView {
+	if w == nil || w.Viewport == nil {
+		return nil
+	}
+	var tv *TabView
+	w.Viewport.FuncDownMeFirst(0, nil, func(k ki.Ki, level int, d any) bool {
+		_, ni := KiToNode2D(k)
+		if ni == nil || ni.IsInvisible() || !ni.PosInWinBBox(pos) {
+			return ki.Break
+		}
+		if t, ok := k.Embed(TypeTabView).(*TabView); ok {
+			tv = t
+		}
+		return ki.Continue
+	})
+	return tv
+}
+
+// DragNDropTarget handles a drag-n-drop onto this tab view: if it is a tab,
+// it is moved here, at the position of the drop
+func (tv *TabView) DragNDropTarget(de *dnd.Event) {
+	stb, ok := de.Source.(*TabButton)
+	if !ok || !de.Data.HasType(TabMimeType) || tv.NoMoveTabs {
+		return
+	}
+	from := stb.TabView()
+	if from == nil || from.NoMoveTabs {
+		return
+	}
+	de.Target = tv.This()
+	de.SetProcessed()
+	idx := stb.Data.(int)
+	to := tv.TabIndexAtWinPos(de.Where)
+	if win := tv.ParentWindow(); win != nil {
+		win.FinalizeDragNDrop(dnd.DropMove) // before moving, which can delete the source
+	}
+	from.MoveTabToAction(idx, tv, to)
+}
+
+func (tv *TabView) ConnectEvents2D() {
+	tv.Layout.ConnectEvents2D()
+	tv.ConnectEvent(oswin.DNDEvent, RegPri, func(recv, send ki.Ki, sig int64, d any) {
+		de := d.(*dnd.Event)
+		tvv := recv.Embed(TypeTabView).(*TabView)
+		if de.Action == dnd.DropOnTarget {
+			tvv.DragNDropTarget(de)
+		}
+	})
+}
+
 // Config initializes the tab widget children if it hasn't been done yet
 func (tv *TabView) Config() {
 	if len(tv.Kids) != 0 {
@@ -514,6 +753,29 @@ func (tv *TabView) Frame() *Frame {
 	return tv.Child(1).(*Frame)
 }
 
+// AccessInfo returns the accessibility info for the tab view, which is a
+// Group containing the TabList of tabs and the current tab contents
+// (see AccessKids)
+func (tv *TabView) AccessInfo() AccessInfo {
+	return tv.AccessInfoBase(AccessRoleGroup, "")
+}
+
+// AccessKids returns the accessibility children of the tab view: the list
+// of tabs, followed by the contents of the current tab only
+func (tv *TabView) AccessKids() []*AccessNode {
+	if tv.NumChildren() < 2 {
+		return nil
+	}
+	tabs := tv.Child(0)
+	tl := NewAccessNode(tabs, AccessInfo{Role: AccessRoleTabList})
+	tl.Kids = AccessChildren(tabs)
+	kids := []*AccessNode{tl}
+	if fr, ok := tv.Child(1).(*Frame); ok && fr.StackTop >= 0 && fr.StackTop < fr.NumChildren() {
+		kids = append(kids, AccessNodes(fr.Child(fr.StackTop))...)
+	}
+	return kids
+}
+
 // UnselectOtherTabs turns off all the tabs except given one
 func (tv *TabView) UnselectOtherTabs(idx int) {
 	sz := tv.NTabs()
@@ -693,6 +955,14 @@ func (tb *TabButton) TabView() *TabView {
 	return tv.Embed(TypeTabView).(*TabView)
 }
 
+// AccessInfo returns the accessibility info for the tab, which is
+// Selected when it is the current tab
+func (tb *TabButton) AccessInfo() AccessInfo {
+	ai := tb.ButtonBase.AccessInfo()
+	ai.Role = AccessRoleTab
+	return ai
+}
+
 func (tb *TabButton) ConfigParts() {
 	if !tb.NoDelete {
 		tb.ConfigPartsDeleteButton()
@@ -734,6 +1004,74 @@ func (tb *TabButton) ConfigPartsDeleteButton() {
 	}
 }
 
+// DragNDropStart starts a drag-n-drop of this tab, to reorder it, move it
+// to another tab view, or detach it into a new window
+func (tb *TabButton) DragNDropStart() {
+	tv := tb.TabView()
+	win := tb.ParentWindow()
+	if tv == nil || tv.NoMoveTabs || win == nil {
+		return
+	}
+	md := mimedata.NewTextPlus(tb.Nm, TabMimeType, []byte(tb.Nm))
+	sp := &Sprite{}
+	sp.GrabRenderFrom(tb)
+	ImageClearer(sp.Pixels, 50.0)
+	win.StartDragNDrop(tb.This(), md, sp)
+}
+
+// DropOutside is called when this tab is dropped outside of its window: if
+// that is on a tab view in another window, it is moved there, and otherwise
+// it is detached into a new window (see TabView.DetachTab) -- satisfies the
+// DragNDropOutsider interface
+func (tb *TabButton) DropOutside(md mimedata.Mimes, scrPos image.Point) {
+	tv := tb.TabView()
+	if tv == nil {
+		return
+	}
+	idx := tb.Data.(int)
+	if w := AllWindows.AtScreenPos(scrPos); w != nil && w != tb.ParentWindow() {
+		pos := w.ScreenToWinPos(scrPos)
+		if to := TabViewAtWinPos(w, pos); to != nil && !to.NoMoveTabs {
+			tv.MoveTabToAction(idx, to, to.TabIndexAtWinPos(pos))
+			return
+		}
+	}
+	if !tv.NoDetachTabs {
+		tv.DetachTabAction(idx, scrPos)
+	}
+}
+
+func (tb *TabButton) ConnectEvents2D() {
+	tb.Action.ConnectEvents2D()
+	tb.ConnectEvent(oswin.DNDEvent, RegPri, func(recv, send ki.Ki, sig int64, d any) {
+		de := d.(*dnd.Event)
+		tbb := recv.Embed(TypeTabButton).(*TabButton)
+		switch de.Action {
+		case dnd.Start:
+			de.SetProcessed()
+			tbb.DragNDropStart()
+		case dnd.DropFmSource: // nothing to do: target does the move
+			de.SetProcessed()
+		}
+	})
+	tb.ConnectEvent(oswin.DNDFocusEvent, RegPri, func(recv, send ki.Ki, sig int64, d any) {
+		de := d.(*dnd.FocusEvent)
+		tbb := recv.Embed(TypeTabButton).(*TabButton)
+		win := tbb.ParentWindow()
+		if win == nil || win.EventMgr.DNDSource == nil {
+			return
+		}
+		switch de.Action {
+		case dnd.Enter:
+			if tv := tbb.TabView(); tv != nil && !tv.NoMoveTabs && win.EventMgr.DNDData.HasType(TabMimeType) {
+				win.DNDSetCursor(dnd.DropMove)
+			}
+		case dnd.Exit:
+			win.DNDNotCursor()
+		}
+	})
+}
+
 func (tb *TabButton) Init2D() {
 	tb.Init2DWidget()
 	tb.ConfigParts()
@@ -0,0 +1,181 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"strings"
+	"testing"
+
+	"goki.dev/gi/v2/icons"
+	"goki.dev/ki/v2/ki"
+)
+
+// noIconMgr is an IconMgr without any icons, for tests that configure
+// widget parts without loading the svg package
+type noIconMgr struct{}
+
+func (im *noIconMgr) IsValid(iconName icons.Icon) bool            { return false }
+func (im *noIconMgr) SetIcon(ic *Icon, iconName icons.Icon) error { return nil }
+func (im *noIconMgr) IconByName(name icons.Icon) (ki.Ki, error)   { return nil, nil }
+func (im *noIconMgr) IconList(alphaSort bool) []icons.Icon        { return nil }
+
+// useNoIconMgr sets TheIconMgr to a noIconMgr, and returns a function that
+// restores the previous one, to defer
+func useNoIconMgr() func() {
+	im := TheIconMgr
+	TheIconMgr = &noIconMgr{}
+	return func() { TheIconMgr = im }
+}
+
+// tabViewTest returns a new tab view with tabs of given names -- call
+// useNoIconMgr first
+func tabViewTest(name string, tabs ...string) *TabView {
+	vp := NewViewport2D(200, 200)
+	vp.InitName(vp, "vp")
+	tv := AddNewTabView(vp, name)
+	for _, tnm := range tabs {
+		tv.AddNewTab(TypeFrame, tnm)
+	}
+	return tv
+}
+
+// tabNames returns the names of the tabs and their widgets, which must match
+func tabNames(t *testing.T, tv *TabView) string {
+	t.Helper()
+	nms := make([]string, tv.NTabs())
+	for i := range nms {
+		widg, tb, ok := tv.TabAtIndex(i)
+		if !ok {
+			t.Fatalf("%v: no tab at index %d", tv.Nm, i)
+		}
+		if widg.Name() != tb.Nm {
+			t.Errorf("%v: tab %d is %v but its widget is %v", tv.Nm, i, tb.Nm, widg.Name())
+		}
+		if tb.Data.(int) != i {
+			t.Errorf("%v: tab %d has index %v", tv.Nm, i, tb.Data)
+		}
+		nms[i] = tb.Nm
+	}
+	return strings.Join(nms, " ")
+}
+
+func TestTabViewMoveTabIndex(t *testing.T) {
+	defer useNoIconMgr()()
+	tests := []struct {
+		from, to int
+		sel      int
+		ok       bool
+		names    string
+		wantSel  int
+	}{
+		{0, 2, 0, true, "b c a d", 2},
+		{3, 0, 0, true, "d a b c", 1},
+		{0, 2, 1, true, "b c a d", 0},
+		{2, 0, 1, true, "c a b d", 2},
+		{3, 1, 2, true, "a d b c", 3},
+		{1, 1, 1, true, "a b c d", 1},
+		{0, 4, 0, false, "a b c d", 0},
+		{-1, 0, 0, false, "a b c d", 0},
+	}
+	for _, tt := range tests {
+		tv := tabViewTest("tv", "a", "b", "c", "d")
+		tv.SelectTabIndex(tt.sel)
+		if ok := tv.MoveTabIndex(tt.from, tt.to); ok != tt.ok {
+			t.Errorf("%d to %d: ok = %v, want %v", tt.from, tt.to, ok, tt.ok)
+		}
+		if nms := tabNames(t, tv); nms != tt.names {
+			t.Errorf("%d to %d: tabs %q, want %q", tt.from, tt.to, nms, tt.names)
+		}
+		if top := tv.Frame().StackTop; top != tt.wantSel {
+			t.Errorf("%d to %d with %d selected: selected %d, want %d", tt.from, tt.to, tt.sel, top, tt.wantSel)
+		}
+	}
+}
+
+func TestTabViewMoveTabTo(t *testing.T) {
+	defer useNoIconMgr()()
+	tests := []struct {
+		idx, toIdx   int
+		names, tnams string
+		toSel        int
+	}{
+		{0, 0, "b c", "a x y", 0},
+		{2, 1, "a b", "x c y", 1},
+		{1, 10, "a c", "x y b", 2},
+		{1, -1, "a c", "b x y", 0},
+	}
+	for _, tt := range tests {
+		tv := tabViewTest("tv", "a", "b", "c")
+		to := tabViewTest("to", "x", "y")
+		if !tv.MoveTabTo(tt.idx, to, tt.toIdx) {
+			t.Errorf("%d to %d: move failed", tt.idx, tt.toIdx)
+			continue
+		}
+		if nms := tabNames(t, tv); nms != tt.names {
+			t.Errorf("%d to %d: from tabs %q, want %q", tt.idx, tt.toIdx, nms, tt.names)
+		}
+		if nms := tabNames(t, to); nms != tt.tnams {
+			t.Errorf("%d to %d: to tabs %q, want %q", tt.idx, tt.toIdx, nms, tt.tnams)
+		}
+		if top := to.Frame().StackTop; top != tt.toSel {
+			t.Errorf("%d to %d: selected %d, want %d", tt.idx, tt.toIdx, top, tt.toSel)
+		}
+	}
+	tv := tabViewTest("tv", "a")
+	if tv.MoveTabTo(1, tabViewTest("to"), 0) {
+		t.Errorf("invalid index: move succeeded")
+	}
+}
+
+func TestTabViewMoveSignals(t *testing.T) {
+	defer useNoIconMgr()()
+	tv := tabViewTest("tv", "a", "b", "c")
+	to := tabViewTest("to", "x")
+	var got []TabMove
+	recv := func(recv, send ki.Ki, sig int64, data any) {
+		if TabViewSignals(sig) == TabMoved {
+			got = append(got, data.(TabMove))
+		}
+	}
+	tv.TabViewSig.Connect(tv.This(), recv)
+	to.TabViewSig.Connect(to.This(), recv)
+
+	tv.MoveTabIndexAction(0, 0) // no move, no signal
+	tv.MoveTabIndexAction(0, 2)
+	tv.MoveTabToAction(1, to, 5)
+	tv.MoveTabToAction(0, tv, 5) // same view: clamped to the last tab
+	want := []TabMove{
+		{Name: "a", From: tv, FromIdx: 0, To: tv, ToIdx: 2},
+		{Name: "c", From: tv, FromIdx: 1, To: to, ToIdx: 1},
+		{Name: "c", From: tv, FromIdx: 1, To: to, ToIdx: 1},
+		{Name: "b", From: tv, FromIdx: 0, To: tv, ToIdx: 1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d TabMoved signals, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("signal %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+	if nms := tabNames(t, tv); nms != "a b" {
+		t.Errorf("tabs %q, want %q", nms, "a b")
+	}
+}
+
+func TestTabViewInsertTab(t *testing.T) {
+	defer useNoIconMgr()()
+	tv := tabViewTest("tv", "a", "b")
+	tv.SelectTabIndex(1)
+	fr := &Frame{}
+	fr.InitName(fr, "c")
+	tv.InsertTab(fr, "c", 0)
+	if nms := tabNames(t, tv); nms != "c a b" {
+		t.Errorf("tabs %q, want %q", nms, "c a b")
+	}
+	if _, idx, _ := tv.CurTab(); idx != 2 {
+		t.Errorf("selected %d, want %d", idx, 2)
+	}
+}
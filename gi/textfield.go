@@ -140,6 +140,24 @@ type TextField struct {
 
 	// replace displayed characters with bullets to conceal text
 	NoEcho bool `copy:"-" json:"-" xml:"-" desc:"replace displayed characters with bullets to conceal text"`
+
+	// [view: -] optional function that validates the text when editing is done -- if it returns an error, the field is shown in an error state, with the message under it, and TextFieldInvalid is emitted -- see Validate
+	Validator func(txt string) error `json:"-" xml:"-" view:"-" desc:"optional function that validates the text when editing is done -- if it returns an error, the field is shown in an error state, with the message under it, and TextFieldInvalid is emitted -- see Validate"`
+
+	// input mask for text with a fixed format, such as dates, IP addresses or phone numbers: 9 stands for a digit, a for a letter, * for a letter or digit, and h for a hex digit -- all other characters are literals, which are inserted automatically, and a backslash makes the next character a literal -- e.g., 99/99/9999 or (999) 999-9999
+	Mask string `xml:"mask" desc:"input mask for text with a fixed format, such as dates, IP addresses or phone numbers: 9 stands for a digit, a for a letter, * for a letter or digit, and h for a hex digit -- all other characters are literals, which are inserted automatically, and a backslash makes the next character a literal -- e.g., 99/99/9999 or (999) 999-9999"`
+
+	// maximum number of characters that can be entered -- 0 for no limit
+	MaxLen int `xml:"max-len" desc:"maximum number of characters that can be entered -- 0 for no limit"`
+
+	// [view: -] optional filter for the characters that can be entered, e.g., CharFilterDigits or CharFilterHex
+	CharFilter CharFilter `json:"-" xml:"-" view:"-" desc:"optional filter for the characters that can be entered, e.g., CharFilterDigits or CharFilterHex"`
+
+	// current validation error, nil if the text is valid -- see Validate
+	ValidErr error `copy:"-" json:"-" xml:"-" desc:"current validation error, nil if the text is valid -- see Validate"`
+
+	// render version of the validation error message
+	RenderMsg girl.Text `copy:"-" json:"-" xml:"-" desc:"render version of the validation error message"`
 }
 
 var TypeTextField = kit.Types.AddType(&TextField{}, TextFieldProps)
@@ -169,6 +187,12 @@ func (tf *TextField) OnInit() {
 		}
 		s.Text.Align = gist.AlignLeft
 		s.Color = ColorScheme.OnSurface
+		if StateTransitionDuration > 0 {
+			s.Transitions = []gist.Transition{
+				{Property: "border-color", Duration: StateTransitionDuration},
+				{Property: "background-color", Duration: StateTransitionDuration},
+			}
+		}
 		switch tf.Type {
 		case TextFieldFilled:
 			s.Border.Style.Set(gist.BorderNone)
@@ -198,6 +222,14 @@ func (tf *TextField) OnInit() {
 		if tf.IsSelected() {
 			s.BackgroundColor.SetSolid(ColorScheme.TertiaryContainer)
 		}
+		if tf.ValidErr != nil {
+			if tf.Type == TextFieldFilled {
+				s.Border.Color.Bottom = ColorScheme.Error
+			} else {
+				s.Border.Color.Set(ColorScheme.Error)
+			}
+			s.Margin.Bottom.SetEm(1.5) // room for the message
+		}
 	})
 }
 
@@ -236,6 +268,10 @@ func (tf *TextField) CopyFieldsFrom(frm any) {
 	tf.CursorWidth = fr.CursorWidth
 	tf.Edited = fr.Edited
 	tf.MaxWidthReq = fr.MaxWidthReq
+	tf.Validator = fr.Validator
+	tf.Mask = fr.Mask
+	tf.MaxLen = fr.MaxLen
+	tf.CharFilter = fr.CharFilter
 }
 
 func (tf *TextField) Disconnect() {
@@ -299,6 +335,10 @@ const (
 	// TextFieldDelete is emitted when a character after cursor is deleted
 	TextFieldDelete
 
+	// TextFieldInvalid is emitted when the text fails validation, by the
+	// Validator or Mask, when editing is done.  data is the error.
+	TextFieldInvalid
+
 	TextFieldSignalsN
 )
 
@@ -321,6 +361,19 @@ func (tf *TextField) Text() string {
 	return tf.Txt
 }
 
+// AccessInfo returns the accessibility info for the text field, named by its
+// placeholder, with its current text as the value -- the value is empty
+// for password fields (NoEcho), which are marked as Protected.
+func (tf *TextField) AccessInfo() AccessInfo {
+	ai := tf.AccessInfoBase(AccessRoleTextField, AccessText(tf.Placeholder))
+	if tf.NoEcho {
+		ai.SetState(true, AccessProtected)
+	} else {
+		ai.Value = string(tf.EditTxt)
+	}
+	return ai
+}
+
 // SetText sets the text to be edited and reverts any current edit to reflect this new text
 func (tf *TextField) SetText(txt string) {
 	if tf.Txt == txt && !tf.Edited {
@@ -377,6 +430,7 @@ func (tf *TextField) EditDone() {
 	if tf.Edited {
 		tf.Edited = false
 		tf.Txt = string(tf.EditTxt)
+		tf.Validate()
 		tf.TextFieldSig.Emit(tf.This(), int64(TextFieldDone), tf.Txt)
 	}
 	tf.ClearSelected()
@@ -390,6 +444,7 @@ func (tf *TextField) EditDeFocused() {
 	if tf.Edited {
 		tf.Edited = false
 		tf.Txt = string(tf.EditTxt)
+		tf.Validate()
 		tf.TextFieldSig.Emit(tf.This(), int64(TextFieldDeFocused), tf.Txt)
 	}
 	tf.ClearSelected()
@@ -405,6 +460,7 @@ func (tf *TextField) Revert() {
 	tf.StartPos = 0
 	tf.EndPos = tf.CharWidth
 	tf.SelectReset()
+	tf.UpdateValid()
 }
 
 // Clear clears any existing text
@@ -495,7 +551,8 @@ func (tf *TextField) CursorEnd() {
 // shift+arrow = select
 // uparrow = start / down = end
 
-// CursorBackspace deletes character(s) immediately before cursor
+// CursorBackspace deletes character(s) immediately before cursor -- with a
+// Mask, its literals are skipped over and kept aligned
 func (tf *TextField) CursorBackspace(steps int) {
 	wupdt := tf.TopUpdateStart()
 	defer tf.TopUpdateEnd(wupdt)
@@ -512,8 +569,13 @@ func (tf *TextField) CursorBackspace(steps int) {
 	updt := tf.UpdateStart()
 	defer tf.UpdateEnd(updt)
 	tf.Edited = true
-	tf.EditTxt = append(tf.EditTxt[:tf.CursorPos-steps], tf.EditTxt[tf.CursorPos:]...)
+	if tf.Mask != "" {
+		tf.EditTxt, steps = tf.maskBackspace(steps)
+	} else {
+		tf.EditTxt = append(tf.EditTxt[:tf.CursorPos-steps], tf.EditTxt[tf.CursorPos:]...)
+	}
 	tf.CursorBackward(steps)
+	tf.UpdateValid()
 	tf.TextFieldSig.Emit(tf.This(), int64(TextFieldBackspace), tf.Txt)
 }
 
@@ -534,7 +596,12 @@ func (tf *TextField) CursorDelete(steps int) {
 	updt := tf.UpdateStart()
 	defer tf.UpdateEnd(updt)
 	tf.Edited = true
-	tf.EditTxt = append(tf.EditTxt[:tf.CursorPos], tf.EditTxt[tf.CursorPos+steps:]...)
+	if tf.Mask != "" {
+		tf.EditTxt = tf.maskDelete(tf.CursorPos, tf.maskForward(steps))
+	} else {
+		tf.EditTxt = append(tf.EditTxt[:tf.CursorPos], tf.EditTxt[tf.CursorPos+steps:]...)
+	}
+	tf.UpdateValid()
 	tf.TextFieldSig.Emit(tf.This(), int64(TextFieldDelete), tf.Txt)
 }
 
@@ -707,15 +774,25 @@ func (tf *TextField) DeleteSelection() string {
 	defer tf.UpdateEnd(updt)
 	cut := tf.Selection()
 	tf.Edited = true
-	tf.EditTxt = append(tf.EditTxt[:tf.SelectStart], tf.EditTxt[tf.SelectEnd:]...)
-	if tf.CursorPos > tf.SelectStart {
-		if tf.CursorPos < tf.SelectEnd {
+	if tf.Mask != "" {
+		// the text after the selection moves back into the slots of the
+		// Mask, so the cursor goes to the start
+		tf.EditTxt = tf.maskDelete(tf.SelectStart, tf.SelectEnd)
+		if tf.CursorPos > tf.SelectStart {
 			tf.CursorPos = tf.SelectStart
-		} else {
-			tf.CursorPos -= tf.SelectEnd - tf.SelectStart
+		}
+	} else {
+		tf.EditTxt = append(tf.EditTxt[:tf.SelectStart], tf.EditTxt[tf.SelectEnd:]...)
+		if tf.CursorPos > tf.SelectStart {
+			if tf.CursorPos < tf.SelectEnd {
+				tf.CursorPos = tf.SelectStart
+			} else {
+				tf.CursorPos -= tf.SelectEnd - tf.SelectStart
+			}
 		}
 	}
 	tf.SelectReset()
+	tf.UpdateValid()
 	return cut
 }
 
@@ -762,7 +839,9 @@ func (tf *TextField) Paste() {
 	}
 }
 
-// InsertAtCursor inserts given text at current cursor position
+// InsertAtCursor inserts given text at current cursor position, keeping
+// only the characters allowed by the CharFilter, Mask and MaxLen (see
+// FilterInput) -- with a Mask, the text after the cursor is overwritten
 func (tf *TextField) InsertAtCursor(str string) {
 	updt := tf.UpdateStart()
 	defer tf.UpdateEnd(updt)
@@ -771,8 +850,18 @@ func (tf *TextField) InsertAtCursor(str string) {
 	if tf.HasSelection() {
 		tf.Cut()
 	}
+	rs := tf.FilterInput([]rune(str))
+	if tf.Mask != "" { // keep the text after the cursor aligned with the mask
+		n := ints.MinInt(len(rs), len(tf.EditTxt)-tf.CursorPos)
+		tf.EditTxt = append(tf.EditTxt[:tf.CursorPos], tf.EditTxt[tf.CursorPos+n:]...)
+	}
+	if tf.MaxLen > 0 && len(tf.EditTxt)+len(rs) > tf.MaxLen {
+		rs = rs[:ints.MaxInt(tf.MaxLen-len(tf.EditTxt), 0)]
+	}
+	if len(rs) == 0 {
+		return
+	}
 	tf.Edited = true
-	rs := []rune(str)
 	rsl := len(rs)
 	nt := append(tf.EditTxt, rs...)                // first append to end
 	copy(nt[tf.CursorPos+rsl:], nt[tf.CursorPos:]) // move stuff to end
@@ -780,6 +869,7 @@ func (tf *TextField) InsertAtCursor(str string) {
 	tf.EditTxt = nt
 	tf.EndPos += rsl
 	tf.CursorForward(rsl)
+	tf.UpdateValid()
 	tf.TextFieldSig.Emit(tf.This(), int64(TextFieldInsert), tf.EditTxt)
 }
 
@@ -1284,7 +1374,7 @@ func (tf *TextField) KeyInput(kt *key.ChordEvent) {
 	if KeyEventTrace {
 		fmt.Printf("TextField KeyInput: %v\n", tf.Path())
 	}
-	kf := KeyFun(kt.Chord())
+	kf := KeyFunFor(tf.This(), kt.Chord())
 	win := tf.ParentWindow()
 
 	if tf.Complete != nil {
@@ -1644,6 +1734,14 @@ func (tf *TextField) RenderTextField() {
 		tf.RenderVis.SetRunes(cur, st.FontRender(), &st.UnContext, &st.Text, true, 0, 0)
 		tf.RenderVis.RenderTopPos(rs, pos)
 	}
+	if tf.ValidErr != nil { // message goes in the bottom margin
+		prevColor := st.Color
+		st.Color = ColorScheme.Error
+		tf.RenderMsg.SetString(tf.ValidErr.Error(), st.FontRender(), &st.UnContext, &st.Text, true, 0, 0)
+		mpos := mat32.Vec2{X: pos.X, Y: tf.LayState.Alloc.Pos.Y + tf.LayState.Alloc.Size.Y - st.EffMargin().Bottom}
+		tf.RenderMsg.RenderTopPos(rs, mpos)
+		st.Color = prevColor
+	}
 }
 
 func (tf *TextField) Render2D() {
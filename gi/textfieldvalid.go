@@ -0,0 +1,253 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// CharFilter returns true if the given character can be entered in a
+// TextField -- see TextField.CharFilter
+type CharFilter func(r rune) bool
+
+var (
+	// CharFilterDigits allows only the decimal digits 0-9
+	CharFilterDigits CharFilter = func(r rune) bool {
+		return r >= '0' && r <= '9'
+	}
+
+	// CharFilterHex allows only hexadecimal digits
+	CharFilterHex CharFilter = func(r rune) bool {
+		return strings.ContainsRune("0123456789abcdefABCDEF", r)
+	}
+
+	// CharFilterLetters allows only letters
+	CharFilterLetters CharFilter = unicode.IsLetter
+
+	// CharFilterAlphaNum allows only letters and digits
+	CharFilterAlphaNum CharFilter = func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+
+	// CharFilterNumber allows the characters of a decimal number: digits,
+	// signs, the decimal point and exponents
+	CharFilterNumber CharFilter = func(r rune) bool {
+		return CharFilterDigits(r) || strings.ContainsRune("+-.eE", r)
+	}
+)
+
+// maskSlot is one character position of a TextField Mask
+type maskSlot struct {
+
+	// the literal character, or the mask character for the kind of
+	// character that can be entered
+	r rune
+
+	// whether this is a literal character, inserted automatically
+	lit bool
+}
+
+// Match returns true if given character can be entered in this slot
+func (ms maskSlot) Match(r rune) bool {
+	if ms.lit {
+		return r == ms.r
+	}
+	switch ms.r {
+	case '9':
+		return CharFilterDigits(r)
+	case 'a':
+		return unicode.IsLetter(r)
+	case '*':
+		return CharFilterAlphaNum(r)
+	case 'h':
+		return CharFilterHex(r)
+	}
+	return false
+}
+
+// parseMask returns the slots of given TextField Mask
+func parseMask(mask string) []maskSlot {
+	var slots []maskSlot
+	esc := false
+	for _, r := range mask {
+		switch {
+		case esc:
+			slots = append(slots, maskSlot{r: r, lit: true})
+			esc = false
+		case r == '\\':
+			esc = true
+		case r == '9' || r == 'a' || r == '*' || r == 'h':
+			slots = append(slots, maskSlot{r: r})
+		default:
+			slots = append(slots, maskSlot{r: r, lit: true})
+		}
+	}
+	return slots
+}
+
+// MaskMatch returns true if given text matches given TextField Mask in
+// full (see TextField.Mask)
+func MaskMatch(mask, txt string) bool {
+	slots := parseMask(mask)
+	rs := []rune(txt)
+	if len(rs) != len(slots) {
+		return false
+	}
+	for i, r := range rs {
+		if !slots[i].Match(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterInput returns the characters of given input to insert at the
+// cursor, dropping those not allowed by the CharFilter or the Mask, and
+// adding the literal characters of the Mask as needed
+func (tf *TextField) FilterInput(rs []rune) []rune {
+	return tf.filterInputAt(rs, tf.CursorPos, len(tf.EditTxt))
+}
+
+// filterInputAt returns the characters of given input to insert at given
+// position in text of given length (see FilterInput)
+func (tf *TextField) filterInputAt(rs []rune, pos, ln int) []rune {
+	if tf.CharFilter == nil && tf.Mask == "" {
+		return rs
+	}
+	slots := parseMask(tf.Mask)
+	out := make([]rune, 0, len(rs))
+	start := pos
+	for _, r := range rs {
+		if len(slots) == 0 {
+			if tf.CharFilter(r) {
+				out = append(out, r)
+			}
+			continue
+		}
+		st := len(out)
+		p := pos
+		for p < len(slots) && slots[p].lit && slots[p].r != r {
+			out = append(out, slots[p].r)
+			p++
+		}
+		if p >= len(slots) || !slots[p].Match(r) || (!slots[p].lit && tf.CharFilter != nil && !tf.CharFilter(r)) {
+			out = out[:st] // drop it, and the literals added for it
+			continue
+		}
+		out = append(out, r)
+		pos = p + 1
+	}
+	if len(out) > 0 && start+len(out) >= ln { // at the end: add the next literals
+		for pos < len(slots) && slots[pos].lit {
+			out = append(out, slots[pos].r)
+			pos++
+		}
+	}
+	return out
+}
+
+// maskBackspace returns the text after deleting given number of characters
+// before the cursor in a field with a Mask, skipping over the literals of
+// the Mask, and the number of positions the cursor moves back (see
+// maskDelete).
+func (tf *TextField) maskBackspace(steps int) ([]rune, int) {
+	slots := parseMask(tf.Mask)
+	p := tf.CursorPos
+	for ; steps > 0 && p > 0; steps-- {
+		for p > 0 && p-1 < len(slots) && slots[p-1].lit {
+			p--
+		}
+		if p > 0 {
+			p--
+		}
+	}
+	return tf.maskDelete(p, tf.CursorPos), tf.CursorPos - p
+}
+
+// maskForward returns the end of the range of given number of characters
+// after the cursor in a field with a Mask, skipping over the literals of
+// the Mask, for deleting them (see maskDelete)
+func (tf *TextField) maskForward(steps int) int {
+	slots := parseMask(tf.Mask)
+	p := tf.CursorPos
+	for ; steps > 0 && p < len(tf.EditTxt); steps-- {
+		for p < len(tf.EditTxt) && p < len(slots) && slots[p].lit {
+			p++
+		}
+		if p < len(tf.EditTxt) {
+			p++
+		}
+	}
+	return p
+}
+
+// maskDelete returns the text after deleting the characters in [st, ed)
+// in a field with a Mask.  The characters after them move back into the
+// slots of the Mask, so that the text stays aligned with it.
+func (tf *TextField) maskDelete(st, ed int) []rune {
+	slots := parseMask(tf.Mask)
+	var rest []rune
+	for i := ed; i < len(tf.EditTxt); i++ {
+		if i >= len(slots) || !slots[i].lit {
+			rest = append(rest, tf.EditTxt[i])
+		}
+	}
+	return append(tf.EditTxt[:st:st], tf.filterInputAt(rest, st, st)...)
+}
+
+// Validate checks the current text, which must match the Mask in full
+// (unless it is empty), not exceed MaxLen, and pass the Validator, setting
+// the error state of the field, and emitting TextFieldInvalid if it is not
+// valid -- returns the error, nil if it is valid.  It is called when
+// editing is done.
+func (tf *TextField) Validate() error {
+	err := tf.TextErr()
+	tf.SetValidErr(err)
+	if err != nil {
+		tf.TextFieldSig.Emit(tf.This(), int64(TextFieldInvalid), err)
+	}
+	return err
+}
+
+// TextErr returns the validation error for the current text (see
+// Validate), without updating the field
+func (tf *TextField) TextErr() error {
+	txt := string(tf.EditTxt)
+	if tf.Mask != "" && txt != "" && !MaskMatch(tf.Mask, txt) {
+		return fmt.Errorf("must have the format: %v", tf.Mask)
+	}
+	if tf.MaxLen > 0 && len(tf.EditTxt) > tf.MaxLen {
+		return fmt.Errorf("must be at most %v characters", tf.MaxLen)
+	}
+	if tf.Validator != nil {
+		return tf.Validator(txt)
+	}
+	return nil
+}
+
+// SetValidErr sets the validation error for the field, nil if it is valid
+// -- if not, the field is shown in an error state, with the message under
+// it
+func (tf *TextField) SetValidErr(err error) {
+	same := (err == nil && tf.ValidErr == nil) || (err != nil && tf.ValidErr != nil && err.Error() == tf.ValidErr.Error())
+	if same {
+		tf.ValidErr = err
+		return
+	}
+	updt := tf.UpdateStart()
+	tf.ValidErr = err
+	tf.SetFullReRender() // margin for the message changes the layout
+	tf.UpdateEnd(updt)
+}
+
+// UpdateValid re-checks the text as it is edited, if it was not valid, so
+// that the error state goes away as soon as it is fixed
+func (tf *TextField) UpdateValid() {
+	if tf.ValidErr != nil {
+		tf.SetValidErr(tf.TextErr())
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import "testing"
+
+func TestMaskMatch(t *testing.T) {
+	tests := []struct {
+		mask string
+		txt  string
+		want bool
+	}{
+		{"99/99/9999", "12/31/2023", true},
+		{"99/99/9999", "12/31/202", false},
+		{"99/99/9999", "12-31-2023", false},
+		{"99/99/9999", "1a/31/2023", false},
+		{"(999) 999-9999", "(555) 123-4567", true},
+		{"aa-99", "AB-12", true},
+		{"aa-99", "A1-12", false},
+		{"***", "a1B", true},
+		{"***", "a-B", false},
+		{"hh:hh", "0f:A9", true},
+		{"hh:hh", "0g:A9", false},
+		{`\9-99`, "9-12", true},
+		{`\9-99`, "8-12", false},
+		{`a\\9`, `x\1`, true},
+		{"", "", true},
+		{"", "a", false},
+		{"99", "١٢", false}, // only the digits 0-9
+	}
+	for _, tt := range tests {
+		if got := MaskMatch(tt.mask, tt.txt); got != tt.want {
+			t.Errorf("MaskMatch(%q, %q) = %v, want %v", tt.mask, tt.txt, got, tt.want)
+		}
+	}
+}
+
+func TestFilterInput(t *testing.T) {
+	tests := []struct {
+		mask   string
+		filter CharFilter
+		txt    string
+		pos    int
+		in     string
+		want   string
+	}{
+		{"", nil, "", 0, "a1-", "a1-"},
+		{"", CharFilterDigits, "", 0, "a1-2", "12"},
+		{"", CharFilterHex, "", 0, "0xfG", "0f"},
+		{"", CharFilterNumber, "", 0, "-1.5e3x", "-1.5e3"},
+		{"99/99", nil, "", 0, "1", "1"},
+		{"99/99", nil, "", 0, "12", "12/"},
+		{"99/99", nil, "", 0, "1234", "12/34"},
+		{"99/99", nil, "", 0, "12/34", "12/34"},
+		{"99/99", nil, "", 0, "1a2", "12/"},
+		{"99/99", nil, "12/", 3, "3", "3"},
+		{"99/99", nil, "12", 2, "3", "/3"},
+		{"99/99", nil, "12/34", 0, "5", "5"},
+		{"99/99", nil, "12/34", 5, "5", ""},
+		{"(999) 999", nil, "", 0, "555", "(555) "},
+		{"aa99", CharFilterLetters, "", 0, "ab12", "ab"},
+		{"**", CharFilterDigits, "", 0, "a1b2", "12"},
+	}
+	for _, tt := range tests {
+		tf := &TextField{}
+		tf.InitName(tf, "tf")
+		tf.Mask = tt.mask
+		tf.CharFilter = tt.filter
+		tf.EditTxt = []rune(tt.txt)
+		tf.CursorPos = tt.pos
+		if got := string(tf.FilterInput([]rune(tt.in))); got != tt.want {
+			t.Errorf("mask %q, %q at %d: FilterInput(%q) = %q, want %q", tt.mask, tt.txt, tt.pos, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMaskBackspace(t *testing.T) {
+	tests := []struct {
+		mask  string
+		txt   string
+		pos   int
+		steps int
+		want  string
+		wpos  int
+	}{
+		{"", "12/34", 3, 1, "1234", 2},
+		{"99/99", "12/34", 5, 1, "12/3", 4},
+		{"99/99", "12/3", 4, 1, "12/", 3},
+		{"99/99", "12/", 3, 1, "1", 1},
+		{"99/99", "12/", 3, 2, "", 0},
+		{"99/99", "12/34", 3, 1, "13/4", 1},
+		{"99/99", "12/34", 1, 1, "23/4", 0},
+		{"99/99", "12/34", 5, 3, "1", 1},
+		{"(999) 999", "(5", 2, 1, "(", 1},
+		{"(999) 999", "(", 1, 1, "", 0},
+		{"(999) 999", "(555) 12", 6, 1, "(551) 2", 3},
+	}
+	for _, tt := range tests {
+		tf := &TextField{}
+		tf.InitName(tf, "tf")
+		tf.Mask = tt.mask
+		tf.EditTxt = []rune(tt.txt)
+		tf.CursorPos = tt.pos
+		tf.CursorBackspace(tt.steps)
+		if got := string(tf.EditTxt); got != tt.want || tf.CursorPos != tt.wpos {
+			t.Errorf("mask %q, %q at %d: CursorBackspace(%d) = %q at %d, want %q at %d", tt.mask, tt.txt, tt.pos, tt.steps, got, tf.CursorPos, tt.want, tt.wpos)
+		}
+	}
+}
+
+func TestMaskDelete(t *testing.T) {
+	tests := []struct {
+		mask  string
+		txt   string
+		pos   int
+		steps int
+		want  string
+	}{
+		{"", "12/34", 2, 1, "1234"},
+		{"99/99", "12/34", 0, 1, "23/4"},
+		{"99/99", "12/34", 2, 1, "12/4"},
+		{"99/99", "12/34", 3, 1, "12/4"},
+		{"99/99", "12/34", 1, 2, "14/"},
+		{"99/99", "12/34", 4, 5, "12/3"},
+		{"99/99", "12/", 2, 1, "12"},
+		{"(999) 999", "(555) 12", 3, 1, "(551) 2"},
+	}
+	for _, tt := range tests {
+		tf := &TextField{}
+		tf.InitName(tf, "tf")
+		tf.Mask = tt.mask
+		tf.EditTxt = []rune(tt.txt)
+		tf.CursorPos = tt.pos
+		tf.CursorDelete(tt.steps)
+		if got := string(tf.EditTxt); got != tt.want || tf.CursorPos != tt.pos {
+			t.Errorf("mask %q, %q at %d: CursorDelete(%d) = %q at %d, want %q at %d", tt.mask, tt.txt, tt.pos, tt.steps, got, tf.CursorPos, tt.want, tt.pos)
+		}
+	}
+}
+
+func TestMaskDeleteSelection(t *testing.T) {
+	tests := []struct {
+		mask   string
+		txt    string
+		st, ed int
+		pos    int
+		want   string
+		wpos   int
+	}{
+		{"", "12/34", 1, 4, 4, "14", 1},
+		{"", "12/34", 1, 2, 5, "1/34", 4},
+		{"99/99", "12/34", 1, 4, 4, "14/", 1},
+		{"99/99", "12/34", 0, 5, 5, "", 0},
+		{"99/99", "12/34", 0, 2, 0, "34/", 0},
+		{"99/99", "12/34", 2, 3, 3, "12/34", 2},
+		{"99/99", "12/34", 3, 5, 5, "12/", 3},
+		{"(999) 999", "(555) 123", 2, 7, 7, "(523) ", 2},
+	}
+	for _, tt := range tests {
+		tf := &TextField{}
+		tf.InitName(tf, "tf")
+		tf.Mask = tt.mask
+		tf.EditTxt = []rune(tt.txt)
+		tf.SelectStart, tf.SelectEnd = tt.st, tt.ed
+		tf.CursorPos = tt.pos
+		sel := tt.txt[tt.st:tt.ed]
+		if cut := tf.DeleteSelection(); cut != sel {
+			t.Errorf("mask %q, %q: DeleteSelection of %d-%d returned %q, want %q", tt.mask, tt.txt, tt.st, tt.ed, cut, sel)
+		}
+		if got := string(tf.EditTxt); got != tt.want || tf.CursorPos != tt.wpos || tf.HasSelection() {
+			t.Errorf("mask %q, %q: DeleteSelection of %d-%d = %q at %d, want %q at %d", tt.mask, tt.txt, tt.st, tt.ed, got, tf.CursorPos, tt.want, tt.wpos)
+		}
+	}
+}
@@ -0,0 +1,396 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"image/color"
+	"sync"
+	"time"
+
+	"goki.dev/colors"
+	"goki.dev/gi/v2/gist"
+	"goki.dev/gi/v2/icons"
+	"goki.dev/gi/v2/units"
+	"goki.dev/ki/v2/ki"
+	"goki.dev/ki/v2/kit"
+)
+
+var (
+	// ToastTimeout is the default amount of time a toast is shown before it
+	// is automatically dismissed
+	ToastTimeout = 4 * time.Second
+
+	// ToastMaxVisible is the maximum number of toasts shown at the same time
+	// in a window -- additional toasts are queued until others are dismissed
+	ToastMaxVisible = 3
+
+	// ToastHistMax is the maximum number of toasts retained in the
+	// notification history of a window
+	ToastHistMax = 100
+
+	// ToastZIndex is the z-index of the stack of toasts in a window, so that
+	// it is rendered on top of the other positioned elements
+	ToastZIndex = 1000
+)
+
+// ToastLevels are the severity levels of toast notifications, which
+// determine their styling
+type ToastLevels int32
+
+const (
+	// ToastInfo is a neutral informational message
+	ToastInfo ToastLevels = iota
+
+	// ToastSuccess reports the successful completion of something
+	ToastSuccess
+
+	// ToastWarning reports a potential problem
+	ToastWarning
+
+	// ToastError reports a failure
+	ToastError
+
+	ToastLevelsN
+)
+
+var TypeToastLevels = kit.Enums.AddEnumAltLower(ToastLevelsN, kit.NotBitFlag, nil, "Toast")
+
+func (ev ToastLevels) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *ToastLevels) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// ToastAction is an optional action button shown on a toast, e.g., "Undo"
+type ToastAction struct {
+
+	// label of the button
+	Label string `desc:"label of the button"`
+
+	// [view: -] function called when the button is clicked, after which the toast is dismissed -- called in the window event loop
+	Func func() `view:"-" json:"-" xml:"-" desc:"function called when the button is clicked, after which the toast is dismissed -- called in the window event loop"`
+}
+
+// Toast is a transient, non-modal notification message, shown stacked with
+// any others in the bottom-right corner of a window -- see Window.Notify
+type Toast struct {
+
+	// the message to show
+	Msg string `desc:"the message to show"`
+
+	// severity level, which determines the styling
+	Level ToastLevels `desc:"severity level, which determines the styling"`
+
+	// how long the toast is shown before it is automatically dismissed: 0 = ToastTimeout, and < 0 = until dismissed by the user
+	Timeout time.Duration `desc:"how long the toast is shown before it is automatically dismissed: 0 = ToastTimeout, and < 0 = until dismissed by the user"`
+
+	// optional action buttons
+	Actions []ToastAction `desc:"optional action buttons"`
+
+	// time when the toast was posted
+	Time time.Time `desc:"time when the toast was posted"`
+
+	// unique id within the window, set when posted
+	id int
+}
+
+// String returns the time, level and message of the toast
+func (t *Toast) String() string {
+	return fmt.Sprintf("%s  %v  %s", t.Time.Format("15:04:05"), kit.Enums.EnumIfaceToAltString(t.Level), t.Msg)
+}
+
+// winToast is the Data of a CustomEvent that posts a new toast
+type winToast struct {
+	toast *Toast
+}
+
+// winToastDismiss is the Data of a CustomEvent that dismisses the toast
+// with given id
+type winToastDismiss struct {
+	id int
+}
+
+// winToasts is the toast notification state of a window: the toasts that
+// are shown, queued and in the history.  The shown and queued toasts and
+// the stack are only accessed in the window event loop, and the history
+// under the mutex.
+type winToasts struct {
+	mu     sync.Mutex
+	lastId int
+	hist   []*Toast
+	shown  []*Toast
+	queue  []*Toast
+	stack  *Frame
+}
+
+// Notify posts a new toast notification with given message, severity level
+// and optional action buttons, using the default ToastTimeout.  It is safe
+// to call from any goroutine, like SendCustomEvent.
+func (w *Window) Notify(msg string, level ToastLevels, actions ...ToastAction) *Toast {
+	t := &Toast{Msg: msg, Level: level, Actions: actions}
+	w.NotifyToast(t)
+	return t
+}
+
+// NotifyToast posts given toast notification, which is shown once the
+// window event loop gets to it, or queued if ToastMaxVisible toasts are
+// already shown, and is recorded in the notification history.  It is safe
+// to call from any goroutine, like SendCustomEvent.
+func (w *Window) NotifyToast(t *Toast) {
+	ts := &w.toasts
+	ts.mu.Lock()
+	ts.lastId++
+	t.id = ts.lastId
+	t.Time = time.Now()
+	ts.hist = append(ts.hist, t)
+	if len(ts.hist) > ToastHistMax {
+		ts.hist = ts.hist[len(ts.hist)-ToastHistMax:]
+	}
+	ts.mu.Unlock()
+	if w.IsClosed() || w.OSWin == nil || w.OSWin.IsClosed() {
+		return
+	}
+	w.SendCustomEvent(winToast{toast: t})
+}
+
+// DismissToast dismisses given toast, whether shown or still queued.
+// It is safe to call from any goroutine.
+func (w *Window) DismissToast(t *Toast) {
+	if w.IsClosed() || w.OSWin == nil || w.OSWin.IsClosed() {
+		return
+	}
+	w.SendCustomEvent(winToastDismiss{id: t.id})
+}
+
+// ToastHistory returns a copy of the notification history of the window,
+// oldest first
+func (w *Window) ToastHistory() []*Toast {
+	ts := &w.toasts
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return append([]*Toast(nil), ts.hist...)
+}
+
+// ClearToastHistory clears the notification history of the window
+func (w *Window) ClearToastHistory() {
+	ts := &w.toasts
+	ts.mu.Lock()
+	ts.hist = nil
+	ts.mu.Unlock()
+}
+
+// toastEvent processes the toast custom events, returning true if it was one
+func (w *Window) toastEvent(data any) bool {
+	switch td := data.(type) {
+	case winToast:
+		w.showToast(td.toast)
+		return true
+	case winToastDismiss:
+		w.dismissToast(td.id)
+		return true
+	}
+	return false
+}
+
+// showToast shows given toast, or queues it if ToastMaxVisible are shown
+func (w *Window) showToast(t *Toast) {
+	ts := &w.toasts
+	if len(ts.shown) >= ToastMaxVisible {
+		ts.queue = append(ts.queue, t)
+		return
+	}
+	if w.MasterVLay == nil {
+		return
+	}
+	updt := w.MasterVLay.UpdateStart()
+	w.addToastFrame(t)
+	w.MasterVLay.SetFullReRender()
+	w.MasterVLay.UpdateEnd(updt)
+}
+
+// dismissToast removes the toast with given id, showing the next queued
+// toast if there is room
+func (w *Window) dismissToast(id int) {
+	ts := &w.toasts
+	for i, t := range ts.queue {
+		if t.id == id {
+			ts.queue = append(ts.queue[:i], ts.queue[i+1:]...)
+			return
+		}
+	}
+	si := -1
+	for i, t := range ts.shown {
+		if t.id == id {
+			si = i
+			break
+		}
+	}
+	if si < 0 || w.MasterVLay == nil {
+		return
+	}
+	updt := w.MasterVLay.UpdateStart()
+	ts.shown = append(ts.shown[:si], ts.shown[si+1:]...)
+	if ts.stack != nil {
+		ts.stack.DeleteChildByName(fmt.Sprintf("toast-%d", id), ki.DestroyKids)
+	}
+	for len(ts.shown) < ToastMaxVisible && len(ts.queue) > 0 {
+		t := ts.queue[0]
+		ts.queue = ts.queue[1:]
+		w.addToastFrame(t)
+	}
+	if len(ts.shown) == 0 && ts.stack != nil {
+		ts.stack.Delete(ki.DestroyKids)
+		ts.stack = nil
+	}
+	w.MasterVLay.SetFullReRender()
+	w.MasterVLay.UpdateEnd(updt)
+}
+
+// toastStack returns the frame holding the shown toasts, creating it if
+// needed as a fixed-position child of the MasterVLay
+func (w *Window) toastStack() *Frame {
+	ts := &w.toasts
+	if ts.stack != nil && !ts.stack.IsDeleted() && ts.stack.Parent() != nil {
+		return ts.stack
+	}
+	fr := AddNewFrame(w.MasterVLay, "toasts", LayoutVert)
+	fr.AddStyler(func(wb *WidgetBase, s *gist.Style) {
+		s.Position = gist.PositionFixed
+		s.ZIndex = ToastZIndex
+		s.SetInset(gist.Sides[bool]{Right: true, Bottom: true}, units.Px(16*Prefs.DensityMul()))
+		s.Border.Style.Set(gist.BorderNone)
+		s.BackgroundColor.SetSolid(colors.Transparent)
+		s.Padding.Set()
+		fr.Spacing.SetPx(8 * Prefs.DensityMul())
+		s.AlignH = gist.AlignRight
+	})
+	ts.stack = fr
+	return fr
+}
+
+// toastColors returns the background and foreground colors for given level
+func toastColors(level ToastLevels) (bg, fg color.RGBA) {
+	switch level {
+	case ToastSuccess:
+		return ColorScheme.PrimaryContainer, ColorScheme.OnPrimaryContainer
+	case ToastWarning:
+		return ColorScheme.TertiaryContainer, ColorScheme.OnTertiaryContainer
+	case ToastError:
+		return ColorScheme.ErrorContainer, ColorScheme.OnErrorContainer
+	}
+	return ColorScheme.InverseSurface, ColorScheme.InverseOnSurface
+}
+
+// addToastFrame adds the frame showing given toast to the stack, and
+// starts its auto-dismiss timer
+func (w *Window) addToastFrame(t *Toast) {
+	ts := &w.toasts
+	st := w.toastStack()
+	fr := AddNewFrame(st, fmt.Sprintf("toast-%d", t.id), LayoutHoriz)
+	fr.AddStyler(func(wb *WidgetBase, s *gist.Style) {
+		bg, fg := toastColors(t.Level)
+		s.BackgroundColor.SetSolid(bg)
+		s.Color = fg
+		s.Border.Style.Set(gist.BorderNone)
+		s.Border.Radius = gist.BorderRadiusExtraSmall
+		s.BoxShadow = BoxShadow3
+		s.Padding.Set(units.Px(8*Prefs.DensityMul()), units.Px(16*Prefs.DensityMul()))
+		s.AlignV = gist.AlignMiddle
+	})
+	lbl := AddNewLabel(fr, "msg", t.Msg)
+	lbl.Type = LabelBodyMedium
+	lbl.AddStyler(func(wb *WidgetBase, s *gist.Style) {
+		_, fg := toastColors(t.Level)
+		s.Color = fg
+		s.Text.WhiteSpace = gist.WhiteSpaceNormal
+		s.MaxWidth.SetCh(60)
+		s.AlignV = gist.AlignMiddle
+	})
+	for i := range t.Actions {
+		act := t.Actions[i]
+		bt := AddNewButton(fr, fmt.Sprintf("act-%d", i))
+		bt.Type = ButtonText
+		bt.SetText(act.Label)
+		bt.AddStyler(func(wb *WidgetBase, s *gist.Style) {
+			if t.Level == ToastInfo {
+				s.Color = ColorScheme.InversePrimary
+			} else {
+				_, s.Color = toastColors(t.Level)
+			}
+		})
+		bt.ButtonSig.Connect(w.This(), func(recv, send ki.Ki, sig int64, data any) {
+			if sig != int64(ButtonClicked) {
+				return
+			}
+			if act.Func != nil {
+				act.Func()
+			}
+			w.DismissToast(t)
+		})
+	}
+	cl := AddNewButton(fr, "close")
+	cl.Type = ButtonText
+	cl.SetIcon(icons.Close)
+	cl.Tooltip = "Dismiss"
+	cl.AddStyler(func(wb *WidgetBase, s *gist.Style) {
+		_, s.Color = toastColors(t.Level)
+	})
+	cl.ButtonSig.Connect(w.This(), func(recv, send ki.Ki, sig int64, data any) {
+		if sig == int64(ButtonClicked) {
+			w.DismissToast(t)
+		}
+	})
+	ts.shown = append(ts.shown, t)
+
+	tout := t.Timeout
+	if tout == 0 {
+		tout = ToastTimeout
+	}
+	if tout > 0 {
+		time.AfterFunc(tout, func() {
+			w.DismissToast(t)
+		})
+	}
+}
+
+// ToastHistoryDialog opens a dialog listing the notification history of
+// the window, newest first, with a button to clear it
+func (w *Window) ToastHistoryDialog() *Dialog {
+	dlg := NewStdDialog(DlgOpts{Title: "Notifications"}, AddOk, NoCancel)
+	frame := dlg.Frame()
+	_, prIdx := dlg.PromptWidget(frame)
+	lst := frame.InsertNewChild(TypeFrame, prIdx+1, "history").(*Frame)
+	lst.Lay = LayoutVert
+	lst.SetStretchMax()
+	lst.SetMinPrefWidth(units.Ch(60))
+	hist := w.ToastHistory()
+	if len(hist) == 0 {
+		AddNewLabel(lst, "none", "No notifications")
+	}
+	for i := len(hist) - 1; i >= 0; i-- {
+		t := hist[i]
+		lbl := AddNewLabel(lst, fmt.Sprintf("toast-%d", t.id), t.String())
+		lbl.AddStyler(func(wb *WidgetBase, s *gist.Style) {
+			s.Text.WhiteSpace = gist.WhiteSpaceNormal
+			switch t.Level {
+			case ToastWarning:
+				s.Color = ColorScheme.Tertiary
+			case ToastError:
+				s.Color = ColorScheme.Error
+			}
+		})
+	}
+	if bb, _ := dlg.ButtonBox(frame); bb != nil {
+		clr := bb.InsertNewChild(TypeButton, 1, "clear").(*Button)
+		clr.SetText("Clear")
+		clr.ButtonSig.Connect(dlg.This(), func(recv, send ki.Ki, sig int64, data any) {
+			if sig == int64(ButtonClicked) {
+				w.ClearToastHistory()
+				dlg.Accept()
+			}
+		})
+	}
+	dlg.UpdateEndNoSig(true)
+	dlg.Open(0, 0, w.Viewport, nil)
+	return dlg
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// toastTestWin returns a window without an OSWin, in which toasts are only
+// recorded in the history by NotifyToast, and are shown and dismissed by
+// sending their events directly, as the event loop would -- call
+// useNoIconMgr first
+func toastTestWin() *Window {
+	w := &Window{}
+	w.InitName(w, "win")
+	w.MasterVLay = &Layout{}
+	w.MasterVLay.InitName(w.MasterVLay, "main-vlay")
+	return w
+}
+
+// toastNames returns the names of the shown toast frames
+func toastNames(w *Window) []string {
+	var nms []string
+	if st := w.toasts.stack; st != nil {
+		for _, k := range *st.Children() {
+			nms = append(nms, k.Name())
+		}
+	}
+	return nms
+}
+
+func toastMsgs(ts []*Toast) []string {
+	var msgs []string
+	for _, t := range ts {
+		msgs = append(msgs, t.Msg)
+	}
+	return msgs
+}
+
+func TestToastQueue(t *testing.T) {
+	defer useNoIconMgr()()
+	defer func(n int) { ToastMaxVisible = n }(ToastMaxVisible)
+	ToastMaxVisible = 2
+	w := toastTestWin()
+	var ts []*Toast
+	for i := 0; i < 4; i++ {
+		tt := &Toast{Msg: fmt.Sprintf("msg %d", i), Timeout: -1}
+		w.NotifyToast(tt)
+		w.toastEvent(winToast{toast: tt})
+		ts = append(ts, tt)
+	}
+	check := func(step string, shown, queued []string) {
+		t.Helper()
+		if got := toastMsgs(w.toasts.shown); fmt.Sprint(got) != fmt.Sprint(shown) {
+			t.Errorf("%s: shown %q, want %q", step, got, shown)
+		}
+		if got := toastMsgs(w.toasts.queue); fmt.Sprint(got) != fmt.Sprint(queued) {
+			t.Errorf("%s: queued %q, want %q", step, got, queued)
+		}
+		var frs []string
+		for _, tt := range w.toasts.shown {
+			frs = append(frs, fmt.Sprintf("toast-%d", tt.id))
+		}
+		if got := toastNames(w); fmt.Sprint(got) != fmt.Sprint(frs) {
+			t.Errorf("%s: toast frames %q, want %q", step, got, frs)
+		}
+	}
+	check("posted", []string{"msg 0", "msg 1"}, []string{"msg 2", "msg 3"})
+
+	w.toastEvent(winToastDismiss{id: ts[2].id}) // still queued
+	check("dismiss queued", []string{"msg 0", "msg 1"}, []string{"msg 3"})
+
+	w.toastEvent(winToastDismiss{id: ts[0].id})
+	check("dismiss shown", []string{"msg 1", "msg 3"}, nil)
+
+	w.toastEvent(winToastDismiss{id: ts[0].id}) // already dismissed
+	check("dismiss again", []string{"msg 1", "msg 3"}, nil)
+
+	w.toastEvent(winToastDismiss{id: ts[1].id})
+	w.toastEvent(winToastDismiss{id: ts[3].id})
+	check("dismiss all", nil, nil)
+	if w.toasts.stack != nil || w.MasterVLay.HasChildren() {
+		t.Errorf("toast stack not removed after the last toast was dismissed")
+	}
+
+	// dismissing does not affect the history
+	if got := toastMsgs(w.ToastHistory()); len(got) != 4 {
+		t.Errorf("history %q, want all 4 toasts", got)
+	}
+}
+
+func TestToastFrame(t *testing.T) {
+	defer useNoIconMgr()()
+	w := toastTestWin()
+	tt := &Toast{Msg: "deleted", Level: ToastWarning, Timeout: -1,
+		Actions: []ToastAction{{Label: "Undo"}}}
+	w.NotifyToast(tt)
+	w.toastEvent(winToast{toast: tt})
+	fr, ok := w.toasts.stack.ChildByName(fmt.Sprintf("toast-%d", tt.id), 0).(*Frame)
+	if !ok {
+		t.Fatalf("no frame for the toast")
+	}
+	var nms []string
+	for _, k := range *fr.Children() {
+		nms = append(nms, k.Name())
+	}
+	if want := []string{"msg", "act-0", "close"}; fmt.Sprint(nms) != fmt.Sprint(want) {
+		t.Errorf("toast parts %q, want %q", nms, want)
+	}
+	if lb := fr.ChildByName("msg", 0).(*Label); lb.Text != tt.Msg {
+		t.Errorf("message %q, want %q", lb.Text, tt.Msg)
+	}
+	if bt := fr.ChildByName("act-0", 0).(*Button); bt.Text != "Undo" {
+		t.Errorf("action button %q, want %q", bt.Text, "Undo")
+	}
+}
+
+func TestToastHistory(t *testing.T) {
+	defer useNoIconMgr()()
+	defer func(n int) { ToastHistMax = n }(ToastHistMax)
+	ToastHistMax = 5
+	w := toastTestWin()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) { // safe from any goroutine
+			defer wg.Done()
+			w.Notify(fmt.Sprintf("msg %d", i), ToastInfo)
+		}(i)
+	}
+	wg.Wait()
+	hist := w.ToastHistory()
+	if len(hist) != ToastHistMax {
+		t.Fatalf("history has %d toasts, want %d", len(hist), ToastHistMax)
+	}
+	ids := map[int]bool{}
+	for i, tt := range hist {
+		if ids[tt.id] {
+			t.Errorf("duplicate toast id %d", tt.id)
+		}
+		ids[tt.id] = true
+		if i > 0 && tt.id < hist[i-1].id {
+			t.Errorf("history not in posting order: id %d after %d", tt.id, hist[i-1].id)
+		}
+		if tt.id <= 8-ToastHistMax {
+			t.Errorf("oldest toast id %d retained", tt.id)
+		}
+	}
+	w.ClearToastHistory()
+	if n := len(w.ToastHistory()); n != 0 {
+		t.Errorf("history has %d toasts after clear", n)
+	}
+}
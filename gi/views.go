@@ -51,6 +51,11 @@ type ViewIFace interface {
 
 	// PrefsDbgView opens an interactive view of given debugging preferences object
 	PrefsDbgView(prefs *PrefsDebug)
+
+	// SavePending saves any view preferences that are waiting to be saved
+	// after a delay (e.g., TableView columns) -- called when a window is
+	// closed, including when the app quits
+	SavePending()
 }
 
 // TheViewIFace is the implementation of the interface, defined in giv package
@@ -56,6 +56,12 @@ type WidgetBase struct {
 
 	// [view: -] mutex protecting updates to the style
 	StyMu sync.RWMutex `copy:"-" view:"-" json:"-" xml:"-" desc:"mutex protecting updates to the style"`
+
+	// state of style transitions and animations -- see AnimateStyle
+	styleAnim *styleAnim
+
+	// CSS rules that can match this widget -- see ApplyCSS
+	cssMatches *cssMatchCache
 }
 
 var TypeWidgetBase = kit.Types.AddType(&WidgetBase{}, WidgetBaseProps)
@@ -127,6 +133,7 @@ func (wb *WidgetBase) Init2DWidget() {
 	wb.StyMu.Lock()
 	wb.Viewport = wb.ParentViewport()
 	wb.Style.Defaults()
+	wb.cssMatches = nil // the tree may have changed
 	wb.StyMu.Unlock()
 	wb.LayState.Defaults() // doesn't overwrite
 	wb.BBoxMu.Unlock()
@@ -351,6 +358,11 @@ func (wb *WidgetBase) Style2DWidget() {
 
 	wb.Style = gist.Style{}
 	wb.Style.Defaults()
+	if gii != nil {
+		if ly := gii.AsLayout2D(); ly != nil && ly.Lay.IsFlex() {
+			wb.Style.FlexDefaults()
+		}
+	}
 
 	pin := prof.Start("Style2DWidget-Inherit")
 
@@ -366,6 +378,9 @@ func (wb *WidgetBase) Style2DWidget() {
 
 	prun.End()
 
+	wb.ApplyCSS()
+	wb.AnimateStyle()
+
 	puc := prof.Start("Style2DWidget-SetUnitContext")
 
 	SetUnitContext(&wb.Style, wb.Viewport, mat32.Vec2{}, mat32.Vec2{})
@@ -390,6 +405,43 @@ func (wb *WidgetBase) RunStyleFuncs() {
 	}
 }
 
+// ApplyCSS aggregates the CSS style properties of all the parents of this
+// widget and its own CSS into CSSAgg, and then applies the properties of all
+// the selectors in it that match this widget, in cascade order (see
+// CSSMatchProps).  It is called after the style funcs in Style2DWidget, so
+// style sheets take precedence over the default styles.  The rules that can
+// match are cached, so that restyling for a change of state (e.g., :hover)
+// only matches the selectors that depend on it again.  The cache is dropped
+// in Init2D, after any change in the tree, and on a change of the rules, of
+// the Class of this widget, or of any class set with SetClass or AddClass.
+func (wb *WidgetBase) ApplyCSS() {
+	wb.CSSAgg = nil
+	if pagg := wb.ParentCSSAgg(); pagg != nil && len(*pagg) > 0 {
+		AggCSS(&wb.CSSAgg, *pagg)
+	}
+	if len(wb.CSS) > 0 {
+		AggCSS(&wb.CSSAgg, wb.CSS)
+	}
+	if len(wb.CSSAgg) == 0 {
+		wb.cssMatches = nil
+		return
+	}
+	if !wb.cssMatches.valid(wb.CSSAgg, wb.Class) {
+		wb.cssMatches = newCSSMatchCache(wb.CSSAgg, wb.This(), wb.Class)
+	}
+	prs := cssMatch(wb.cssMatches.rules, wb.This())
+	if len(prs) == 0 {
+		return
+	}
+	parSty := wb.ParentActiveStyle()
+	for _, pr := range prs {
+		wb.Style.StyleFromProps(parSty, pr, wb.Viewport)
+	}
+	if parSty != nil {
+		wb.ParentStyleRUnlock()
+	}
+}
+
 func (wb *WidgetBase) Style2D() {
 	wb.StyMu.Lock()
 	defer wb.StyMu.Unlock()
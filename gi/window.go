@@ -16,6 +16,7 @@ import (
 	"runtime"
 	"runtime/debug"
 	"runtime/pprof"
+	"strings"
 	"sync"
 	"time"
 
@@ -72,6 +73,11 @@ var (
 	// register a Hover event
 	HoverMaxPix = 5
 
+	// KeySeqTimeoutMSec is the number of milliseconds to wait for the next
+	// chord of a multi-key sequence (e.g., "Control+X Control+S") before
+	// the pending prefix is abandoned
+	KeySeqTimeoutMSec = 2000
+
 	// LocalMainMenu controls whether the main menu is displayed locally at top of
 	// each window, in addition to the global menu at the top of the screen.  Mac
 	// native apps do not do this, but OTOH it makes things more consistent with
@@ -222,6 +228,9 @@ type Window struct {
 	// this popup will be popped at the end of the current event cycle -- use SetDelPopup
 	DelPopup ki.Ki `json:"-" xml:"-" desc:"this popup will be popped at the end of the current event cycle -- use SetDelPopup"`
 
+	// [view: -] records all events received by the window, when non-nil -- see StartRecording
+	EventRec *EventRecorder `json:"-" xml:"-" view:"-" desc:"records all events received by the window, when non-nil -- see StartRecording"`
+
 	// [view: -] read-write mutex that protects popup updating and access
 	PopMu             sync.RWMutex `json:"-" xml:"-" view:"-" desc:"read-write mutex that protects popup updating and access"`
 	lastWinMenuUpdate time.Time
@@ -229,6 +238,10 @@ type Window struct {
 	delPop        bool
 	skippedResize *window.Event
 	lastEt        oswin.EventType
+	accessState   *accessState // tracked when TheAccessBridge is set -- see AccessUpdate
+	anim          winAnim      // animation clock -- see AddAnimation
+	keySeqHint    *Viewport2D  // popup showing the pending key sequence prefix
+	toasts        winToasts    // toast notifications -- see Notify
 
 	// the currently selected widget through the inspect editor selection mode
 	SelectedWidget *WidgetBase `desc:"the currently selected widget through the inspect editor selection mode"`
@@ -410,8 +423,9 @@ func NewWindow(name, title string, opts *oswin.NewWindowOptions) *Window {
 	win.OSWin.SetName(title)
 	win.OSWin.SetParent(win.This())
 	win.NodeSig.Connect(win.This(), SignalWindowPublish)
-	drw := win.OSWin.Drawer()
-	drw.SetMaxTextures(vgpu.MaxTexturesPerSet * 3) // use 3 sets
+	if drw := win.OSWin.Drawer(); drw != nil { // nil for offscreen windows
+		drw.SetMaxTextures(vgpu.MaxTexturesPerSet * 3) // use 3 sets
+	}
 
 	win.DirDraws.SetIdxRange(1, MaxDirectUploads)
 	// win.DirDraws.FlipY = true // drawing is flipped in general here.
@@ -816,7 +830,7 @@ func (w *Window) Resized(sz image.Point) {
 		return
 	}
 	drw := w.OSWin.Drawer()
-	if drw.Impl.MaxTextures != vgpu.MaxTexturesPerSet*3 { // this is essential after hibernate
+	if drw != nil && drw.Impl.MaxTextures != vgpu.MaxTexturesPerSet*3 { // this is essential after hibernate
 		drw.SetMaxTextures(vgpu.MaxTexturesPerSet * 3) // use 3 sets
 	}
 	w.FocusInactivate()
@@ -882,6 +896,15 @@ func (w *Window) CloseReq() {
 
 // Closed frees any resources after the window has been closed.
 func (w *Window) Closed() {
+	if w.IsRecording() {
+		w.StopRecording()
+	}
+	if TheAccessBridge != nil {
+		TheAccessBridge.AccessWindowClosed(w)
+	}
+	if TheViewIFace != nil {
+		TheViewIFace.SavePending()
+	}
 	w.UpMu.Lock()
 	AllWindows.Delete(w)
 	MainWindows.Delete(w)
@@ -1111,6 +1134,11 @@ func (w *Window) UploadVpRegion(vp *Viewport2D, vpBBox, winBBox image.Rectangle)
 	w.SetWinUpdating()
 	// pr := prof.Start("win.UploadVpRegion")
 
+	if w.OSWin.Drawer() == nil { // offscreen: Publish composites the viewports directly
+		w.ClearWinUpdating()
+		w.UpMu.Unlock()
+		return
+	}
 	idx, over := w.UpdtRegs.Add(winBBox, vp)
 	if over {
 		w.ResetUpdateRegionsImpl()
@@ -1158,7 +1186,9 @@ func (w *Window) UploadVp(vp *Viewport2D, offset image.Point) {
 	updt := w.UpdateStart()
 	idx := 0
 	drw := w.OSWin.Drawer()
-	if vp == w.Viewport {
+	if drw == nil {
+		// offscreen: Publish composites the viewports directly
+	} else if vp == w.Viewport {
 		drw.SetGoImage(idx, 0, vp.Pixels, vgpu.NoFlipY)
 	} else {
 		// pr := prof.Start("win.UploadVp")
@@ -1236,6 +1266,9 @@ func (w *Window) ResetUpdateRegionsImpl() {
 	w.UpdtRegs.Reset()
 	w.PopDraws.Reset()
 	drw := w.OSWin.Drawer()
+	if drw == nil { // offscreen
+		return
+	}
 	drw.SetGoImage(0, 0, w.Viewport.Pixels, vgpu.NoFlipY)
 	// then all the current popups
 	// fmt.Printf("upload all views pop locked: %v\n", w.Nm)
@@ -1302,6 +1335,13 @@ func (w *Window) Publish() {
 	// and using RunOnMain makes the thing hella slow -- like opengl -- that was the issue there!
 	// oswin.TheApp.RunOnMain(func() {
 
+	if ow, ok := w.OSWin.(oswin.OffscreenWindow); ok && ow.Drawer() == nil {
+		w.PublishOffscreen(ow)
+		w.ClearWinUpdating()
+		w.UpMu.Unlock()
+		return
+	}
+
 	if w.Sprites.Modified || w.Sprites.HasSizeChanged() {
 		w.ConfigSprites()
 		w.Sprites.Modified = false
@@ -1630,6 +1670,9 @@ func (w *Window) PollEvents() {
 			break
 		}
 		w.ProcessEvent(evi)
+		if TheAccessBridge != nil {
+			w.AccessUpdate()
+		}
 	}
 }
 
@@ -1659,6 +1702,33 @@ func (w *Window) EventLoop() {
 	w.This().Destroy()
 }
 
+// winIdleSync is the Data of a CustomEvent sent by WaitIdle: it is closed
+// when the event is processed, signaling that all prior events are done.
+type winIdleSync chan struct{}
+
+// WaitIdle waits until all events that were sent to the window prior to
+// calling it have been processed, including any rendering and publishing
+// that they trigger, and then repeats once more to capture any further
+// events that were sent during that processing.  Must not be called from
+// within the window's event loop.  Returns false if the window is closed
+// or if it does not go idle within the given timeout.
+func (w *Window) WaitIdle(timeout time.Duration) bool {
+	deadline := time.After(timeout)
+	for i := 0; i < 2; i++ {
+		if w.IsClosed() || w.OSWin == nil || w.OSWin.IsClosed() {
+			return false
+		}
+		ws := make(winIdleSync)
+		oswin.SendCustomEvent(w.OSWin, ws)
+		select {
+		case <-ws:
+		case <-deadline:
+			return false
+		}
+	}
+	return true
+}
+
 // ProcessEvent processes given oswin.Event
 func (w *Window) ProcessEvent(evi oswin.Event) {
 	et := evi.Type()
@@ -1684,6 +1754,29 @@ func (w *Window) ProcessEvent(evi oswin.Event) {
 			}
 		}
 	}
+	if ce, ok := evi.(*oswin.CustomEvent); ok {
+		if ws, ok := ce.Data.(winIdleSync); ok { // see WaitIdle
+			close(ws)
+			return
+		}
+		if _, ok := ce.Data.(winAnimTick); ok { // see AddAnimation
+			w.animStep()
+			return
+		}
+		if tm, ok := ce.Data.(winKeySeqTimeout); ok { // see KeySeqEvent
+			w.keySeqTimeout(tm)
+			return
+		}
+		if w.toastEvent(ce.Data) { // see Notify
+			return
+		}
+	}
+	w.RecordEvent(evi) // before key sequences, so prefix chords are recorded
+	if ke, ok := evi.(*key.ChordEvent); ok {
+		if w.KeySeqEvent(ke) {
+			return
+		}
+	}
 	if FilterLaggyKeyEvents || et != oswin.KeyEvent { // don't filter key events
 		if !w.FilterEvent(evi) {
 			return
@@ -2168,6 +2261,163 @@ func (w *Window) DeleteShortcut(chord key.Chord, act *Action) {
 	}
 }
 
+/////////////////////////////////////////////////////////////////////////////
+//                   Key Sequences
+
+// winKeySeqTimeout is the Data of a CustomEvent sent when the pending
+// multi-key sequence prefix has timed out
+type winKeySeqTimeout struct {
+	gen int // EventMgr.keySeqGen when the prefix was set
+}
+
+// IsKeySeqPrefix returns true if the given chord or chord sequence is
+// the leading part of a multi-key sequence in the active KeyMap, in the
+// scoped KeyCmds layers of the focused widget, or in the window Shortcuts
+func (w *Window) IsKeySeqPrefix(seq key.Chord) bool {
+	if ActiveKeyMap != nil && ActiveKeyMap.IsSeqPrefix(seq) {
+		return true
+	}
+	if KeyCmdsSeqPrefix(w.EventMgr.CurFocus(), seq) {
+		return true
+	}
+	pfx := string(seq) + key.SeqSep
+	for ch := range w.Shortcuts {
+		if strings.HasPrefix(string(ch), pfx) {
+			return true
+		}
+	}
+	return false
+}
+
+// KeySeqEvent processes a key chord event with respect to multi-key
+// chord sequences (e.g., "Control+X Control+S").  A chord that starts
+// or extends a sequence prefix is consumed, recorded as the pending
+// EventMgr.KeySeqPrefix and shown as a hint, until the next chord or
+// the KeySeqTimeoutMSec timeout.  A chord that completes a bound
+// sequence is dispatched normally, with the full sequence set as its
+// key.ChordEvent.Seq, so its Chord is the full sequence for KeyFun and
+// the shortcuts.  A chord that completes an unbound sequence is consumed.
+// Widgets in focus that are recording key chords (KeyChordRecorder), and
+// events that already have their Seq, bypass all of this.  Returns true
+// if the event was consumed.
+func (w *Window) KeySeqEvent(e *key.ChordEvent) bool {
+	em := &w.EventMgr
+	if e.Seq != "" {
+		return false
+	}
+	if kr, ok := em.CurFocus().(KeyChordRecorder); ok && kr.IsRecordingKeys() {
+		return false
+	}
+	ch := e.Event.Chord()
+	em.TimerMu.Lock()
+	pfx := em.KeySeqPrefix
+	em.TimerMu.Unlock()
+	if pfx == "" {
+		if !w.IsKeySeqPrefix(ch) {
+			return false
+		}
+		w.SetKeySeqPrefix(ch)
+		e.SetProcessed()
+		return true
+	}
+	seq := key.Seq(pfx, ch)
+	if w.IsKeySeqPrefix(seq) {
+		w.SetKeySeqPrefix(seq)
+		e.SetProcessed()
+		return true
+	}
+	w.KeySeqReset()
+	bound := false
+	if ActiveKeyMap != nil {
+		_, bound = (*ActiveKeyMap)[seq]
+	}
+	if !bound {
+		_, _, bound = KeyCmdFor(em.CurFocus(), seq)
+	}
+	if !bound && w.Shortcuts != nil {
+		_, bound = w.Shortcuts[seq]
+	}
+	if KeyEventTrace {
+		fmt.Printf("Win: %v key sequence: %v bound: %v\n", w.Nm, seq, bound)
+	}
+	if !bound {
+		e.SetProcessed()
+		return true
+	}
+	e.Seq = seq
+	return false
+}
+
+// SetKeySeqPrefix sets the pending multi-key sequence prefix, showing
+// a hint with the partial sequence and (re)starting the timeout
+func (w *Window) SetKeySeqPrefix(seq key.Chord) {
+	em := &w.EventMgr
+	em.TimerMu.Lock()
+	em.KeySeqPrefix = seq
+	em.keySeqGen++
+	gen := em.keySeqGen
+	if em.keySeqTimer != nil {
+		em.keySeqTimer.Stop()
+	}
+	em.keySeqTimer = time.AfterFunc(time.Duration(KeySeqTimeoutMSec)*time.Millisecond, func() {
+		if w.IsClosed() || w.OSWin == nil || w.OSWin.IsClosed() {
+			return
+		}
+		oswin.SendCustomEvent(w.OSWin, winKeySeqTimeout{gen: gen})
+	})
+	em.TimerMu.Unlock()
+	w.closeKeySeqHint()
+	cpop := w.CurPopup()
+	if cpop != nil && !PopupIsTooltip(cpop) {
+		return // don't cover menus or completers
+	}
+	if cpop != nil {
+		w.ClosePopup(cpop)
+	}
+	w.keySeqHint = PopupTooltip(seq.Shortcut()+" -", 10, w.Viewport.Geom.Size.Y, w.Viewport, "key-seq")
+}
+
+// KeySeqReset abandons any pending multi-key sequence prefix and removes
+// its hint
+func (w *Window) KeySeqReset() {
+	em := &w.EventMgr
+	em.TimerMu.Lock()
+	em.KeySeqPrefix = ""
+	em.keySeqGen++
+	if em.keySeqTimer != nil {
+		em.keySeqTimer.Stop()
+		em.keySeqTimer = nil
+	}
+	em.TimerMu.Unlock()
+	w.closeKeySeqHint()
+}
+
+// keySeqTimeout resets the pending multi-key sequence prefix on its timeout,
+// unless the prefix has changed since the timeout was started: a timer that
+// already fired cannot be stopped, so its event can arrive after a new prefix
+// was set
+func (w *Window) keySeqTimeout(tm winKeySeqTimeout) {
+	em := &w.EventMgr
+	em.TimerMu.Lock()
+	stale := tm.gen != em.keySeqGen
+	em.TimerMu.Unlock()
+	if stale {
+		return
+	}
+	w.KeySeqReset()
+}
+
+// closeKeySeqHint closes the key sequence hint popup if it is still open
+func (w *Window) closeKeySeqHint() {
+	if w.keySeqHint == nil {
+		return
+	}
+	if hint := w.keySeqHint.This(); hint != nil { // nil if already closed, e.g., by a click
+		w.ClosePopup(hint)
+	}
+	w.keySeqHint = nil
+}
+
 // TriggerShortcut attempts to trigger a shortcut, returning true if one was
 // triggered, and false otherwise.  Also eliminates any shortcuts with deleted
 // actions, and does not trigger for Inactive actions.
@@ -2435,7 +2685,7 @@ func (w *Window) KeyChordEventHiPri(e *key.ChordEvent) bool {
 		return false
 	}
 	cs := e.Chord()
-	kf := KeyFun(cs)
+	kf := KeyFunFor(w.EventMgr.CurFocus(), cs)
 	cpop := w.CurPopup()
 	switch kf {
 	case KeyFunWinClose:
@@ -2446,6 +2696,11 @@ func (w *Window) KeyChordEventHiPri(e *key.ChordEvent) bool {
 			w.MainMenu.GrabFocus()
 			e.SetProcessed()
 		}
+	case KeyFunCommandPalette:
+		if _, isdlg := DialogWindows.FindName(w.Nm); !isdlg && cpop == nil {
+			w.CommandPalette()
+			e.SetProcessed()
+		}
 	case KeyFunAbort:
 		if PopupIsMenu(cpop) || PopupIsTooltip(cpop) {
 			delPop = true
@@ -2473,7 +2728,7 @@ func (w *Window) KeyChordEventLowPri(e *key.ChordEvent) bool {
 		return false
 	}
 	cs := e.Chord()
-	kf := KeyFun(cs)
+	kf := KeyFunFor(w.EventMgr.CurFocus(), cs)
 	delPop := false
 	switch kf {
 	case KeyFunWinSnapshot:
@@ -2619,12 +2874,65 @@ func (w *Window) DNDMoveEvent(e *mouse.DragEvent) {
 
 // DNDDropEvent handles drag-n-drop drop event (action = release).
 func (w *Window) DNDDropEvent(e *mouse.Event) {
+	if w.DNDDropOutside(e) {
+		return
+	}
 	proc := w.EventMgr.SendDNDDropEvent(e)
 	if !proc {
 		w.ClearDragNDrop()
 	}
 }
 
+// DNDDropOutside handles a drag-n-drop drop event outside of the window,
+// if the source is a DragNDropOutsider, calling its DropOutside with the
+// screen position of the drop -- returns true if so.
+func (w *Window) DNDDropOutside(e *mouse.Event) bool {
+	if e.Where.In(image.Rectangle{Max: w.OSWin.Size()}) {
+		return false
+	}
+	src := w.EventMgr.DNDSource
+	dout, ok := src.(DragNDropOutsider)
+	if !ok {
+		return false
+	}
+	md := w.EventMgr.DNDData
+	src.ClearFlag(int(NodeDragging))
+	e.SetProcessed()
+	w.ClearDragNDrop()
+	if DNDTrace {
+		fmt.Printf("DNDDroppedOutside at: %v\n", w.WinToScreenPos(e.Where))
+	}
+	dout.DropOutside(md, w.WinToScreenPos(e.Where))
+	return true
+}
+
+// DevPixRatio returns the device pixel ratio of the screen of the window,
+// i.e., the number of raw dots per OS window manager unit
+func (w *Window) DevPixRatio() float32 {
+	if w.OSWin == nil {
+		return 1
+	}
+	if sc := w.OSWin.Screen(); sc != nil && sc.DevicePixelRatio > 0 {
+		return sc.DevicePixelRatio
+	}
+	return 1
+}
+
+// WinToScreenPos converts given position within the window, in raw dots as
+// in events, to OS window manager screen coordinates, as in OSWin.Position
+func (w *Window) WinToScreenPos(pt image.Point) image.Point {
+	dpr := w.DevPixRatio()
+	return w.OSWin.Position().Add(image.Point{int(float32(pt.X) / dpr), int(float32(pt.Y) / dpr)})
+}
+
+// ScreenToWinPos converts given position in OS window manager screen
+// coordinates to a position within the window, in raw dots as in events
+func (w *Window) ScreenToWinPos(pt image.Point) image.Point {
+	dpr := w.DevPixRatio()
+	pt = pt.Sub(w.OSWin.Position())
+	return image.Point{int(float32(pt.X) * dpr), int(float32(pt.Y) * dpr)}
+}
+
 // FinalizeDragNDrop is called by a node to finalize the drag-n-drop
 // operation, after given action has been performed on the target -- allows
 // target to cancel, by sending dnd.DropIgnore.
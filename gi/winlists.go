@@ -111,6 +111,25 @@ func (wl *WindowList) Win(idx int) *Window {
 	return (*wl)[idx]
 }
 
+// AtScreenPos returns the last window in this list that contains given
+// position in OS window manager screen coordinates (see Window.WinToScreenPos),
+// skipping minimized and closed windows, or nil if none
+func (wl *WindowList) AtScreenPos(pos image.Point) *Window {
+	WindowGlobalMu.Lock()
+	defer WindowGlobalMu.Unlock()
+	for i := len(*wl) - 1; i >= 0; i-- {
+		w := (*wl)[i]
+		if w.OSWin == nil || w.IsClosed() || w.OSWin.IsClosed() || w.OSWin.IsMinimized() {
+			continue
+		}
+		wp := w.OSWin.Position()
+		if pos.In(image.Rectangle{Min: wp, Max: wp.Add(w.OSWin.WinSize())}) {
+			return w
+		}
+	}
+	return nil
+}
+
 // Focused returns the (first) window in this list that has the WinFlagGotFocus flag set
 // and the index in the list (nil, -1 if not present)
 func (wl *WindowList) Focused() (*Window, int) {
@@ -345,6 +364,9 @@ func (wu *WindowDrawers) Add(node Node, winBBox image.Rectangle) (int, bool) {
 
 // Delete removes given node from list of drawers
 func (wu *WindowDrawers) Delete(node Node) {
+	if wu.Nodes == nil {
+		return
+	}
 	nb := node.AsGiNode()
 	wu.Nodes.DeleteKey(nb)
 }
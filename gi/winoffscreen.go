@@ -0,0 +1,87 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"image"
+	"image/draw"
+
+	"goki.dev/gi/v2/oswin"
+	"goki.dev/ki/v2/ki"
+)
+
+// PublishOffscreen does the final step of updating an offscreen window
+// (one without a GPU Drawer, e.g., from the headless driver), by
+// compositing the current window image in software and publishing it
+// to the given OffscreenWindow.  Called by Publish, with UpMu locked.
+func (w *Window) PublishOffscreen(ow oswin.OffscreenWindow) {
+	img := w.CompositeImage()
+	if img == nil {
+		return
+	}
+	ow.PublishImage(img)
+}
+
+// CompositeImage renders the current full window image in software,
+// in the same order used for the GPU rendering: the main Viewport,
+// then any popups, then active sprites.  Direct uploaders (e.g.,
+// gi3d.Scene) render only on the GPU and are not included.
+// Returns nil if the window does not yet have a viewport image.
+func (w *Window) CompositeImage() *image.RGBA {
+	if w.Viewport == nil || w.Viewport.Pixels == nil {
+		return nil
+	}
+	vpix := w.Viewport.Pixels
+	img := image.NewRGBA(vpix.Bounds())
+	draw.Draw(img, img.Bounds(), vpix, vpix.Bounds().Min, draw.Src)
+
+	w.PopMu.RLock()
+	for _, pop := range w.PopupStack {
+		w.compositePopup(img, pop)
+	}
+	if w.Popup != nil {
+		w.compositePopup(img, w.Popup)
+	}
+	w.PopMu.RUnlock()
+
+	for _, kv := range w.Sprites.Names.Order {
+		sp := kv.Val
+		if !sp.On || sp.Pixels == nil {
+			continue
+		}
+		r := sp.Pixels.Bounds().Sub(sp.Pixels.Bounds().Min).Add(sp.Geom.Pos)
+		draw.Draw(img, r, sp.Pixels, sp.Pixels.Bounds().Min, draw.Over)
+	}
+	return img
+}
+
+// compositePopup draws given popup viewport onto img at its window location
+func (w *Window) compositePopup(img *image.RGBA, pop ki.Ki) {
+	gii, _ := KiToNode2D(pop)
+	if gii == nil {
+		return
+	}
+	vp := gii.AsViewport2D()
+	if vp == nil || vp.Pixels == nil {
+		return
+	}
+	r := vp.Pixels.Bounds().Sub(vp.Pixels.Bounds().Min).Add(vp.WinBBox.Min)
+	draw.Draw(img, r, vp.Pixels, vp.Pixels.Bounds().Min, draw.Src)
+}
+
+// CaptureImage returns a copy of the current full window image.
+// For offscreen windows (e.g., from the headless driver) this is the
+// most recently published image, and otherwise it is generated via
+// CompositeImage.  Returns nil if nothing has been rendered yet.
+func (w *Window) CaptureImage() *image.RGBA {
+	if ow, ok := w.OSWin.(oswin.OffscreenWindow); ok && ow.Drawer() == nil {
+		if img := ow.Image(); img != nil {
+			return img
+		}
+	}
+	w.UpMu.Lock()
+	defer w.UpMu.Unlock()
+	return w.CompositeImage()
+}
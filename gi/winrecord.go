@@ -0,0 +1,296 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"goki.dev/gi/v2/oswin"
+	"goki.dev/gi/v2/oswin/dnd"
+	"goki.dev/gi/v2/oswin/key"
+	"goki.dev/gi/v2/oswin/mouse"
+	"goki.dev/gi/v2/oswin/osevent"
+	"goki.dev/gi/v2/oswin/touch"
+	"goki.dev/gi/v2/oswin/window"
+)
+
+// WinEventRecord, when true, causes all windows to record all the events
+// they receive, saving them to a JSON file in the app prefs "events"
+// directory when the window is closed or this is turned off.
+// The recording can be replayed with Window.Replay or PrefsDebug.ReplayEvents,
+// e.g., to reproduce a bug.  See PrefsDebug.
+var WinEventRecord = false
+
+// EventRecording is a recording of the events received by a window,
+// which can be saved and loaded as JSON, and replayed into a window.
+type EventRecording struct {
+
+	// name of the window the events were recorded from
+	WinName string `desc:"name of the window the events were recorded from"`
+
+	// size of the window at the start of recording, in raw pixels
+	WinSize image.Point `desc:"size of the window at the start of recording, in raw pixels"`
+
+	// time at which the recording was started
+	Start time.Time `desc:"time at which the recording was started"`
+
+	// the recorded events, in order
+	Events []*RecordedEvent `desc:"the recorded events, in order"`
+}
+
+// RecordedEvent is one event in an EventRecording
+type RecordedEvent struct {
+
+	// time of the event relative to the start of the recording
+	Time time.Duration `desc:"time of the event relative to the start of the recording"`
+
+	// type of the event, which determines how Event is decoded
+	Type string `desc:"type of the event, which determines how Event is decoded"`
+
+	// for window resize events, the new size of the window, in raw pixels
+	Size image.Point `desc:"for window resize events, the new size of the window, in raw pixels"`
+
+	// the event itself, in JSON format
+	Event json.RawMessage `desc:"the event itself, in JSON format"`
+}
+
+// NewEvent returns a new event of the type recorded in Type, decoded
+// from Event, with its time set to now.  Returns an error for event
+// types that are not recorded.
+func (re *RecordedEvent) NewEvent() (oswin.Event, error) {
+	var et oswin.EventType
+	if err := et.FromString(re.Type); err != nil {
+		return nil, err
+	}
+	var ev oswin.Event
+	switch et {
+	case oswin.MouseEvent:
+		ev = &mouse.Event{}
+	case oswin.MouseMoveEvent:
+		ev = &mouse.MoveEvent{}
+	case oswin.MouseDragEvent:
+		ev = &mouse.DragEvent{}
+	case oswin.MouseScrollEvent:
+		ev = &mouse.ScrollEvent{}
+	case oswin.KeyEvent:
+		ev = &key.Event{}
+	case oswin.KeyChordEvent:
+		ev = &key.ChordEvent{}
+	case oswin.TouchEvent:
+		ev = &touch.Event{}
+	case oswin.WindowEvent, oswin.WindowResizeEvent, oswin.WindowPaintEvent:
+		ev = &window.Event{}
+	case oswin.DNDEvent:
+		ev = &dnd.Event{}
+	case oswin.OSEvent:
+		ev = &osevent.Event{}
+	case oswin.OSOpenFilesEvent:
+		ev = &osevent.OpenFilesEvent{}
+	default:
+		return nil, fmt.Errorf("gi.RecordedEvent: event type %v is not supported", et)
+	}
+	if err := json.Unmarshal(re.Event, ev); err != nil {
+		return nil, err
+	}
+	ev.Init()
+	return ev, nil
+}
+
+// OpenEventRecording opens an EventRecording from given JSON file
+func OpenEventRecording(filename FileName) (*EventRecording, error) {
+	b, err := os.ReadFile(string(filename))
+	if err != nil {
+		return nil, err
+	}
+	rec := &EventRecording{}
+	err = json.Unmarshal(b, rec)
+	if err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+// Save saves the recording to given JSON file
+func (rec *EventRecording) Save(filename FileName) error {
+	b, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(string(filename), b, 0644)
+}
+
+// EventRecorder records the events received by a window
+type EventRecorder struct {
+
+	// the recording
+	Rec EventRecording `desc:"the recording"`
+
+	// file to save the recording to when stopped
+	File FileName `desc:"file to save the recording to when stopped"`
+
+	// true if recording was started automatically by WinEventRecord, and is thus stopped when that is turned off
+	Auto bool `desc:"true if recording was started automatically by WinEventRecord, and is thus stopped when that is turned off"`
+
+	// mutex protecting the recording
+	Mu sync.Mutex `json:"-" xml:"-" view:"-" desc:"mutex protecting the recording"`
+}
+
+// Record adds given event to the recording -- events that cannot be
+// replayed (synthetic and custom events) are skipped.
+func (er *EventRecorder) Record(evi oswin.Event, w *Window) {
+	re := &RecordedEvent{Type: evi.Type().String()}
+	switch evi.Type() {
+	case oswin.MouseFocusEvent, oswin.MouseHoverEvent, oswin.WindowShowEvent, oswin.WindowFocusEvent,
+		oswin.DNDMoveEvent, oswin.DNDFocusEvent, oswin.MagnifyEvent, oswin.RotateEvent, oswin.CustomEventType:
+		return
+	case oswin.WindowResizeEvent:
+		re.Size = w.OSWin.Size()
+	}
+	b, err := json.Marshal(evi)
+	if err != nil {
+		log.Printf("gi.EventRecorder: error recording event: %v: %v\n", evi, err)
+		return
+	}
+	re.Event = b
+	er.Mu.Lock()
+	re.Time = evi.Time().Sub(er.Rec.Start)
+	er.Rec.Events = append(er.Rec.Events, re)
+	er.Mu.Unlock()
+}
+
+// RecordEvent records given event if the window is recording, and starts
+// or stops automatic recording based on WinEventRecord.
+// Called at the start of ProcessEvent.
+func (w *Window) RecordEvent(evi oswin.Event) {
+	if WinEventRecord {
+		if w.EventRec == nil {
+			if err := w.StartRecording(""); err != nil {
+				log.Printf("gi.Window: %v could not start event recording: %v\n", w.Nm, err)
+				return
+			}
+			w.EventRec.Auto = true
+		}
+	} else if w.EventRec != nil && w.EventRec.Auto {
+		w.StopRecording()
+		return
+	}
+	if w.EventRec != nil {
+		w.EventRec.Record(evi, w)
+	}
+}
+
+// IsRecording returns true if the window is recording events
+func (w *Window) IsRecording() bool {
+	return w.EventRec != nil
+}
+
+// StartRecording starts recording all events received by the window,
+// to be saved to given file when StopRecording is called (or the window
+// is closed).  If filename is empty, a new file named by the window and
+// the current time is used, in the app prefs "events" directory.
+func (w *Window) StartRecording(filename FileName) error {
+	if filename == "" {
+		pdir := filepath.Join(oswin.TheApp.AppPrefsDir(), "events")
+		if err := os.MkdirAll(pdir, 0755); err != nil {
+			return err
+		}
+		filename = FileName(filepath.Join(pdir, fmt.Sprintf("%v-%v.json", w.Nm, time.Now().Format("20060102-150405"))))
+	}
+	w.EventRec = &EventRecorder{File: filename}
+	w.EventRec.Rec.WinName = w.Nm
+	w.EventRec.Rec.WinSize = w.OSWin.Size()
+	w.EventRec.Rec.Start = time.Now()
+	if WinEventTrace {
+		fmt.Printf("Win: %v started event recording to: %v\n", w.Nm, filename)
+	}
+	return nil
+}
+
+// StopRecording stops recording events and saves the recording
+// to the file set in StartRecording.
+func (w *Window) StopRecording() error {
+	er := w.EventRec
+	if er == nil {
+		return nil
+	}
+	w.EventRec = nil
+	er.Mu.Lock()
+	defer er.Mu.Unlock()
+	err := er.Rec.Save(er.File)
+	if err != nil {
+		log.Printf("gi.Window: %v error saving event recording: %v\n", w.Nm, err)
+	} else {
+		log.Printf("gi.Window: %v saved event recording of %v events to: %v\n", w.Nm, len(er.Rec.Events), er.File)
+	}
+	return err
+}
+
+// Replay replays given recording into this window, in the calling goroutine,
+// which must not be the window's event loop.  The window is first resized to
+// the recorded size.  If realTime is true, events are sent at their recorded
+// times, and otherwise as fast as possible, waiting for each event to be
+// fully processed before sending the next, which is the most deterministic.
+// Resize events are replayed by setting the window size.
+func (w *Window) Replay(rec *EventRecording, realTime bool) error {
+	if rec.WinSize != (image.Point{}) && rec.WinSize != w.OSWin.Size() {
+		w.OSWin.SetSize(rec.WinSize)
+	}
+	if !w.WaitIdle(10 * time.Second) {
+		return fmt.Errorf("gi.Window: %v did not go idle before replay", w.Nm)
+	}
+	start := time.Now()
+	for i, re := range rec.Events {
+		if w.IsClosed() {
+			return fmt.Errorf("gi.Window: %v closed during replay at event: %v", w.Nm, i)
+		}
+		ev, err := re.NewEvent()
+		if err != nil {
+			return err
+		}
+		if realTime {
+			if dt := time.Until(start.Add(re.Time)); dt > 0 {
+				time.Sleep(dt)
+			}
+			ev.SetTime()
+		}
+		if we, ok := ev.(*window.Event); ok {
+			switch we.Action {
+			case window.Resize:
+				w.OSWin.SetSize(re.Size)
+				continue
+			case window.Close:
+				w.OSWin.CloseReq()
+				continue
+			}
+		}
+		w.OSWin.Send(ev)
+		if !realTime && !w.WaitIdle(10*time.Second) {
+			return fmt.Errorf("gi.Window: %v did not go idle during replay at event: %v", w.Nm, i)
+		}
+	}
+	return nil
+}
+
+// ReplayFile opens the event recording in given file and replays it into
+// this window in a separate goroutine (see Replay).
+func (w *Window) ReplayFile(filename FileName, realTime bool) error {
+	rec, err := OpenEventRecording(filename)
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := w.Replay(rec, realTime); err != nil {
+			log.Println(err)
+		}
+	}()
+	return nil
+}
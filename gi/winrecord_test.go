@@ -0,0 +1,90 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"image"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"goki.dev/gi/v2/oswin"
+	"goki.dev/gi/v2/oswin/key"
+	"goki.dev/gi/v2/oswin/mouse"
+)
+
+func TestEventRecorder(t *testing.T) {
+	er := &EventRecorder{}
+	er.Rec.Start = time.Now().Add(-time.Second)
+	kev := &key.ChordEvent{Event: key.Event{Rune: 'a', Code: key.CodeA, Modifiers: 1 << uint32(key.Control)}}
+	mev := &mouse.Event{Where: image.Point{10, 20}, Button: mouse.Left, Action: mouse.Press}
+	mmv := &mouse.MoveEvent{Event: mouse.Event{Where: image.Point{3, 4}}, From: image.Point{1, 2}}
+	hev := &mouse.HoverEvent{Event: mouse.Event{Where: image.Point{5, 5}}}
+	evs := []oswin.Event{kev, mev, hev, mmv}
+	for _, ev := range evs {
+		ev.Init()
+		er.Record(ev, nil)
+	}
+	// hover events are synthetic and skipped
+	want := []oswin.Event{kev, mev, mmv}
+	if len(er.Rec.Events) != len(want) {
+		t.Fatalf("recorded %d events, want %d", len(er.Rec.Events), len(want))
+	}
+
+	fn := FileName(filepath.Join(t.TempDir(), "rec.json"))
+	if err := er.Rec.Save(fn); err != nil {
+		t.Fatal(err)
+	}
+	rec, err := OpenEventRecording(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, re := range rec.Events {
+		if re.Time < time.Second {
+			t.Errorf("event %d: time %v is not relative to the recording start", i, re.Time)
+		}
+		ev, err := re.NewEvent()
+		if err != nil {
+			t.Fatalf("event %d: %v", i, err)
+		}
+		if ev.Type() != want[i].Type() {
+			t.Errorf("event %d: type %v, want %v", i, ev.Type(), want[i].Type())
+		}
+		// compare everything but the event base, which has the new time
+		switch gv := ev.(type) {
+		case *key.ChordEvent:
+			if gv.Rune != kev.Rune || gv.Code != kev.Code || gv.Modifiers != kev.Modifiers {
+				t.Errorf("event %d: got %v, want %v", i, gv, kev)
+			}
+		case *mouse.Event:
+			if gv.Where != mev.Where || gv.Button != mev.Button || gv.Action != mev.Action {
+				t.Errorf("event %d: got %v, want %v", i, gv, mev)
+			}
+		case *mouse.MoveEvent:
+			if gv.Where != mmv.Where || gv.From != mmv.From {
+				t.Errorf("event %d: got %v, want %v", i, gv, mmv)
+			}
+		}
+	}
+}
+
+func TestRecordedEventUnsupported(t *testing.T) {
+	tests := []struct {
+		typ string
+		ok  bool
+	}{
+		{oswin.KeyChordEvent.String(), true},
+		{oswin.MouseScrollEvent.String(), true},
+		{oswin.CustomEventType.String(), false},
+		{"NotAnEvent", false},
+	}
+	for _, tt := range tests {
+		re := &RecordedEvent{Type: tt.typ, Event: []byte("{}")}
+		_, err := re.NewEvent()
+		if (err == nil) != tt.ok {
+			t.Errorf("%v: error %v, want ok = %v", tt.typ, err, tt.ok)
+		}
+	}
+}
@@ -48,6 +48,9 @@ func (sc *Scene) ConfigFrame() bool {
 		return false
 	}
 	drw := sc.Win.OSWin.Drawer()
+	if drw == nil { // offscreen window: no GPU available
+		return false
+	}
 	sf := drw.Surf
 	newFrame := sc.ConfigFrameImpl(sf.GPU, &sf.Device)
 	if newFrame {
@@ -33,6 +33,15 @@ func Main(mainrun func()) {
 	})
 }
 
+// MainHeadless is the same as Main, except that it uses the in-memory
+// headless driver, which requires no display or GPU -- e.g., for running
+// in CI or batch jobs.  See also the GOGI_DRIVER=headless environment
+// variable, which selects the same driver for Main.
+func MainHeadless(mainrun func()) {
+	driver.Headless = true
+	Main(mainrun)
+}
+
 var quit = make(chan struct{})
 
 var started int32
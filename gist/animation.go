@@ -0,0 +1,653 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gist
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"goki.dev/gi/v2/units"
+	"goki.dev/ki/v2/ki"
+	"goki.dev/ki/v2/kit"
+	"goki.dev/mat32/v2"
+)
+
+// Style transitions and animations are CSS-like: a transition smoothly
+// changes the value of a property from its old to its new value when it
+// changes (e.g., the background-color when hovering), and an animation
+// runs through the property values given by a named set of Keyframes.
+// Only the properties in TransitionProps can be interpolated.
+// The styled widgets drive these, with a window animation clock.
+
+// Easings are the standard easing functions for transitions and animations,
+// which determine how the progress changes over time.
+type Easings int32
+
+const (
+	// EasingEase starts slowly, speeds up, and then ends slowly (the default)
+	EasingEase Easings = iota
+
+	// EasingLinear changes at a constant speed
+	EasingLinear
+
+	// EasingEaseIn starts slowly and then speeds up
+	EasingEaseIn
+
+	// EasingEaseOut starts quickly and then slows down
+	EasingEaseOut
+
+	// EasingEaseInOut starts slowly and ends slowly, symmetrically
+	EasingEaseInOut
+
+	EasingsN
+)
+
+var TypeEasings = kit.Enums.AddEnumAltLower(EasingsN, kit.NotBitFlag, StylePropProps, "Easing")
+
+func (ev Easings) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *Easings) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// SetCSS sets the easing from its CSS name, e.g., ease-in-out
+func (ev *Easings) SetCSS(s string) error {
+	nm := strings.ToLower(strings.ReplaceAll(s, "-", ""))
+	for e := EasingEase; e < EasingsN; e++ {
+		if strings.ToLower(strings.TrimPrefix(e.String(), "Easing")) == nm {
+			*ev = e
+			return nil
+		}
+	}
+	return fmt.Errorf("gist.Easings: unknown easing: %q", s)
+}
+
+// easingCurves are the cubic bezier control points of the easings
+var easingCurves = [EasingsN][4]float32{
+	EasingEase:      {0.25, 0.1, 0.25, 1},
+	EasingLinear:    {0, 0, 1, 1},
+	EasingEaseIn:    {0.42, 0, 1, 1},
+	EasingEaseOut:   {0, 0, 0.58, 1},
+	EasingEaseInOut: {0.42, 0, 0.58, 1},
+}
+
+// Apply returns the eased progress for given linear progress t (0-1)
+func (ev Easings) Apply(t float32) float32 {
+	if t <= 0 {
+		return 0
+	}
+	if t >= 1 {
+		return 1
+	}
+	if ev == EasingLinear || ev < 0 || ev >= EasingsN {
+		return t
+	}
+	c := easingCurves[ev]
+	return cubicBezier(c[0], c[1], c[2], c[3], t)
+}
+
+// cubicBezier returns the y value of the cubic bezier curve from (0,0)
+// to (1,1) with given control points, at given x value, per CSS
+func cubicBezier(x1, y1, x2, y2, x float32) float32 {
+	bez := func(p1, p2, s float32) float32 {
+		is := 1 - s
+		return 3*is*is*s*p1 + 3*is*s*s*p2 + s*s*s
+	}
+	// bisection on s, as x is monotonic in s for valid curves
+	lo, hi := float32(0), float32(1)
+	s := x
+	for i := 0; i < 24; i++ {
+		bx := bez(x1, x2, s)
+		if mat32.Abs(bx-x) < 1e-5 {
+			break
+		}
+		if bx < x {
+			lo = s
+		} else {
+			hi = s
+		}
+		s = (lo + hi) / 2
+	}
+	return bez(y1, y2, s)
+}
+
+// ParseCSSTime parses a CSS time value, e.g., 0.2s or 200ms
+func ParseCSSTime(s string) (time.Duration, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	mult := float64(time.Second)
+	switch {
+	case strings.HasSuffix(s, "ms"):
+		s = strings.TrimSuffix(s, "ms")
+		mult = float64(time.Millisecond)
+	case strings.HasSuffix(s, "s"):
+		s = strings.TrimSuffix(s, "s")
+	default:
+		return 0, fmt.Errorf("gist.ParseCSSTime: time must end in s or ms: %q", s)
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(v * mult), nil
+}
+
+/////////////////////////////////////////////////////////////////////
+//   Transition
+
+// Transition specifies how changes in the value of a style property
+// are animated over time, like the CSS transition property.
+type Transition struct {
+
+	// name of the property to transition (see TransitionProps), or all for all of them
+	Property string `desc:"name of the property to transition (see TransitionProps), or all for all of them"`
+
+	// duration of the transition
+	Duration time.Duration `desc:"duration of the transition"`
+
+	// delay before the transition starts
+	Delay time.Duration `desc:"delay before the transition starts"`
+
+	// easing function for the transition
+	Easing Easings `desc:"easing function for the transition"`
+}
+
+// Props returns the property names that this transition applies to
+func (tr *Transition) Props() []string {
+	if tr.Property == "all" || tr.Property == "" {
+		return TransitionPropNames
+	}
+	return []string{tr.Property}
+}
+
+// ParseTransitions parses a CSS transition property value, which is a
+// comma-separated list of property, duration, easing and delay specs,
+// e.g., "background-color 0.2s ease-in-out, color 100ms".
+func ParseTransitions(str string) ([]Transition, error) {
+	var trs []Transition
+	if strings.TrimSpace(str) == "none" {
+		return nil, nil
+	}
+	for _, spec := range strings.Split(str, ",") {
+		tr := Transition{Property: "all"}
+		ntimes := 0
+		for _, tok := range strings.Fields(spec) {
+			if d, err := ParseCSSTime(tok); err == nil {
+				if ntimes == 0 {
+					tr.Duration = d
+				} else {
+					tr.Delay = d
+				}
+				ntimes++
+				continue
+			}
+			var ev Easings
+			if ev.SetCSS(tok) == nil {
+				tr.Easing = ev
+				continue
+			}
+			tok = strings.ToLower(tok)
+			if tok != "all" && !TransitionProps[tok] && !transitionLayoutProps[tok] {
+				return nil, fmt.Errorf("gist.ParseTransitions: property cannot be transitioned: %q", tok)
+			}
+			tr.Property = tok
+		}
+		if ntimes == 0 {
+			return nil, fmt.Errorf("gist.ParseTransitions: no duration in: %q", spec)
+		}
+		trs = append(trs, tr)
+	}
+	return trs, nil
+}
+
+/////////////////////////////////////////////////////////////////////
+//   Animation
+
+// Animation specifies a keyframe animation of style properties,
+// like the CSS animation property.
+type Animation struct {
+
+	// name of the Keyframes for the animation (see AddKeyframes) -- no animation if empty
+	Name string `desc:"name of the Keyframes for the animation (see AddKeyframes) -- no animation if empty"`
+
+	// duration of one iteration of the animation
+	Duration time.Duration `desc:"duration of one iteration of the animation"`
+
+	// delay before the animation starts
+	Delay time.Duration `desc:"delay before the animation starts"`
+
+	// easing function for each segment between keyframes
+	Easing Easings `desc:"easing function for each segment between keyframes"`
+
+	// number of iterations of the animation -- if <= 0, it repeats forever
+	Iterations int `desc:"number of iterations of the animation -- if <= 0, it repeats forever"`
+
+	// if true, every other iteration runs backwards
+	Alternate bool `desc:"if true, every other iteration runs backwards"`
+}
+
+// ParseAnimation parses a CSS animation property value, e.g.,
+// "pulse 1s ease-in-out infinite alternate".  The default is one iteration.
+func ParseAnimation(str string) (Animation, error) {
+	an := Animation{Iterations: 1}
+	if strings.TrimSpace(str) == "none" {
+		return Animation{}, nil
+	}
+	ntimes := 0
+	for _, tok := range strings.Fields(str) {
+		if d, err := ParseCSSTime(tok); err == nil {
+			if ntimes == 0 {
+				an.Duration = d
+			} else {
+				an.Delay = d
+			}
+			ntimes++
+			continue
+		}
+		var ev Easings
+		if ev.SetCSS(tok) == nil {
+			an.Easing = ev
+			continue
+		}
+		switch tok {
+		case "infinite":
+			an.Iterations = 0
+		case "alternate":
+			an.Alternate = true
+		case "normal":
+		default:
+			if n, err := strconv.Atoi(tok); err == nil {
+				an.Iterations = n
+			} else {
+				an.Name = tok
+			}
+		}
+	}
+	if an.Name == "" || ntimes == 0 {
+		return an, fmt.Errorf("gist.ParseAnimation: animation needs a name and a duration: %q", str)
+	}
+	return an, nil
+}
+
+// Progress returns the linear progress (0-1) through the keyframes at given
+// time since the start of the animation, and whether it is still running.
+// As in CSS, the keyframes no longer apply once it is done running.
+func (an *Animation) Progress(el time.Duration) (pos float32, running bool) {
+	el -= an.Delay
+	if el < 0 {
+		return 0, true
+	}
+	if an.Duration <= 0 {
+		return 1, false
+	}
+	iter := int(el / an.Duration)
+	if an.Iterations > 0 && iter >= an.Iterations {
+		iter = an.Iterations - 1
+		pos = 1
+	} else {
+		pos = float32(el%an.Duration) / float32(an.Duration)
+		running = true
+	}
+	if an.Alternate && iter%2 == 1 {
+		pos = 1 - pos
+	}
+	return
+}
+
+// Keyframe is one keyframe of an animation: the style properties
+// at a given position in the animation
+type Keyframe struct {
+
+	// position of the keyframe in the animation, from 0 (from) to 1 (to)
+	Pos float32 `desc:"position of the keyframe in the animation, from 0 (from) to 1 (to)"`
+
+	// style properties at this keyframe
+	Props ki.Props `desc:"style properties at this keyframe"`
+}
+
+// Keyframes are the keyframes of an animation, in order of position
+type Keyframes []Keyframe
+
+var (
+	// StyleKeyframes are the named Keyframes for animations -- use AddKeyframes
+	StyleKeyframes = map[string]Keyframes{}
+
+	// StyleKeyframesMu is a mutex protecting updates to StyleKeyframes
+	StyleKeyframesMu sync.RWMutex
+)
+
+// AddKeyframes adds given keyframes for animations with given name,
+// sorting them by position
+func AddKeyframes(name string, kfs Keyframes) {
+	kfs = append(Keyframes{}, kfs...)
+	sort.SliceStable(kfs, func(i, j int) bool { return kfs[i].Pos < kfs[j].Pos })
+	StyleKeyframesMu.Lock()
+	StyleKeyframes[name] = kfs
+	StyleKeyframesMu.Unlock()
+}
+
+// KeyframesByName returns the keyframes with given name
+func KeyframesByName(name string) (Keyframes, bool) {
+	StyleKeyframesMu.RLock()
+	defer StyleKeyframesMu.RUnlock()
+	kfs, ok := StyleKeyframes[name]
+	return kfs, ok
+}
+
+// ParseKeyframePos parses a keyframe selector: from, to or a percentage
+func ParseKeyframePos(s string) (float32, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	switch s {
+	case "from":
+		return 0, nil
+	case "to":
+		return 1, nil
+	}
+	if !strings.HasSuffix(s, "%") {
+		return 0, fmt.Errorf("gist.ParseKeyframePos: invalid keyframe: %q", s)
+	}
+	v, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 32)
+	if err != nil {
+		return 0, err
+	}
+	return mat32.Clamp(float32(v)/100, 0, 1), nil
+}
+
+// Apply sets the properties in the keyframes in given style to their values
+// at given linear position in the animation, interpolating between the
+// keyframes with given easing.  The keyframe properties are applied on top
+// of the base style, which is also used for any missing first or last keyframe.
+func (kfs Keyframes) Apply(s *Style, base *Style, pos float32, easing Easings, ctxt Context) {
+	if len(kfs) == 0 {
+		return
+	}
+	k0 := Keyframe{Pos: 0}
+	k1 := Keyframe{Pos: 1}
+	for _, kf := range kfs {
+		if kf.Pos <= pos {
+			k0 = kf
+		}
+		if kf.Pos >= pos {
+			k1 = kf
+			break
+		}
+	}
+	s0 := *base
+	s0.StyleFromProps(nil, k0.Props, ctxt)
+	s1 := *base
+	s1.StyleFromProps(nil, k1.Props, ctxt)
+	t := float32(0)
+	if k1.Pos > k0.Pos {
+		t = easing.Apply((pos - k0.Pos) / (k1.Pos - k0.Pos))
+	}
+	// all the properties used in the animation, so others are not affected
+	for _, kf := range kfs {
+		for key := range kf.Props {
+			s.Lerp(key, &s0, &s1, t)
+		}
+	}
+}
+
+// AffectsLayout returns true if any of the properties in the
+// keyframes affect the layout (see TransitionAffectsLayout)
+func (kfs Keyframes) AffectsLayout() bool {
+	for _, kf := range kfs {
+		for key := range kf.Props {
+			if TransitionAffectsLayout(key) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+/////////////////////////////////////////////////////////////////////
+//   Interpolation
+
+// TransitionProps are the style properties that can be interpolated in
+// transitions and animations, which do not affect the layout
+var TransitionProps = map[string]bool{
+	"color":            true,
+	"background-color": true,
+	"border-color":     true,
+	"border-radius":    true,
+	"outline-color":    true,
+	"box-shadow":       true,
+	"opacity":          true,
+}
+
+// transitionLayoutProps are the style properties that can be interpolated,
+// which affect the layout
+var transitionLayoutProps = map[string]bool{
+	"width":        true,
+	"height":       true,
+	"min-width":    true,
+	"min-height":   true,
+	"max-width":    true,
+	"max-height":   true,
+	"margin":       true,
+	"padding":      true,
+	"border-width": true,
+	"font-size":    true,
+}
+
+// TransitionPropNames are the names of all the properties that can be
+// interpolated, which is what the all transition property applies to
+var TransitionPropNames = []string{"color", "background-color", "border-color", "border-radius", "outline-color", "box-shadow", "opacity",
+	"width", "height", "min-width", "min-height", "max-width", "max-height", "margin", "padding", "border-width", "font-size"}
+
+// TransitionAffectsLayout returns true if interpolating given property
+// affects the layout, so that it must be redone on each step
+func TransitionAffectsLayout(prop string) bool {
+	return transitionLayoutProps[prop]
+}
+
+// Lerp sets the given property in this style to the interpolation
+// between its values in the from and to styles, at given position (0-1).
+// Properties that cannot be interpolated (e.g., between different units
+// or gradients) are set to the to value for t > 0, and otherwise the from
+// value.  Properties not in TransitionPropNames are ignored.
+func (s *Style) Lerp(prop string, from, to *Style, t float32) {
+	switch prop {
+	case "color":
+		s.Color = lerpColor(from.Color, to.Color, t)
+	case "background-color":
+		if from.BackgroundColor.Source == SolidColor && to.BackgroundColor.Source == SolidColor {
+			s.BackgroundColor = to.BackgroundColor
+			s.BackgroundColor.Color = lerpColor(from.BackgroundColor.Color, to.BackgroundColor.Color, t)
+		} else {
+			s.BackgroundColor = snap(from.BackgroundColor, to.BackgroundColor, t)
+		}
+	case "border-color":
+		s.Border.Color.Sides = lerpSides(from.Border.Color.Sides, to.Border.Color.Sides, t, lerpColor)
+	case "border-radius":
+		s.Border.Radius.Sides = lerpSides(from.Border.Radius.Sides, to.Border.Radius.Sides, t, lerpValue)
+	case "border-width":
+		s.Border.Width.Sides = lerpSides(from.Border.Width.Sides, to.Border.Width.Sides, t, lerpValue)
+	case "outline-color":
+		s.Outline.Color.Sides = lerpSides(from.Outline.Color.Sides, to.Outline.Color.Sides, t, lerpColor)
+	case "box-shadow":
+		s.BoxShadow = lerpShadows(from.BoxShadow, to.BoxShadow, t)
+	case "opacity":
+		s.Font.Opacity = from.Font.Opacity + t*(to.Font.Opacity-from.Font.Opacity)
+	case "width":
+		s.Width = lerpValue(from.Width, to.Width, t)
+	case "height":
+		s.Height = lerpValue(from.Height, to.Height, t)
+	case "min-width":
+		s.MinWidth = lerpValue(from.MinWidth, to.MinWidth, t)
+	case "min-height":
+		s.MinHeight = lerpValue(from.MinHeight, to.MinHeight, t)
+	case "max-width":
+		s.MaxWidth = lerpValue(from.MaxWidth, to.MaxWidth, t)
+	case "max-height":
+		s.MaxHeight = lerpValue(from.MaxHeight, to.MaxHeight, t)
+	case "margin":
+		s.Margin.Sides = lerpSides(from.Margin.Sides, to.Margin.Sides, t, lerpValue)
+	case "padding":
+		s.Padding.Sides = lerpSides(from.Padding.Sides, to.Padding.Sides, t, lerpValue)
+	case "font-size":
+		s.Font.Size = lerpValue(from.Font.Size, to.Font.Size, t)
+	}
+}
+
+// PropEqual returns true if the given property has the same value in
+// this style and the other one (see Lerp for the properties)
+func (s *Style) PropEqual(prop string, os *Style) bool {
+	return styleLerpEqual(prop, s, os)
+}
+
+// styleLerpEqual compares the given property of two styles
+func styleLerpEqual(prop string, a, b *Style) bool {
+	switch prop {
+	case "color":
+		return a.Color == b.Color
+	case "background-color":
+		return a.BackgroundColor.Source == b.BackgroundColor.Source && a.BackgroundColor.Color == b.BackgroundColor.Color && a.BackgroundColor.Gradient == b.BackgroundColor.Gradient
+	case "border-color":
+		return a.Border.Color.Sides == b.Border.Color.Sides
+	case "border-radius":
+		return sidesValEqual(a.Border.Radius.Sides, b.Border.Radius.Sides)
+	case "border-width":
+		return sidesValEqual(a.Border.Width.Sides, b.Border.Width.Sides)
+	case "outline-color":
+		return a.Outline.Color.Sides == b.Outline.Color.Sides
+	case "box-shadow":
+		if len(a.BoxShadow) != len(b.BoxShadow) {
+			return false
+		}
+		for i := range a.BoxShadow {
+			as, bs := &a.BoxShadow[i], &b.BoxShadow[i]
+			if !valEqual(as.HOffset, bs.HOffset) || !valEqual(as.VOffset, bs.VOffset) || !valEqual(as.Blur, bs.Blur) || !valEqual(as.Spread, bs.Spread) || as.Color != bs.Color || as.Inset != bs.Inset {
+				return false
+			}
+		}
+		return true
+	case "opacity":
+		return a.Font.Opacity == b.Font.Opacity
+	case "width":
+		return valEqual(a.Width, b.Width)
+	case "height":
+		return valEqual(a.Height, b.Height)
+	case "min-width":
+		return valEqual(a.MinWidth, b.MinWidth)
+	case "min-height":
+		return valEqual(a.MinHeight, b.MinHeight)
+	case "max-width":
+		return valEqual(a.MaxWidth, b.MaxWidth)
+	case "max-height":
+		return valEqual(a.MaxHeight, b.MaxHeight)
+	case "margin":
+		return sidesValEqual(a.Margin.Sides, b.Margin.Sides)
+	case "padding":
+		return sidesValEqual(a.Padding.Sides, b.Padding.Sides)
+	case "font-size":
+		return valEqual(a.Font.Size, b.Font.Size)
+	}
+	return true
+}
+
+// ApplyOpacity applies the Font.Opacity to the colors of the style,
+// making them more transparent when it is < 1
+func (s *Style) ApplyOpacity() {
+	if s.Font.Opacity >= 1 {
+		return
+	}
+	op := mat32.Clamp(s.Font.Opacity, 0, 1)
+	s.Color = fadeColor(s.Color, op)
+	s.BackgroundColor.Color = fadeColor(s.BackgroundColor.Color, op)
+	bc := &s.Border.Color.Sides
+	bc.Top, bc.Right, bc.Bottom, bc.Left = fadeColor(bc.Top, op), fadeColor(bc.Right, op), fadeColor(bc.Bottom, op), fadeColor(bc.Left, op)
+	if len(s.BoxShadow) > 0 {
+		shs := make([]Shadow, len(s.BoxShadow))
+		for i, sh := range s.BoxShadow {
+			sh.Color = fadeColor(sh.Color, op)
+			shs[i] = sh
+		}
+		s.BoxShadow = shs
+	}
+}
+
+// fadeColor scales given premultiplied color by given opacity
+func fadeColor(c color.RGBA, op float32) color.RGBA {
+	return color.RGBA{uint8(float32(c.R) * op), uint8(float32(c.G) * op), uint8(float32(c.B) * op), uint8(float32(c.A) * op)}
+}
+
+// lerpColor interpolates between premultiplied colors, as in CSS
+func lerpColor(a, b color.RGBA, t float32) color.RGBA {
+	l := func(x, y uint8) uint8 {
+		return uint8(mat32.Clamp(float32(x)+t*(float32(y)-float32(x))+0.5, 0, 255))
+	}
+	return color.RGBA{l(a.R, b.R), l(a.G, b.G), l(a.B, b.B), l(a.A, b.A)}
+}
+
+// lerpValue interpolates between unit values with the same units,
+// snapping between those with different units
+func lerpValue(a, b units.Value, t float32) units.Value {
+	if a.Un != b.Un || a.DotsFunc != nil || b.DotsFunc != nil {
+		return snap(a, b, t)
+	}
+	return units.New(a.Val+t*(b.Val-a.Val), a.Un)
+}
+
+// valEqual returns true if the unit values are the same
+func valEqual(a, b units.Value) bool {
+	return a.Val == b.Val && a.Un == b.Un && a.DotsFunc == nil && b.DotsFunc == nil
+}
+
+func sidesValEqual(a, b Sides[units.Value]) bool {
+	return valEqual(a.Top, b.Top) && valEqual(a.Right, b.Right) && valEqual(a.Bottom, b.Bottom) && valEqual(a.Left, b.Left)
+}
+
+func lerpSides[T any](a, b Sides[T], t float32, fun func(a, b T, t float32) T) Sides[T] {
+	return Sides[T]{Top: fun(a.Top, b.Top, t), Right: fun(a.Right, b.Right, t), Bottom: fun(a.Bottom, b.Bottom, t), Left: fun(a.Left, b.Left, t)}
+}
+
+// lerpShadows interpolates between lists of shadows, padding the shorter
+// list with transparent shadows as in CSS, and snapping between lists with
+// different inset settings
+func lerpShadows(a, b []Shadow, t float32) []Shadow {
+	n := max(len(a), len(b))
+	shs := make([]Shadow, n)
+	for i := range shs {
+		var as, bs Shadow
+		if i < len(a) {
+			as = a[i]
+		} else {
+			as = transparentShadow(b[i])
+		}
+		if i < len(b) {
+			bs = b[i]
+		} else {
+			bs = transparentShadow(a[i])
+		}
+		if as.Inset != bs.Inset {
+			return snap(a, b, t)
+		}
+		shs[i] = Shadow{HOffset: lerpValue(as.HOffset, bs.HOffset, t), VOffset: lerpValue(as.VOffset, bs.VOffset, t),
+			Blur: lerpValue(as.Blur, bs.Blur, t), Spread: lerpValue(as.Spread, bs.Spread, t),
+			Color: lerpColor(as.Color, bs.Color, t), Inset: as.Inset}
+	}
+	if t >= 1 {
+		return b
+	}
+	return shs
+}
+
+// transparentShadow returns a transparent shadow with no extent, with the
+// same units and inset setting as given shadow, for interpolating to and from it
+func transparentShadow(sh Shadow) Shadow {
+	return Shadow{HOffset: units.New(0, sh.HOffset.Un), VOffset: units.New(0, sh.VOffset.Un),
+		Blur: units.New(0, sh.Blur.Un), Spread: units.New(0, sh.Spread.Un), Inset: sh.Inset}
+}
+
+// snap returns a if t is 0, and otherwise b
+func snap[T any](a, b T, t float32) T {
+	if t <= 0 {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gist
+
+import (
+	"image/color"
+	"testing"
+	"time"
+
+	"goki.dev/gi/v2/units"
+	"goki.dev/ki/v2/ki"
+	"goki.dev/mat32/v2"
+)
+
+func TestParseTransitions(t *testing.T) {
+	trs, err := ParseTransitions("background-color 0.2s ease-in-out, color 100ms 50ms")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Transition{
+		{Property: "background-color", Duration: 200 * time.Millisecond, Easing: EasingEaseInOut},
+		{Property: "color", Duration: 100 * time.Millisecond, Delay: 50 * time.Millisecond},
+	}
+	if len(trs) != len(want) {
+		t.Fatalf("got %d transitions, want %d", len(trs), len(want))
+	}
+	for i := range want {
+		if trs[i] != want[i] {
+			t.Errorf("transition %d: got %+v, want %+v", i, trs[i], want[i])
+		}
+	}
+	if _, err := ParseTransitions("display 1s"); err == nil {
+		t.Errorf("expected error for non-interpolable property")
+	}
+}
+
+func TestAnimationProgress(t *testing.T) {
+	an, err := ParseAnimation("pulse 1s linear 2 alternate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		el      time.Duration
+		pos     float32
+		running bool
+	}{
+		{250 * time.Millisecond, 0.25, true},
+		{1250 * time.Millisecond, 0.75, true},
+		{3 * time.Second, 0, false},
+	}
+	for _, tt := range tests {
+		pos, running := an.Progress(tt.el)
+		if pos != tt.pos || running != tt.running {
+			t.Errorf("Progress(%v): got %v %v, want %v %v", tt.el, pos, running, tt.pos, tt.running)
+		}
+	}
+}
+
+func TestEasings(t *testing.T) {
+	for e := EasingEase; e < EasingsN; e++ {
+		if e.Apply(0) != 0 || e.Apply(1) != 1 {
+			t.Errorf("%v: does not go from 0 to 1", e)
+		}
+		prev := float32(0)
+		for i := 1; i <= 10; i++ {
+			v := e.Apply(float32(i) / 10)
+			if v < prev {
+				t.Errorf("%v: not monotonic at %v", e, float32(i)/10)
+			}
+			prev = v
+		}
+	}
+	if v := EasingEaseIn.Apply(0.5); v >= 0.5 {
+		t.Errorf("ease-in at 0.5 should be < 0.5, got %v", v)
+	}
+}
+
+func TestStyleLerp(t *testing.T) {
+	var from, to, s Style
+	from.Defaults()
+	to.Defaults()
+	from.BackgroundColor.SetSolid(color.RGBA{0, 0, 0, 255})
+	to.BackgroundColor.SetSolid(color.RGBA{200, 100, 0, 255})
+	from.Width = units.Px(10)
+	to.Width = units.Px(20)
+	s = to
+	s.Lerp("background-color", &from, &to, 0.5)
+	s.Lerp("width", &from, &to, 0.5)
+	if c := s.BackgroundColor.Color; c != (color.RGBA{100, 50, 0, 255}) {
+		t.Errorf("background-color: got %v", c)
+	}
+	if s.Width.Val != 15 {
+		t.Errorf("width: got %v", s.Width)
+	}
+	if s.PropEqual("width", &to) {
+		t.Errorf("width should differ from target")
+	}
+
+	AddKeyframes("test-fade", Keyframes{{Pos: 0.5, Props: ki.Props{"opacity": 0.2}}})
+	kfs, _ := KeyframesByName("test-fade")
+	base := to
+	s = to
+	kfs.Apply(&s, &base, 0.25, EasingLinear, nil)
+	if mat32.Abs(s.Font.Opacity-0.6) > 1e-6 {
+		t.Errorf("keyframe opacity: got %v, want 0.6", s.Font.Opacity)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gist
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"goki.dev/gi/v2/units"
+	"goki.dev/ki/v2/kit"
+)
+
+// GridTrackTypes are the ways of sizing a row or column track in an
+// irregular grid layout
+type GridTrackTypes int32
+
+const (
+	// GridTrackAuto sizes the track to fit its content, and stretches it if
+	// any of its elements stretch (the default, as in CSS auto)
+	GridTrackAuto GridTrackTypes = iota
+
+	// GridTrackFixed gives the track a fixed size
+	GridTrackFixed
+
+	// GridTrackFr gives the track a fraction of the space remaining after
+	// the other tracks are sized, in proportion to its Fr relative to the
+	// other fraction tracks (as in CSS fr units), but never less than its content
+	GridTrackFr
+
+	GridTrackTypesN
+)
+
+var TypeGridTrackTypes = kit.Enums.AddEnumAltLower(GridTrackTypesN, kit.NotBitFlag, StylePropProps, "GridTrack")
+
+func (ev GridTrackTypes) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *GridTrackTypes) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// GridTrack specifies the size of one row or column track in an
+// irregular grid layout (see GridTemplateColumns, GridTemplateRows)
+type GridTrack struct {
+
+	// how the track is sized
+	Type GridTrackTypes `desc:"how the track is sized"`
+
+	// size of a GridTrackFixed track
+	Size units.Value `desc:"size of a GridTrackFixed track"`
+
+	// fraction of the remaining space for a GridTrackFr track
+	Fr float32 `desc:"fraction of the remaining space for a GridTrackFr track"`
+}
+
+// GridTrackAutoSize returns an auto-sized grid track
+func GridTrackAutoSize() GridTrack {
+	return GridTrack{Type: GridTrackAuto}
+}
+
+// GridTrackFixedSize returns a fixed-size grid track
+func GridTrackFixedSize(size units.Value) GridTrack {
+	return GridTrack{Type: GridTrackFixed, Size: size}
+}
+
+// GridTrackFraction returns a grid track with given fraction of the remaining space
+func GridTrackFraction(fr float32) GridTrack {
+	return GridTrack{Type: GridTrackFr, Fr: fr}
+}
+
+// String returns the CSS representation of the track
+func (gt GridTrack) String() string {
+	switch gt.Type {
+	case GridTrackFixed:
+		return gt.Size.String()
+	case GridTrackFr:
+		return fmt.Sprintf("%gfr", gt.Fr)
+	}
+	return "auto"
+}
+
+// GridTrackAt returns the track at given index in given track list,
+// which is auto for any tracks beyond those specified
+func GridTrackAt(tracks []GridTrack, idx int) GridTrack {
+	if idx < len(tracks) {
+		return tracks[idx]
+	}
+	return GridTrack{}
+}
+
+// ParseGridTracks parses a CSS grid-template-columns or grid-template-rows
+// value, which is a space-separated list of track sizes: auto, fixed sizes
+// in any units (e.g., 100px, 10em), or fractions (e.g., 1fr), along with
+// repeat(n, tracks), e.g., "200px repeat(2, 1fr) auto".
+func ParseGridTracks(str string) ([]GridTrack, error) {
+	var tracks []GridTrack
+	str = strings.TrimSpace(str)
+	if str == "none" {
+		return nil, nil
+	}
+	for len(str) > 0 {
+		if strings.HasPrefix(str, "repeat(") {
+			end := strings.Index(str, ")")
+			if end < 0 {
+				return nil, fmt.Errorf("gist.ParseGridTracks: missing ) in: %q", str)
+			}
+			args := strings.SplitN(str[len("repeat("):end], ",", 2)
+			if len(args) != 2 {
+				return nil, fmt.Errorf("gist.ParseGridTracks: repeat needs a count and tracks: %q", str[:end+1])
+			}
+			n, err := strconv.Atoi(strings.TrimSpace(args[0]))
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("gist.ParseGridTracks: invalid repeat count: %q", args[0])
+			}
+			rtr, err := ParseGridTracks(args[1])
+			if err != nil {
+				return nil, err
+			}
+			for i := 0; i < n; i++ {
+				tracks = append(tracks, rtr...)
+			}
+			str = strings.TrimSpace(str[end+1:])
+			continue
+		}
+		tok := str
+		if sp := strings.IndexAny(str, " \t"); sp >= 0 {
+			tok = str[:sp]
+		}
+		str = strings.TrimSpace(str[len(tok):])
+		gt, err := parseGridTrack(tok)
+		if err != nil {
+			return nil, err
+		}
+		tracks = append(tracks, gt)
+	}
+	return tracks, nil
+}
+
+// parseGridTrack parses one grid track size
+func parseGridTrack(tok string) (GridTrack, error) {
+	tok = strings.ToLower(tok)
+	switch {
+	case tok == "auto":
+		return GridTrackAutoSize(), nil
+	case strings.HasSuffix(tok, "fr"):
+		fr, err := strconv.ParseFloat(strings.TrimSuffix(tok, "fr"), 32)
+		if err != nil || fr < 0 {
+			return GridTrack{}, fmt.Errorf("gist.ParseGridTracks: invalid fraction: %q", tok)
+		}
+		return GridTrackFraction(float32(fr)), nil
+	}
+	var v units.Value
+	if err := v.SetString(tok); err != nil {
+		return GridTrack{}, fmt.Errorf("gist.ParseGridTracks: invalid track size: %q: %w", tok, err)
+	}
+	return GridTrackFixedSize(v), nil
+}
+
+// GridTracksToDots runs ToDots on the sizes of given tracks
+func GridTracksToDots(tracks []GridTrack, uc *units.Context) {
+	for i := range tracks {
+		tracks[i].Size.ToDots(uc)
+	}
+}
+
+// styleGridTracks sets given tracks from a property value, which can be
+// a string to parse or a []GridTrack
+func styleGridTracks(tracks *[]GridTrack, key string, val any) {
+	switch vt := val.(type) {
+	case string:
+		trs, err := ParseGridTracks(vt)
+		if err != nil {
+			StyleSetError(key, val)
+			return
+		}
+		*tracks = trs
+	case []GridTrack:
+		*tracks = vt
+	default:
+		StyleSetError(key, val)
+	}
+}
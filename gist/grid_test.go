@@ -0,0 +1,37 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gist
+
+import (
+	"testing"
+
+	"goki.dev/gi/v2/units"
+)
+
+func TestParseGridTracks(t *testing.T) {
+	trs, err := ParseGridTracks("100px repeat(2, 1fr auto) 2.5fr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []GridTrack{
+		GridTrackFixedSize(units.Px(100)),
+		GridTrackFraction(1), GridTrackAutoSize(),
+		GridTrackFraction(1), GridTrackAutoSize(),
+		GridTrackFraction(2.5),
+	}
+	if len(trs) != len(want) {
+		t.Fatalf("got %d tracks, want %d: %v", len(trs), len(want), trs)
+	}
+	for i := range want {
+		if trs[i].Type != want[i].Type || trs[i].Fr != want[i].Fr || trs[i].Size.Val != want[i].Size.Val || trs[i].Size.Un != want[i].Size.Un {
+			t.Errorf("track %d: got %v, want %v", i, trs[i], want[i])
+		}
+	}
+	for _, bad := range []string{"1xfr", "repeat(0, 1fr)", "repeat(2 1fr)"} {
+		if _, err := ParseGridTracks(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
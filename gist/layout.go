@@ -5,6 +5,10 @@
 package gist
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	"goki.dev/gi/v2/units"
 	"goki.dev/ki/v2/ki"
 	"goki.dev/ki/v2/kit"
@@ -13,10 +17,7 @@ import (
 
 // todo: for style
 // Align = layouts
-// Flex -- flexbox -- https://www.w3schools.com/css/css3_flexbox.asp -- key to look at further for layout ideas
-// as is Position -- absolute, sticky, etc
 // Resize: user-resizability
-// z-index
 
 // CSS vs. Layout alignment
 //
@@ -64,6 +65,17 @@ func (s *Style) LayoutDefaults() {
 	s.MinWidth.SetPx(2)
 	s.MinHeight.SetPx(2)
 	s.ScrollBarWidth.SetPx(ScrollBarWidthDefault)
+	s.Row = -1
+	s.Col = -1
+	s.FlexShrink = 1
+	s.FlexBasis.SetPx(-1)
+	s.JustifyContent = AlignFlexStart
+}
+
+// FlexDefaults sets the defaults that only apply to flex layouts,
+// after Defaults: items stretch across each line, as in CSS.
+func (s *Style) FlexDefaults() {
+	s.AlignItems = AlignStretch
 }
 
 func (s *Style) LayoutSetStylePost(props ki.Props) {
@@ -107,11 +119,17 @@ func (s *Style) LayoutToDots(uc *units.Context) {
 	s.Height.ToDots(uc)
 	s.MaxWidth.ToDots(uc)
 	s.MaxHeight.ToDots(uc)
+	s.Inset.ToDots(uc)
 	s.MinWidth.ToDots(uc)
 	s.MinHeight.ToDots(uc)
 	s.Margin.ToDots(uc)
 	s.Padding.ToDots(uc)
 	s.ScrollBarWidth.ToDots(uc)
+	GridTracksToDots(s.GridTemplateColumns, uc)
+	GridTracksToDots(s.GridTemplateRows, uc)
+	s.ColumnGap.ToDots(uc)
+	s.RowGap.ToDots(uc)
+	s.FlexBasis.ToDots(uc)
 }
 
 // SetMinPrefWidth sets minimum and preferred width;
@@ -191,6 +209,10 @@ const (
 	AlignSub
 	// align to superscript
 	AlignSuper
+	// same as CSS space-evenly: equal space between and around elements
+	AlignSpaceEvenly
+	// stretch elements to fill the space, as in CSS align-items: stretch
+	AlignStretch
 	AlignN
 )
 
@@ -199,6 +221,87 @@ var TypeAlign = kit.Enums.AddEnumAltLower(AlignN, kit.NotBitFlag, StylePropProps
 func (ev Align) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
 func (ev *Align) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
 
+// SetCSS sets the alignment from its CSS name, as used in justify-content
+// and align-items, e.g., flex-start, space-between, stretch -- also accepts
+// the regular alignment names
+func (ev *Align) SetCSS(str string) error {
+	switch strings.ToLower(strings.TrimSpace(str)) {
+	case "start", "flex-start", "self-start", "normal":
+		*ev = AlignFlexStart
+	case "end", "flex-end", "self-end":
+		*ev = AlignFlexEnd
+	case "space-between":
+		*ev = AlignJustify
+	case "space-around":
+		*ev = AlignSpaceAround
+	case "space-evenly":
+		*ev = AlignSpaceEvenly
+	default:
+		return kit.Enums.SetAnyEnumIfaceFromString(ev, strings.ReplaceAll(str, "-", ""))
+	}
+	return nil
+}
+
+// styleAlignCSS sets given alignment from a property value, which can be a
+// CSS alignment name (see SetCSS), an Align value or an int
+func styleAlignCSS(al *Align, key string, val any) {
+	switch vt := val.(type) {
+	case string:
+		if al.SetCSS(vt) != nil {
+			StyleSetError(key, val)
+		}
+	case Align:
+		*al = vt
+	default:
+		if iv, ok := kit.ToInt(val); ok {
+			*al = Align(iv)
+		} else {
+			StyleSetError(key, val)
+		}
+	}
+}
+
+// SetFlex sets the FlexGrow, FlexShrink and FlexBasis from a CSS flex
+// shorthand value: none, auto, or a grow factor, optionally followed by a
+// shrink factor and / or a basis, e.g., "1" (= 1 1 0), "2 0 100px" or "1 10em"
+func (s *Style) SetFlex(str string) error {
+	switch strings.TrimSpace(str) {
+	case "none":
+		s.FlexGrow, s.FlexShrink = 0, 0
+		s.FlexBasis.SetPx(-1)
+		return nil
+	case "auto":
+		s.FlexGrow, s.FlexShrink = 1, 1
+		s.FlexBasis.SetPx(-1)
+		return nil
+	}
+	flds := strings.Fields(str)
+	if len(flds) == 0 || len(flds) > 3 {
+		return fmt.Errorf("gist.Style.SetFlex: invalid flex value: %q", str)
+	}
+	grow, err := strconv.ParseFloat(flds[0], 32)
+	if err != nil {
+		return fmt.Errorf("gist.Style.SetFlex: invalid flex-grow: %q", flds[0])
+	}
+	shrink := float64(1)
+	basis := units.Px(0)
+	for i, fld := range flds[1:] {
+		if i == 0 {
+			if sv, err := strconv.ParseFloat(fld, 32); err == nil {
+				shrink = sv
+				continue
+			}
+		}
+		if fld == "auto" {
+			basis.SetPx(-1)
+		} else if err := basis.SetString(fld); err != nil {
+			return fmt.Errorf("gist.Style.SetFlex: invalid flex-basis: %q", fld)
+		}
+	}
+	s.FlexGrow, s.FlexShrink, s.FlexBasis = float32(grow), float32(shrink), basis
+	return nil
+}
+
 // is this a generalized alignment to start of container?
 func IsAlignStart(a Align) bool {
 	return (a == AlignLeft || a == AlignTop || a == AlignFlexStart || a == AlignTextTop)
@@ -249,6 +352,103 @@ var TypeOverflow = kit.Enums.AddEnumAltLower(OverflowN, kit.NotBitFlag, StylePro
 func (ev Overflow) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
 func (ev *Overflow) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
 
+// Positions are the ways an element is positioned (CSS position)
+type Positions int32
+
+const (
+	// PositionStatic places the element by the layout of its parent (the default)
+	PositionStatic Positions = iota
+
+	// PositionRelative places the element by the layout of its parent and
+	// then offsets it by its Inset, without affecting the other elements.
+	// It is a positioned ancestor for absolute elements within it.
+	PositionRelative
+
+	// PositionAbsolute takes the element out of the layout of its parent,
+	// and places it by its Inset relative to its nearest positioned ancestor
+	// (or the viewport if there is none)
+	PositionAbsolute
+
+	// PositionFixed takes the element out of the layout of its parent,
+	// and places it by its Inset relative to the viewport, so it does not
+	// move when its parents scroll
+	PositionFixed
+
+	// PositionSticky places the element by the layout of its parent, but
+	// keeps it pinned within its scrolling layout at its Inset when the
+	// layout is scrolled past it, as long as its parent is in view
+	PositionSticky
+
+	PositionsN
+)
+
+var TypePositions = kit.Enums.AddEnumAltLower(PositionsN, kit.NotBitFlag, StylePropProps, "Position")
+
+func (ev Positions) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *Positions) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// IsPositioned returns true if the element is positioned, i.e., not static:
+// absolute elements are placed relative to their nearest positioned ancestor,
+// and only positioned elements are ordered by ZIndex
+func (s *Style) IsPositioned() bool {
+	return s.Position != PositionStatic
+}
+
+// IsOutOfFlow returns true if the element is taken out of the layout of its
+// parent and placed by its Inset instead (absolute or fixed)
+func (s *Style) IsOutOfFlow() bool {
+	return s.Position == PositionAbsolute || s.Position == PositionFixed
+}
+
+// SetInset sets the inset on given side to given value -- see also SetInsetAuto
+func (s *Style) SetInset(side Sides[bool], val units.Value) {
+	if side.Top {
+		s.Inset.Top = val
+		s.InsetSides.Top = true
+	}
+	if side.Right {
+		s.Inset.Right = val
+		s.InsetSides.Right = true
+	}
+	if side.Bottom {
+		s.Inset.Bottom = val
+		s.InsetSides.Bottom = true
+	}
+	if side.Left {
+		s.Inset.Left = val
+		s.InsetSides.Left = true
+	}
+}
+
+// SetInsetAuto resets the inset on all sides to auto
+func (s *Style) SetInsetAuto() {
+	s.Inset.Set()
+	s.InsetSides = Sides[bool]{}
+}
+
+// styleInsetSide sets the inset on one side from a property value,
+// which can be auto
+func styleInsetSide(s *Style, side Sides[bool], key string, val any) {
+	if str, ok := val.(string); ok && strings.TrimSpace(str) == "auto" {
+		if side.Top {
+			s.InsetSides.Top = false
+		}
+		if side.Right {
+			s.InsetSides.Right = false
+		}
+		if side.Bottom {
+			s.InsetSides.Bottom = false
+		}
+		if side.Left {
+			s.InsetSides.Left = false
+		}
+		return
+	}
+	var v units.Value
+	v.SetIFace(val, key)
+	s.SetInset(side, v)
+}
+
 ////////////////////////////////////////////////////////////////////////////////////////
 // Layout Data for actually computing the layout
 
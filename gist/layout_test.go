@@ -0,0 +1,101 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gist
+
+import (
+	"testing"
+
+	"goki.dev/ki/v2/ki"
+)
+
+func TestFlexProps(t *testing.T) {
+	var s, p Style
+	s.Defaults()
+	p.Defaults()
+	props := ki.Props{
+		"flex":            "2 0 100px",
+		"flex-wrap":       "wrap",
+		"justify-content": "space-between",
+		"align-items":     "center",
+		"gap":             "4px 8px",
+	}
+	s.StyleFromProps(&p, props, nil)
+	if s.FlexGrow != 2 || s.FlexShrink != 0 || s.FlexBasis.Val != 100 {
+		t.Errorf("flex: got %v %v %v", s.FlexGrow, s.FlexShrink, s.FlexBasis)
+	}
+	if !s.FlexWrap {
+		t.Errorf("flex-wrap: not set")
+	}
+	if s.JustifyContent != AlignJustify {
+		t.Errorf("justify-content: got %v", s.JustifyContent)
+	}
+	if s.AlignItems != AlignCenter {
+		t.Errorf("align-items: got %v", s.AlignItems)
+	}
+	if s.RowGap.Val != 4 || s.ColumnGap.Val != 8 {
+		t.Errorf("gap: got %v %v", s.RowGap, s.ColumnGap)
+	}
+
+	s.Defaults()
+	if err := s.SetFlex("auto"); err != nil || s.FlexGrow != 1 || s.FlexShrink != 1 || s.FlexBasis.Val >= 0 {
+		t.Errorf("flex auto: got %v %v %v %v", s.FlexGrow, s.FlexShrink, s.FlexBasis, err)
+	}
+	if err := s.SetFlex("1"); err != nil || s.FlexGrow != 1 || s.FlexBasis.Val != 0 {
+		t.Errorf("flex 1: got %v %v %v", s.FlexGrow, s.FlexBasis, err)
+	}
+}
+
+func TestFlexDefaults(t *testing.T) {
+	var s, p, in Style
+	s.Defaults()
+	p.Defaults()
+	in.Defaults()
+	in.StyleFromProps(&p, ki.Props{"justify-content": "initial"}, nil)
+	if s.JustifyContent != AlignFlexStart || s.JustifyContent != in.JustifyContent {
+		t.Errorf("justify-content: default %v, initial %v, want %v", s.JustifyContent, in.JustifyContent, AlignFlexStart)
+	}
+	if s.AlignItems == AlignStretch {
+		t.Errorf("align-items: default is stretch without FlexDefaults")
+	}
+	s.FlexDefaults()
+	if s.AlignItems != AlignStretch {
+		t.Errorf("align-items: flex default %v, want %v", s.AlignItems, AlignStretch)
+	}
+}
+
+func TestPositionProps(t *testing.T) {
+	var s, p Style
+	s.Defaults()
+	p.Defaults()
+	props := ki.Props{
+		"position": "absolute",
+		"right":    "10px",
+		"bottom":   "2em",
+		"z-index":  3,
+	}
+	s.StyleFromProps(&p, props, nil)
+	if s.Position != PositionAbsolute || !s.IsOutOfFlow() {
+		t.Errorf("position: got %v", s.Position)
+	}
+	if s.ZIndex != 3 {
+		t.Errorf("z-index: got %v", s.ZIndex)
+	}
+	want := Sides[bool]{Right: true, Bottom: true}
+	if s.InsetSides != want {
+		t.Errorf("inset sides: got %+v, want %+v", s.InsetSides, want)
+	}
+	if s.Inset.Right.Val != 10 || s.Inset.Bottom.Val != 2 {
+		t.Errorf("inset: got %v", s.Inset)
+	}
+
+	// left follows inset whatever the order of the map
+	want = Sides[bool]{Top: true, Right: true, Bottom: true}
+	for i := 0; i < 20; i++ {
+		s.StyleFromProps(&p, ki.Props{"inset": "0", "left": "auto", "position": "sticky"}, nil)
+		if s.InsetSides != want || s.IsOutOfFlow() || !s.IsPositioned() {
+			t.Fatalf("inset auto: got %+v, %v", s.InsetSides, s.Position)
+		}
+	}
+}
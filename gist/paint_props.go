@@ -67,9 +67,9 @@ func (pc *Paint) StyleFromProps(par *Paint, props ki.Props, ctxt Context) {
 		}
 		if sfunc, ok := StyleFontFuncs[key]; ok {
 			if par != nil {
-				sfunc(&pc.FontStyle, key, val, &par.FontStyle, ctxt)
+				sfunc(&pc.FontStyle.Font, key, val, &par.FontStyle.Font, ctxt)
 			} else {
-				sfunc(&pc.FontStyle, key, val, nil, ctxt)
+				sfunc(&pc.FontStyle.Font, key, val, nil, ctxt)
 			}
 			continue
 		}
@@ -0,0 +1,38 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gist
+
+import (
+	"testing"
+
+	"goki.dev/ki/v2/ki"
+)
+
+func TestPaintFontProps(t *testing.T) {
+	var pc, par Paint
+	pc.Defaults()
+	par.Defaults()
+	par.FontStyle.Family = "serif"
+	props := ki.Props{
+		"opacity":     "0.5",
+		"font-size":   "20px",
+		"font-weight": "bold",
+		"font-family": "inherit",
+	}
+	pc.StyleFromProps(&par, props, nil)
+	fs := &pc.FontStyle.Font
+	if fs.Opacity != 0.5 {
+		t.Errorf("opacity: got %v, want 0.5", fs.Opacity)
+	}
+	if fs.Size.Val != 20 {
+		t.Errorf("font-size: got %v, want 20px", fs.Size)
+	}
+	if fs.Weight != WeightBold {
+		t.Errorf("font-weight: got %v, want bold", fs.Weight)
+	}
+	if fs.Family != "serif" {
+		t.Errorf("font-family: got %q, want serif from parent", fs.Family)
+	}
+}
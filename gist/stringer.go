@@ -1,4 +1,4 @@
-// Code generated by "stringer -output stringer.go -type=BorderStyles,ColorSchemeTypes,ColorSources,FontStyles,FontWeights,FontStretch,TextDecorations,BaselineShifts,FontVariants,Align,Overflow,FillRules,VectorEffects,LineCaps,LineJoins,UnicodeBidi,TextDirections,TextAnchors,WhiteSpaces"; DO NOT EDIT.
+// Code generated by "stringer -output stringer.go -type=BorderStyles,ColorSchemeTypes,ColorSources,FontStyles,FontWeights,FontStretch,TextDecorations,BaselineShifts,FontVariants,Align,Overflow,FillRules,VectorEffects,LineCaps,LineJoins,UnicodeBidi,TextDirections,TextAnchors,WhiteSpaces,Easings,GridTrackTypes,Positions"; DO NOT EDIT.
 
 package gist
 
@@ -496,12 +496,14 @@ func _() {
 	_ = x[AlignTextBottom-12]
 	_ = x[AlignSub-13]
 	_ = x[AlignSuper-14]
-	_ = x[AlignN-15]
+	_ = x[AlignSpaceEvenly-15]
+	_ = x[AlignStretch-16]
+	_ = x[AlignN-17]
 }
 
-const _Align_name = "AlignLeftAlignTopAlignCenterAlignMiddleAlignRightAlignBottomAlignBaselineAlignJustifyAlignSpaceAroundAlignFlexStartAlignFlexEndAlignTextTopAlignTextBottomAlignSubAlignSuperAlignN"
+const _Align_name = "AlignLeftAlignTopAlignCenterAlignMiddleAlignRightAlignBottomAlignBaselineAlignJustifyAlignSpaceAroundAlignFlexStartAlignFlexEndAlignTextTopAlignTextBottomAlignSubAlignSuperAlignSpaceEvenlyAlignStretchAlignN"
 
-var _Align_index = [...]uint8{0, 9, 17, 28, 39, 49, 60, 73, 85, 101, 115, 127, 139, 154, 162, 172, 178}
+var _Align_index = [...]uint8{0, 9, 17, 28, 39, 49, 60, 73, 85, 101, 115, 127, 139, 154, 162, 172, 188, 200, 206}
 
 func (i Align) String() string {
 	if i < 0 || i >= Align(len(_Align_index)-1) {
@@ -536,7 +538,9 @@ var _Align_descMap = map[Align]string{
 	12: ``,
 	13: `align to subscript`,
 	14: `align to superscript`,
-	15: ``,
+	15: `same as CSS space-evenly: equal space between and around elements`,
+	16: `stretch elements to fill the space, as in CSS align-items: stretch`,
+	17: ``,
 }
 
 func (i Align) Desc() string {
@@ -963,3 +967,146 @@ func (i WhiteSpaces) Desc() string {
 	}
 	return "WhiteSpaces(" + strconv.FormatInt(int64(i), 10) + ")"
 }
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[EasingEase-0]
+	_ = x[EasingLinear-1]
+	_ = x[EasingEaseIn-2]
+	_ = x[EasingEaseOut-3]
+	_ = x[EasingEaseInOut-4]
+	_ = x[EasingsN-5]
+}
+
+const _Easings_name = "EasingEaseEasingLinearEasingEaseInEasingEaseOutEasingEaseInOutEasingsN"
+
+var _Easings_index = [...]uint8{0, 10, 22, 34, 47, 62, 70}
+
+func (i Easings) String() string {
+	if i < 0 || i >= Easings(len(_Easings_index)-1) {
+		return "Easings(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Easings_name[_Easings_index[i]:_Easings_index[i+1]]
+}
+
+func (i *Easings) FromString(s string) error {
+	for j := 0; j < len(_Easings_index)-1; j++ {
+		if s == _Easings_name[_Easings_index[j]:_Easings_index[j+1]] {
+			*i = Easings(j)
+			return nil
+		}
+	}
+	return errors.New("String: " + s + " is not a valid option for type: Easings")
+}
+
+var _Easings_descMap = map[Easings]string{
+	0: `EasingEase starts slowly, speeds up, and then ends slowly (the default)`,
+	1: `EasingLinear changes at a constant speed`,
+	2: `EasingEaseIn starts slowly and then speeds up`,
+	3: `EasingEaseOut starts quickly and then slows down`,
+	4: `EasingEaseInOut starts slowly and ends slowly, symmetrically`,
+	5: ``,
+}
+
+func (i Easings) Desc() string {
+	if str, ok := _Easings_descMap[i]; ok {
+		return str
+	}
+	return "Easings(" + strconv.FormatInt(int64(i), 10) + ")"
+}
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[GridTrackAuto-0]
+	_ = x[GridTrackFixed-1]
+	_ = x[GridTrackFr-2]
+	_ = x[GridTrackTypesN-3]
+}
+
+const _GridTrackTypes_name = "GridTrackAutoGridTrackFixedGridTrackFrGridTrackTypesN"
+
+var _GridTrackTypes_index = [...]uint8{0, 13, 27, 38, 53}
+
+func (i GridTrackTypes) String() string {
+	if i < 0 || i >= GridTrackTypes(len(_GridTrackTypes_index)-1) {
+		return "GridTrackTypes(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _GridTrackTypes_name[_GridTrackTypes_index[i]:_GridTrackTypes_index[i+1]]
+}
+
+func (i *GridTrackTypes) FromString(s string) error {
+	for j := 0; j < len(_GridTrackTypes_index)-1; j++ {
+		if s == _GridTrackTypes_name[_GridTrackTypes_index[j]:_GridTrackTypes_index[j+1]] {
+			*i = GridTrackTypes(j)
+			return nil
+		}
+	}
+	return errors.New("String: " + s + " is not a valid option for type: GridTrackTypes")
+}
+
+var _GridTrackTypes_descMap = map[GridTrackTypes]string{
+	0: `GridTrackAuto sizes the track to fit its content, and stretches it if any of its elements stretch (the default, as in CSS auto)`,
+	1: `GridTrackFixed gives the track a fixed size`,
+	2: `GridTrackFr gives the track a fraction of the space remaining after the other tracks are sized, in proportion to its Fr relative to the other fraction tracks (as in CSS fr units), but never less than its content`,
+	3: ``,
+}
+
+func (i GridTrackTypes) Desc() string {
+	if str, ok := _GridTrackTypes_descMap[i]; ok {
+		return str
+	}
+	return "GridTrackTypes(" + strconv.FormatInt(int64(i), 10) + ")"
+}
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[PositionStatic-0]
+	_ = x[PositionRelative-1]
+	_ = x[PositionAbsolute-2]
+	_ = x[PositionFixed-3]
+	_ = x[PositionSticky-4]
+	_ = x[PositionsN-5]
+}
+
+const _Positions_name = "PositionStaticPositionRelativePositionAbsolutePositionFixedPositionStickyPositionsN"
+
+var _Positions_index = [...]uint8{0, 14, 30, 46, 59, 73, 83}
+
+func (i Positions) String() string {
+	if i < 0 || i >= Positions(len(_Positions_index)-1) {
+		return "Positions(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Positions_name[_Positions_index[i]:_Positions_index[i+1]]
+}
+
+func (i *Positions) FromString(s string) error {
+	for j := 0; j < len(_Positions_index)-1; j++ {
+		if s == _Positions_name[_Positions_index[j]:_Positions_index[j+1]] {
+			*i = Positions(j)
+			return nil
+		}
+	}
+	return errors.New("String: " + s + " is not a valid option for type: Positions")
+}
+
+var _Positions_descMap = map[Positions]string{
+	0: `PositionStatic places the element by the layout of its parent (the default)`,
+	1: `PositionRelative places the element by the layout of its parent and then offsets it by its Inset, without affecting the other elements. It is a positioned ancestor for absolute elements within it.`,
+	2: `PositionAbsolute takes the element out of the layout of its parent, and places it by its Inset relative to its nearest positioned ancestor (or the viewport if there is none)`,
+	3: `PositionFixed takes the element out of the layout of its parent, and places it by its Inset relative to the viewport, so it does not move when its parents scroll`,
+	4: `PositionSticky places the element by the layout of its parent, but keeps it pinned within its scrolling layout at its Inset when the layout is scrolled past it, as long as its parent is in view`,
+	5: ``,
+}
+
+func (i Positions) Desc() string {
+	if str, ok := _Positions_descMap[i]; ok {
+		return str
+	}
+	return "Positions(" + strconv.FormatInt(int64(i), 10) + ")"
+}
@@ -4,4 +4,4 @@
 
 package gist
 
-//go:generate stringer -output stringer.go -type=BorderStyles,ColorSchemeTypes,ColorSources,FontStyles,FontWeights,FontStretch,TextDecorations,BaselineShifts,FontVariants,Align,Overflow,FillRules,VectorEffects,LineCaps,LineJoins,UnicodeBidi,TextDirections,TextAnchors,WhiteSpaces
+//go:generate stringer -output stringer.go -type=BorderStyles,ColorSchemeTypes,ColorSources,FontStyles,FontWeights,FontStretch,TextDecorations,BaselineShifts,FontVariants,Align,Overflow,FillRules,VectorEffects,LineCaps,LineJoins,UnicodeBidi,TextDirections,TextAnchors,WhiteSpaces,Easings,GridTrackTypes,Positions
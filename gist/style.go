@@ -56,8 +56,17 @@ type Style struct {
 	// the cursor to switch to upon hovering over the element (inherited)
 	Cursor cursor.Shapes `desc:"the cursor to switch to upon hovering over the element (inherited)"`
 
-	// prop: z-index = ordering factor for rendering depth -- lower numbers rendered first -- sort children according to this factor
-	ZIndex int `xml:"z-index" desc:"prop: z-index = ordering factor for rendering depth -- lower numbers rendered first -- sort children according to this factor"`
+	// prop: z-index = ordering factor for rendering depth of positioned elements -- lower numbers rendered first, and after any static siblings if >= 0, and events go to the highest first
+	ZIndex int `xml:"z-index" desc:"prop: z-index = ordering factor for rendering depth of positioned elements -- lower numbers rendered first, and after any static siblings if >= 0, and events go to the highest first"`
+
+	// prop: position = how the element is positioned: static by the layout of its parent (default), relative to that by its Inset, absolute relative to its nearest positioned ancestor, fixed relative to the viewport, or sticky within its scrolling layout
+	Position Positions `xml:"position" desc:"prop: position = how the element is positioned: static by the layout of its parent (default), relative to that by its Inset, absolute relative to its nearest positioned ancestor, fixed relative to the viewport, or sticky within its scrolling layout"`
+
+	// prop: inset = offsets of a positioned element from the sides of its containing block, set by the top, right, bottom and left properties or the inset shorthand -- only the sides in InsetSides are used, the others are auto
+	Inset SideValues `xml:"inset" desc:"prop: inset = offsets of a positioned element from the sides of its containing block, set by the top, right, bottom and left properties or the inset shorthand -- only the sides in InsetSides are used, the others are auto"`
+
+	// which sides of the Inset have been set -- the others are auto
+	InsetSides Sides[bool] `view:"-" desc:"which sides of the Inset have been set -- the others are auto"`
 
 	// prop: horizontal-align specifies the horizontal alignment of widget elements within a *vertical* layout container (has no effect within horizontal layouts -- use space / stretch elements instead).  For text layout, use text-align. This is not a standard css property.
 	AlignH Align `xml:"horizontal-align" desc:"prop: horizontal-align specifies the horizontal alignment of widget elements within a *vertical* layout container (has no effect within horizontal layouts -- use space / stretch elements instead).  For text layout, use text-align. This is not a standard css property."`
@@ -101,18 +110,48 @@ type Style struct {
 	// prop: columns = number of columns to use in a grid layout -- used as a constraint in layout if individual elements do not specify their row, column positions
 	Columns int `xml:"columns" alt:"grid-cols" desc:"prop: columns = number of columns to use in a grid layout -- used as a constraint in layout if individual elements do not specify their row, column positions"`
 
-	// prop: row = specifies the row that this element should appear within a grid layout
-	Row int `xml:"row" desc:"prop: row = specifies the row that this element should appear within a grid layout"`
+	// prop: row = specifies the row that this element should appear within a grid layout -- -1 (the default) places it automatically
+	Row int `xml:"row" desc:"prop: row = specifies the row that this element should appear within a grid layout -- -1 (the default) places it automatically"`
 
-	// prop: col = specifies the column that this element should appear within a grid layout
-	Col int `xml:"col" desc:"prop: col = specifies the column that this element should appear within a grid layout"`
+	// prop: col = specifies the column that this element should appear within a grid layout -- -1 (the default) places it automatically
+	Col int `xml:"col" desc:"prop: col = specifies the column that this element should appear within a grid layout -- -1 (the default) places it automatically"`
 
-	// prop: row-span = specifies the number of sequential rows that this element should occupy within a grid layout (todo: not currently supported)
-	RowSpan int `xml:"row-span" desc:"prop: row-span = specifies the number of sequential rows that this element should occupy within a grid layout (todo: not currently supported)"`
+	// prop: row-span = specifies the number of sequential rows that this element should occupy within a grid layout (only supported in LayoutGridIrreg)
+	RowSpan int `xml:"row-span" desc:"prop: row-span = specifies the number of sequential rows that this element should occupy within a grid layout (only supported in LayoutGridIrreg)"`
 
 	// prop: col-span = specifies the number of sequential columns that this element should occupy within a grid layout
 	ColSpan int `xml:"col-span" desc:"prop: col-span = specifies the number of sequential columns that this element should occupy within a grid layout"`
 
+	// prop: grid-template-columns = sizes of the column tracks in an irregular grid layout, e.g., 100px 1fr auto -- columns beyond these are auto sized -- also determines the number of columns if set
+	GridTemplateColumns []GridTrack `xml:"grid-template-columns" desc:"prop: grid-template-columns = sizes of the column tracks in an irregular grid layout, e.g., 100px 1fr auto -- columns beyond these are auto sized -- also determines the number of columns if set"`
+
+	// prop: grid-template-rows = sizes of the row tracks in an irregular grid layout, e.g., auto 1fr -- rows beyond these are auto sized
+	GridTemplateRows []GridTrack `xml:"grid-template-rows" desc:"prop: grid-template-rows = sizes of the row tracks in an irregular grid layout, e.g., auto 1fr -- rows beyond these are auto sized"`
+
+	// prop: column-gap = space between columns in an irregular grid layout, or between elements across a flex layout -- uses the layout spacing if 0
+	ColumnGap units.Value `xml:"column-gap" desc:"prop: column-gap = space between columns in an irregular grid layout, or between elements across a flex layout -- uses the layout spacing if 0"`
+
+	// prop: row-gap = space between rows in an irregular grid layout, or between elements down a flex layout -- uses the layout spacing if 0
+	RowGap units.Value `xml:"row-gap" desc:"prop: row-gap = space between rows in an irregular grid layout, or between elements down a flex layout -- uses the layout spacing if 0"`
+
+	// prop: flex-grow = how much this element grows, relative to the other elements, to fill any extra space along a flex layout -- 0 (the default) means it does not grow
+	FlexGrow float32 `xml:"flex-grow" desc:"prop: flex-grow = how much this element grows, relative to the other elements, to fill any extra space along a flex layout -- 0 (the default) means it does not grow"`
+
+	// prop: flex-shrink = how much this element shrinks, relative to the other elements (weighted by their basis), when there is not enough space along a flex layout -- it never shrinks below its minimum size -- default is 1
+	FlexShrink float32 `xml:"flex-shrink" desc:"prop: flex-shrink = how much this element shrinks, relative to the other elements (weighted by their basis), when there is not enough space along a flex layout -- it never shrinks below its minimum size -- default is 1"`
+
+	// prop: flex-basis = initial size of this element along a flex layout, before growing or shrinking -- negative (the default, or auto) means use its preferred size
+	FlexBasis units.Value `xml:"flex-basis" desc:"prop: flex-basis = initial size of this element along a flex layout, before growing or shrinking -- negative (the default, or auto) means use its preferred size"`
+
+	// prop: flex-wrap = whether the elements of a flex layout wrap onto multiple lines when they do not fit
+	FlexWrap bool `xml:"flex-wrap" desc:"prop: flex-wrap = whether the elements of a flex layout wrap onto multiple lines when they do not fit"`
+
+	// prop: justify-content = how the elements of a flex layout are distributed along each line when they do not fill it: flex-start (the default), center, flex-end, space-between (Justify), space-around or space-evenly
+	JustifyContent Align `xml:"justify-content" desc:"prop: justify-content = how the elements of a flex layout are distributed along each line when they do not fill it: flex-start (the default), center, flex-end, space-between (Justify), space-around or space-evenly"`
+
+	// prop: align-items = how the elements of a flex layout are aligned across each line: stretch (the default for flex layouts), flex-start, center or flex-end
+	AlignItems Align `xml:"align-items" desc:"prop: align-items = how the elements of a flex layout are aligned across each line: stretch (the default for flex layouts), flex-start, center or flex-end"`
+
 	// prop: scrollbar-width = width of a layout scrollbar
 	ScrollBarWidth units.Value `xml:"scrollbar-width" desc:"prop: scrollbar-width = width of a layout scrollbar"`
 
@@ -140,6 +179,12 @@ type Style struct {
 	// prop: pointer-events = does this element respond to pointer events -- default is true
 	PointerEvents bool `xml:"pointer-events" desc:"prop: pointer-events = does this element respond to pointer events -- default is true"`
 
+	// prop: transition = how changes in the values of properties are animated, e.g., background-color 0.2s ease-in-out -- see TransitionProps for the properties that can be transitioned
+	Transitions []Transition `xml:"transition" desc:"prop: transition = how changes in the values of properties are animated, e.g., background-color 0.2s ease-in-out -- see TransitionProps for the properties that can be transitioned"`
+
+	// prop: animation = keyframe animation of properties, e.g., pulse 1s infinite alternate -- the keyframes are added with AddKeyframes or @keyframes in a StyleSheet
+	Animation Animation `xml:"animation" desc:"prop: animation = keyframe animation of properties, e.g., pulse 1s infinite alternate -- the keyframes are added with AddKeyframes or @keyframes in a StyleSheet"`
+
 	// units context -- parameters necessary for anchoring relative units
 	UnContext units.Context `xml:"-" desc:"units context -- parameters necessary for anchoring relative units"`
 
@@ -166,8 +211,6 @@ func (s *Style) Defaults() {
 	s.Text.Defaults()
 }
 
-// todo: Animation
-
 // Clear -- no floating elements
 
 // Clip -- clip images
@@ -180,8 +223,6 @@ func (s *Style) Defaults() {
 
 // visibility -- support more than just hidden  inherit:"true"
 
-// transition -- animation of hover, etc
-
 // RebuildDefaultStyles is a global state var used by Prefs to trigger rebuild
 // of all the default styles, which are otherwise compiled and not updated
 var RebuildDefaultStyles bool
@@ -6,6 +6,7 @@ package gist
 
 import (
 	"log"
+	"strings"
 
 	"goki.dev/colors"
 	"goki.dev/gi/v2/units"
@@ -51,8 +52,17 @@ type StyleFunc func(obj any, key string, val any, par any, ctxt Context)
 func (s *Style) StyleFromProps(par *Style, props ki.Props, ctxt Context) {
 	// pr := prof.Start("StyleFromProps")
 	// defer pr.End()
+	// the inset shorthand is set first, so that the top, right, bottom and
+	// left props override it regardless of the (random) order of the map
+	if val, ok := props["inset"]; ok {
+		if par != nil {
+			StyleLayoutFuncs["inset"](s, "inset", val, par, ctxt)
+		} else {
+			StyleLayoutFuncs["inset"](s, "inset", val, nil, ctxt)
+		}
+	}
 	for key, val := range props {
-		if len(key) == 0 {
+		if len(key) == 0 || key == "inset" {
 			continue
 		}
 		if key[0] == '#' || key[0] == '.' || key[0] == ':' || key[0] == '_' {
@@ -203,6 +213,56 @@ var StyleStyleFuncs = map[string]StyleFunc{
 		}
 		fs.BackgroundColor.SetIFace(val, ctxt, key)
 	},
+	"transition": func(obj any, key string, val any, par any, ctxt Context) {
+		s := obj.(*Style)
+		if inh, init := StyleInhInit(val, par); inh || init {
+			if inh {
+				s.Transitions = par.(*Style).Transitions
+			} else if init {
+				s.Transitions = nil
+			}
+			return
+		}
+		switch vt := val.(type) {
+		case string:
+			trs, err := ParseTransitions(vt)
+			if err != nil {
+				StyleSetError(key, val)
+				return
+			}
+			s.Transitions = trs
+		case Transition:
+			s.Transitions = []Transition{vt}
+		case []Transition:
+			s.Transitions = vt
+		default:
+			StyleSetError(key, val)
+		}
+	},
+	"animation": func(obj any, key string, val any, par any, ctxt Context) {
+		s := obj.(*Style)
+		if inh, init := StyleInhInit(val, par); inh || init {
+			if inh {
+				s.Animation = par.(*Style).Animation
+			} else if init {
+				s.Animation = Animation{}
+			}
+			return
+		}
+		switch vt := val.(type) {
+		case string:
+			an, err := ParseAnimation(vt)
+			if err != nil {
+				StyleSetError(key, val)
+				return
+			}
+			s.Animation = an
+		case Animation:
+			s.Animation = vt
+		default:
+			StyleSetError(key, val)
+		}
+	},
 }
 
 /////////////////////////////////////////////////////////////////////////////////
@@ -226,6 +286,104 @@ var StyleLayoutFuncs = map[string]StyleFunc{
 			s.ZIndex = int(iv)
 		}
 	},
+	"position": func(obj any, key string, val any, par any, ctxt Context) {
+		s := obj.(*Style)
+		if inh, init := StyleInhInit(val, par); inh || init {
+			if inh {
+				s.Position = par.(*Style).Position
+			} else if init {
+				s.Position = PositionStatic
+			}
+			return
+		}
+		switch vt := val.(type) {
+		case string:
+			if err := kit.Enums.SetAnyEnumIfaceFromString(&s.Position, vt); err != nil {
+				StyleSetError(key, val)
+			}
+		case Positions:
+			s.Position = vt
+		default:
+			if iv, ok := kit.ToInt(val); ok {
+				s.Position = Positions(iv)
+			} else {
+				StyleSetError(key, val)
+			}
+		}
+	},
+	"inset": func(obj any, key string, val any, par any, ctxt Context) {
+		s := obj.(*Style)
+		if inh, init := StyleInhInit(val, par); inh || init {
+			if inh {
+				s.Inset = par.(*Style).Inset
+				s.InsetSides = par.(*Style).InsetSides
+			} else if init {
+				s.SetInsetAuto()
+			}
+			return
+		}
+		if str, ok := val.(string); ok && strings.TrimSpace(str) == "auto" {
+			s.SetInsetAuto()
+			return
+		}
+		if err := s.Inset.SetAny(val); err != nil {
+			StyleSetError(key, val)
+			return
+		}
+		s.InsetSides.SetAll(true)
+	},
+	"top": func(obj any, key string, val any, par any, ctxt Context) {
+		s := obj.(*Style)
+		if inh, init := StyleInhInit(val, par); inh || init {
+			if inh {
+				s.Inset.Top = par.(*Style).Inset.Top
+				s.InsetSides.Top = par.(*Style).InsetSides.Top
+			} else if init {
+				s.InsetSides.Top = false
+			}
+			return
+		}
+		styleInsetSide(s, Sides[bool]{Top: true}, key, val)
+	},
+	"right": func(obj any, key string, val any, par any, ctxt Context) {
+		s := obj.(*Style)
+		if inh, init := StyleInhInit(val, par); inh || init {
+			if inh {
+				s.Inset.Right = par.(*Style).Inset.Right
+				s.InsetSides.Right = par.(*Style).InsetSides.Right
+			} else if init {
+				s.InsetSides.Right = false
+			}
+			return
+		}
+		styleInsetSide(s, Sides[bool]{Right: true}, key, val)
+	},
+	"bottom": func(obj any, key string, val any, par any, ctxt Context) {
+		s := obj.(*Style)
+		if inh, init := StyleInhInit(val, par); inh || init {
+			if inh {
+				s.Inset.Bottom = par.(*Style).Inset.Bottom
+				s.InsetSides.Bottom = par.(*Style).InsetSides.Bottom
+			} else if init {
+				s.InsetSides.Bottom = false
+			}
+			return
+		}
+		styleInsetSide(s, Sides[bool]{Bottom: true}, key, val)
+	},
+	"left": func(obj any, key string, val any, par any, ctxt Context) {
+		s := obj.(*Style)
+		if inh, init := StyleInhInit(val, par); inh || init {
+			if inh {
+				s.Inset.Left = par.(*Style).Inset.Left
+				s.InsetSides.Left = par.(*Style).InsetSides.Left
+			} else if init {
+				s.InsetSides.Left = false
+			}
+			return
+		}
+		styleInsetSide(s, Sides[bool]{Left: true}, key, val)
+	},
 	"horizontal-align": func(obj any, key string, val any, par any, ctxt Context) {
 		s := obj.(*Style)
 		if inh, init := StyleInhInit(val, par); inh || init {
@@ -435,7 +593,7 @@ var StyleLayoutFuncs = map[string]StyleFunc{
 			if inh {
 				s.Row = par.(*Style).Row
 			} else if init {
-				s.Row = 0
+				s.Row = -1
 			}
 			return
 		}
@@ -449,7 +607,7 @@ var StyleLayoutFuncs = map[string]StyleFunc{
 			if inh {
 				s.Col = par.(*Style).Col
 			} else if init {
-				s.Col = 0
+				s.Col = -1
 			}
 			return
 		}
@@ -485,6 +643,178 @@ var StyleLayoutFuncs = map[string]StyleFunc{
 			s.ColSpan = int(iv)
 		}
 	},
+	"grid-template-columns": func(obj any, key string, val any, par any, ctxt Context) {
+		s := obj.(*Style)
+		if inh, init := StyleInhInit(val, par); inh || init {
+			if inh {
+				s.GridTemplateColumns = par.(*Style).GridTemplateColumns
+			} else if init {
+				s.GridTemplateColumns = nil
+			}
+			return
+		}
+		styleGridTracks(&s.GridTemplateColumns, key, val)
+	},
+	"grid-template-rows": func(obj any, key string, val any, par any, ctxt Context) {
+		s := obj.(*Style)
+		if inh, init := StyleInhInit(val, par); inh || init {
+			if inh {
+				s.GridTemplateRows = par.(*Style).GridTemplateRows
+			} else if init {
+				s.GridTemplateRows = nil
+			}
+			return
+		}
+		styleGridTracks(&s.GridTemplateRows, key, val)
+	},
+	"column-gap": func(obj any, key string, val any, par any, ctxt Context) {
+		s := obj.(*Style)
+		if inh, init := StyleInhInit(val, par); inh || init {
+			if inh {
+				s.ColumnGap = par.(*Style).ColumnGap
+			} else if init {
+				s.ColumnGap = units.Value{}
+			}
+			return
+		}
+		s.ColumnGap.SetIFace(val, key)
+	},
+	"row-gap": func(obj any, key string, val any, par any, ctxt Context) {
+		s := obj.(*Style)
+		if inh, init := StyleInhInit(val, par); inh || init {
+			if inh {
+				s.RowGap = par.(*Style).RowGap
+			} else if init {
+				s.RowGap = units.Value{}
+			}
+			return
+		}
+		s.RowGap.SetIFace(val, key)
+	},
+	"gap": func(obj any, key string, val any, par any, ctxt Context) {
+		s := obj.(*Style)
+		if inh, init := StyleInhInit(val, par); inh || init {
+			if inh {
+				s.RowGap = par.(*Style).RowGap
+				s.ColumnGap = par.(*Style).ColumnGap
+			} else if init {
+				s.RowGap = units.Value{}
+				s.ColumnGap = units.Value{}
+			}
+			return
+		}
+		if str, ok := val.(string); ok { // row-gap column-gap
+			if flds := strings.Fields(str); len(flds) == 2 {
+				s.RowGap.SetIFace(flds[0], key)
+				s.ColumnGap.SetIFace(flds[1], key)
+				return
+			}
+		}
+		s.RowGap.SetIFace(val, key)
+		s.ColumnGap = s.RowGap
+	},
+	"flex-grow": func(obj any, key string, val any, par any, ctxt Context) {
+		s := obj.(*Style)
+		if inh, init := StyleInhInit(val, par); inh || init {
+			if inh {
+				s.FlexGrow = par.(*Style).FlexGrow
+			} else if init {
+				s.FlexGrow = 0
+			}
+			return
+		}
+		if iv, ok := kit.ToFloat32(val); ok {
+			s.FlexGrow = iv
+		}
+	},
+	"flex-shrink": func(obj any, key string, val any, par any, ctxt Context) {
+		s := obj.(*Style)
+		if inh, init := StyleInhInit(val, par); inh || init {
+			if inh {
+				s.FlexShrink = par.(*Style).FlexShrink
+			} else if init {
+				s.FlexShrink = 1
+			}
+			return
+		}
+		if iv, ok := kit.ToFloat32(val); ok {
+			s.FlexShrink = iv
+		}
+	},
+	"flex-basis": func(obj any, key string, val any, par any, ctxt Context) {
+		s := obj.(*Style)
+		if inh, init := StyleInhInit(val, par); inh || init {
+			if inh {
+				s.FlexBasis = par.(*Style).FlexBasis
+			} else if init {
+				s.FlexBasis.SetPx(-1)
+			}
+			return
+		}
+		if str, ok := val.(string); ok && (str == "auto" || str == "content") {
+			s.FlexBasis.SetPx(-1)
+			return
+		}
+		s.FlexBasis.SetIFace(val, key)
+	},
+	"flex": func(obj any, key string, val any, par any, ctxt Context) {
+		s := obj.(*Style)
+		if inh, init := StyleInhInit(val, par); inh || init {
+			if inh {
+				ps := par.(*Style)
+				s.FlexGrow, s.FlexShrink, s.FlexBasis = ps.FlexGrow, ps.FlexShrink, ps.FlexBasis
+			} else if init {
+				s.FlexGrow, s.FlexShrink = 0, 1
+				s.FlexBasis.SetPx(-1)
+			}
+			return
+		}
+		if err := s.SetFlex(kit.ToString(val)); err != nil {
+			StyleSetError(key, val)
+		}
+	},
+	"flex-wrap": func(obj any, key string, val any, par any, ctxt Context) {
+		s := obj.(*Style)
+		if inh, init := StyleInhInit(val, par); inh || init {
+			if inh {
+				s.FlexWrap = par.(*Style).FlexWrap
+			} else if init {
+				s.FlexWrap = false
+			}
+			return
+		}
+		if str, ok := val.(string); ok {
+			s.FlexWrap = str == "wrap" || str == "wrap-reverse"
+			return
+		}
+		if bv, ok := kit.ToBool(val); ok {
+			s.FlexWrap = bv
+		}
+	},
+	"justify-content": func(obj any, key string, val any, par any, ctxt Context) {
+		s := obj.(*Style)
+		if inh, init := StyleInhInit(val, par); inh || init {
+			if inh {
+				s.JustifyContent = par.(*Style).JustifyContent
+			} else if init {
+				s.JustifyContent = AlignFlexStart
+			}
+			return
+		}
+		styleAlignCSS(&s.JustifyContent, key, val)
+	},
+	"align-items": func(obj any, key string, val any, par any, ctxt Context) {
+		s := obj.(*Style)
+		if inh, init := StyleInhInit(val, par); inh || init {
+			if inh {
+				s.AlignItems = par.(*Style).AlignItems
+			} else if init {
+				s.AlignItems = AlignStretch
+			}
+			return
+		}
+		styleAlignCSS(&s.AlignItems, key, val)
+	},
 	"scrollbar-width": func(obj any, key string, val any, par any, ctxt Context) {
 		s := obj.(*Style)
 		if inh, init := StyleInhInit(val, par); inh || init {
@@ -0,0 +1,536 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package gitest provides a Driver for writing automated tests of gi GUIs,
+by finding widgets in a Window, sending synthetic mouse and keyboard
+input to them, waiting for the Window to process that input, and then
+checking the resulting widget state.
+
+All input is sent to the Window's oswin.Window event queue, exactly as
+the OS driver would send it, so it goes through the full Window event
+processing pipeline (FilterEvent, HiPriorityEvents, EventMgr), on the
+Window's own event loop.  Tests run within the headless oswin driver (see
+gimain.MainHeadless), by calling Main from TestMain, so that each Test
+function runs on its own test goroutine while the driver runs the app.
+The Driver is then created and used directly on the test goroutine, where
+its failures can stop the test via Fatalf:
+
+	func TestMain(m *testing.M) {
+		gitest.Main(m)
+	}
+
+	func TestName(t *testing.T) {
+		win := buildMyGui()
+		win.GoStartEventLoop()
+		defer win.Close()
+		td := gitest.New(win, t)
+		td.Click("ok-button")
+		td.Type("hello")
+		td.KeyFun(gi.KeyFunAccept)
+		td.AssertText("name-field", "hello")
+	}
+
+testing.T.Fatalf (and thus a Driver with TB set) must only be called from
+the test goroutine, so do not pass t to a Driver used within a function
+passed to gimain.MainHeadless, or any other goroutine.  Instead, use a
+Driver with a nil TB, which panics on failure, or report failures with
+t.Errorf and send the results back on a channel that the test goroutine
+waits on:
+
+	done := make(chan string)
+	go func() {
+		td := gitest.New(win, nil)
+		td.Type("hello")
+		done <- td.Text("name-field")
+	}()
+	if got := <-done; got != "hello" {
+		t.Errorf("name-field: got %q, want %q", got, "hello")
+	}
+
+Widgets can be specified either by their full ki path (anything with a /),
+or by their Name, which is found by a depth-first search of the Window,
+including any current popup.
+
+For visual regression testing, RenderWidget and RenderSVG render content at
+a fixed size and DPI, and AssertGolden compares the result to a stored
+golden PNG image, writing a diff image on failure.  Run the tests with
+-update-golden to (re)generate the golden images.  See Main for running
+all tests in a package within the headless driver.
+*/
+package gitest
+
+import (
+	"fmt"
+	"image"
+	"strings"
+	"testing"
+	"time"
+
+	"goki.dev/gi/v2/gi"
+	"goki.dev/gi/v2/oswin"
+	"goki.dev/gi/v2/oswin/key"
+	"goki.dev/gi/v2/oswin/mouse"
+	"goki.dev/ki/v2/ki"
+)
+
+// DefaultTimeout is the default time to wait for the Window to go idle
+var DefaultTimeout = 10 * time.Second
+
+// Driver sends synthetic input to a Window and checks the resulting state.
+// Methods that find widgets or wait report failures to TB (via Fatalf),
+// if it is set, and otherwise panic, so tests can be written as a simple
+// sequence of steps without explicit error checks.
+type Driver struct {
+
+	// the window being driven
+	Win *gi.Window `desc:"the window being driven"`
+
+	// test to report failures to -- if nil, failures cause a panic
+	TB testing.TB `desc:"test to report failures to -- if nil, failures cause a panic"`
+
+	// maximum time to wait for the window to process events
+	Timeout time.Duration `desc:"maximum time to wait for the window to process events"`
+
+	// if true, WaitIdle is automatically called after each input action, so that widget state is up-to-date at the start of the next step
+	AutoWait bool `desc:"if true, WaitIdle is automatically called after each input action, so that widget state is up-to-date at the start of the next step"`
+
+	// current mouse position, in window coordinates
+	MousePos image.Point `desc:"current mouse position, in window coordinates"`
+
+	// current key modifiers, used for all mouse events -- see SetModifiers
+	Mods int32 `desc:"current key modifiers, used for all mouse events -- see SetModifiers"`
+
+	// time of last mouse event sent, to keep event times strictly increasing
+	lastTime time.Time
+}
+
+// New returns a new Driver for given window, reporting failures to given
+// test, which can be nil, with AutoWait on.  A non-nil test must only be
+// used on the test goroutine, as failures call its Fatalf.
+func New(win *gi.Window, tb testing.TB) *Driver {
+	return &Driver{Win: win, TB: tb, Timeout: DefaultTimeout, AutoWait: true}
+}
+
+// Failf reports a failure, with given format and args
+func (d *Driver) Failf(format string, args ...any) {
+	if d.TB != nil {
+		d.TB.Helper()
+		d.TB.Fatalf(format, args...)
+		return
+	}
+	panic(fmt.Sprintf("gitest: "+format, args...))
+}
+
+// WaitIdle waits until the window has processed all events sent to it,
+// failing if it takes longer than Timeout.
+func (d *Driver) WaitIdle() {
+	if d.TB != nil {
+		d.TB.Helper()
+	}
+	if !d.Win.WaitIdle(d.Timeout) {
+		d.Failf("window %v did not go idle within %v", d.Win.Nm, d.Timeout)
+	}
+}
+
+// send sends given event to the window queue, waiting for it to be
+// processed if AutoWait is on
+func (d *Driver) send(ev oswin.Event, wait bool) {
+	d.Win.OSWin.Send(ev)
+	if wait && d.AutoWait {
+		d.WaitIdle()
+	}
+}
+
+// SetModifiers sets the key modifiers used for subsequent mouse events,
+// e.g., key.Shift for extending a selection.  Call with no args to clear.
+func (d *Driver) SetModifiers(mods ...key.Modifiers) {
+	d.Mods = 0
+	key.SetModifierBits(&d.Mods, mods...)
+}
+
+/////////////////////////////////////////////////////////////////////
+//   Finding widgets
+
+// Find returns the node at given ki path (if it contains a /), or with
+// given name, searching the current popup first and then the main window.
+// Returns nil if not found.
+func (d *Driver) Find(pathOrName string) ki.Ki {
+	if strings.Contains(pathOrName, "/") {
+		return d.Win.FindPath(pathOrName)
+	}
+	var roots []ki.Ki
+	d.Win.PopMu.RLock()
+	if d.Win.Popup != nil {
+		roots = append(roots, d.Win.Popup)
+	}
+	d.Win.PopMu.RUnlock()
+	roots = append(roots, d.Win.This())
+	for _, rt := range roots {
+		var fk ki.Ki
+		rt.FuncDownMeFirst(0, nil, func(k ki.Ki, level int, data any) bool {
+			if k.Name() == pathOrName {
+				fk = k
+				return ki.Break
+			}
+			return ki.Continue
+		})
+		if fk != nil {
+			return fk
+		}
+	}
+	return nil
+}
+
+// Node returns the gi node at given path or name (see Find),
+// failing if not found.
+func (d *Driver) Node(pathOrName string) *gi.NodeBase {
+	if d.TB != nil {
+		d.TB.Helper()
+	}
+	k := d.Find(pathOrName)
+	if k == nil {
+		d.Failf("node %q not found in window %v", pathOrName, d.Win.Nm)
+		return nil
+	}
+	gii, ok := k.(gi.Node)
+	if !ok {
+		d.Failf("node %q is not a gi.Node: %T", pathOrName, k)
+		return nil
+	}
+	return gii.AsGiNode()
+}
+
+// Center returns the center of the WinBBox of the node at given path
+// or name (see Find), failing if not found or not currently visible.
+func (d *Driver) Center(pathOrName string) image.Point {
+	if d.TB != nil {
+		d.TB.Helper()
+	}
+	nb := d.Node(pathOrName)
+	if nb == nil {
+		return image.Point{}
+	}
+	nb.BBoxMu.RLock()
+	bb := nb.WinBBox
+	nb.BBoxMu.RUnlock()
+	if bb.Empty() {
+		d.Failf("node %q is not visible: empty WinBBox", pathOrName)
+	}
+	return bb.Min.Add(bb.Max).Div(2)
+}
+
+/////////////////////////////////////////////////////////////////////
+//   Mouse
+
+// nextTime returns the time to use for the next event, which is always
+// after the last one
+func (d *Driver) nextTime() time.Time {
+	now := time.Now()
+	if !now.After(d.lastTime) {
+		now = d.lastTime.Add(time.Microsecond)
+	}
+	d.lastTime = now
+	return now
+}
+
+// mouseEvent returns a new mouse event at current position
+func (d *Driver) mouseEvent(but mouse.Buttons, act mouse.Actions) *mouse.Event {
+	ev := &mouse.Event{
+		Where:     d.MousePos,
+		Button:    but,
+		Action:    act,
+		Modifiers: d.Mods,
+	}
+	ev.GenTime.SetTime(d.nextTime())
+	return ev
+}
+
+// MoveTo moves the mouse to given window position, generating a
+// mouse.MoveEvent (e.g., for hover and enter / exit events).
+func (d *Driver) MoveTo(pos image.Point) {
+	ev := &mouse.MoveEvent{
+		Event: mouse.Event{
+			Where:     pos,
+			Button:    mouse.NoButton,
+			Action:    mouse.Move,
+			Modifiers: d.Mods,
+		},
+		From: d.MousePos,
+	}
+	ev.GenTime.SetTime(d.nextTime())
+	d.MousePos = pos
+	d.send(ev, true)
+}
+
+// Press presses given mouse button at the current mouse position
+func (d *Driver) Press(but mouse.Buttons) {
+	d.send(d.mouseEvent(but, mouse.Press), true)
+}
+
+// Release releases given mouse button at the current mouse position
+func (d *Driver) Release(but mouse.Buttons) {
+	d.send(d.mouseEvent(but, mouse.Release), true)
+}
+
+// ClickAt moves the mouse to given window position and then
+// presses and releases the left mouse button.
+func (d *Driver) ClickAt(pos image.Point) {
+	d.MoveTo(pos)
+	d.Press(mouse.Left)
+	d.Release(mouse.Left)
+}
+
+// Click clicks the left mouse button at the center of the node
+// at given path or name (see Find).
+func (d *Driver) Click(pathOrName string) {
+	if d.TB != nil {
+		d.TB.Helper()
+	}
+	d.ClickAt(d.Center(pathOrName))
+}
+
+// RightClick clicks the right mouse button at the center of the node
+// at given path or name (see Find), e.g., for context menus.
+func (d *Driver) RightClick(pathOrName string) {
+	if d.TB != nil {
+		d.TB.Helper()
+	}
+	d.MoveTo(d.Center(pathOrName))
+	d.Press(mouse.Right)
+	d.Release(mouse.Right)
+}
+
+// DoubleClickAt moves the mouse to given window position and sends a
+// double-click, using the same sequence of events as the OS driver:
+// Press, Release, DoubleClick, Release.
+func (d *Driver) DoubleClickAt(pos image.Point) {
+	d.MoveTo(pos)
+	d.send(d.mouseEvent(mouse.Left, mouse.Press), false)
+	d.send(d.mouseEvent(mouse.Left, mouse.Release), false)
+	d.send(d.mouseEvent(mouse.Left, mouse.DoubleClick), false)
+	d.send(d.mouseEvent(mouse.Left, mouse.Release), true)
+}
+
+// DoubleClick double-clicks at the center of the node at given
+// path or name (see Find).
+func (d *Driver) DoubleClick(pathOrName string) {
+	if d.TB != nil {
+		d.TB.Helper()
+	}
+	d.DoubleClickAt(d.Center(pathOrName))
+}
+
+// DragFromTo drags with the left mouse button from given start position
+// to given end position, in given number of steps (minimum 1),
+// using the same events as the OS driver: Press, then a mouse.DragEvent
+// for each step, then Release.
+func (d *Driver) DragFromTo(start, end image.Point, steps int) {
+	if steps < 1 {
+		steps = 1
+	}
+	d.MoveTo(start)
+	d.Press(mouse.Left)
+	for i := 1; i <= steps; i++ {
+		pos := start.Add(end.Sub(start).Mul(i).Div(steps))
+		ev := &mouse.DragEvent{
+			MoveEvent: mouse.MoveEvent{
+				Event: mouse.Event{
+					Where:     pos,
+					Button:    mouse.Left,
+					Action:    mouse.Drag,
+					Modifiers: d.Mods,
+				},
+				From: d.MousePos,
+			},
+			Start: start,
+		}
+		ev.GenTime.SetTime(d.nextTime())
+		d.MousePos = pos
+		d.send(ev, true)
+	}
+	d.Release(mouse.Left)
+}
+
+// Drag drags from the center of the node at given path or name (see Find)
+// to the center of the other one, e.g., for drag-and-drop.
+func (d *Driver) Drag(fromPathOrName, toPathOrName string) {
+	if d.TB != nil {
+		d.TB.Helper()
+	}
+	d.DragFromTo(d.Center(fromPathOrName), d.Center(toPathOrName), 4)
+}
+
+// Scroll sends a scroll wheel event with given delta at the center
+// of the node at given path or name (see Find).
+func (d *Driver) Scroll(pathOrName string, delta image.Point) {
+	if d.TB != nil {
+		d.TB.Helper()
+	}
+	d.MoveTo(d.Center(pathOrName))
+	ev := &mouse.ScrollEvent{
+		Event: mouse.Event{
+			Where:     d.MousePos,
+			Action:    mouse.Scroll,
+			Modifiers: d.Mods,
+		},
+		Delta: delta,
+	}
+	ev.GenTime.SetTime(d.nextTime())
+	d.send(ev, true)
+}
+
+/////////////////////////////////////////////////////////////////////
+//   Keyboard
+
+// KeyChord sends a key chord, which can be a single rune or a named key
+// with modifiers, in the same format as KeyMap, e.g., "Control+ReturnEnter"
+// (see gi.NewKeyChordEvent).  Keyboard events go to the current focus.
+func (d *Driver) KeyChord(chord key.Chord) {
+	if d.TB != nil {
+		d.TB.Helper()
+	}
+	ke, err := gi.NewKeyChordEvent(chord)
+	if err != nil {
+		d.Failf("invalid key chord %q: %v", chord, err)
+		return
+	}
+	d.send(ke, true)
+}
+
+// KeyFun sends the key chord for given key function,
+// from the current ActiveKeyMap.
+func (d *Driver) KeyFun(kf gi.KeyFuns) {
+	if d.TB != nil {
+		d.TB.Helper()
+	}
+	chord := gi.ActiveKeyMap.ChordForFun(kf)
+	if chord == "" {
+		d.Failf("no key chord for key function %v in active keymap", kf)
+		return
+	}
+	d.KeyChord(chord)
+}
+
+// Type types given string, sending a key chord for each rune,
+// and then waits for all of them to be processed.
+func (d *Driver) Type(str string) {
+	if d.TB != nil {
+		d.TB.Helper()
+	}
+	for _, r := range str {
+		var ke *key.ChordEvent
+		var err error
+		switch r {
+		case '\n':
+			ke, err = gi.NewKeyChordEvent("ReturnEnter")
+		case '\t':
+			ke, err = gi.NewKeyChordEvent("Tab")
+		default:
+			ke, err = gi.NewKeyChordEvent(key.Chord(string(r)))
+		}
+		if err != nil {
+			d.Failf("cannot type rune %q: %v", r, err)
+			return
+		}
+		d.send(ke, false)
+	}
+	if d.AutoWait {
+		d.WaitIdle()
+	}
+}
+
+/////////////////////////////////////////////////////////////////////
+//   State and Assertions
+
+// Focus returns the node that currently has keyboard focus, if any
+func (d *Driver) Focus() ki.Ki {
+	return d.Win.EventMgr.CurFocus()
+}
+
+// Text returns the text of the node at given path or name (see Find):
+// the current Text() of a TextField, or the Text of a Label or button.
+// Fails if the node does not have any text.
+func (d *Driver) Text(pathOrName string) string {
+	if d.TB != nil {
+		d.TB.Helper()
+	}
+	k := d.Find(pathOrName)
+	switch w := k.(type) {
+	case nil:
+		d.Failf("node %q not found in window %v", pathOrName, d.Win.Nm)
+	case *gi.TextField:
+		return w.Text()
+	case *gi.Label:
+		return w.Text
+	case gi.ButtonWidget:
+		return w.AsButtonBase().Text
+	default:
+		d.Failf("node %q of type %T does not have text", pathOrName, k)
+	}
+	return ""
+}
+
+// AssertFocused checks that the node at given path or name (see Find)
+// has keyboard focus.
+func (d *Driver) AssertFocused(pathOrName string) {
+	if d.TB != nil {
+		d.TB.Helper()
+	}
+	k := d.Find(pathOrName)
+	if k == nil {
+		d.Failf("node %q not found in window %v", pathOrName, d.Win.Nm)
+		return
+	}
+	if foc := d.Focus(); foc != k {
+		fnm := "<nil>"
+		if foc != nil {
+			fnm = foc.Path()
+		}
+		d.Failf("node %q does not have focus -- focus is: %v", pathOrName, fnm)
+	}
+}
+
+// AssertText checks that the text of the node at given path or name
+// (see Text) is equal to given string.
+func (d *Driver) AssertText(pathOrName, text string) {
+	if d.TB != nil {
+		d.TB.Helper()
+	}
+	if txt := d.Text(pathOrName); txt != text {
+		d.Failf("node %q text is %q, expected %q", pathOrName, txt, text)
+	}
+}
+
+// AssertChecked checks that the button at given path or name (see Find),
+// e.g., a CheckBox, has the given checked state.
+func (d *Driver) AssertChecked(pathOrName string, checked bool) {
+	if d.TB != nil {
+		d.TB.Helper()
+	}
+	k := d.Find(pathOrName)
+	bw, ok := k.(gi.ButtonWidget)
+	if !ok {
+		d.Failf("node %q is not a button: %T", pathOrName, k)
+		return
+	}
+	if bw.AsButtonBase().IsChecked() != checked {
+		d.Failf("node %q checked is %v, expected %v", pathOrName, !checked, checked)
+	}
+}
+
+// AssertDisabled checks that the node at given path or name (see Find)
+// has the given disabled state.
+func (d *Driver) AssertDisabled(pathOrName string, disabled bool) {
+	if d.TB != nil {
+		d.TB.Helper()
+	}
+	nb := d.Node(pathOrName)
+	if nb == nil {
+		return
+	}
+	if nb.IsDisabled() != disabled {
+		d.Failf("node %q disabled is %v, expected %v", pathOrName, !disabled, disabled)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitest
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"goki.dev/gi/v2/gi"
+	"goki.dev/ki/v2/ki"
+)
+
+// driverTestWin returns a new running window with a button, a checkbox and
+// two text fields, counting the clicks on the button in clicks
+func driverTestWin(t *testing.T, clicks *int32) *gi.Window {
+	t.Helper()
+	gi.Init()
+	nm := "driver-" + t.Name()
+	win := gi.NewMainWindow(nm, nm, 300, 200)
+	vp := win.WinViewport2D()
+	updt := vp.UpdateStart()
+	mfr := win.SetMainFrame()
+	bt := gi.AddNewButton(mfr, "ok")
+	bt.SetText("OK")
+	bt.ButtonSig.Connect(win.This(), func(recv, send ki.Ki, sig int64, data any) {
+		if sig == int64(gi.ButtonClicked) {
+			atomic.AddInt32(clicks, 1)
+		}
+	})
+	cb := gi.AddNewCheckBox(mfr, "check")
+	cb.SetText("Check")
+	gi.AddNewTextField(mfr, "first")
+	gi.AddNewTextField(mfr, "second")
+	vp.UpdateEndNoSig(updt)
+	win.GoStartEventLoop()
+	return win
+}
+
+func TestDriverClick(t *testing.T) {
+	var clicks int32
+	win := driverTestWin(t, &clicks)
+	defer win.Close()
+	td := New(win, t)
+	td.WaitIdle()
+
+	td.Click("ok")
+	if n := atomic.LoadInt32(&clicks); n != 1 {
+		t.Errorf("%d clicks after Click, want 1", n)
+	}
+	td.AssertText("ok", "OK")
+
+	td.AssertChecked("check", false)
+	td.Click("check")
+	td.AssertChecked("check", true)
+	td.Click("check")
+	td.AssertChecked("check", false)
+
+	td.Click("second")
+	td.AssertFocused("second")
+	td.Click(td.Find("first").Path())
+	td.AssertFocused("first")
+}
+
+func TestDriverType(t *testing.T) {
+	var clicks int32
+	win := driverTestWin(t, &clicks)
+	defer win.Close()
+	td := New(win, t)
+	td.WaitIdle()
+
+	td.Click("first")
+	td.Type("Hello, world")
+	td.AssertText("first", "Hello, world")
+	td.AssertText("second", "")
+
+	// tab moves the focus on to the next field
+	td.Type("\tAbc 123")
+	td.AssertFocused("second")
+	td.AssertText("first", "Hello, world")
+	td.AssertText("second", "Abc 123")
+}
+
+func TestDriverKeyFun(t *testing.T) {
+	var clicks int32
+	win := driverTestWin(t, &clicks)
+	defer win.Close()
+	td := New(win, t)
+	td.WaitIdle()
+
+	td.Click("first")
+	td.Type("abc")
+	td.KeyFun(gi.KeyFunMoveLeft)
+	td.Type("x")
+	td.AssertText("first", "abxc")
+	td.KeyFun(gi.KeyFunHome)
+	td.KeyFun(gi.KeyFunDelete)
+	td.AssertText("first", "bxc")
+	td.KeyFun(gi.KeyFunFocusNext)
+	td.AssertFocused("second")
+}
+
+func TestDriverWaitIdle(t *testing.T) {
+	var clicks int32
+	win := driverTestWin(t, &clicks)
+	defer win.Close()
+	td := New(win, t)
+	td.WaitIdle()
+	td.Click("first")
+
+	// without AutoWait, the input is only known to be done after WaitIdle
+	td.AutoWait = false
+	for i := 0; i < 5; i++ {
+		td.Click("ok")
+	}
+	td.Type("waited")
+	td.WaitIdle()
+	if n := atomic.LoadInt32(&clicks); n != 5 {
+		t.Errorf("%d clicks after WaitIdle, want 5", n)
+	}
+	td.AssertText("first", "waited")
+}
+
+func TestDriverFail(t *testing.T) {
+	var clicks int32
+	win := driverTestWin(t, &clicks)
+	defer win.Close()
+	ft := &failTB{TB: t}
+	td := New(win, ft)
+	td.WaitIdle()
+
+	tests := []struct {
+		name string
+		step func()
+	}{
+		{"missing node", func() { td.Click("nothing") }},
+		{"no text", func() { td.Text(win.Viewport.Nm) }},
+		{"wrong text", func() { td.AssertText("ok", "Cancel") }},
+		{"not a button", func() { td.AssertChecked("first", true) }},
+		{"not focused", func() { td.AssertFocused("second") }},
+	}
+	for _, tt := range tests {
+		ft.msgs = nil
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			tt.step()
+		}()
+		<-done
+		if len(ft.msgs) != 1 {
+			t.Errorf("%s: got failures %q, want one", tt.name, ft.msgs)
+		}
+	}
+
+	// without a test, failures panic
+	td.TB = nil
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("no panic for missing node without a test")
+			}
+		}()
+		td.Node("nothing")
+	}()
+}
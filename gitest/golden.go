@@ -0,0 +1,258 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitest
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"goki.dev/colors"
+	"goki.dev/gi/v2/gi"
+	"goki.dev/gi/v2/gimain"
+	"goki.dev/gi/v2/oswin"
+	"goki.dev/gi/v2/svg"
+	"goki.dev/mat32/v2"
+)
+
+var (
+	// UpdateGolden causes AssertGolden to write the current image as the new
+	// golden file, instead of comparing against it.  Set from the
+	// -update-golden test flag, or the GOGI_UPDATE_GOLDEN=1 environment variable.
+	UpdateGolden = flag.Bool("update-golden", os.Getenv("GOGI_UPDATE_GOLDEN") == "1", "update golden image files instead of comparing against them")
+
+	// GoldenDir is the directory where golden images are stored,
+	// relative to the test package directory.
+	GoldenDir = filepath.Join("testdata", "golden")
+
+	// GoldenTolerance is the default maximum difference allowed in any one
+	// color channel (0-255) of a pixel, before the pixel counts as different.
+	// It absorbs the off-by-one rounding of alpha blending and color space
+	// conversion that differs across CPUs and compilers (FMA, SIMD), which
+	// otherwise changes many pixels of any antialiased image by 1 or 2.
+	GoldenTolerance = 2
+
+	// GoldenMaxDiff is the default maximum number of differing pixels
+	// allowed for an image to still match the golden image.  Antialiasing
+	// and font rasterization can change a few edge pixels by more than the
+	// tolerance across platforms, so a small budget is allowed; any real
+	// change in the rendering, such as a shape or glyph moving by a pixel,
+	// changes many more pixels than this.
+	GoldenMaxDiff = 16
+)
+
+// Main runs the tests in given testing.M within the headless driver, so that
+// tests can create and drive windows, and then exits with the result.
+// Call it from TestMain in any test package that uses windows:
+//
+//	func TestMain(m *testing.M) {
+//		gitest.Main(m)
+//	}
+func Main(m *testing.M) {
+	code := 0
+	gimain.MainHeadless(func() {
+		code = m.Run()
+	})
+	os.Exit(code)
+}
+
+/////////////////////////////////////////////////////////////////////
+//   Rendering
+
+// renderWinN counts render windows, to give each a unique name
+var renderWinN int32
+
+// RenderWidget renders the widget tree built by given config function in a
+// new window of given size in raw pixels, at given logical DPI, and returns
+// the resulting image, after which the window is closed.  The config function
+// is called within an update block on the main window Viewport, and typically
+// calls win.SetMainFrame or adds widgets directly to it.  Requires a running
+// app, typically headless (see Main), and must not be called on the main thread.
+func RenderWidget(size image.Point, dpi float32, config func(vp *gi.Viewport2D)) (*image.RGBA, error) {
+	if oswin.TheApp == nil {
+		return nil, errors.New("gitest.RenderWidget: no app running -- see gitest.Main")
+	}
+	nm := fmt.Sprintf("gitest-render-%d", atomic.AddInt32(&renderWinN, 1))
+	win := gi.NewMainWindow(nm, nm, size.X, size.Y)
+	if win == nil {
+		return nil, errors.New("gitest.RenderWidget: could not create window")
+	}
+	win.OSWin.SetLogicalDPI(dpi)
+	win.OSWin.SetSize(size)
+	vp := win.WinViewport2D()
+	updt := vp.UpdateStart()
+	config(vp)
+	vp.UpdateEndNoSig(updt)
+	win.GoStartEventLoop()
+	defer win.Close()
+	if !win.WaitIdle(DefaultTimeout) {
+		return nil, fmt.Errorf("gitest.RenderWidget: window did not go idle within %v", DefaultTimeout)
+	}
+	img := win.CaptureImage()
+	if img == nil {
+		return nil, errors.New("gitest.RenderWidget: window did not render")
+	}
+	return CropImage(img, size), nil
+}
+
+// RenderSVG renders given svg file, scaled to fit within given size in raw
+// pixels, at given logical DPI, on a white background (see RenderWidget).
+// The svg is rendered as an svg.Icon of the given size, which normalizes
+// its ViewBox to that size.  Files without a
+// viewBox use their width and height as the ViewBox.
+func RenderSVG(fname string, size image.Point, dpi float32) (*image.RGBA, error) {
+	var err error
+	img, rerr := RenderWidget(size, dpi, func(vp *gi.Viewport2D) {
+		ic := svg.AddNewIcon(vp, "svg")
+		ic.Fill = true
+		ic.Style.BackgroundColor.SetColor(colors.White)
+		ic.Resize(size)
+		err = ic.OpenXML(gi.FileName(fname))
+		if err == nil && ic.ViewBox.Size == mat32.Vec2Zero {
+			ic.ViewBox.Size.Set(ic.PhysWidth.Dots, ic.PhysHeight.Dots)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return img, rerr
+}
+
+// CropImage returns a copy of given image restricted to the given size,
+// starting from its upper-left corner.
+func CropImage(img image.Image, size image.Point) *image.RGBA {
+	r := image.Rectangle{Max: size}.Intersect(img.Bounds().Sub(img.Bounds().Min))
+	cp := image.NewRGBA(r)
+	draw.Draw(cp, r, img, img.Bounds().Min, draw.Src)
+	return cp
+}
+
+/////////////////////////////////////////////////////////////////////
+//   Comparison
+
+// CompareImages compares given image to the golden image, pixel by pixel,
+// counting the pixels where any color channel differs by more than tol.
+// Returns that count and a diff image, which shows the golden image faded,
+// with differing pixels in red.  Images of different sizes have all pixels
+// outside of their common region counted as different.
+func CompareImages(golden, img image.Image, tol int) (ndiff int, diff *image.RGBA) {
+	gb := golden.Bounds()
+	ib := img.Bounds()
+	sz := image.Point{max(gb.Dx(), ib.Dx()), max(gb.Dy(), ib.Dy())}
+	diff = image.NewRGBA(image.Rectangle{Max: sz})
+	red := color.RGBA{255, 0, 0, 255}
+	for y := 0; y < sz.Y; y++ {
+		for x := 0; x < sz.X; x++ {
+			gp := image.Point{x, y}.Add(gb.Min)
+			ip := image.Point{x, y}.Add(ib.Min)
+			if !gp.In(gb) || !ip.In(ib) {
+				ndiff++
+				diff.SetRGBA(x, y, red)
+				continue
+			}
+			gc := color.RGBAModel.Convert(golden.At(gp.X, gp.Y)).(color.RGBA)
+			ic := color.RGBAModel.Convert(img.At(ip.X, ip.Y)).(color.RGBA)
+			if chanDiff(gc.R, ic.R) > tol || chanDiff(gc.G, ic.G) > tol || chanDiff(gc.B, ic.B) > tol || chanDiff(gc.A, ic.A) > tol {
+				ndiff++
+				diff.SetRGBA(x, y, red)
+				continue
+			}
+			// faded golden pixel, for context
+			diff.SetRGBA(x, y, color.RGBA{fade(gc.R), fade(gc.G), fade(gc.B), 255})
+		}
+	}
+	return
+}
+
+// chanDiff returns the absolute difference between two color channels
+func chanDiff(a, b uint8) int {
+	d := int(a) - int(b)
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// fade moves given color channel 3/4 of the way to white
+func fade(c uint8) uint8 {
+	return uint8(191 + int(c)/4)
+}
+
+// AssertGolden compares given image to the golden image with given name in
+// GoldenDir (name.png), using GoldenTolerance and GoldenMaxDiff.
+// See AssertGoldenTol for details.
+func AssertGolden(tb testing.TB, name string, img image.Image) {
+	tb.Helper()
+	AssertGoldenTol(tb, name, img, GoldenTolerance, GoldenMaxDiff)
+}
+
+// AssertGoldenTol compares given image to the golden image with given name
+// in GoldenDir (name.png), with given per-channel tolerance and maximum
+// number of differing pixels (see CompareImages).  On failure, the image
+// and a diff image are saved as name.failed.png and name.diff.png next to
+// the golden image, and the test is marked as failed.  If UpdateGolden is
+// set, the image is saved as the new golden image instead.  A missing
+// golden image is a failure, so that a golden image that was not committed
+// is not silently recreated from the current (possibly wrong) rendering.
+func AssertGoldenTol(tb testing.TB, name string, img image.Image, tol, maxDiff int) {
+	tb.Helper()
+	gfn := filepath.Join(GoldenDir, name+".png")
+	if *UpdateGolden {
+		if err := WritePNG(gfn, img); err != nil {
+			tb.Fatalf("gitest: error writing golden image: %v", err)
+		}
+		return
+	}
+	golden, err := ReadPNG(gfn)
+	if errors.Is(err, os.ErrNotExist) {
+		tb.Fatalf("gitest: golden image %v does not exist -- run with -update-golden to create it", gfn)
+	}
+	if err != nil {
+		tb.Fatalf("gitest: error reading golden image: %v", err)
+	}
+	ndiff, diff := CompareImages(golden, img, tol)
+	ffn := filepath.Join(GoldenDir, name+".failed.png")
+	dfn := filepath.Join(GoldenDir, name+".diff.png")
+	if ndiff <= maxDiff {
+		os.Remove(ffn) // remove any stale results from prior failures
+		os.Remove(dfn)
+		return
+	}
+	WritePNG(ffn, img)
+	WritePNG(dfn, diff)
+	tb.Errorf("gitest: image %q does not match golden image: %d pixels differ (max %d, tolerance %d) -- see %v; run with -update-golden to accept", name, ndiff, maxDiff, tol, dfn)
+}
+
+// ReadPNG reads a PNG image from given file
+func ReadPNG(fname string) (image.Image, error) {
+	f, err := os.Open(fname)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return png.Decode(f)
+}
+
+// WritePNG writes given image to given file in PNG format,
+// making the directory if it does not exist.
+func WritePNG(fname string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(fname), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(fname)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return png.Encode(f, img)
+}
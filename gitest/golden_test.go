@@ -0,0 +1,182 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitest
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"goki.dev/colors"
+	"goki.dev/gi/v2/gi"
+	"goki.dev/gi/v2/gist"
+	"goki.dev/gi/v2/units"
+)
+
+func TestMain(m *testing.M) {
+	Main(m)
+}
+
+func TestCompareImages(t *testing.T) {
+	solid := func(sz image.Point, c color.RGBA) *image.RGBA {
+		img := image.NewRGBA(image.Rectangle{Max: sz})
+		for y := 0; y < sz.Y; y++ {
+			for x := 0; x < sz.X; x++ {
+				img.SetRGBA(x, y, c)
+			}
+		}
+		return img
+	}
+	gray := color.RGBA{100, 100, 100, 255}
+	tests := []struct {
+		name  string
+		img   *image.RGBA
+		tol   int
+		ndiff int
+	}{
+		{"same", solid(image.Pt(4, 3), gray), 0, 0},
+		{"within tol", solid(image.Pt(4, 3), color.RGBA{102, 98, 100, 255}), 2, 0},
+		{"beyond tol", solid(image.Pt(4, 3), color.RGBA{103, 100, 100, 255}), 2, 12},
+		{"larger", solid(image.Pt(5, 3), gray), 0, 3},
+		{"smaller", solid(image.Pt(4, 2), gray), 0, 4},
+	}
+	golden := solid(image.Pt(4, 3), gray)
+	for _, tt := range tests {
+		ndiff, diff := CompareImages(golden, tt.img, tt.tol)
+		if ndiff != tt.ndiff {
+			t.Errorf("%s: ndiff = %d, want %d", tt.name, ndiff, tt.ndiff)
+		}
+		gb, ib := golden.Bounds().Size(), tt.img.Bounds().Size()
+		want := image.Pt(max(gb.X, ib.X), max(gb.Y, ib.Y))
+		if sz := diff.Bounds().Size(); sz != want {
+			t.Errorf("%s: diff size = %v, want %v", tt.name, sz, want)
+		}
+	}
+	// an offset image compares from its own origin
+	sub := golden.SubImage(image.Rect(1, 1, 4, 3))
+	if ndiff, _ := CompareImages(solid(image.Pt(3, 2), gray), sub, 0); ndiff != 0 {
+		t.Errorf("offset: ndiff = %d, want 0", ndiff)
+	}
+}
+
+// failTB records failures, stopping at Fatalf like testing.T does
+type failTB struct {
+	testing.TB
+	msgs []string
+}
+
+func (ft *failTB) Helper() {}
+
+func (ft *failTB) Errorf(format string, args ...any) {
+	ft.msgs = append(ft.msgs, fmt.Sprintf(format, args...))
+}
+
+func (ft *failTB) Fatalf(format string, args ...any) {
+	ft.Errorf(format, args...)
+	runtime.Goexit()
+}
+
+func TestAssertGoldenMissing(t *testing.T) {
+	ft := &failTB{TB: t}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		AssertGolden(ft, "does-not-exist", image.NewRGBA(image.Rect(0, 0, 2, 2)))
+	}()
+	<-done
+	if len(ft.msgs) != 1 || !strings.Contains(ft.msgs[0], "-update-golden") {
+		t.Errorf("missing golden image: got failures %q, want one asking for -update-golden", ft.msgs)
+	}
+	if _, err := os.Stat(filepath.Join(GoldenDir, "does-not-exist.png")); err == nil {
+		t.Errorf("missing golden image was created")
+	}
+}
+
+func TestGoldenText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{"plain", "The quick brown fox jumps over the lazy dog"},
+		{"styled", "<b>bold</b> <i>italic</i> <u>underline</u> x<sup>2</sup> H<sub>2</sub>O"},
+		{"wrapped", "This is a longer label that wraps onto multiple lines within the fixed width of the window."},
+	}
+	for _, tt := range tests {
+		img, err := RenderWidget(image.Pt(240, 80), 96, func(vp *gi.Viewport2D) {
+			mfr := vp.Win.SetMainFrame()
+			mfr.AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
+				s.BackgroundColor.SetColor(colors.White)
+			})
+			lb := gi.AddNewLabel(mfr, "label", tt.text)
+			lb.AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
+				s.Font.Family = "Go"
+				s.Font.Size.SetPt(12)
+				s.Color = colors.Black
+				s.Text.WhiteSpace = gist.WhiteSpaceNormal
+				s.Width.SetPx(230)
+			})
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		AssertGolden(t, "text-"+tt.name, img)
+	}
+}
+
+func TestGoldenBoxShadow(t *testing.T) {
+	tests := []struct {
+		name   string
+		shadow gist.Shadow
+	}{
+		{"offset", gist.Shadow{HOffset: units.Px(4), VOffset: units.Px(4), Color: colors.Black}},
+		{"blur", gist.Shadow{HOffset: units.Px(2), VOffset: units.Px(2), Blur: units.Px(8), Color: colors.Black}},
+		{"spread", gist.Shadow{Blur: units.Px(4), Spread: units.Px(4), Color: color.RGBA{0, 0, 128, 255}}},
+		{"inset", gist.Shadow{HOffset: units.Px(3), VOffset: units.Px(3), Blur: units.Px(4), Color: colors.Black, Inset: true}},
+	}
+	for _, tt := range tests {
+		img, err := RenderWidget(image.Pt(120, 120), 96, func(vp *gi.Viewport2D) {
+			mfr := vp.Win.SetMainFrame()
+			mfr.AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
+				s.BackgroundColor.SetColor(colors.White)
+				s.Padding.Set(units.Px(24))
+			})
+			fr := gi.AddNewFrame(mfr, "box", gi.LayoutVert)
+			fr.AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
+				s.Width.SetPx(64)
+				s.Height.SetPx(64)
+				s.BackgroundColor.SetColor(color.RGBA{230, 230, 230, 255})
+				s.Border.Radius = gist.BorderRadiusMedium
+				s.BoxShadow = []gist.Shadow{tt.shadow}
+			})
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		AssertGolden(t, "shadow-"+tt.name, img)
+	}
+}
+
+func TestGoldenSVG(t *testing.T) {
+	fnms, err := filepath.Glob(filepath.Join("..", "examples", "svg", "svgs", "*.svg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fnms) == 0 {
+		t.Fatal("no svg files found in examples/svg/svgs")
+	}
+	for _, fn := range fnms {
+		img, err := RenderSVG(fn, image.Pt(128, 128), 96)
+		if err != nil {
+			t.Errorf("%v: %v", fn, err)
+			continue
+		}
+		AssertGolden(t, "svg-"+strings.TrimSuffix(filepath.Base(fn), ".svg"), img)
+	}
+}
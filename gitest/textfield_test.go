@@ -0,0 +1,50 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitest
+
+import (
+	"testing"
+
+	"goki.dev/gi/v2/gi"
+	"goki.dev/gi/v2/oswin"
+	"goki.dev/pi/v2/filecat"
+)
+
+func TestTextFieldMaskCut(t *testing.T) {
+	gi.Init()
+	win := gi.NewMainWindow("tf-mask", "tf-mask", 300, 100)
+	vp := win.WinViewport2D()
+	updt := vp.UpdateStart()
+	mfr := win.SetMainFrame()
+	tf := gi.AddNewTextField(mfr, "date")
+	tf.Mask = "99/99/9999"
+	vp.UpdateEndNoSig(updt)
+	win.GoStartEventLoop()
+	defer win.Close()
+	td := New(win, t)
+	td.WaitIdle()
+
+	td.Click("date")
+	td.Type("12312023")
+	td.AssertText("date", "12/31/2023")
+
+	// cut the day: the year moves back into the slots of the mask
+	tf.SelectStart, tf.SelectEnd = 3, 6
+	tf.CursorPos = 6
+	td.KeyFun(gi.KeyFunCut)
+	td.AssertText("date", "12/20/23")
+	cb := oswin.TheApp.ClipBoard(win.OSWin).Read([]string{filecat.TextPlain})
+	if cb == nil || cb.Text(filecat.TextPlain) != "31/" {
+		t.Errorf("clipboard %v, want 31/", cb)
+	}
+	if tf.CursorPos != 3 {
+		t.Errorf("cursor at %d after cut, want 3", tf.CursorPos)
+	}
+
+	// delete skips the literal after the cursor
+	tf.CursorPos = 2
+	td.KeyFun(gi.KeyFunDelete)
+	td.AssertText("date", "12/02/3")
+}
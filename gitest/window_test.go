@@ -0,0 +1,64 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitest
+
+import (
+	"testing"
+	"time"
+
+	"goki.dev/gi/v2/gi"
+	"goki.dev/gi/v2/oswin/key"
+	"goki.dev/ki/v2/ki"
+)
+
+func TestKeySeqStaleTimeout(t *testing.T) {
+	defer func(ms int) { gi.KeySeqTimeoutMSec = ms }(gi.KeySeqTimeoutMSec)
+	gi.Init()
+	win := gi.NewMainWindow("key-seq", "key-seq", 300, 100)
+	vp := win.WinViewport2D()
+	updt := vp.UpdateStart()
+	mfr := win.SetMainFrame()
+	// the timeout of the prefix set by the first button fires while the
+	// second one sleeps, so its event is only processed after the second
+	// prefix is set
+	seqs := []struct {
+		name  string
+		seq   key.Chord
+		ms    int
+		sleep time.Duration
+	}{
+		{"first", "Control+X", 200, 0},
+		{"second", "Control+C", 60000, 500 * time.Millisecond},
+	}
+	for _, sq := range seqs {
+		sq := sq
+		bt := gi.AddNewButton(mfr, sq.name)
+		bt.SetText(sq.name)
+		bt.ButtonSig.Connect(win.This(), func(recv, send ki.Ki, sig int64, data any) {
+			if sig != int64(gi.ButtonClicked) {
+				return
+			}
+			time.Sleep(sq.sleep)
+			gi.KeySeqTimeoutMSec = sq.ms
+			win.SetKeySeqPrefix(sq.seq)
+		})
+	}
+	vp.UpdateEndNoSig(updt)
+	win.GoStartEventLoop()
+	defer win.Close()
+	td := New(win, t)
+	td.WaitIdle()
+
+	td.Click("first")
+	td.Click("second")
+	td.WaitIdle()
+	em := &win.EventMgr
+	em.TimerMu.Lock()
+	pfx := em.KeySeqPrefix
+	em.TimerMu.Unlock()
+	if pfx != "Control+C" {
+		t.Errorf("prefix %q after the stale timeout, want %q", pfx, "Control+C")
+	}
+}
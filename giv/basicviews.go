@@ -1030,7 +1030,10 @@ func (vv *NilValueView) ConfigWidget(widg gi.Node2D) {
 
 var DefaultTimeFormat = "2006-01-02 15:04:05 MST"
 
-// TimeValueView presents a text field for a time
+// TimeValueView presents a text field for a time, with a trailing action
+// that opens a calendar date and time picker dialog (see TimeViewDialog).
+// The min and max tags, if present, constrain the time, and are parsed
+// using ParseTimeTag, e.g., min:"2020-01-01"
 type TimeValueView struct {
 	ValueViewBase
 }
@@ -1054,6 +1057,38 @@ func (vv *TimeValueView) TimeVal() *time.Time {
 	return nil
 }
 
+// MinMax returns the min and max times from the min and max tags, if
+// present -- zero times otherwise
+func (vv *TimeValueView) MinMax() (min, max time.Time) {
+	if mintag, ok := vv.Tag("min"); ok {
+		if tm, err := ParseTimeTag(mintag); err == nil {
+			min = tm
+		} else {
+			log.Println(err)
+		}
+	}
+	if maxtag, ok := vv.Tag("max"); ok {
+		if tm, err := ParseTimeTag(maxtag); err == nil {
+			max = tm
+		} else {
+			log.Println(err)
+		}
+	}
+	return
+}
+
+// SetTime sets the time value, constrained to the min and max tags
+func (vv *TimeValueView) SetTime(nt time.Time) {
+	tm := vv.TimeVal()
+	if tm == nil {
+		return
+	}
+	min, max := vv.MinMax()
+	*tm = ClampTime(nt, min, max)
+	vv.ViewSig.Emit(vv.This(), 0, nil)
+	vv.UpdateWidget()
+}
+
 func (vv *TimeValueView) UpdateWidget() {
 	if vv.Widget == nil {
 		return
@@ -1073,6 +1108,12 @@ func (vv *TimeValueView) ConfigWidget(widg gi.Node2D) {
 	tf.AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
 		tf.Style.MinWidth.SetCh(float32(len(DefaultTimeFormat) + 2))
 	})
+	tf.TrailingIcon = icons.CalendarMonth
+	tf.TrailingIconSig.ConnectOnly(vv.This(), func(recv, send ki.Ki, sig int64, data any) {
+		vvv, _ := recv.Embed(TypeTimeValueView).(*TimeValueView)
+		tf := vvv.Widget.(*gi.TextField)
+		vvv.Activate(tf.ViewportSafe(), nil, nil)
+	})
 	tf.TextFieldSig.ConnectOnly(vv.This(), func(recv, send ki.Ki, sig int64, data any) {
 		if sig == int64(gi.TextFieldDone) || sig == int64(gi.TextFieldDeFocused) {
 			vvv, _ := recv.Embed(TypeTimeValueView).(*TimeValueView)
@@ -1081,12 +1122,35 @@ func (vv *TimeValueView) ConfigWidget(widg gi.Node2D) {
 			if err != nil {
 				log.Println(err)
 			} else {
-				tm := vvv.TimeVal()
-				*tm = nt
-				vvv.ViewSig.Emit(vvv.This(), 0, nil)
-				vvv.UpdateWidget()
+				vvv.SetTime(nt)
 			}
 		}
 	})
 	vv.UpdateWidget()
 }
+
+func (vv *TimeValueView) HasAction() bool {
+	return true
+}
+
+func (vv *TimeValueView) Activate(vp *gi.Viewport2D, dlgRecv ki.Ki, dlgFunc ki.RecvFunc) {
+	if vv.IsInactive() {
+		return
+	}
+	tm := vv.TimeVal()
+	if tm == nil {
+		return
+	}
+	desc, _ := vv.Tag("desc")
+	min, max := vv.MinMax()
+	TimeViewDialog(vp, *tm, min, max, DlgOpts{Title: "Select Date and Time", Prompt: desc, TmpSave: vv.TmpSave},
+		vv.This(), func(recv, send ki.Ki, sig int64, data any) {
+			if sig == int64(gi.DialogAccepted) {
+				ddlg := send.Embed(gi.TypeDialog).(*gi.Dialog)
+				vv.SetTime(TimeViewDialogValue(ddlg))
+			}
+			if dlgRecv != nil && dlgFunc != nil {
+				dlgFunc(dlgRecv, send, sig, data)
+			}
+		})
+}
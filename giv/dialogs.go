@@ -156,6 +156,14 @@ func StructViewDialog(avp *gi.Viewport2D, stru any, opts DlgOpts, recv ki.Ki, dl
 	sv.ViewPath = opts.ViewPath
 	sv.TmpSave = opts.TmpSave
 	sv.SetStruct(stru)
+	if sv.HasValids && !opts.Inactive {
+		dlg.ValidFunc = sv.Validate
+		sv.ViewSig.Connect(dlg.This(), func(recv, send ki.Ki, sig int64, data any) {
+			ddlg := recv.Embed(gi.TypeDialog).(*gi.Dialog)
+			ddlg.UpdateValid()
+		})
+		dlg.UpdateValid()
+	}
 	if recv != nil && dlgFunc != nil {
 		dlg.DialogSig.Connect(recv, dlgFunc)
 	}
@@ -351,6 +359,14 @@ func TableViewDialog(avp *gi.Viewport2D, slcOfStru any, opts DlgOpts, styleFunc
 		sv.SetDisabled()
 	}
 	sv.SetSlice(slcOfStru)
+	if sv.HasValids && !opts.Inactive {
+		dlg.ValidFunc = sv.Validate
+		sv.ViewSig.Connect(dlg.This(), func(recv, send ki.Ki, sig int64, data any) {
+			ddlg := recv.Embed(gi.TypeDialog).(*gi.Dialog)
+			ddlg.UpdateValid()
+		})
+		dlg.UpdateValid()
+	}
 
 	if recv != nil && dlgFunc != nil {
 		dlg.DialogSig.Connect(recv, dlgFunc)
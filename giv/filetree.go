@@ -1946,7 +1946,7 @@ func (ftv *FileTreeView) KeyInput(kt *key.ChordEvent) {
 	if gi.KeyEventTrace {
 		fmt.Printf("TreeView KeyInput: %v\n", ftv.Path())
 	}
-	kf := gi.KeyFun(kt.Chord())
+	kf := gi.KeyFunFor(ftv.This(), kt.Chord())
 	selMode := mouse.SelectModeBits(kt.Modifiers)
 
 	if selMode == mouse.SelectOne {
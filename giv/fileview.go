@@ -831,7 +831,7 @@ func (fv *FileView) KeyInput(kt *key.ChordEvent) {
 	if gi.KeyEventTrace {
 		fmt.Printf("FileView KeyInput: %v\n", fv.Path())
 	}
-	kf := gi.KeyFun(kt.Chord())
+	kf := gi.KeyFunFor(fv.This(), kt.Chord())
 	switch kf {
 	case gi.KeyFunJump, gi.KeyFunWordLeft:
 		kt.SetProcessed()
@@ -6,6 +6,7 @@ package giv
 
 import (
 	"reflect"
+	"time"
 
 	"goki.dev/gi/v2/gi"
 	"goki.dev/gi/v2/gist"
@@ -67,7 +68,11 @@ func (vv *KeyChordValueView) HasAction() bool {
 
 // KeyChordEdit is a label widget that shows a key chord string, and, when in
 // focus (after being clicked) will update to whatever key chord is typed --
-// used for representing and editing key chords.
+// used for representing and editing key chords.  Chords typed in quick
+// succession (within gi.KeySeqTimeoutMSec of each other) are recorded as a
+// multi-key sequence, e.g., "Control+X Control+S", until the sequence is
+// ended by EndSeq or Clear (both in the context menu), or by losing focus.
+// KeyChordSig is emitted after each chord.
 type KeyChordEdit struct {
 	gi.Label
 
@@ -76,6 +81,9 @@ type KeyChordEdit struct {
 
 	// [view: -] signal -- only one event, when chord is updated from key input
 	KeyChordSig ki.Signal `json:"-" xml:"-" view:"-" desc:"signal -- only one event, when chord is updated from key input"`
+
+	// time of the last chord typed, for recording multi-key sequences
+	lastChord time.Time
 }
 
 var TypeKeyChordEdit = kit.Types.AddType(&KeyChordEdit{}, KeyChordEditProps)
@@ -115,11 +123,15 @@ func (kc *KeyChordEdit) ChordUpdated() {
 }
 
 func (kc *KeyChordEdit) MakeContextMenu(m *gi.Menu) {
+	m.AddAction(gi.ActOpts{Label: "End Sequence"},
+		kc, func(recv, send ki.Ki, sig int64, data any) {
+			kcc := recv.Embed(TypeKeyChordEdit).(*KeyChordEdit)
+			kcc.EndSeq()
+		})
 	m.AddAction(gi.ActOpts{Label: "Clear"},
 		kc, func(recv, send ki.Ki, sig int64, data any) {
 			kcc := recv.Embed(TypeKeyChordEdit).(*KeyChordEdit)
-			kcc.SetText("")
-			kcc.ChordUpdated()
+			kcc.Clear()
 		})
 }
 
@@ -152,13 +164,48 @@ func (kc *KeyChordEdit) KeyChordEvent() {
 		if kcc.HasFocus() && kcc.FocusActive {
 			kt := d.(*key.ChordEvent)
 			kt.SetProcessed()
-			kcc.SetText(string(kt.Chord())) // that's easy!
-			oswin.TheApp.ClipBoard(kc.ParentWindow().OSWin).Write(mimedata.NewText(string(kt.Chord())))
-			kcc.ChordUpdated()
+			kcc.RecordChord(kt.Chord())
+			oswin.TheApp.ClipBoard(kc.ParentWindow().OSWin).Write(mimedata.NewText(kcc.Text))
+			kcc.UpdateSig()
 		}
 	})
 }
 
+// RecordChord records a newly typed chord, and emits KeyChordSig: if it
+// follows the previous one within gi.KeySeqTimeoutMSec, and the sequence
+// has not been ended (see EndSeq), it is appended to form a multi-key
+// sequence, and otherwise it replaces the current chord
+func (kc *KeyChordEdit) RecordChord(ch key.Chord) {
+	now := time.Now()
+	seq := ch
+	if kc.Text != "" && !kc.lastChord.IsZero() && now.Sub(kc.lastChord) < time.Duration(gi.KeySeqTimeoutMSec)*time.Millisecond {
+		seq = key.Seq(key.Chord(kc.Text), ch)
+	}
+	kc.lastChord = now
+	kc.SetText(string(seq))
+	kc.ChordUpdated()
+}
+
+// EndSeq ends the multi-key sequence being recorded, so that the next
+// chord typed replaces the current chord
+func (kc *KeyChordEdit) EndSeq() {
+	kc.lastChord = time.Time{}
+}
+
+// Clear clears the chord, ending any sequence being recorded, and emits
+// KeyChordSig
+func (kc *KeyChordEdit) Clear() {
+	kc.EndSeq()
+	kc.SetText("")
+	kc.ChordUpdated()
+}
+
+// IsRecordingKeys is the gi.KeyChordRecorder interface: all chords are
+// recorded while the focus is active, including multi-key sequence prefixes
+func (kc *KeyChordEdit) IsRecordingKeys() bool {
+	return kc.FocusActive && kc.HasFocus()
+}
+
 func (kc *KeyChordEdit) Style2D() {
 	kc.SetCanFocusIfActive()
 	kc.Selectable = true
@@ -180,19 +227,20 @@ func (kc *KeyChordEdit) FocusChanged2D(change gi.FocusChanges) {
 	switch change {
 	case gi.FocusLost:
 		kc.FocusActive = false
+		kc.EndSeq()
 		kc.ClearSelected()
-		kc.ChordUpdated()
 		kc.UpdateSig()
 	case gi.FocusGot:
 		kc.FocusActive = true
+		kc.EndSeq()
 		kc.SetSelected()
 		kc.ScrollToMe()
 		kc.EmitFocusedSignal()
 		kc.UpdateSig()
 	case gi.FocusInactive:
 		kc.FocusActive = false
+		kc.EndSeq()
 		kc.ClearSelected()
-		kc.ChordUpdated()
 		kc.UpdateSig()
 	case gi.FocusActive:
 		// we don't re-activate on keypress here, so that you don't end up stuck
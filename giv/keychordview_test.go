@@ -0,0 +1,54 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"goki.dev/gi/v2/gi"
+	"goki.dev/gi/v2/gitest"
+	"goki.dev/gi/v2/giv"
+	"goki.dev/gi/v2/oswin/key"
+	"goki.dev/ki/v2/ki"
+)
+
+// the giv tests run in a headless app, for rendering text etc
+func TestMain(m *testing.M) {
+	gitest.Main(m)
+}
+
+func TestKeyChordEditRecord(t *testing.T) {
+	gi.Init() // loads the fonts for rendering the chord text
+	kc := &giv.KeyChordEdit{}
+	kc.InitName(kc, "kc")
+	var emitted []string
+	kc.KeyChordSig.Connect(kc.This(), func(recv, send ki.Ki, sig int64, data any) {
+		emitted = append(emitted, data.(string))
+	})
+	kc.RecordChord("Control+X")
+	kc.RecordChord("Control+S")
+	if kc.Text != "Control+X Control+S" {
+		t.Errorf("sequence %q, want %q", kc.Text, "Control+X Control+S")
+	}
+	kc.EndSeq()
+	kc.RecordChord("Control+A")
+	kc.Clear()
+	kc.RecordChord("Control+B")
+
+	defer func(ms int) { gi.KeySeqTimeoutMSec = ms }(gi.KeySeqTimeoutMSec)
+	gi.KeySeqTimeoutMSec = 1
+	time.Sleep(5 * time.Millisecond)
+	kc.RecordChord("Control+C") // after the timeout
+
+	want := []string{"Control+X", "Control+X Control+S", "Control+A", "", "Control+B", "Control+C"}
+	if !reflect.DeepEqual(emitted, want) {
+		t.Errorf("emitted %q, want %q", emitted, want)
+	}
+	if key.Chord(kc.Text).IsSeq() {
+		t.Errorf("chord %q after the timeout is a sequence", kc.Text)
+	}
+}
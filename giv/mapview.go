@@ -10,6 +10,8 @@ import (
 	"goki.dev/gi/v2/gi"
 	"goki.dev/gi/v2/gist"
 	"goki.dev/gi/v2/icons"
+	"goki.dev/gi/v2/oswin"
+	"goki.dev/gi/v2/oswin/key"
 	"goki.dev/gi/v2/units"
 	"goki.dev/ki/v2/ki"
 	"goki.dev/ki/v2/kit"
@@ -61,6 +63,9 @@ type MapView struct {
 
 	// the map that we successfully set a toolbar for
 	ToolbarMap any `desc:"the map that we successfully set a toolbar for"`
+
+	// [view: -] undo records for the edits of the map -- nil if they cannot be undone (see NewViewUndo)
+	Undos *ViewUndo `copy:"-" json:"-" xml:"-" view:"-" desc:"undo records for the edits of the map -- nil if they cannot be undone (see NewViewUndo)"`
 }
 
 var TypeMapView = kit.Types.AddType(&MapView{}, MapViewProps)
@@ -110,6 +115,9 @@ func (mv *MapView) SetMap(mp any) {
 	// note: because we make new maps, and due to the strangeness of reflect, they
 	// end up not being comparable types, so we can't check if equal
 	mv.Map = mp
+	if !mv.Undos.IsFor(mp) {
+		mv.Undos = NewViewUndo(mp)
+	}
 	mv.Config()
 }
 
@@ -264,6 +272,7 @@ func (mv *MapView) ConfigMapGrid() {
 		vvb := vv.AsValueViewBase()
 		vvb.ViewSig.ConnectOnly(mv.This(), func(recv, send ki.Ki, sig int64, data any) {
 			mvv, _ := recv.Embed(TypeMapView).(*MapView)
+			mvv.SaveUndo("Edit", send.(ValueView).AsValueViewBase().Key)
 			mvv.SetChanged()
 		})
 		keyw := sg.Child(i * ncol).(gi.Node2D)
@@ -272,6 +281,7 @@ func (mv *MapView) ConfigMapGrid() {
 		kvb := kv.AsValueViewBase()
 		kvb.ViewSig.ConnectOnly(mv.This(), func(recv, send ki.Ki, sig int64, data any) {
 			mvv, _ := recv.Embed(TypeMapView).(*MapView)
+			mvv.SaveUndo("Rename to", kit.NonPtrValue(send.(ValueView).Val()).Interface())
 			mvv.SetChanged()
 		})
 		kv.ConfigWidget(keyw)
@@ -325,6 +335,30 @@ func (mv *MapView) SetChanged() {
 	mv.ToolBar().UpdateActions() // nil safe
 }
 
+// SaveUndo records given action on the element with given key for undo,
+// if the map has changed (see ViewUndo)
+func (mv *MapView) SaveUndo(action string, key any) {
+	mv.Undos.Save(action + " " + kit.ToString(key))
+}
+
+// ViewUndos returns the undo records for the edits of the map
+func (mv *MapView) ViewUndos() *ViewUndo {
+	return mv.Undos
+}
+
+// UndoUpdate updates the view after the map has been restored by an undo
+// or redo of given action
+func (mv *MapView) UndoUpdate(action string) {
+	updt := mv.UpdateStart()
+	defer mv.UpdateEnd(updt)
+
+	if mv.TmpSave != nil {
+		mv.TmpSave.SaveTmp()
+	}
+	mv.ConfigMapGrid()
+	mv.SetChanged()
+}
+
 // MapChangeValueType changes the type of the value for given map element at
 // idx -- for maps with any values
 func (mv *MapView) MapChangeValueType(idx int, typ reflect.Type) {
@@ -353,6 +387,7 @@ func (mv *MapView) MapChangeValueType(idx int, typ reflect.Type) {
 		mv.TmpSave.SaveTmp()
 	}
 	mv.ConfigMapGrid()
+	mv.SaveUndo("Change type of", ck.Interface())
 	mv.SetChanged()
 }
 
@@ -376,6 +411,7 @@ func (mv *MapView) MapAdd() {
 		mv.TmpSave.SaveTmp()
 	}
 	mv.ConfigMapGrid()
+	mv.Undos.Save("Add")
 	mv.SetChanged()
 	mv.MapViewSig.Emit(mv.This(), int64(MapViewAdded), nil)
 }
@@ -396,6 +432,7 @@ func (mv *MapView) MapDelete(key reflect.Value) {
 		mv.TmpSave.SaveTmp()
 	}
 	mv.ConfigMapGrid()
+	mv.SaveUndo("Delete", kvi)
 	mv.SetChanged()
 	mv.MapViewSig.Emit(mv.This(), int64(MapViewDeleted), kvi)
 }
@@ -413,7 +450,7 @@ func (mv *MapView) ConfigToolbar() {
 		return
 	}
 	tb := mv.ToolBar()
-	ndef := 3 // number of default actions
+	ndef := 4 // number of default actions
 	if mv.IsDisabled() {
 		ndef = 2
 	}
@@ -435,6 +472,7 @@ func (mv *MapView) ConfigToolbar() {
 					mvv := recv.Embed(TypeMapView).(*MapView)
 					mvv.MapAdd()
 				})
+			AddUndoAction(tb, mv)
 		}
 	}
 	sz := len(*tb.Children())
@@ -458,6 +496,15 @@ func (mv *MapView) Style2D() {
 	mv.Frame.Style2D()
 }
 
+func (mv *MapView) ConnectEvents2D() {
+	mv.Frame.ConnectEvents2D()
+	mv.ConnectEvent(oswin.KeyChordEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d any) {
+		mvv := recv.Embed(TypeMapView).(*MapView)
+		kt := d.(*key.ChordEvent)
+		UndoKeyFun(mvv, gi.KeyFunFor(mvv.This(), kt.Chord()), kt)
+	})
+}
+
 func (mv *MapView) Render2D() {
 	if mv.IsConfiged() {
 		mv.ToolBar().UpdateActions() // nil safe..
@@ -0,0 +1,470 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"goki.dev/gi/v2/gi"
+	"goki.dev/gi/v2/icons"
+	"goki.dev/ki/v2/ki"
+	"goki.dev/ki/v2/kit"
+)
+
+// CSVValueHeader is the header of the single column of CSV data for
+// slices of elements that are not structs
+var CSVValueHeader = "Value"
+
+// CSVDelim returns the delimiter for CSV data in a file of given name:
+// a tab for .tsv and .tab files, and a comma otherwise
+func CSVDelim(filename string) rune {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".tsv", ".tab":
+		return '\t'
+	}
+	return ','
+}
+
+// CSVHeader returns the CSV column header for given struct field: its
+// label tag if set, and its name otherwise
+func CSVHeader(fld reflect.StructField) string {
+	if lbl := fld.Tag.Get("label"); lbl != "" {
+		return lbl
+	}
+	return fld.Name
+}
+
+// CSVFields returns the fields of given slice element type that are
+// written as CSV columns: the TableView fields for structs, and nil
+// otherwise, for a single column with the element values
+func CSVFields(eltyp reflect.Type) []reflect.StructField {
+	if eltyp = kit.NonPtrType(eltyp); eltyp.Kind() != reflect.Struct {
+		return nil
+	}
+	return TableViewFields(eltyp, false)
+}
+
+// CSVString returns the CSV cell text for given value, in the same format
+// as shown by the value views: durations as by FormatDuration, times in
+// DefaultTimeFormat, and the standard string conversion otherwise
+func CSVString(val reflect.Value) string {
+	if !val.IsValid() {
+		return ""
+	}
+	switch v := val.Interface().(type) {
+	case time.Duration:
+		return FormatDuration(v)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format(DefaultTimeFormat)
+	}
+	return kit.ToString(val.Interface())
+}
+
+// SetValueFromString sets given settable value from given text, using the
+// same conversions as the value views: durations are parsed with
+// ParseDuration, times with ParseTimeTag, enums by name, and other types
+// with kit.SetRobust.  Empty text sets non-string values to zero.
+func SetValueFromString(val reflect.Value, str string) error {
+	if val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			val.Set(reflect.New(val.Type().Elem()))
+		}
+		val = val.Elem()
+	}
+	tstr := strings.TrimSpace(str)
+	if val.Kind() != reflect.String && tstr == "" {
+		val.Set(reflect.Zero(val.Type()))
+		return nil
+	}
+	switch val.Interface().(type) {
+	case time.Duration:
+		d, err := ParseDuration(tstr)
+		if err != nil {
+			return err
+		}
+		val.SetInt(int64(d))
+		return nil
+	case time.Time:
+		tm, err := ParseTimeTag(tstr)
+		if err != nil {
+			return err
+		}
+		val.Set(reflect.ValueOf(tm))
+		return nil
+	}
+	if kit.Enums.TypeRegistered(val.Type()) {
+		return kit.Enums.SetAnyEnumValueFromString(val.Addr(), tstr)
+	}
+	if !kit.SetRobust(val.Addr().Interface(), str) {
+		return fmt.Errorf("cannot convert %q to %v", str, val.Type())
+	}
+	return nil
+}
+
+// WriteSliceCSV writes the elements of given slice (or pointer to one) at
+// given indexes (all, in order, if nil) to given writer as CSV data with
+// given delimiter (',' for CSV, '\t' for TSV).  The first row has the
+// column headers: for a slice of structs, the columns are the fields
+// shown in a TableView, headed by their CSVHeader, and otherwise there is
+// a single CSVValueHeader column with the element values.
+func WriteSliceCSV(w io.Writer, slice any, idxs []int, delim rune) error {
+	svnp := kit.NonPtrValue(reflect.ValueOf(slice))
+	if svnp.Kind() != reflect.Slice && svnp.Kind() != reflect.Array {
+		return fmt.Errorf("giv.WriteSliceCSV: not a slice or array: %T", slice)
+	}
+	return writeSliceCSV(w, svnp, CSVFields(svnp.Type().Elem()), idxs, delim)
+}
+
+// writeSliceCSV writes the elements of given slice value at given indexes
+// as CSV data with given fields as columns (nil for the element values)
+func writeSliceCSV(w io.Writer, svnp reflect.Value, flds []reflect.StructField, idxs []int, delim rune) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = delim
+	var rec []string
+	if flds == nil {
+		rec = []string{CSVValueHeader}
+	} else {
+		rec = make([]string, len(flds))
+		for fi, fld := range flds {
+			rec[fi] = CSVHeader(fld)
+		}
+	}
+	cw.Write(rec)
+	n := svnp.Len()
+	if idxs != nil {
+		n = len(idxs)
+	}
+	for i := 0; i < n; i++ {
+		si := i
+		if idxs != nil {
+			si = idxs[i]
+		}
+		val := kit.NonPtrValue(svnp.Index(si))
+		if flds == nil {
+			rec[0] = CSVString(val)
+		} else {
+			for fi, fld := range flds {
+				fval, err := val.FieldByIndexErr(fld.Index)
+				if err != nil { // nil embedded pointer
+					rec[fi] = ""
+					continue
+				}
+				rec[fi] = CSVString(fval)
+			}
+		}
+		cw.Write(rec)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// CSVCellError is an error setting the value of a cell of CSV data read
+// by ReadSliceCSV -- the cell is left at its zero value
+type CSVCellError struct {
+
+	// row of the cell, starting at 1 after the header row -- 0 for a header that does not match any field
+	Row int `desc:"row of the cell, starting at 1 after the header row -- 0 for a header that does not match any field"`
+
+	// column of the cell, starting at 0
+	Col int `desc:"column of the cell, starting at 0"`
+
+	// header of the column
+	Header string `desc:"header of the column"`
+
+	// text of the cell
+	Text string `desc:"text of the cell"`
+
+	// the error
+	Err error `desc:"the error"`
+}
+
+func (ce *CSVCellError) Error() string {
+	if ce.Row == 0 {
+		return fmt.Sprintf("column %d %q: %v", ce.Col+1, ce.Header, ce.Err)
+	}
+	return fmt.Sprintf("row %d, column %q: %q: %v", ce.Row, ce.Header, ce.Text, ce.Err)
+}
+
+func (ce *CSVCellError) Unwrap() error {
+	return ce.Err
+}
+
+// ErrCSVNoField is the error of a CSVCellError for a column whose header
+// does not match any field
+var ErrCSVNoField = errors.New("no matching field -- column ignored")
+
+// csvElemOK returns true if values of given slice element type can be
+// read from CSV data by ReadSliceCSV: not Ki nodes, interfaces, funcs or
+// chans, or pointers to them, which cannot be created from text
+func csvElemOK(typ reflect.Type) bool {
+	if ki.IsKi(typ) {
+		return false
+	}
+	npt := kit.NonPtrType(typ)
+	switch npt.Kind() {
+	case reflect.Interface, reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return false
+	}
+	return !ki.IsKi(npt)
+}
+
+// ReadSliceCSV reads CSV data with given delimiter (',' for CSV, '\t' for
+// TSV) from given reader into given pointer to a slice, replacing its
+// elements, or appending to them if appnd is true -- the elements cannot
+// be Ki nodes, interfaces, funcs or chans (see csvElemOK).  The first row
+// has the column headers, which are matched to the fields of a slice of
+// structs by their CSVHeader or name, ignoring case -- for a slice of other
+// types, the values are read from the first column.  Cell values are set with
+// SetValueFromString, and cells that cannot be set are returned as
+// CSVCellErrors, with the rest of the data still read -- the returned
+// error is only for data that cannot be read at all.
+func ReadSliceCSV(r io.Reader, slice any, delim rune, appnd bool) ([]*CSVCellError, error) {
+	svp := reflect.ValueOf(slice)
+	if svp.Kind() != reflect.Pointer || svp.Elem().Kind() != reflect.Slice {
+		return nil, fmt.Errorf("giv.ReadSliceCSV: must pass a pointer to a slice, not: %T", slice)
+	}
+	svnp := svp.Elem()
+	eltyp := svnp.Type().Elem()
+	if !csvElemOK(eltyp) {
+		return nil, fmt.Errorf("giv.ReadSliceCSV: cannot read into a slice of Ki, interface, func or chan elements: %T", slice)
+	}
+	nptyp := kit.NonPtrType(eltyp)
+	cr := csv.NewReader(r)
+	cr.Comma = delim
+	cr.FieldsPerRecord = -1
+	recs, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("giv.ReadSliceCSV: %w", err)
+	}
+	nsl := svnp
+	if !appnd {
+		nsl = reflect.MakeSlice(svnp.Type(), 0, max(len(recs)-1, 0))
+	}
+	if len(recs) == 0 {
+		svnp.Set(nsl)
+		return nil, nil
+	}
+	var cerrs []*CSVCellError
+	hdr := recs[0]
+	flds := CSVFields(eltyp)
+	cols := make([][]int, len(hdr)) // field index for each column, nil to skip
+	for ci, h := range hdr {
+		h = strings.TrimSpace(h)
+		if flds == nil {
+			if ci == 0 {
+				cols[ci] = []int{}
+			}
+			continue
+		}
+		for _, fld := range flds {
+			if strings.EqualFold(h, CSVHeader(fld)) || strings.EqualFold(h, fld.Name) {
+				cols[ci] = fld.Index
+				break
+			}
+		}
+		if cols[ci] == nil {
+			cerrs = append(cerrs, &CSVCellError{Col: ci, Header: h, Text: h, Err: ErrCSVNoField})
+		}
+	}
+	for ri, rec := range recs[1:] {
+		nval := reflect.New(nptyp)
+		for ci, txt := range rec {
+			if ci >= len(cols) || cols[ci] == nil {
+				continue
+			}
+			fval := nval.Elem() // non-struct value
+			var err error
+			if len(cols[ci]) > 0 {
+				fval, err = fval.FieldByIndexErr(cols[ci])
+			}
+			if err == nil {
+				err = SetValueFromString(fval, txt)
+			}
+			if err != nil {
+				cerrs = append(cerrs, &CSVCellError{Row: ri + 1, Col: ci, Header: hdr[ci], Text: txt, Err: err})
+			}
+		}
+		if eltyp.Kind() == reflect.Pointer {
+			nsl = reflect.Append(nsl, nval)
+		} else {
+			nsl = reflect.Append(nsl, nval.Elem())
+		}
+	}
+	svnp.Set(nsl)
+	return cerrs, nil
+}
+
+/////////////////////////////////////////////////////////////////////////////
+//  SliceViewBase CSV
+
+// csvFields returns the fields written as CSV columns for the view: the
+// visible fields of a TableView, and otherwise as in CSVFields
+func (sv *SliceViewBase) csvFields() []reflect.StructField {
+	if tv, ok := sv.This().Embed(TypeTableView).(*TableView); ok {
+		return tv.VisFields
+	}
+	return CSVFields(sv.SliceNPVal.Type().Elem())
+}
+
+// ExportCSV writes the rows of the view, in their current (sorted,
+// filtered) order, to given writer as CSV data with given delimiter --
+// see WriteSliceCSV for details
+func (sv *SliceViewBase) ExportCSV(w io.Writer, delim rune) error {
+	if sv.Source != nil {
+		return errors.New("giv.SliceViewBase: ExportCSV is not supported for a SliceSource")
+	}
+	if kit.IfaceIsNil(sv.Slice) {
+		return nil
+	}
+	return writeSliceCSV(w, sv.SliceNPVal, sv.csvFields(), sv.ViewIdxs, delim)
+}
+
+// SaveCSV saves the rows of the view to a CSV file, or TSV for a .tsv
+// file -- see ExportCSV
+func (sv *SliceViewBase) SaveCSV(filename gi.FileName) error {
+	f, err := os.Create(string(filename))
+	if err != nil {
+		return err
+	}
+	err = sv.ExportCSV(f, CSVDelim(string(filename)))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// ImportCSV reads CSV data with given delimiter from given reader into
+// the slice, replacing its elements, or appending to them if appnd is
+// true, and updates the view -- see ReadSliceCSV for details
+func (sv *SliceViewBase) ImportCSV(r io.Reader, delim rune, appnd bool) ([]*CSVCellError, error) {
+	if sv.Source != nil || sv.isArray || kit.IfaceIsNil(sv.Slice) {
+		return nil, errors.New("giv.SliceViewBase: ImportCSV is only supported for a slice")
+	}
+	sv.ViewMuLock()
+	cerrs, err := ReadSliceCSV(r, sv.Slice, delim, appnd)
+	sv.ViewMuUnlock()
+	if err != nil {
+		return cerrs, err
+	}
+	sv.ResetSelectedIdxs()
+	sv.SelectedIdx = -1
+	if sv.TmpSave != nil {
+		sv.TmpSave.SaveTmp()
+	}
+	sv.Undos.Save("Import CSV")
+	sv.SetChanged()
+	if tv, ok := sv.This().Embed(TypeTableView).(*TableView); ok {
+		tv.FilterSortAction()
+	} else {
+		sv.Update()
+	}
+	return cerrs, nil
+}
+
+// OpenCSV reads a CSV file, or TSV for a .tsv file, into the slice,
+// replacing its elements -- see ImportCSV
+func (sv *SliceViewBase) OpenCSV(filename gi.FileName) ([]*CSVCellError, error) {
+	f, err := os.Open(string(filename))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return sv.ImportCSV(f, CSVDelim(string(filename)), false)
+}
+
+// CanImportCSV returns true if CSV data can be imported into the view --
+// not for slices of elements that ReadSliceCSV cannot read (see csvElemOK)
+func (sv *SliceViewBase) CanImportCSV() bool {
+	if sv.Source != nil || sv.isArray || sv.IsDisabled() || sv.NoAdd || sv.NoDelete {
+		return false
+	}
+	return !kit.IfaceIsNil(sv.Slice) && csvElemOK(sv.SliceNPVal.Type().Elem())
+}
+
+// ExportCSVAction opens a file dialog to save the rows of the view as a
+// CSV or TSV file
+func (sv *SliceViewBase) ExportCSVAction() {
+	FileViewDialog(sv.ViewportSafe(), "", ".csv,.tsv", DlgOpts{Title: "Export CSV", Prompt: "Save the rows of the table, as currently shown, to a CSV file, or TSV for a .tsv file"}, nil,
+		sv.This(), func(recv, send ki.Ki, sig int64, data any) {
+			if sig != int64(gi.DialogAccepted) {
+				return
+			}
+			svv := recv.Embed(TypeSliceViewBase).(*SliceViewBase)
+			fn := FileViewDialogValue(send.(*gi.Dialog))
+			if err := svv.SaveCSV(gi.FileName(fn)); err != nil {
+				gi.PromptDialog(svv.ViewportSafe(), gi.DlgOpts{Title: "Export CSV Failed", Prompt: err.Error()}, gi.AddOk, gi.NoCancel, nil, nil)
+			}
+		})
+}
+
+// ImportCSVAction opens a file dialog to replace the elements of the
+// slice with the rows of a CSV or TSV file, reporting any cells that
+// could not be read
+func (sv *SliceViewBase) ImportCSVAction() {
+	FileViewDialog(sv.ViewportSafe(), "", ".csv,.tsv", DlgOpts{Title: "Import CSV", Prompt: "Replace the rows of the table with those of a CSV file, or TSV for a .tsv file, with a header row naming the fields"}, nil,
+		sv.This(), func(recv, send ki.Ki, sig int64, data any) {
+			if sig != int64(gi.DialogAccepted) {
+				return
+			}
+			svv := recv.Embed(TypeSliceViewBase).(*SliceViewBase)
+			fn := FileViewDialogValue(send.(*gi.Dialog))
+			cerrs, err := svv.OpenCSV(gi.FileName(fn))
+			if err != nil {
+				gi.PromptDialog(svv.ViewportSafe(), gi.DlgOpts{Title: "Import CSV Failed", Prompt: err.Error()}, gi.AddOk, gi.NoCancel, nil, nil)
+				return
+			}
+			if len(cerrs) == 0 {
+				return
+			}
+			const maxErrs = 20
+			msgs := make([]string, 0, maxErrs+1)
+			for i, ce := range cerrs {
+				if i == maxErrs {
+					msgs = append(msgs, fmt.Sprintf("... and %d more", len(cerrs)-maxErrs))
+					break
+				}
+				msgs = append(msgs, html.EscapeString(ce.Error()))
+			}
+			gi.PromptDialog(svv.ViewportSafe(), gi.DlgOpts{Title: "Import CSV Errors", Prompt: fmt.Sprintf("%d cells could not be read, and were left empty:<br>%s", len(cerrs), strings.Join(msgs, "<br>"))}, gi.AddOk, gi.NoCancel, nil, nil)
+		})
+}
+
+// AddCSVAction adds an action to given toolbar with a menu of the CSV
+// export and import actions
+func (sv *SliceViewBase) AddCSVAction(tb *gi.ToolBar) {
+	ac := tb.AddAction(gi.ActOpts{Label: "CSV", Icon: icons.Csv, Tooltip: "export the rows to a CSV or TSV file, or import them from one"}, nil, nil)
+	ac.MakeMenuFunc = func(obj ki.Ki, m *gi.Menu) {
+		*m = (*m)[:0]
+		sv.CSVMenu(m)
+	}
+}
+
+// CSVMenu adds the CSV export and import actions to given menu
+func (sv *SliceViewBase) CSVMenu(m *gi.Menu) {
+	m.AddAction(gi.ActOpts{Label: "Export CSV..."},
+		sv.This(), func(recv, send ki.Ki, sig int64, data any) {
+			svv := recv.Embed(TypeSliceViewBase).(*SliceViewBase)
+			svv.ExportCSVAction()
+		})
+	if sv.CanImportCSV() {
+		m.AddAction(gi.ActOpts{Label: "Import CSV..."},
+			sv.This(), func(recv, send ki.Ki, sig int64, data any) {
+				svv := recv.Embed(TypeSliceViewBase).(*SliceViewBase)
+				svv.ImportCSVAction()
+			})
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"goki.dev/gi/v2/gi"
+	"goki.dev/gi/v2/giv"
+)
+
+type csvRow struct {
+	Name   string
+	Count  int           `label:"N"`
+	On     bool          `label:"Enabled"`
+	Lay    gi.Layouts    `label:"Layout"`
+	Dur    time.Duration `label:"Duration"`
+	When   time.Time
+	Hidden string `view:"-"`
+}
+
+func csvRows() []csvRow {
+	when := time.Date(2023, time.May, 4, 10, 30, 0, 0, time.UTC)
+	return []csvRow{
+		{"alpha", 1, true, gi.LayoutHoriz, 90 * time.Minute, when, "x"},
+		{"beta, \"quoted\"", -2, false, gi.LayoutGrid, time.Second, time.Time{}, "y"},
+		{"", 0, false, gi.LayoutVert, 0, when.Add(time.Hour), ""},
+	}
+}
+
+func TestWriteSliceCSV(t *testing.T) {
+	rows := csvRows()
+	var buf bytes.Buffer
+	if err := giv.WriteSliceCSV(&buf, &rows, []int{1, 0}, ','); err != nil {
+		t.Fatal(err)
+	}
+	want := "Name,N,Enabled,Layout,Duration,When\n" +
+		"\"beta, \"\"quoted\"\"\",-2,false,LayoutGrid,1s,\n" +
+		"alpha,1,true,LayoutHoriz,1h 30m,2023-05-04 10:30:00 UTC\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	buf.Reset()
+	if err := giv.WriteSliceCSV(&buf, []float64{1.5, 2}, nil, '\t'); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "Value\n1.5\n2\n"; got != want {
+		t.Errorf("values: got %q, want %q", got, want)
+	}
+
+	if err := giv.WriteSliceCSV(&buf, 42, nil, ','); err == nil {
+		t.Errorf("expected error for a non-slice")
+	}
+}
+
+func TestReadSliceCSV(t *testing.T) {
+	for _, delim := range []rune{',', '\t'} {
+		rows := csvRows()
+		var buf bytes.Buffer
+		if err := giv.WriteSliceCSV(&buf, rows, nil, delim); err != nil {
+			t.Fatal(err)
+		}
+		var got []*csvRow // pointer elements read the same
+		cerrs, err := giv.ReadSliceCSV(&buf, &got, delim, false)
+		if err != nil || len(cerrs) != 0 {
+			t.Fatalf("%q: errors %v, %v", delim, err, cerrs)
+		}
+		if len(got) != len(rows) {
+			t.Fatalf("%q: read %d rows, want %d", delim, len(got), len(rows))
+		}
+		for i, r := range got {
+			want := rows[i]
+			want.Hidden = "" // not written
+			if !r.When.Equal(want.When) {
+				t.Errorf("%q: row %d: time %v, want %v", delim, i, r.When, want.When)
+			}
+			r.When, want.When = time.Time{}, time.Time{}
+			if *r != want {
+				t.Errorf("%q: row %d: got %+v, want %+v", delim, i, *r, want)
+			}
+		}
+	}
+}
+
+func TestReadSliceCSVErrors(t *testing.T) {
+	data := "name,n,Unknown,Enabled\n" +
+		"a,1,x,true\n" +
+		"b,two,y,maybe\n" +
+		"c\n"
+	rows := []csvRow{{Name: "old"}}
+	cerrs, err := giv.ReadSliceCSV(strings.NewReader(data), &rows, ',', true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := []string{}
+	for _, r := range rows {
+		names = append(names, r.Name)
+	}
+	if want := []string{"old", "a", "b", "c"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("appended rows %q, want %q", names, want)
+	}
+	if rows[2].Count != 0 {
+		t.Errorf("invalid cell not left at zero: %d", rows[2].Count)
+	}
+	type cell struct {
+		row, col int
+	}
+	var cells []cell
+	for _, ce := range cerrs {
+		cells = append(cells, cell{ce.Row, ce.Col})
+	}
+	if want := []cell{{0, 2}, {2, 1}, {2, 3}}; !reflect.DeepEqual(cells, want) {
+		t.Errorf("cell errors at %v, want %v: %v", cells, want, cerrs)
+	}
+	if len(cerrs) > 0 && !errors.Is(cerrs[0], giv.ErrCSVNoField) {
+		t.Errorf("unknown column error %v, want ErrCSVNoField", cerrs[0])
+	}
+
+	var vals []int
+	if _, err := giv.ReadSliceCSV(strings.NewReader("Value\n3\n4\n"), &vals, ',', false); err != nil || !reflect.DeepEqual(vals, []int{3, 4}) {
+		t.Errorf("values: got %v, %v", vals, err)
+	}
+
+	bad := []struct {
+		name  string
+		slice any
+	}{
+		{"not a pointer", []csvRow{}},
+		{"ki elements", &[]*gi.Frame{}},
+		{"interface elements", &[]any{}},
+		{"func elements", &[]func(){}},
+		{"chan pointer elements", &[]*chan int{}},
+	}
+	for _, b := range bad {
+		if _, err := giv.ReadSliceCSV(strings.NewReader("Name\na\n"), b.slice, ',', false); err == nil {
+			t.Errorf("%s: expected error", b.name)
+		}
+	}
+	if _, err := giv.ReadSliceCSV(strings.NewReader("a,\"b\n"), &rows, ',', false); err == nil {
+		t.Errorf("expected error for malformed CSV")
+	}
+}
+
+func TestCanImportCSV(t *testing.T) {
+	gi.Init()
+	fr := &gi.Frame{}
+	fr.InitName(fr, "fr")
+	tests := []struct {
+		slice any
+		can   bool
+	}{
+		{&[]csvRow{}, true},
+		{&[]*gi.Frame{}, false},
+		{&[]any{}, false},
+		{&[]func(){}, false},
+		{&[]*int{}, true},
+		{&[2]csvRow{}, false},
+	}
+	for _, tt := range tests {
+		sv := giv.AddNewSliceView(fr, "sv")
+		sv.SetSlice(tt.slice)
+		if can := sv.CanImportCSV(); can != tt.can {
+			t.Errorf("%T: CanImportCSV = %v, want %v", tt.slice, can, tt.can)
+		}
+		fr.DeleteChildren(true)
+	}
+}
@@ -0,0 +1,260 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"reflect"
+	"sync"
+)
+
+// SliceSource is a data source that a SliceView or TableView can display
+// directly (see SliceViewBase.SetSource), instead of a slice held in
+// memory: rows are only fetched for the visible window of the view, so
+// the source can be arbitrarily large (e.g., a database query or a log
+// file).
+type SliceSource interface {
+	// Len returns the total number of rows in the source
+	Len() int
+
+	// RowType returns the type of each row -- for a TableView this must
+	// be a struct or a pointer to a struct
+	RowType() reflect.Type
+
+	// Row returns the row at the given index, and false if it is not
+	// (yet) available -- the view then shows a placeholder for it, and
+	// asks a SliceSourceLoader to load it
+	Row(idx int) (any, bool)
+}
+
+// SliceSourceLoader is optionally implemented by a SliceSource that loads
+// rows asynchronously: LoadRows is called with the range of visible rows
+// [st, ed) whenever some of them are not available, and must call done
+// (from any goroutine) once they have been loaded.  It may be called again
+// for rows that are already loading, so it should ignore those requests.
+type SliceSourceLoader interface {
+	LoadRows(st, ed int, done func())
+}
+
+// SliceSourceSetter is optionally implemented by a SliceSource that can be
+// edited: SetRow is called with the new value of a row edited in the view.
+// Views of sources that are not setters are read-only.
+type SliceSourceSetter interface {
+	SetRow(idx int, row any)
+}
+
+// SliceSourceNotifier is optionally implemented by a SliceSource that can
+// change (e.g., a growing log file): the view registers a function with
+// OnChange, which the source calls (from any goroutine) to update the view
+type SliceSourceNotifier interface {
+	OnChange(fun func())
+}
+
+// PagedSliceSource is a SliceSource that loads rows asynchronously, in
+// pages of PageSize rows, using the Fetch function, and keeps up to
+// MaxPages pages cached, dropping the least recently used ones.
+type PagedSliceSource struct {
+
+	// total number of rows -- use SetLen to change it
+	N int `desc:"total number of rows -- use SetLen to change it"`
+
+	// type of each row
+	Type reflect.Type `desc:"type of each row"`
+
+	// number of rows loaded at a time -- 200 if not set
+	PageSize int `desc:"number of rows loaded at a time -- 200 if not set"`
+
+	// maximum number of pages kept in the cache
+	MaxPages int `desc:"maximum number of pages kept in the cache"`
+
+	// function that fetches rows [st, ed) -- it is called in a separate goroutine
+	Fetch func(st, ed int) []any `desc:"function that fetches rows [st, ed) -- it is called in a separate goroutine"`
+
+	// mutex protecting the cache
+	mu sync.Mutex
+
+	// cached pages, by page number
+	pages map[int][]any
+
+	// pages currently being loaded, with the generation of each load
+	loading map[int]int
+
+	// generation of the last load started -- loads of pages that have been
+	// dropped (see SetLen and Reset) no longer match, and are discarded
+	gen int
+
+	// page numbers in order of use, most recent last
+	used []int
+
+	// functions to call when the source has changed
+	changeFuncs []func()
+}
+
+// NewPagedSliceSource returns a new PagedSliceSource with n rows of given
+// type, fetched using given function, with default page sizes
+func NewPagedSliceSource(n int, typ reflect.Type, fetch func(st, ed int) []any) *PagedSliceSource {
+	return &PagedSliceSource{N: n, Type: typ, PageSize: 200, MaxPages: 50, Fetch: fetch}
+}
+
+func (ps *PagedSliceSource) Len() int {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return ps.N
+}
+
+func (ps *PagedSliceSource) RowType() reflect.Type {
+	return ps.Type
+}
+
+// pageSize returns the PageSize, or the default if it is not set
+func (ps *PagedSliceSource) pageSize() int {
+	if ps.PageSize <= 0 {
+		return 200
+	}
+	return ps.PageSize
+}
+
+func (ps *PagedSliceSource) Row(idx int) (any, bool) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if idx < 0 || idx >= ps.N {
+		return nil, false
+	}
+	psz := ps.pageSize()
+	pg := idx / psz
+	rows, ok := ps.pages[pg]
+	if !ok {
+		return nil, false
+	}
+	ps.touch(pg)
+	ri := idx - pg*psz
+	if ri < 0 || ri >= len(rows) {
+		return nil, false
+	}
+	return rows[ri], true
+}
+
+// touch marks given page as most recently used -- must be locked
+func (ps *PagedSliceSource) touch(pg int) {
+	for i, p := range ps.used {
+		if p == pg {
+			ps.used = append(ps.used[:i], ps.used[i+1:]...)
+			break
+		}
+	}
+	ps.used = append(ps.used, pg)
+}
+
+func (ps *PagedSliceSource) LoadRows(st, ed int, done func()) {
+	ps.mu.Lock()
+	if ps.pages == nil {
+		ps.pages = make(map[int][]any)
+		ps.loading = make(map[int]int)
+	}
+	psz := ps.pageSize()
+	var load, gens []int
+	for pg := st / psz; pg*psz < ed; pg++ {
+		if _, has := ps.pages[pg]; has {
+			continue
+		}
+		if _, has := ps.loading[pg]; has {
+			continue
+		}
+		ps.gen++
+		ps.loading[pg] = ps.gen
+		load = append(load, pg)
+		gens = append(gens, ps.gen)
+	}
+	ps.mu.Unlock()
+	for i, pg := range load {
+		go ps.loadPage(pg, gens[i], done)
+	}
+}
+
+// loadPage fetches the rows of given page and adds them to the cache, if
+// the load of given generation is still current
+func (ps *PagedSliceSource) loadPage(pg, gen int, done func()) {
+	ps.mu.Lock()
+	psz := ps.pageSize()
+	st := pg * psz
+	ed := st + psz
+	if ed > ps.N {
+		ed = ps.N
+	}
+	ps.mu.Unlock()
+	rows := ps.Fetch(st, ed)
+	ps.mu.Lock()
+	if lg, has := ps.loading[pg]; !has || lg != gen { // dropped while loading
+		ps.mu.Unlock()
+		return
+	}
+	delete(ps.loading, pg)
+	ps.pages[pg] = rows
+	ps.touch(pg)
+	for ps.MaxPages > 0 && len(ps.used) > ps.MaxPages {
+		delete(ps.pages, ps.used[0])
+		ps.used = ps.used[1:]
+	}
+	ps.mu.Unlock()
+	done()
+}
+
+func (ps *PagedSliceSource) OnChange(fun func()) {
+	ps.mu.Lock()
+	ps.changeFuncs = append(ps.changeFuncs, fun)
+	ps.mu.Unlock()
+}
+
+// SetLen sets the total number of rows, e.g., as a log file grows, and
+// updates the views of the source -- the pages from the last one of the
+// old rows to the last one of the new rows are fetched again
+func (ps *PagedSliceSource) SetLen(n int) {
+	ps.mu.Lock()
+	oldN := ps.N
+	ps.N = n
+	psz := ps.pageSize()
+	st, ed := oldN/psz, n/psz
+	if st > ed {
+		st, ed = ed, st
+	}
+	for pg := st; pg <= ed; pg++ {
+		ps.drop(pg)
+	}
+	ps.mu.Unlock()
+	ps.Changed()
+}
+
+// drop removes given page from the cache, and discards any load of it
+// -- must be locked
+func (ps *PagedSliceSource) drop(pg int) {
+	delete(ps.pages, pg)
+	delete(ps.loading, pg)
+	for i, p := range ps.used {
+		if p == pg {
+			ps.used = append(ps.used[:i], ps.used[i+1:]...)
+			break
+		}
+	}
+}
+
+// Reset clears the cache so that all rows are fetched again, and updates
+// the views of the source
+func (ps *PagedSliceSource) Reset() {
+	ps.mu.Lock()
+	ps.pages = nil
+	ps.loading = nil
+	ps.used = nil
+	ps.mu.Unlock()
+	ps.Changed()
+}
+
+// Changed calls the OnChange functions to update the views of the source
+func (ps *PagedSliceSource) Changed() {
+	ps.mu.Lock()
+	fs := ps.changeFuncs
+	ps.mu.Unlock()
+	for _, fun := range fs {
+		fun()
+	}
+}
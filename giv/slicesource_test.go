@@ -0,0 +1,198 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv_test
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"goki.dev/gi/v2/giv"
+)
+
+// psFetch returns a fetch function returning the row index prefixed by the
+// current version, counting its calls in n
+func psFetch(ver, n *int32) func(st, ed int) []any {
+	return func(st, ed int) []any {
+		atomic.AddInt32(n, 1)
+		v := atomic.LoadInt32(ver)
+		rows := make([]any, ed-st)
+		for i := range rows {
+			rows[i] = fmt.Sprintf("%d:%d", v, st+i)
+		}
+		return rows
+	}
+}
+
+// psLoad loads rows [st, ed) of given source, asking for them again until
+// they are all available, as a view does on each update, failing if that
+// does not finish within a few seconds
+func psLoad(t *testing.T, ps *giv.PagedSliceSource, st, ed int) {
+	t.Helper()
+	for i := 0; i < 5000; i++ {
+		ps.LoadRows(st, ed, func() {})
+		if psHas(ps, st, ed) {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("rows %d-%d not loaded", st, ed)
+}
+
+// psHas returns whether all rows [st, ed) are available
+func psHas(ps *giv.PagedSliceSource, st, ed int) bool {
+	for i := st; i < ed; i++ {
+		if _, ok := ps.Row(i); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPagedSliceSourceLen(t *testing.T) {
+	var ver, n int32
+	ps := giv.NewPagedSliceSource(250, reflect.TypeOf(""), psFetch(&ver, &n))
+	ps.PageSize = 100
+	psLoad(t, ps, 0, 250)
+	if r, ok := ps.Row(249); !ok || r != "0:249" {
+		t.Errorf("Row(249) = %v, %v, want 0:249", r, ok)
+	}
+	if _, ok := ps.Row(250); ok {
+		t.Errorf("Row(250) available beyond Len")
+	}
+
+	// growing drops the old partial page
+	atomic.StoreInt32(&ver, 1)
+	ps.SetLen(450)
+	if _, ok := ps.Row(260); ok {
+		t.Errorf("Row(260) available before loading")
+	}
+	if r, ok := ps.Row(150); !ok || r != "0:150" {
+		t.Errorf("full page dropped on grow: Row(150) = %v, %v", r, ok)
+	}
+	psLoad(t, ps, 200, 450)
+	for _, i := range []int{200, 260, 449} {
+		if r, ok := ps.Row(i); !ok || r != fmt.Sprintf("1:%d", i) {
+			t.Errorf("after grow: Row(%d) = %v, %v, want 1:%d", i, r, ok, i)
+		}
+	}
+
+	// shrinking drops the new partial page and the ones beyond it
+	atomic.StoreInt32(&ver, 2)
+	ps.SetLen(150)
+	if _, ok := ps.Row(120); ok {
+		t.Errorf("Row(120) of new partial page available before loading")
+	}
+	if _, ok := ps.Row(300); ok {
+		t.Errorf("Row(300) available beyond Len")
+	}
+	ps.SetLen(450)
+	if _, ok := ps.Row(300); ok {
+		t.Errorf("Row(300) dropped by shrink available after growing again")
+	}
+	ps.SetLen(150)
+	psLoad(t, ps, 0, 150)
+	if r, ok := ps.Row(149); !ok || r != "2:149" {
+		t.Errorf("after shrink: Row(149) = %v, %v, want 2:149", r, ok)
+	}
+}
+
+func TestPagedSliceSourcePageSize(t *testing.T) {
+	var ver, n int32
+	ps := &giv.PagedSliceSource{N: 300, Type: reflect.TypeOf(""), Fetch: psFetch(&ver, &n)}
+	if _, ok := ps.Row(10); ok {
+		t.Errorf("Row(10) available before loading")
+	}
+	psLoad(t, ps, 0, 300)
+	if n != 2 {
+		t.Errorf("%d pages fetched with the default size, want 2", n)
+	}
+}
+
+func TestPagedSliceSourceReset(t *testing.T) {
+	var ver, n int32
+	ps := giv.NewPagedSliceSource(100, reflect.TypeOf(""), psFetch(&ver, &n))
+	ps.PageSize = 10
+	psLoad(t, ps, 0, 20)
+	atomic.StoreInt32(&ver, 1)
+	ps.Reset()
+	if _, ok := ps.Row(5); ok {
+		t.Errorf("Row(5) available after Reset")
+	}
+	psLoad(t, ps, 0, 20)
+	if r, _ := ps.Row(5); r != "1:5" {
+		t.Errorf("Row(5) after Reset = %v, want 1:5", r)
+	}
+
+	// a load started before Reset, finishing after a new one started, is
+	// discarded
+	fetch := psFetch(&ver, &n)
+	stale := make(chan struct{})
+	var first int32
+	ps.Fetch = func(st, ed int) []any {
+		rows := fetch(st, ed)
+		if atomic.AddInt32(&first, 1) == 1 {
+			<-stale
+		}
+		return rows
+	}
+	atomic.StoreInt32(&ver, 2)
+	ps.Reset()
+	var staleDone int32
+	ps.LoadRows(0, 10, func() { atomic.StoreInt32(&staleDone, 1) })
+	for atomic.LoadInt32(&first) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	atomic.StoreInt32(&ver, 3)
+	ps.Reset()
+	done := make(chan struct{})
+	ps.LoadRows(0, 10, func() { close(done) })
+	close(stale)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("load after Reset not done")
+	}
+	time.Sleep(10 * time.Millisecond)
+	if r, ok := ps.Row(5); !ok || r != "3:5" {
+		t.Errorf("Row(5) = %v, %v, want 3:5 of the load after Reset", r, ok)
+	}
+	if atomic.LoadInt32(&staleDone) != 0 {
+		t.Errorf("load from before Reset reported done")
+	}
+}
+
+func TestPagedSliceSourceConcurrent(t *testing.T) {
+	var ver, n int32
+	ps := giv.NewPagedSliceSource(1000, reflect.TypeOf(""), psFetch(&ver, &n))
+	ps.PageSize = 10
+	ps.MaxPages = 20
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				st := (i*97 + j*31) % 900
+				ps.LoadRows(st, st+50, func() {})
+				ps.Row(st)
+				if j%7 == 0 {
+					ps.SetLen(1000 - j)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	ps.SetLen(1000)
+	psLoad(t, ps, 500, 600)
+	for i := 500; i < 600; i++ {
+		if r, ok := ps.Row(i); !ok || r != fmt.Sprintf("0:%d", i) {
+			t.Errorf("Row(%d) = %v, %v", i, r, ok)
+		}
+	}
+}
@@ -141,6 +141,24 @@ type SliceViewBase struct {
 	// [view: -] non-ptr reflect.Value of the slice
 	SliceNPVal reflect.Value `copy:"-" view:"-" json:"-" xml:"-" desc:"non-ptr reflect.Value of the slice"`
 
+	// [view: -] optional data source that provides the rows on demand, instead of a slice held in memory -- see SetSource -- Slice is then a window onto the visible rows of the source
+	Source SliceSource `copy:"-" view:"-" json:"-" xml:"-" desc:"optional data source that provides the rows on demand, instead of a slice held in memory -- see SetSource -- Slice is then a window onto the visible rows of the source"`
+
+	// [view: -] slice indexes of the rows shown, in display order, when the view is filtered or sorted without modifying the slice (see TableView) -- nil shows all rows in slice order -- set with SetViewIdxs
+	ViewIdxs []int `copy:"-" view:"-" json:"-" xml:"-" desc:"slice indexes of the rows shown, in display order, when the view is filtered or sorted without modifying the slice (see TableView) -- nil shows all rows in slice order -- set with SetViewIdxs"`
+
+	// length of the slice when ViewIdxs was set, to detect external changes
+	viewSliceLen int
+
+	// pointer to the window slice holding the visible rows fetched from Source
+	srcWin reflect.Value
+
+	// which of the rows in the window have been loaded from Source
+	srcLoaded []bool
+
+	// index of the first row of the window within Source
+	srcStart int
+
 	// [view: -] ValueView for the slice itself, if this was created within value view framework -- otherwise nil
 	SliceValView ValueView `copy:"-" view:"-" json:"-" xml:"-" desc:"ValueView for the slice itself, if this was created within value view framework -- otherwise nil"`
 
@@ -233,6 +251,9 @@ type SliceViewBase struct {
 
 	// [view: -] temp idx state for e.g., dnd
 	CurIdx int `copy:"-" view:"-" json:"-" xml:"-" desc:"temp idx state for e.g., dnd"`
+
+	// [view: -] undo records for the edits of the slice -- nil if they cannot be undone (see NewViewUndo), including for a Source
+	Undos *ViewUndo `copy:"-" view:"-" json:"-" xml:"-" desc:"undo records for the edits of the slice -- nil if they cannot be undone (see NewViewUndo), including for a Source"`
 }
 
 var TypeSliceViewBase = kit.Types.AddType(&SliceViewBase{}, nil)
@@ -305,6 +326,7 @@ func (sv *SliceViewBase) AsSliceViewBase() *SliceViewBase {
 // SetSlice sets the source slice that we are viewing -- rebuilds the children
 // to represent this slice
 func (sv *SliceViewBase) SetSlice(sl any) {
+	sv.ClearSourceFor(sl)
 	if kit.IfaceIsNil(sl) {
 		sv.Slice = nil
 		return
@@ -321,6 +343,7 @@ func (sv *SliceViewBase) SetSlice(sl any) {
 	}
 	updt := sv.UpdateStart()
 	sv.StartIdx = 0
+	sv.ViewIdxs = nil
 	sv.Slice = sl
 	sv.SliceNPVal = kit.NonPtrValue(reflect.ValueOf(sv.Slice))
 	sv.isArray = kit.NonPtrType(reflect.TypeOf(sl)).Kind() == reflect.Array
@@ -332,6 +355,10 @@ func (sv *SliceViewBase) SetSlice(sl any) {
 			val.Set(reflect.New(kit.NonPtrType(val.Type())))
 		}
 	}
+	sv.Undos = nil
+	if sv.Source == nil {
+		sv.Undos = NewViewUndo(sl)
+	}
 	if !sv.IsDisabled() {
 		sv.SelectedIdx = -1
 	}
@@ -341,6 +368,309 @@ func (sv *SliceViewBase) SetSlice(sl any) {
 	sv.UpdateEnd(updt)
 }
 
+// SetSource sets a data source to view directly, instead of a slice held
+// in memory: rows are fetched from the source only for the visible rows,
+// into a window slice that is used as the Slice, with disabled placeholder
+// rows shown while rows are loading.  Rows cannot be added or deleted, and
+// edits are only possible if the source is a SliceSourceSetter.  Note that
+// a StyleFunc is passed the window slice, not the full source, with the
+// row index within the window (the source index is StartIdx + row).
+func (sv *SliceViewBase) SetSource(src SliceSource) {
+	ss, ok := sv.This().(interface{ SetSlice(sl any) })
+	if !ok {
+		return
+	}
+	if src == nil {
+		ss.SetSlice(nil)
+		return
+	}
+	sv.Source = src
+	sv.srcWin = reflect.New(reflect.SliceOf(src.RowType()))
+	sv.srcWin.Elem().Set(reflect.MakeSlice(sv.srcWin.Elem().Type(), 1, 1))
+	sv.srcLoaded = make([]bool, 1)
+	sv.srcStart = 0
+	sv.NoAdd = true
+	sv.NoDelete = true
+	if _, ok := src.(SliceSourceSetter); !ok {
+		sv.SetDisabled()
+	}
+	if sn, ok := src.(SliceSourceNotifier); ok {
+		sn.OnChange(func() {
+			if sv.Source == src {
+				sv.Update()
+			}
+		})
+	}
+	ss.SetSlice(sv.srcWin.Interface())
+	sv.isArray = true // no structural changes
+}
+
+// ClearSourceFor clears the Source when a slice other than its window is
+// set to be viewed
+func (sv *SliceViewBase) ClearSourceFor(sl any) {
+	if sv.Source == nil {
+		return
+	}
+	if !sv.srcWin.IsValid() || sl != sv.srcWin.Interface() {
+		sv.Source = nil
+	}
+}
+
+// FetchRows fetches the visible rows, starting at StartIdx, from the Source
+// into the window slice, and asks a SliceSourceLoader to load any rows that
+// are not yet available -- must be protected by mutex
+func (sv *SliceViewBase) FetchRows() {
+	if sv.Source == nil {
+		return
+	}
+	n := ints.MaxInt(sv.DispRows, 1)
+	win := sv.srcWin.Elem()
+	if win.Len() != n {
+		win.Set(reflect.MakeSlice(win.Type(), n, n))
+		sv.srcLoaded = make([]bool, n)
+	}
+	sv.srcStart = sv.StartIdx
+	sz := sv.Source.Len()
+	st, ed := -1, -1
+	for i := 0; i < n; i++ {
+		si := sv.StartIdx + i
+		el := win.Index(i)
+		var row any
+		ok := false
+		if si < sz {
+			row, ok = sv.Source.Row(si)
+		}
+		ok = ok && setSourceRow(el, row)
+		if !ok {
+			if el.Kind() == reflect.Pointer {
+				el.Set(reflect.New(el.Type().Elem()))
+			} else {
+				el.Set(reflect.Zero(el.Type()))
+			}
+			if si < sz {
+				if st < 0 {
+					st = si
+				}
+				ed = si + 1
+			}
+		}
+		sv.srcLoaded[i] = ok
+	}
+	if st < 0 {
+		return
+	}
+	if ld, ok := sv.Source.(SliceSourceLoader); ok {
+		src := sv.Source
+		ld.LoadRows(st, ed, func() {
+			if sv.Source == src {
+				sv.Update()
+			}
+		})
+	}
+}
+
+// setSourceRow sets the given window element to the given row from a
+// Source, converting between values and pointers as needed -- returns
+// false if the row is not of a compatible type
+func setSourceRow(el reflect.Value, row any) bool {
+	if row == nil {
+		return false
+	}
+	rv := reflect.ValueOf(row)
+	switch {
+	case rv.Type().AssignableTo(el.Type()):
+		el.Set(rv)
+	case rv.Kind() == reflect.Pointer && rv.Elem().Type().AssignableTo(el.Type()):
+		el.Set(rv.Elem())
+	case el.Kind() == reflect.Pointer && rv.Type().AssignableTo(el.Type().Elem()):
+		nv := reflect.New(el.Type().Elem())
+		nv.Elem().Set(rv)
+		el.Set(nv)
+	default:
+		return false
+	}
+	return true
+}
+
+// RowVal returns the reflect.Value of the element at given view index,
+// which for a Source must be within the window of visible rows
+func (sv *SliceViewBase) RowVal(idx int) reflect.Value {
+	if sv.Source == nil {
+		return sv.SliceNPVal.Index(sv.SliceIdx(idx))
+	}
+	wi := idx - sv.srcStart
+	if wi < 0 || wi >= sv.SliceNPVal.Len() {
+		return reflect.Value{}
+	}
+	return sv.SliceNPVal.Index(wi)
+}
+
+// StyleRowIdx returns the index of the row at given view index within the
+// slice passed to StyleRow, which is the window slice for a Source, and
+// otherwise the slice itself -- returns -1 if out of range
+func (sv *SliceViewBase) StyleRowIdx(idx int) int {
+	if sv.Source == nil {
+		return sv.SliceIdx(idx)
+	}
+	wi := idx - sv.srcStart
+	if wi < 0 || wi >= sv.SliceNPVal.Len() {
+		return -1
+	}
+	return wi
+}
+
+// RowLoading returns true if the row at given slice index is a placeholder
+// for a row that is still being loaded from the Source
+func (sv *SliceViewBase) RowLoading(idx int) bool {
+	if sv.Source == nil {
+		return false
+	}
+	wi := idx - sv.srcStart
+	if wi < 0 || wi >= len(sv.srcLoaded) {
+		return true
+	}
+	return !sv.srcLoaded[wi]
+}
+
+// UpdateRowLoading sets the disabled state of the given row widget
+// according to whether its row is still loading from the Source
+func (sv *SliceViewBase) UpdateRowLoading(widg gi.Node2D, idx int) {
+	if sv.Source == nil || sv.IsDisabled() {
+		return
+	}
+	widg.AsNode2D().SetDisabledState(sv.RowLoading(idx))
+}
+
+// SetSourceRow writes the edited row at given slice index back to the
+// Source, if it is a SliceSourceSetter
+func (sv *SliceViewBase) SetSourceRow(idx int) {
+	ss, ok := sv.Source.(SliceSourceSetter)
+	if !ok || sv.RowLoading(idx) {
+		return
+	}
+	if val := sv.RowVal(idx); val.IsValid() {
+		ss.SetRow(idx, val.Interface())
+	}
+}
+
+// SliceIdx returns the slice index of the row at given view index, which
+// differs when ViewIdxs is set -- returns -1 if out of range
+func (sv *SliceViewBase) SliceIdx(idx int) int {
+	if sv.ViewIdxs == nil {
+		return idx
+	}
+	if idx < 0 || idx >= len(sv.ViewIdxs) {
+		return -1
+	}
+	return sv.ViewIdxs[idx]
+}
+
+// ViewIdx returns the view index of the row at given slice index, which
+// differs when ViewIdxs is set -- returns -1 if the row is not shown
+func (sv *SliceViewBase) ViewIdx(sidx int) int {
+	if sv.ViewIdxs == nil {
+		return sidx
+	}
+	for vi, si := range sv.ViewIdxs {
+		if si == sidx {
+			return vi
+		}
+	}
+	return -1
+}
+
+// SetViewIdxs sets the ViewIdxs of the rows shown, keeping the selection
+// on the same slice elements, except for those that are no longer shown
+func (sv *SliceViewBase) SetViewIdxs(idxs []int) {
+	sel := make([]int, 0, len(sv.SelectedIdxs))
+	for idx := range sv.SelectedIdxs {
+		if si := sv.SliceIdx(idx); si >= 0 {
+			sel = append(sel, si)
+		}
+	}
+	selIdx := -1
+	if sv.SelectedIdx >= 0 {
+		selIdx = sv.SliceIdx(sv.SelectedIdx)
+	}
+	sv.ViewIdxs = idxs
+	sv.viewSliceLen = sv.SliceNPVal.Len()
+	vidx := make(map[int]int, len(idxs))
+	for vi, si := range idxs {
+		vidx[si] = vi
+	}
+	toView := func(si int) int {
+		if idxs == nil {
+			return si
+		}
+		if vi, ok := vidx[si]; ok {
+			return vi
+		}
+		return -1
+	}
+	sv.ResetSelectedIdxs()
+	for _, si := range sel {
+		if vi := toView(si); vi >= 0 {
+			sv.SelectedIdxs[vi] = struct{}{}
+		}
+	}
+	if selIdx >= 0 {
+		sv.SelectedIdx = toView(selIdx)
+	}
+}
+
+// ViewIdxsStale returns true if ViewIdxs is set and the slice length has
+// changed other than through the view since it was set
+func (sv *SliceViewBase) ViewIdxsStale() bool {
+	return sv.ViewIdxs != nil && sv.SliceNPVal.IsValid() && sv.SliceNPVal.Len() != sv.viewSliceLen
+}
+
+// SliceInsertIdx returns the slice index at which to insert a new element
+// before the row at given view index -- -1 means the end
+func (sv *SliceViewBase) SliceInsertIdx(idx int) int {
+	if sv.ViewIdxs == nil {
+		return idx
+	}
+	return sv.SliceIdx(idx)
+}
+
+// viewIdxsInserted updates ViewIdxs for n elements inserted into the
+// slice at slice index sidx, showing them before the row at view index idx
+func (sv *SliceViewBase) viewIdxsInserted(idx, sidx, n int) {
+	if sv.ViewIdxs == nil {
+		return
+	}
+	for vi, si := range sv.ViewIdxs {
+		if si >= sidx {
+			sv.ViewIdxs[vi] = si + n
+		}
+	}
+	if idx < 0 || idx > len(sv.ViewIdxs) {
+		idx = len(sv.ViewIdxs)
+	}
+	ins := make([]int, n)
+	for i := range ins {
+		ins[i] = sidx + i
+	}
+	sv.ViewIdxs = append(sv.ViewIdxs[:idx], append(ins, sv.ViewIdxs[idx:]...)...)
+	sv.viewSliceLen += n
+}
+
+// viewIdxsDeleted updates ViewIdxs for the deletion of the row at given
+// view index from the slice
+func (sv *SliceViewBase) viewIdxsDeleted(idx int) {
+	if sv.ViewIdxs == nil || idx < 0 || idx >= len(sv.ViewIdxs) {
+		return
+	}
+	sidx := sv.ViewIdxs[idx]
+	sv.ViewIdxs = append(sv.ViewIdxs[:idx], sv.ViewIdxs[idx+1:]...)
+	for vi, si := range sv.ViewIdxs {
+		if si > sidx {
+			sv.ViewIdxs[vi] = si - 1
+		}
+	}
+	sv.viewSliceLen--
+}
+
 // Update is the high-level update display call -- robust to any changes
 func (sv *SliceViewBase) Update() {
 	if !sv.This().(gi.Node2D).IsVisible() {
@@ -457,8 +787,84 @@ func (sv *SliceViewBase) RowWidgetNs() (nWidgPerRow, idxOff int) {
 	return
 }
 
+// AccessInfo returns the accessibility info for the slice view, which is
+// a List of rows (see AccessKids)
+func (sv *SliceViewBase) AccessInfo() gi.AccessInfo {
+	return sv.AccessInfoBase(gi.AccessRoleList, "")
+}
+
+// AccessKids returns the accessibility children of the slice view: the
+// toolbar, followed by a Row for each visible row (see AccessRows)
+func (sv *SliceViewBase) AccessKids() []*gi.AccessNode {
+	var kids []*gi.AccessNode
+	if tb := sv.ToolBar(); tb != nil {
+		kids = gi.AccessNodes(tb.This())
+	}
+	return append(kids, sv.AccessRows(1, nil)...)
+}
+
+// AccessRows returns a Row node for each visible row of the slice grid,
+// named by its slice index, containing a Cell for each of the given number
+// of value widgets in the row, with the value widget as its child.
+// The index label and add / delete buttons are skipped.  Cells are named
+// by the given column names, if non-nil, and have the value of their widget.
+func (sv *SliceViewBase) AccessRows(nFlds int, cols []string) []*gi.AccessNode {
+	svi := sv.This().(SliceViewer)
+	if !svi.IsConfiged() {
+		return nil
+	}
+	sg := svi.SliceGrid()
+	if sg == nil {
+		return nil
+	}
+	nWidgPerRow, idxOff := svi.RowWidgetNs()
+	var rows []*gi.AccessNode
+	for i := 0; i < sv.DispRows; i++ {
+		ridx := i * nWidgPerRow
+		if ridx+idxOff+nFlds > len(sg.Kids) {
+			break
+		}
+		si := sv.StartIdx + i
+		rpath := sg.Path() + "/row-" + strconv.Itoa(i)
+		rn := &gi.AccessNode{Path: rpath, Role: gi.AccessRoleRow, Name: strconv.Itoa(si)}
+		if sv.IdxIsSelected(si) {
+			rn.States = []gi.AccessStates{gi.AccessSelected}
+		}
+		for fli := 0; fli < nFlds; fli++ {
+			w := sg.Kids[ridx+idxOff+fli]
+			if w == nil {
+				continue
+			}
+			cn := gi.NewAccessNode(w, gi.AccessInfo{Role: gi.AccessRoleCell})
+			cn.Path = rpath + "/cell-" + strconv.Itoa(fli)
+			if fli < len(cols) {
+				cn.Name = cols[fli]
+			}
+			cn.Kids = gi.AccessNodes(w)
+			if len(cn.Kids) > 0 {
+				cn.Value = cn.Kids[0].Value
+				if cn.Value == "" {
+					cn.Value = cn.Kids[0].Name
+				}
+			}
+			rn.BBox = rn.BBox.Union(cn.BBox)
+			rn.Kids = append(rn.Kids, cn)
+		}
+		rows = append(rows, rn)
+	}
+	return rows
+}
+
 // UpdtSliceSize updates and returns the size of the slice and sets SliceSize
 func (sv *SliceViewBase) UpdtSliceSize() int {
+	if sv.Source != nil {
+		sv.SliceSize = sv.Source.Len()
+		return sv.SliceSize
+	}
+	if sv.ViewIdxs != nil {
+		sv.SliceSize = len(sv.ViewIdxs)
+		return sv.SliceSize
+	}
 	sz := sv.SliceNPVal.Len()
 	sv.SliceSize = sz
 	return sz
@@ -731,12 +1137,13 @@ func (sv *SliceViewBase) UpdateSliceGrid() {
 	}
 
 	sv.UpdateStartIdx()
+	sv.FetchRows()
 
 	for i := 0; i < sv.DispRows; i++ {
 		ridx := i * nWidgPerRow
 		si := sv.StartIdx + i // slice idx
 		issel := sv.IdxIsSelected(si)
-		val := kit.OnePtrUnderlyingValue(sv.SliceNPVal.Index(si)) // deal with pointer lists
+		val := kit.OnePtrUnderlyingValue(sv.RowVal(si)) // deal with pointer lists
 		var vv ValueView
 		if sv.Values[i] == nil {
 			vv = ToValueView(val.Interface(), "")
@@ -772,7 +1179,11 @@ func (sv *SliceViewBase) UpdateSliceGrid() {
 					}
 				})
 			}
-			idxlab.SetText(sitxt)
+			if sv.RowLoading(si) {
+				idxlab.SetText("…")
+			} else {
+				idxlab.SetText(sitxt)
+			}
 			idxlab.SetSelectedState(issel)
 		}
 
@@ -809,6 +1220,9 @@ func (sv *SliceViewBase) UpdateSliceGrid() {
 				vvb := vv.AsValueViewBase()
 				vvb.ViewSig.ConnectOnly(sv.This(), func(recv, send ki.Ki, sig int64, data any) {
 					svv, _ := recv.Embed(TypeSliceViewBase).(*SliceViewBase)
+					idx := send.(ValueView).AsValueViewBase().Idx
+					svv.SetSourceRow(idx)
+					svv.Undos.Save(fmt.Sprintf("Edit row %d", idx))
 					svv.SetChanged()
 				})
 				if !sv.isArray {
@@ -849,7 +1263,10 @@ func (sv *SliceViewBase) UpdateSliceGrid() {
 				}
 			}
 		}
-		sv.This().(SliceViewer).StyleRow(sv.SliceNPVal, widg, si, 0, vv)
+		sv.UpdateRowLoading(widg, si)
+		if ri := sv.StyleRowIdx(si); ri >= 0 {
+			sv.This().(SliceViewer).StyleRow(sv.SliceNPVal, widg, ri, 0, vv)
+		}
 	}
 	if sv.SelVal != nil {
 		sv.SelectedIdx, _ = SliceIdxByValue(sv.Slice, sv.SelVal)
@@ -888,13 +1305,15 @@ func (sv *SliceViewBase) SliceNewAt(idx int) {
 	defer sv.UpdateEnd(updt)
 
 	sv.SliceNewAtSel(idx)
+	vidx := idx
+	idx = sv.SliceInsertIdx(idx)
 
 	sltyp := kit.SliceElType(sv.Slice) // has pointer if it is there
 	iski := ki.IsKi(sltyp)
 	slptr := sltyp.Kind() == reflect.Ptr
 
 	svl := reflect.ValueOf(sv.Slice)
-	sz := sv.SliceSize
+	sz := sv.SliceNPVal.Len()
 
 	svnp := sv.SliceNPVal
 
@@ -938,12 +1357,16 @@ func (sv *SliceViewBase) SliceNewAt(idx int) {
 	}
 
 	sv.SliceNPVal = kit.NonPtrValue(reflect.ValueOf(sv.Slice)) // need to update after changes
+	if !iski {
+		sv.viewIdxsInserted(vidx, idx, 1)
+	}
 
 	sv.This().(SliceViewer).UpdtSliceSize()
 
 	if sv.TmpSave != nil {
 		sv.TmpSave.SaveTmp()
 	}
+	sv.Undos.Save(fmt.Sprintf("Insert row %d", idx))
 	sv.SetChanged()
 	sv.ScrollBar().SetFullReRender()
 	sv.SetFullReRender()
@@ -1011,7 +1434,10 @@ func (sv *SliceViewBase) SliceDeleteAt(idx int, doupdt bool) {
 
 	sv.SliceDeleteAtSel(idx)
 
+	vidx := idx
+	idx = sv.SliceIdx(idx)
 	kit.SliceDeleteAt(sv.Slice, idx)
+	sv.viewIdxsDeleted(vidx)
 
 	sv.This().(SliceViewer).UpdtSliceSize()
 
@@ -1021,6 +1447,9 @@ func (sv *SliceViewBase) SliceDeleteAt(idx int, doupdt bool) {
 
 	sv.ViewMuUnlock()
 
+	if doupdt { // otherwise part of a larger action, e.g., DeleteIdxs
+		sv.Undos.Save(fmt.Sprintf("Delete row %d", idx))
+	}
 	sv.SetChanged()
 	if doupdt {
 		sv.SetFullReRender()
@@ -1045,9 +1474,13 @@ func (sv *SliceViewBase) ConfigToolbar() {
 		return
 	}
 	tb := sv.ToolBar()
+	hasAdd := !(sv.isArray || sv.IsDisabled() || sv.NoAdd)
 	ndef := 2 // number of default actions
-	if sv.isArray || sv.IsDisabled() || sv.NoAdd {
-		ndef = 1
+	if hasAdd {
+		ndef++
+	}
+	if !sv.IsDisabled() {
+		ndef++ // undo
 	}
 	if len(*tb.Children()) < ndef {
 		tb.SetStretchMaxWidth()
@@ -1057,13 +1490,17 @@ func (sv *SliceViewBase) ConfigToolbar() {
 				svv.This().(SliceViewer).UpdateSliceGrid()
 
 			})
-		if ndef > 1 {
+		if hasAdd {
 			tb.AddAction(gi.ActOpts{Label: "Add", Icon: icons.Add, Tooltip: "add a new element to the slice"},
 				sv.This(), func(recv, send ki.Ki, sig int64, data any) {
 					svv := recv.Embed(TypeSliceViewBase).(*SliceViewBase)
 					svv.This().(SliceViewer).SliceNewAt(-1)
 				})
 		}
+		if !sv.IsDisabled() {
+			AddUndoAction(tb, sv)
+		}
+		sv.AddCSVAction(tb)
 	}
 	sz := len(*tb.Children())
 	if sz > ndef {
@@ -1148,6 +1585,28 @@ func (sv *SliceViewBase) Render2D() {
 	}
 }
 
+// ViewUndos returns the undo records for the edits of the slice
+func (sv *SliceViewBase) ViewUndos() *ViewUndo {
+	return sv.Undos
+}
+
+// UndoUpdate updates the view after the slice has been restored by an undo
+// or redo of given action
+func (sv *SliceViewBase) UndoUpdate(action string) {
+	sv.SliceNPVal = kit.NonPtrValue(reflect.ValueOf(sv.Slice))
+	sv.ResetSelectedIdxs()
+	sv.SelectedIdx = -1
+	if sv.TmpSave != nil {
+		sv.TmpSave.SaveTmp()
+	}
+	sv.SetChanged()
+	if tv, ok := sv.This().Embed(TypeTableView).(*TableView); ok {
+		tv.FilterSortAction()
+	} else {
+		sv.Update()
+	}
+}
+
 func (sv *SliceViewBase) NeedsDoubleReRender() bool {
 	return false
 }
@@ -1177,7 +1636,11 @@ func (sv *SliceViewBase) SliceVal(idx int) any {
 		fmt.Printf("giv.SliceViewBase: slice index out of range: %v\n", idx)
 		return nil
 	}
-	val := kit.OnePtrUnderlyingValue(sv.SliceNPVal.Index(idx)) // deal with pointer lists
+	if sv.Source != nil {
+		row, _ := sv.Source.Row(idx)
+		return row
+	}
+	val := kit.OnePtrUnderlyingValue(sv.SliceNPVal.Index(sv.SliceIdx(idx))) // deal with pointer lists
 	vali := val.Interface()
 	return vali
 }
@@ -1311,6 +1774,7 @@ func (sv *SliceViewBase) SelectVal(val string) bool {
 	if sv.SelVal != nil {
 		sv.ViewMuLock()
 		idx, _ := SliceIdxByValue(sv.Slice, sv.SelVal)
+		idx = sv.ViewIdx(idx)
 		sv.ViewMuUnlock()
 		if idx >= 0 {
 			sv.ScrollToIdx(idx)
@@ -1760,6 +2224,7 @@ func (sv *SliceViewBase) DeleteIdxs() {
 	for _, i := range ixs {
 		sv.This().(SliceViewer).SliceDeleteAt(i, false)
 	}
+	sv.Undos.Save("Delete rows")
 	sv.SetChanged()
 	sv.This().(SliceViewer).UpdateSliceGrid()
 	sv.UpdateEnd(updt)
@@ -1782,6 +2247,7 @@ func (sv *SliceViewBase) Cut() {
 	for _, i := range ixs {
 		sv.This().(SliceViewer).SliceDeleteAt(i, false)
 	}
+	sv.Undos.Save("Cut rows")
 	sv.SetChanged()
 	sv.SetFullReRender()
 	sv.This().(SliceViewer).UpdateSliceGrid()
@@ -1855,12 +2321,21 @@ func (sv *SliceViewBase) PasteAssign(md mimedata.Mimes, idx int) {
 	if len(sl) == 0 {
 		return
 	}
+	if sv.Source != nil {
+		if ss, ok := sv.Source.(SliceSourceSetter); ok {
+			ss.SetRow(idx, sl[0])
+			sv.SetChanged()
+			sv.Update()
+		}
+		return
+	}
 	updt := sv.UpdateStart()
 	ns := sl[0]
-	sv.SliceNPVal.Index(idx).Set(reflect.ValueOf(ns).Elem())
+	sv.SliceNPVal.Index(sv.SliceIdx(idx)).Set(reflect.ValueOf(ns).Elem())
 	if sv.TmpSave != nil {
 		sv.TmpSave.SaveTmp()
 	}
+	sv.Undos.Save(fmt.Sprintf("Paste to row %d", sv.SliceIdx(idx)))
 	sv.SetChanged()
 	sv.This().(SliceViewer).UpdateSliceGrid()
 	sv.UpdateEnd(updt)
@@ -1877,6 +2352,15 @@ func (sv *SliceViewBase) PasteAtIdx(md mimedata.Mimes, idx int) {
 	wupdt := sv.TopUpdateStart()
 	defer sv.TopUpdateEnd(wupdt)
 	updt := sv.UpdateStart()
+	if idx < 0 || idx > sv.SliceSize {
+		idx = sv.SliceSize
+	}
+	vidx := idx
+	idx = sv.SliceInsertIdx(idx)
+	if idx < 0 {
+		idx = svnp.Len()
+	}
+	sidx := idx
 	for _, ns := range sl {
 		sz := svnp.Len()
 		svnp = reflect.Append(svnp, reflect.ValueOf(ns).Elem())
@@ -1890,15 +2374,17 @@ func (sv *SliceViewBase) PasteAtIdx(md mimedata.Mimes, idx int) {
 	}
 
 	sv.SliceNPVal = kit.NonPtrValue(reflect.ValueOf(sv.Slice)) // need to update after changes
+	sv.viewIdxsInserted(vidx, sidx, len(sl))
 
 	if sv.TmpSave != nil {
 		sv.TmpSave.SaveTmp()
 	}
+	sv.Undos.Save("Paste rows")
 	sv.SetChanged()
 	sv.SetFullReRender()
 	sv.This().(SliceViewer).UpdateSliceGrid()
 	sv.UpdateEnd(updt)
-	sv.SelectIdxAction(idx, mouse.SelectOne)
+	sv.SelectIdxAction(vidx+len(sl), mouse.SelectOne)
 }
 
 // Duplicate copies selected items and inserts them after current selection --
@@ -2028,6 +2514,7 @@ func (sv *SliceViewBase) DragNDropSource(de *dnd.Event) {
 	for _, i := range sv.DraggedIdxs {
 		sv.This().(SliceViewer).SliceDeleteAt(i, false)
 	}
+	sv.Undos.Save("Move rows")
 	sv.DraggedIdxs = nil
 	sv.This().(SliceViewer).UpdateSliceGrid()
 	sv.UpdateEnd(updt)
@@ -2100,6 +2587,12 @@ func (sv *SliceViewBase) StdCtxtMenu(m *gi.Menu, idx int) {
 			svv := recv.Embed(TypeSliceViewBase).(*SliceViewBase)
 			svv.Duplicate()
 		})
+	m.AddSeparator("sep-csv")
+	sv.CSVMenu(m)
+	if sv.Undos != nil {
+		m.AddSeparator("sep-undo")
+		UndoMenu(m, sv)
+	}
 }
 
 func (sv *SliceViewBase) ItemCtxtMenu(idx int) {
@@ -2131,7 +2624,7 @@ func (sv *SliceViewBase) ItemCtxtMenu(idx int) {
 
 // KeyInputNav supports multiple selection navigation keys
 func (sv *SliceViewBase) KeyInputNav(kt *key.ChordEvent) {
-	kf := gi.KeyFun(kt.Chord())
+	kf := gi.KeyFunFor(sv.This(), kt.Chord())
 	selMode := mouse.SelectModeBits(kt.Modifiers)
 	if selMode == mouse.SelectOne {
 		if sv.SelectMode {
@@ -2174,7 +2667,7 @@ func (sv *SliceViewBase) KeyInputActive(kt *key.ChordEvent) {
 		return
 	}
 	idx := sv.SelectedIdx
-	kf := gi.KeyFun(kt.Chord())
+	kf := gi.KeyFunFor(sv.This(), kt.Chord())
 	switch kf {
 	// case gi.KeyFunDelete: // too dangerous
 	// 	sv.This().(SliceViewer).SliceDeleteAt(sv.SelectedIdx, true)
@@ -2211,6 +2704,14 @@ func (sv *SliceViewBase) KeyInputActive(kt *key.ChordEvent) {
 		sv.PasteIdx(sv.SelectedIdx)
 		sv.SelectMode = false
 		kt.SetProcessed()
+	case gi.KeyFunUndo:
+		UndoAction(sv)
+		sv.SelectMode = false
+		kt.SetProcessed()
+	case gi.KeyFunRedo:
+		RedoAction(sv)
+		sv.SelectMode = false
+		kt.SetProcessed()
 	}
 }
 
@@ -2224,7 +2725,7 @@ func (sv *SliceViewBase) KeyInputInactive(kt *key.ChordEvent) {
 			return
 		}
 	}
-	kf := gi.KeyFun(kt.Chord())
+	kf := gi.KeyFunFor(sv.This(), kt.Chord())
 	idx := sv.SelectedIdx
 	switch {
 	case kf == gi.KeyFunMoveDown:
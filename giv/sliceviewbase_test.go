@@ -0,0 +1,72 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv_test
+
+import (
+	"fmt"
+	"image"
+	"reflect"
+	"sync"
+	"testing"
+
+	"goki.dev/gi/v2/gi"
+	"goki.dev/gi/v2/gitest"
+	"goki.dev/gi/v2/giv"
+)
+
+type svRow struct {
+	Idx int
+}
+
+// svSource is a SliceSource of n rows, with the index of each as its value
+type svSource struct {
+	n int
+}
+
+func (ss *svSource) Len() int                { return ss.n }
+func (ss *svSource) RowType() reflect.Type   { return reflect.TypeOf(svRow{}) }
+func (ss *svSource) Row(idx int) (any, bool) { return svRow{Idx: idx}, true }
+
+func TestTableViewSourceStyleFunc(t *testing.T) {
+	win := gi.NewMainWindow("sv-style", "sv-style", 400, 300)
+	vp := win.WinViewport2D()
+	updt := vp.UpdateStart()
+	mfr := win.SetMainFrame()
+	tv := giv.AddNewTableView(mfr, "tv")
+	var mu sync.Mutex
+	var calls int
+	var bad []string
+	tv.StyleFunc = func(tv *giv.TableView, slice any, widg gi.Node2D, row, col int, vv giv.ValueView) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		sl := slice.([]svRow)
+		if row < 0 || row >= len(sl) {
+			bad = append(bad, fmt.Sprintf("row %d out of range of window slice of len %d", row, len(sl)))
+			return
+		}
+		if want := tv.StartIdx + row; sl[row].Idx != want {
+			bad = append(bad, fmt.Sprintf("row %d is source row %d, want %d", row, sl[row].Idx, want))
+		}
+	}
+	tv.SetSource(&svSource{n: 1000})
+	vp.UpdateEndNoSig(updt)
+	win.GoStartEventLoop()
+	defer win.Close()
+	td := gitest.New(win, t)
+	td.WaitIdle()
+	td.Scroll("tv", image.Pt(0, 400))
+	if tv.StartIdx == 0 {
+		t.Fatalf("table did not scroll")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if calls == 0 {
+		t.Errorf("StyleFunc was not called")
+	}
+	for _, b := range bad {
+		t.Error(b)
+	}
+}
@@ -6,9 +6,11 @@ package giv
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -17,6 +19,8 @@ import (
 	"goki.dev/gi/v2/gi"
 	"goki.dev/gi/v2/gist"
 	"goki.dev/gi/v2/icons"
+	"goki.dev/gi/v2/oswin"
+	"goki.dev/gi/v2/oswin/key"
 	"goki.dev/gi/v2/units"
 	"goki.dev/ki/v2/bools"
 	"goki.dev/ki/v2/ki"
@@ -69,6 +73,12 @@ type StructView struct {
 
 	// extra tags by field name -- from type properties
 	TypeFieldTags map[string]string `json:"-" xml:"-" inactive:"+" desc:"extra tags by field name -- from type properties"`
+
+	// if true, the struct has validation tags or a Validate method -- validate when values change, showing errors in a third column of the grid
+	HasValids bool `json:"-" xml:"-" inactive:"+" desc:"if true, the struct has validation tags or a Validate method -- validate when values change, showing errors in a third column of the grid"`
+
+	// [view: -] undo records for the edits of the struct -- nil if they cannot be undone (see NewViewUndo)
+	Undos *ViewUndo `copy:"-" json:"-" xml:"-" view:"-" desc:"undo records for the edits of the struct -- nil if they cannot be undone (see NewViewUndo)"`
 }
 
 var TypeStructView = kit.Types.AddType(&StructView{}, StructViewProps)
@@ -104,13 +114,31 @@ func (sv *StructView) OnChildAdded(child ki.Ki) {
 				s.SetMinPrefWidth(units.Em(10))
 				s.SetStretchMax()                // for this to work, ALL layers above need it too
 				s.Overflow = gist.OverflowScroll // this still gives it true size during PrefSize
-				s.Columns = 2
+				s.Columns = sv.GridCols()
+			})
+		case "valid-msg":
+			w.AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
+				ValidMsgStyle(s)
 			})
 		}
 		if w.Parent().Name() == "struct-grid" {
 			w.AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
 				s.AlignH = gist.AlignLeft
 			})
+			switch {
+			case strings.HasPrefix(w.Name(), "value-"):
+				w.AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
+					if idx, ok := w.IndexInParent(); ok && sv.HasValids {
+						if fi := idx / sv.GridCols(); fi < len(sv.FieldViews) {
+							ValidErrStyle(sv.FieldViews[fi].AsValueViewBase().ValidErr, s)
+						}
+					}
+				})
+			case strings.HasPrefix(w.Name(), "msg-"):
+				w.AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
+					ValidMsgStyle(s)
+				})
+			}
 		}
 	}
 }
@@ -138,6 +166,7 @@ func (sv *StructView) SetStruct(st any) {
 			}
 		}
 		sv.Struct = st
+		sv.Undos = NewViewUndo(st)
 		tp := kit.Types.Properties(kit.NonPtrType(reflect.TypeOf(sv.Struct)), false)
 		if tp != nil {
 			if sfp, has := ki.SubTypeProps(*tp, "StructViewFields"); has {
@@ -168,6 +197,9 @@ func (sv *StructView) UpdateFields() {
 	for _, vv := range sv.FieldViews {
 		vv.UpdateWidget()
 	}
+	if sv.HasValids {
+		sv.Validate()
+	}
 	sv.UpdateEnd(updt)
 }
 
@@ -190,9 +222,13 @@ func (sv *StructView) Config() {
 			return
 		}
 	}
+	sv.HasValids = TypeHasValidation(reflect.TypeOf(sv.Struct))
 	config := kit.TypeAndNameList{}
 	config.Add(gi.TypeToolBar, "toolbar")
 	config.Add(gi.TypeFrame, "struct-grid")
+	if sv.HasValids {
+		config.Add(gi.TypeLabel, "valid-msg")
+	}
 	mods, updt := sv.ConfigChildren(config)
 	sv.ConfigStructGrid()
 	sv.ConfigToolbar()
@@ -214,6 +250,24 @@ func (sv *StructView) StructGrid() *gi.Frame {
 	return sv.ChildByName("struct-grid", 2).(*gi.Frame)
 }
 
+// ValidMsg returns the label showing the validation errors for the struct
+// as a whole, nil if HasValids is false
+func (sv *StructView) ValidMsg() *gi.Label {
+	if lbl, ok := sv.ChildByName("valid-msg", 2).(*gi.Label); ok {
+		return lbl
+	}
+	return nil
+}
+
+// GridCols returns the number of columns in the StructGrid: the label and
+// value of each field, plus its validation error message if HasValids
+func (sv *StructView) GridCols() int {
+	if sv.HasValids {
+		return 3
+	}
+	return 2
+}
+
 // ToolBar returns the toolbar widget
 func (sv *StructView) ToolBar() *gi.ToolBar {
 	return sv.ChildByName("toolbar", 1).(*gi.ToolBar)
@@ -236,17 +290,23 @@ func (sv *StructView) ConfigToolbar() {
 	tb := sv.ToolBar()
 	svtp := kit.NonPtrType(reflect.TypeOf(sv.Struct))
 	ttip := "update this StructView (not any other views that might be present) to show current state of this struct of type: " + svtp.String()
+	ndef := 2 // number of default actions
+	if sv.IsDisabled() {
+		ndef = 1
+	}
 	if len(*tb.Children()) == 0 {
 		tb.AddAction(gi.ActOpts{Label: "UpdtView", Icon: icons.Refresh, Tooltip: ttip},
 			sv.This(), func(recv, send ki.Ki, sig int64, data any) {
 				svv := recv.Embed(TypeStructView).(*StructView)
 				svv.UpdateFields()
 			})
+		if ndef > 1 {
+			AddUndoAction(tb, sv)
+		}
 	} else {
 		act := tb.Child(0).(*gi.Action)
 		act.Tooltip = ttip
 	}
-	ndef := 1 // number of default actions
 	sz := len(*tb.Children())
 	if sz > ndef {
 		for i := sz - 1; i >= ndef; i-- {
@@ -330,6 +390,9 @@ func (sv *StructView) ConfigStructGrid() {
 				valnm := fmt.Sprintf("value-%v", fnm)
 				config.Add(gi.TypeLabel, labnm)
 				config.Add(svtyp, valnm) // todo: extend to diff types using interface..
+				if sv.HasValids {
+					config.Add(gi.TypeLabel, fmt.Sprintf("msg-%v", fnm))
+				}
 				sv.FieldViews = append(sv.FieldViews, svv)
 				return true
 			})
@@ -347,6 +410,9 @@ func (sv *StructView) ConfigStructGrid() {
 		valnm := fmt.Sprintf("value-%v", field.Name)
 		config.Add(gi.TypeLabel, labnm)
 		config.Add(vtyp, valnm) // todo: extend to diff types using interface..
+		if sv.HasValids {
+			config.Add(gi.TypeLabel, fmt.Sprintf("msg-%v", field.Name))
+		}
 		sv.FieldViews = append(sv.FieldViews, vv)
 		return true
 	})
@@ -357,12 +423,13 @@ func (sv *StructView) ConfigStructGrid() {
 		updt = sg.UpdateStart()
 	}
 	sv.HasDefs = false
+	nc := sv.GridCols()
 	for i, vv := range sv.FieldViews {
-		lbl := sg.Child(i * 2).(*gi.Label)
+		lbl := sg.Child(i * nc).(*gi.Label)
 		vvb := vv.AsValueViewBase()
 		vvb.ViewPath = sv.ViewPath
 		lbl.Redrawable = true
-		widg := sg.Child((i * 2) + 1).(gi.Node2D)
+		widg := sg.Child((i * nc) + 1).(gi.Node2D)
 		hasDef, inactTag := StructViewFieldTags(vv, lbl, widg, sv.IsDisabled())
 		if hasDef {
 			sv.HasDefs = true
@@ -384,6 +451,11 @@ func (sv *StructView) ConfigStructGrid() {
 						updtr.Update()
 					}
 				}
+				fnm := vvv.Field.Name
+				if lbl, has := vvv.Tag("label"); has {
+					fnm = lbl
+				}
+				svv.Undos.Save("Edit " + fnm)
 				tb := svv.ToolBar()
 				if tb != nil {
 					tb.UpdateActions()
@@ -394,6 +466,9 @@ func (sv *StructView) ConfigStructGrid() {
 			})
 		}
 	}
+	if sv.HasValids {
+		sv.Validate()
+	}
 	sg.UpdateEnd(updt)
 }
 
@@ -414,12 +489,107 @@ func (sv *StructView) UpdateFieldAction() {
 	} else if sv.HasDefs {
 		sg := sv.StructGrid()
 		updt := sg.UpdateStart()
+		nc := sv.GridCols()
 		for i, vv := range sv.FieldViews {
-			lbl := sg.Child(i * 2).(*gi.Label)
+			lbl := sg.Child(i * nc).(*gi.Label)
 			StructViewFieldDefTag(vv, lbl)
 		}
 		sg.UpdateEnd(updt)
 	}
+	if sv.HasValids && !sv.HasViewIfs { // Config validates
+		sv.Validate()
+	}
+}
+
+// Validate validates the struct (see ValidateStruct), showing the errors
+// for each field next to it, and the other errors below the fields --
+// returns the error, nil if the struct is valid
+func (sv *StructView) Validate() error {
+	if kit.IfaceIsNil(sv.Struct) || !sv.HasValids {
+		return nil
+	}
+	err := ValidateStruct(sv.Struct)
+	if !sv.IsConfiged() {
+		return err
+	}
+	ferrs, serr := SplitFieldErrors(err)
+	sg := sv.StructGrid()
+	nc := sv.GridCols()
+	if sg.NumChildren() < len(sv.FieldViews)*nc {
+		return err
+	}
+	updt := sv.UpdateStart()
+	chg := false // messages changed size: need to redo layout
+	shown := make(map[string]bool, len(ferrs))
+	for i, vv := range sv.FieldViews {
+		vvb := vv.AsValueViewBase()
+		fnm := vvb.Field.Name
+		ferr := ferrs[fnm]
+		shown[fnm] = true
+		vvb.SetValidErr(ferr)
+		msg := sg.Child((i * nc) + 2).(*gi.Label)
+		if msg.Text != errMsg(ferr) {
+			msg.SetText(errMsg(ferr))
+			chg = true
+		}
+	}
+	// errors for fields that are not shown, e.g., view:"-", go below
+	var nms []string
+	for fnm := range ferrs {
+		if !shown[fnm] {
+			nms = append(nms, fnm)
+		}
+	}
+	sort.Strings(nms)
+	var oerrs []error
+	for _, fnm := range nms {
+		oerrs = append(oerrs, &FieldError{Field: fnm, Err: ferrs[fnm]})
+	}
+	if serr != nil {
+		oerrs = append(oerrs, serr)
+	}
+	omsg := errMsg(errors.Join(oerrs...))
+	if vm := sv.ValidMsg(); vm != nil && vm.Text != omsg {
+		vm.SetText(omsg)
+		chg = true
+	}
+	if chg {
+		sv.SetFullReRender()
+	}
+	sv.UpdateEnd(updt)
+	return err
+}
+
+// ViewUndos returns the undo records for the edits of the struct
+func (sv *StructView) ViewUndos() *ViewUndo {
+	return sv.Undos
+}
+
+// UndoUpdate updates the fields after the struct has been restored by an
+// undo or redo of given action
+func (sv *StructView) UndoUpdate(action string) {
+	updt := sv.UpdateStart()
+	if sv.TmpSave != nil {
+		sv.TmpSave.SaveTmp()
+	}
+	sv.Changed = true
+	if sv.ChangeFlag != nil {
+		sv.ChangeFlag.SetBool(true)
+	}
+	sv.UpdateFieldAction()
+	sv.UpdateFields()
+	sv.ToolBar().UpdateActions()
+	sv.ViewSig.Emit(sv.This(), 0, nil)
+	sv.UpdateEnd(updt)
+}
+
+func (sv *StructView) ConnectEvents2D() {
+	sv.Frame.ConnectEvents2D()
+	sv.ConnectEvent(oswin.KeyChordEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d any) {
+		svv := recv.Embed(TypeStructView).(*StructView)
+		kt := d.(*key.ChordEvent)
+		UndoKeyFun(svv, gi.KeyFunFor(svv.This(), kt.Chord()), kt)
+	})
 }
 
 func (sv *StructView) Render2D() {
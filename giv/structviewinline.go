@@ -5,6 +5,7 @@
 package giv
 
 import (
+	"errors"
 	"reflect"
 	"strings"
 
@@ -47,6 +48,9 @@ type StructViewInline struct {
 
 	// if true, some fields have viewif conditional view tags -- update after..
 	HasViewIfs bool `json:"-" xml:"-" inactive:"+" desc:"if true, some fields have viewif conditional view tags -- update after.."`
+
+	// if true, the struct has validation tags or a Validate method -- validate when values change, showing errors after each field
+	HasValids bool `json:"-" xml:"-" inactive:"+" desc:"if true, the struct has validation tags or a Validate method -- validate when values change, showing errors after each field"`
 }
 
 var TypeStructViewInline = kit.Types.AddType(&StructViewInline{}, StructViewInlineProps)
@@ -65,6 +69,20 @@ func (sv *StructViewInline) OnChildAdded(child ki.Ki) {
 				s.AlignH = gist.AlignLeft
 			})
 		}
+		if w.Parent().Name() == "Parts" && strings.HasPrefix(w.Name(), "value-") {
+			w.AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
+				if idx, ok := w.IndexInParent(); ok && sv.HasValids {
+					if fi := idx / sv.PartsPerField(); fi < len(sv.FieldViews) {
+						ValidErrStyle(sv.FieldViews[fi].AsValueViewBase().ValidErr, s)
+					}
+				}
+			})
+		}
+		if w.Parent().Name() == "Parts" && strings.HasPrefix(w.Name(), "msg-") {
+			w.AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
+				ValidMsgStyle(s)
+			})
+		}
 	}
 }
 
@@ -103,6 +121,7 @@ func (sv *StructViewInline) ConfigParts() {
 		return
 	}
 	sv.Parts.Lay = gi.LayoutHoriz
+	sv.HasValids = TypeHasValidation(reflect.TypeOf(sv.Struct))
 	config := kit.TypeAndNameList{}
 	// always start fresh!
 	sv.FieldViews = make([]ValueView, 0)
@@ -131,6 +150,9 @@ func (sv *StructViewInline) ConfigParts() {
 		valnm := "value-" + field.Name
 		config.Add(gi.TypeLabel, labnm)
 		config.Add(vtyp, valnm) // todo: extend to diff types using interface..
+		if sv.HasValids {
+			config.Add(gi.TypeLabel, "msg-"+field.Name)
+		}
 		sv.FieldViews = append(sv.FieldViews, vv)
 		return true
 	})
@@ -142,11 +164,12 @@ func (sv *StructViewInline) ConfigParts() {
 		updt = sv.Parts.UpdateStart()
 	}
 	sv.HasDefs = false
+	np := sv.PartsPerField()
 	for i, vv := range sv.FieldViews {
-		lbl := sv.Parts.Child(i * 2).(*gi.Label)
+		lbl := sv.Parts.Child(i * np).(*gi.Label)
 		vvb := vv.AsValueViewBase()
 		vvb.ViewPath = sv.ViewPath
-		widg := sv.Parts.Child((i * 2) + 1).(gi.Node2D)
+		widg := sv.Parts.Child((i * np) + 1).(gi.Node2D)
 		hasDef, inactTag := StructViewFieldTags(vv, lbl, widg, sv.IsDisabled()) // in structview.go
 		if hasDef {
 			sv.HasDefs = true
@@ -161,9 +184,56 @@ func (sv *StructViewInline) ConfigParts() {
 			})
 		}
 	}
+	if sv.HasValids {
+		sv.Validate()
+	}
 	sv.Parts.UpdateEnd(updt)
 }
 
+// PartsPerField returns the number of parts for each field: its label and
+// value, plus its validation error message if HasValids
+func (sv *StructViewInline) PartsPerField() int {
+	if sv.HasValids {
+		return 3
+	}
+	return 2
+}
+
+// Validate validates the struct (see ValidateStruct), showing the errors
+// for each field after it, and the errors for the struct as a whole after
+// the first field -- returns the error, nil if the struct is valid
+func (sv *StructViewInline) Validate() error {
+	if kit.IfaceIsNil(sv.Struct) || !sv.HasValids {
+		return nil
+	}
+	err := ValidateStruct(sv.Struct)
+	np := sv.PartsPerField()
+	if sv.Parts.NumChildren() < len(sv.FieldViews)*np {
+		return err
+	}
+	ferrs, serr := SplitFieldErrors(err)
+	updt := sv.UpdateStart()
+	chg := false // messages changed size: need to redo layout
+	for i, vv := range sv.FieldViews {
+		vvb := vv.AsValueViewBase()
+		ferr := ferrs[vvb.Field.Name]
+		if i == 0 && serr != nil {
+			ferr = errors.Join(ferr, serr)
+		}
+		vvb.SetValidErr(ferr)
+		msg := sv.Parts.Child((i * np) + 2).(*gi.Label)
+		if msg.Text != errMsg(ferr) {
+			msg.SetText(errMsg(ferr))
+			chg = true
+		}
+	}
+	if chg {
+		sv.SetFullReRender()
+	}
+	sv.UpdateEnd(updt)
+	return err
+}
+
 func (sv *StructViewInline) UpdateFields() {
 	updt := sv.UpdateStart()
 	for _, vv := range sv.FieldViews {
@@ -178,12 +248,16 @@ func (sv *StructViewInline) UpdateFieldAction() {
 	} else if sv.HasDefs {
 		updt := sv.UpdateStart()
 		sv.SetFullReRender() // key to regen
+		np := sv.PartsPerField()
 		for i, vv := range sv.FieldViews {
-			lbl := sv.Parts.Child(i * 2).(*gi.Label)
+			lbl := sv.Parts.Child(i * np).(*gi.Label)
 			StructViewFieldDefTag(vv, lbl)
 		}
 		sv.UpdateEnd(updt)
 	}
+	if sv.HasValids && !sv.HasViewIfs { // ConfigParts validates
+		sv.Validate()
+	}
 }
 
 func (sv *StructViewInline) Render2D() {
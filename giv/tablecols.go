@@ -0,0 +1,690 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"encoding/json"
+	"image"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+
+	"goki.dev/gi/v2/gi"
+	"goki.dev/gi/v2/gist"
+	"goki.dev/gi/v2/icons"
+	"goki.dev/gi/v2/oswin"
+	"goki.dev/gi/v2/oswin/cursor"
+	"goki.dev/gi/v2/oswin/mouse"
+	"goki.dev/gi/v2/units"
+	"goki.dev/ki/v2/ints"
+	"goki.dev/ki/v2/ki"
+	"goki.dev/mat32/v2"
+)
+
+// TableViewCols is the column configuration of a TableView: the order,
+// widths and hidden set of the columns, and the sort fields.  It can be
+// saved in the app prefs under a name (see TableView.ColPrefsKey and
+// TableViewColsMgr).
+type TableViewCols struct {
+
+	// field names in column order -- fields not listed are shown after those listed, in struct order
+	Order []string `desc:"field names in column order -- fields not listed are shown after those listed, in struct order"`
+
+	// column widths in Dp, by field name -- columns without a width are sized by the layout
+	Widths map[string]float32 `desc:"column widths in Dp, by field name -- columns without a width are sized by the layout"`
+
+	// names of the fields whose columns are hidden
+	Hidden map[string]bool `desc:"names of the fields whose columns are hidden"`
+
+	// sort fields, in the format of TableView.SortFieldName
+	Sort string `desc:"sort fields, in the format of TableView.SortFieldName"`
+}
+
+// Names returns the names of given fields in column order, including
+// the hidden ones
+func (tc *TableViewCols) Names(flds []reflect.StructField) []string {
+	has := make(map[string]bool, len(flds))
+	for _, fld := range flds {
+		has[fld.Name] = true
+	}
+	nms := make([]string, 0, len(flds))
+	for _, nm := range tc.Order {
+		if has[nm] {
+			nms = append(nms, nm)
+			has[nm] = false
+		}
+	}
+	for _, fld := range flds {
+		if has[fld.Name] {
+			nms = append(nms, fld.Name)
+		}
+	}
+	return nms
+}
+
+// Fields returns given fields in column order, without the hidden ones
+func (tc *TableViewCols) Fields(flds []reflect.StructField) []reflect.StructField {
+	byName := make(map[string]reflect.StructField, len(flds))
+	for _, fld := range flds {
+		byName[fld.Name] = fld
+	}
+	vis := make([]reflect.StructField, 0, len(flds))
+	for _, nm := range tc.Names(flds) {
+		if !tc.Hidden[nm] {
+			vis = append(vis, byName[nm])
+		}
+	}
+	return vis
+}
+
+// Clone returns a copy of the column configuration, not sharing any
+// slice or map with it
+func (tc *TableViewCols) Clone() TableViewCols {
+	cp := TableViewCols{Sort: tc.Sort}
+	if tc.Order != nil {
+		cp.Order = append([]string{}, tc.Order...)
+	}
+	if tc.Widths != nil {
+		cp.Widths = make(map[string]float32, len(tc.Widths))
+		for nm, wd := range tc.Widths {
+			cp.Widths[nm] = wd
+		}
+	}
+	if tc.Hidden != nil {
+		cp.Hidden = make(map[string]bool, len(tc.Hidden))
+		for nm, hid := range tc.Hidden {
+			cp.Hidden[nm] = hid
+		}
+	}
+	return cp
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//    TableViewColsPrefsMgr
+
+// TableViewColsMgr is the manager of TableView column preferences
+var TableViewColsMgr = TableViewColsPrefsMgr{}
+
+// TableViewColsPrefs is the data structure for recording the column
+// configurations of TableViews by name (see TableView.ColPrefsKey)
+type TableViewColsPrefs map[string]TableViewCols
+
+// TableViewColsPrefsMgr is the manager of TableView column preferences.
+// Records the column configuration of TableViews by name in a persistent
+// file in the app prefs directory, which is only read once, in the same
+// way that WinGeomPrefsMgr records the window geometry -- changes are
+// saved after SaveDelay, so that a series of them is saved only once.
+type TableViewColsPrefsMgr struct {
+
+	// the full set of column configurations
+	Cols TableViewColsPrefs `desc:"the full set of column configurations"`
+
+	// base name of the preferences file in the app prefs directory
+	FileName string `desc:"base name of the preferences file in the app prefs directory"`
+
+	// wait time before saving changes to the preferences file
+	SaveDelay time.Duration `desc:"wait time before saving changes to the preferences file"`
+
+	// mutex that protects updating of Cols
+	Mu sync.Mutex `desc:"mutex that protects updating of Cols"`
+
+	// whether the preferences file has been opened
+	opened bool
+
+	// timer for delayed save
+	saveTimer *time.Timer
+}
+
+// Init does initialization if not yet initialized
+func (mgr *TableViewColsPrefsMgr) Init() {
+	if mgr.Cols == nil {
+		mgr.Cols = make(TableViewColsPrefs)
+		mgr.FileName = "table_view_prefs"
+		mgr.SaveDelay = 1 * time.Second
+	}
+}
+
+// Open opens column preferences from the app prefs directory -- called
+// under mutex
+func (mgr *TableViewColsPrefsMgr) Open() error {
+	mgr.Init()
+	mgr.opened = true
+	pdir := oswin.TheApp.AppPrefsDir()
+	pnm := filepath.Join(pdir, mgr.FileName+".json")
+	b, err := ioutil.ReadFile(pnm)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(b, &mgr.Cols)
+	if err != nil {
+		log.Println(err)
+	}
+	return err
+}
+
+// Save saves column preferences to the app prefs directory -- called
+// under mutex
+func (mgr *TableViewColsPrefsMgr) Save() error {
+	if mgr.Cols == nil {
+		return nil
+	}
+	pdir := oswin.TheApp.AppPrefsDir()
+	pnm := filepath.Join(pdir, mgr.FileName+".json")
+	b, err := json.MarshalIndent(mgr.Cols, "", "\t")
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	err = ioutil.WriteFile(pnm, b, 0644)
+	if err != nil {
+		log.Println(err)
+	}
+	return err
+}
+
+// openIfNeeded opens the preferences file if it has not been opened yet
+// -- called under mutex
+func (mgr *TableViewColsPrefsMgr) openIfNeeded() {
+	if !mgr.opened {
+		mgr.Open()
+	}
+}
+
+// NameCols returns the column configuration saved under given name
+func (mgr *TableViewColsPrefsMgr) NameCols(name string) (TableViewCols, bool) {
+	mgr.Mu.Lock()
+	defer mgr.Mu.Unlock()
+	mgr.openIfNeeded()
+	tc, ok := mgr.Cols[name]
+	if !ok {
+		return tc, false
+	}
+	return tc.Clone(), true
+}
+
+// SetNameCols records given column configuration under given name, and
+// saves the preferences file after SaveDelay
+func (mgr *TableViewColsPrefsMgr) SetNameCols(name string, tc TableViewCols) {
+	mgr.Mu.Lock()
+	defer mgr.Mu.Unlock()
+	mgr.openIfNeeded()
+	mgr.Cols[name] = tc.Clone()
+	if mgr.saveTimer == nil {
+		mgr.saveTimer = time.AfterFunc(mgr.SaveDelay, func() {
+			mgr.Mu.Lock()
+			mgr.saveTimer = nil
+			mgr.Save()
+			mgr.Mu.Unlock()
+		})
+	}
+}
+
+// SavePending saves the preferences file now if there are changes waiting
+// to be saved after SaveDelay
+func (mgr *TableViewColsPrefsMgr) SavePending() error {
+	mgr.Mu.Lock()
+	defer mgr.Mu.Unlock()
+	if mgr.saveTimer == nil {
+		return nil
+	}
+	mgr.saveTimer.Stop()
+	mgr.saveTimer = nil
+	return mgr.Save()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//    TableView columns
+
+// tableColGrab is the distance in dots from the border between two
+// columns of the header within which dragging resizes the column
+const tableColGrab = 4
+
+// tableMinColWidth is the minimum width of a column resized by dragging,
+// in Dp
+const tableMinColWidth = 20
+
+// tableColDrag is the state of a column of the header being resized or
+// moved with the mouse
+type tableColDrag struct {
+
+	// visible field index of the column
+	fldIdx int
+
+	// whether the column is being resized, else moved
+	resize bool
+
+	// whether the column has started moving
+	moved bool
+
+	// mouse position where the drag started
+	startX int
+
+	// width of the column in the grid when the drag started, in dots
+	startWd float32
+
+	// space around the contents of the cells of the column, in dots
+	boxSpc float32
+}
+
+// ColPrefsKey returns the name under which the column configuration is
+// saved in the app prefs: ColPrefsName if set, and otherwise the name of
+// the struct type (StruType) -- empty for no saving
+func (tv *TableView) ColPrefsKey() string {
+	switch {
+	case tv.ColPrefsName == "-":
+		return ""
+	case tv.ColPrefsName != "":
+		return tv.ColPrefsName
+	case tv.StruType != nil:
+		return tv.StruType.Name()
+	}
+	return ""
+}
+
+// OpenColPrefs sets the column configuration (Cols) to the one saved in
+// the app prefs under ColPrefsKey, if any, including the sort fields if no
+// sort has been set -- the prefs are only opened once for a given key
+// and struct type, so that they do not override later changes, e.g., a
+// sort set by the app
+func (tv *TableView) OpenColPrefs() {
+	typ := tv.StructType()
+	key := tv.ColPrefsKey()
+	if key == "" || (tv.colPrefsKey == key && tv.colPrefsType == typ) {
+		return
+	}
+	tv.colPrefsKey, tv.colPrefsType = key, typ
+	tc, ok := TableViewColsMgr.NameCols(key)
+	if !ok {
+		return
+	}
+	tv.Cols = tc
+	if tv.Cols.Sort != "" && tv.SortIdx < 0 {
+		tv.CacheVisFields()
+		tv.SetSortFieldName(tv.Cols.Sort)
+	}
+}
+
+// SaveColPrefs saves the column configuration (Cols), with the current
+// sort fields, in the app prefs under ColPrefsKey, after a delay (see
+// TableViewColsPrefsMgr) -- does nothing if the key is empty
+func (tv *TableView) SaveColPrefs() {
+	key := tv.ColPrefsKey()
+	if key == "" {
+		return
+	}
+	tv.Cols.Sort = tv.SortFieldName()
+	TableViewColsMgr.SetNameCols(key, tv.Cols)
+}
+
+// ColsChanged updates the display after a change in the order or hidden
+// set of the columns (Cols), keeping the sort fields, and saves Cols in
+// the app prefs (see SaveColPrefs)
+func (tv *TableView) ColsChanged() {
+	srt := tv.SortFieldName()
+	tv.CacheVisFields()
+	tv.SortIdx = -1
+	tv.SetSortFieldName(srt)
+	tv.SaveColPrefs()
+	if !tv.IsConfiged() {
+		return
+	}
+	tv.Values = nil
+	updt := tv.UpdateStart()
+	tv.SetFullReRender()
+	tv.ConfigSliceGrid()
+	tv.UpdateEnd(updt)
+	tv.Update()
+}
+
+// SetColHidden sets whether the column of the field of given name is
+// hidden, and updates the display -- the last visible column cannot be
+// hidden
+func (tv *TableView) SetColHidden(fldName string, hide bool) {
+	if tv.Cols.Hidden[fldName] == hide {
+		return
+	}
+	if hide {
+		if tv.VisFieldIdx(fldName) >= 0 && tv.NVisFields <= 1 {
+			return
+		}
+		if tv.Cols.Hidden == nil {
+			tv.Cols.Hidden = make(map[string]bool)
+		}
+		tv.Cols.Hidden[fldName] = true
+	} else {
+		delete(tv.Cols.Hidden, fldName)
+	}
+	tv.ColsChanged()
+}
+
+// MoveCol moves the column of the visible field at index from to before
+// the column of the visible field at index to (NVisFields for after the
+// last one), and updates the display
+func (tv *TableView) MoveCol(from, to int) {
+	if from < 0 || from >= tv.NVisFields || to < 0 || to > tv.NVisFields || to == from || to == from+1 {
+		return
+	}
+	fnm := tv.VisFields[from].Name
+	var tnm string // insert before this one, or after the last visible one if none
+	if to < tv.NVisFields {
+		tnm = tv.VisFields[to].Name
+	}
+	lnm := tv.VisFields[tv.NVisFields-1].Name
+	ord := tv.Cols.Names(TableViewFields(tv.StruType, tv.IsDisabled()))
+	nord := make([]string, 0, len(ord))
+	for _, nm := range ord {
+		switch nm {
+		case fnm:
+			continue
+		case tnm:
+			nord = append(nord, fnm, nm)
+		case lnm:
+			nord = append(nord, nm)
+			if tnm == "" {
+				nord = append(nord, fnm)
+			}
+		default:
+			nord = append(nord, nm)
+		}
+	}
+	tv.Cols.Order = nord
+	tv.ColsChanged()
+}
+
+// SetColWidth sets the width of the column of the field of given name, in
+// Dp -- 0 for the width given by the layout -- and updates the display and
+// saves it in the app prefs (see SaveColPrefs)
+func (tv *TableView) SetColWidth(fldName string, wd float32) {
+	tv.setColWidth(fldName, wd)
+	tv.SaveColPrefs()
+}
+
+// setColWidth sets the width of the column of the field of given name, in
+// Dp, and updates the display
+func (tv *TableView) setColWidth(fldName string, wd float32) {
+	if wd <= 0 {
+		delete(tv.Cols.Widths, fldName)
+	} else {
+		if tv.Cols.Widths == nil {
+			tv.Cols.Widths = make(map[string]float32)
+		}
+		tv.Cols.Widths[fldName] = wd
+	}
+	updt := tv.UpdateStart()
+	tv.SetFullReRender()
+	tv.UpdateEnd(updt)
+}
+
+// ResetCols resets the order, widths and hidden set of the columns to the
+// defaults, keeping the sort fields, and updates the display
+func (tv *TableView) ResetCols() {
+	tv.Cols = TableViewCols{}
+	tv.ColsChanged()
+}
+
+// ColWidthStyler adds a styler to given widget in the column of the field
+// of given name, giving it the width of the column set in Cols, if any
+func (tv *TableView) ColWidthStyler(widg gi.Node2D, fldName string) {
+	wb := widg.AsWidget()
+	if wb == nil {
+		return
+	}
+	wb.AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
+		if wd := tv.Cols.Widths[fldName]; wd > 0 {
+			s.SetFixedWidth(units.Dp(wd))
+		}
+	})
+}
+
+// HeaderColAt returns the visible field index of the column of the header
+// at given window position, or -1 if none, and whether it is on the right
+// border of the column, for resizing it
+func (tv *TableView) HeaderColAt(pos image.Point) (int, bool) {
+	if !tv.IsConfiged() {
+		return -1, false
+	}
+	sgh := tv.SliceHeader()
+	_, idxOff := tv.RowWidgetNs()
+	for fli := 0; fli < tv.NVisFields && idxOff+fli < sgh.NumChildren(); fli++ {
+		hdr, ok := sgh.Child(idxOff + fli).(gi.Node2D)
+		if !ok {
+			continue
+		}
+		wb := hdr.AsWidget()
+		wb.BBoxMu.RLock()
+		bb := wb.WinBBox
+		wb.BBoxMu.RUnlock()
+		if pos.Y < bb.Min.Y || pos.Y >= bb.Max.Y {
+			continue
+		}
+		if ints.AbsInt(pos.X-bb.Max.X) <= tableColGrab {
+			return fli, true
+		}
+		if pos.X >= bb.Min.X && pos.X < bb.Max.X {
+			return fli, false
+		}
+	}
+	return -1, false
+}
+
+// headerInsertIdx returns the visible field index of the column before
+// which a column dropped at given window x position is inserted, or
+// NVisFields for the end
+func (tv *TableView) headerInsertIdx(x int) int {
+	sgh := tv.SliceHeader()
+	_, idxOff := tv.RowWidgetNs()
+	for fli := 0; fli < tv.NVisFields && idxOff+fli < sgh.NumChildren(); fli++ {
+		wb := sgh.Child(idxOff + fli).(gi.Node2D).AsWidget()
+		wb.BBoxMu.RLock()
+		bb := wb.WinBBox
+		wb.BBoxMu.RUnlock()
+		if x < (bb.Min.X+bb.Max.X)/2 {
+			return fli
+		}
+	}
+	return tv.NVisFields
+}
+
+// HeaderPress starts resizing or moving the column of the header at the
+// position of given mouse press
+func (tv *TableView) HeaderPress(me *mouse.Event) {
+	tv.colDrag = nil
+	fli, border := tv.HeaderColAt(me.Where)
+	if fli < 0 {
+		return
+	}
+	cd := &tableColDrag{fldIdx: fli, resize: border, startX: me.Where.X}
+	if border {
+		_, idxOff := tv.RowWidgetNs()
+		sg := tv.SliceGrid()
+		gd := sg.GridData[gi.Col]
+		if idxOff+fli >= len(gd) { // grid empty, e.g., all filtered out
+			return
+		}
+		me.SetProcessed()
+		if me.Action == mouse.DoubleClick {
+			tv.SetColWidth(tv.VisFields[fli].Name, 0)
+			return
+		}
+		cd.startWd = gd[idxOff+fli].AllocSize
+		if sg.Kids.IsValidIndex(idxOff+fli) == nil {
+			if wb := sg.Child(idxOff + fli).(gi.Node2D).AsWidget(); wb != nil {
+				cd.boxSpc = wb.BoxSpace().Size().X
+			}
+		}
+	}
+	tv.colDrag = cd
+}
+
+// HeaderDrag resizes or moves the column of the header started by
+// HeaderPress, for given mouse drag
+func (tv *TableView) HeaderDrag(me *mouse.DragEvent) {
+	cd := tv.colDrag
+	if cd == nil || cd.fldIdx >= tv.NVisFields {
+		return
+	}
+	dx := me.Where.X - cd.startX
+	if cd.resize {
+		me.SetProcessed()
+		dp := tv.Style.UnContext.ToDots(1, units.UnitDp)
+		wd := mat32.Max(cd.startWd+float32(dx)-cd.boxSpc, tableMinColWidth*dp)
+		tv.setColWidth(tv.VisFields[cd.fldIdx].Name, wd/dp)
+		return
+	}
+	if !cd.moved {
+		if ints.AbsInt(dx) < gi.DragStartPix {
+			return
+		}
+		cd.moved = true
+		if win := tv.ParentWindow(); win != nil {
+			oswin.TheApp.Cursor(win.OSWin).PushIfNot(cursor.HandClosed)
+		}
+	}
+	me.SetProcessed()
+}
+
+// HeaderRelease finishes resizing or moving the column of the header
+// started by HeaderPress, for given mouse release
+func (tv *TableView) HeaderRelease(me *mouse.Event) {
+	cd := tv.colDrag
+	tv.colDrag = nil
+	if cd == nil {
+		return
+	}
+	switch {
+	case cd.resize:
+		me.SetProcessed()
+		tv.SaveColPrefs()
+	case cd.moved:
+		me.SetProcessed()
+		if win := tv.ParentWindow(); win != nil {
+			oswin.TheApp.Cursor(win.OSWin).PopIf(cursor.HandClosed)
+		}
+		_, idxOff := tv.RowWidgetNs()
+		if hdr, ok := tv.SliceHeader().Child(idxOff + cd.fldIdx).(*gi.Action); ok {
+			hdr.WasPressed = false // not a click
+		}
+		tv.MoveCol(cd.fldIdx, tv.headerInsertIdx(me.Where.X))
+	}
+}
+
+// HeaderEvents connects the mouse events of the header for resizing the
+// columns by dragging their right border and moving them by dragging
+// their header -- at high priority, to get them before the header actions
+func (tv *TableView) HeaderEvents() {
+	if !tv.IsConfiged() {
+		return
+	}
+	sgh, ok := tv.SliceFrame().ChildByName("header", 0).(*gi.ToolBar)
+	if !ok {
+		return
+	}
+	sgh.ConnectEvent(oswin.MouseEvent, gi.HiPri, func(recv, send ki.Ki, sig int64, d any) {
+		me := d.(*mouse.Event)
+		tvv := headerTableView(recv)
+		if tvv == nil || me.Button != mouse.Left {
+			return
+		}
+		switch me.Action {
+		case mouse.Press, mouse.DoubleClick:
+			tvv.HeaderPress(me)
+		case mouse.Release:
+			tvv.HeaderRelease(me)
+		}
+	})
+	sgh.ConnectEvent(oswin.MouseDragEvent, gi.HiPri, func(recv, send ki.Ki, sig int64, d any) {
+		me := d.(*mouse.DragEvent)
+		if tvv := headerTableView(recv); tvv != nil {
+			tvv.HeaderDrag(me)
+		}
+	})
+	sgh.ConnectEvent(oswin.MouseMoveEvent, gi.HiPri, func(recv, send ki.Ki, sig int64, d any) {
+		me := d.(*mouse.MoveEvent)
+		tvv := headerTableView(recv)
+		if tvv == nil {
+			return
+		}
+		win := tvv.ParentWindow()
+		if win == nil {
+			return
+		}
+		if _, border := tvv.HeaderColAt(me.Where); border {
+			oswin.TheApp.Cursor(win.OSWin).PushIfNot(cursor.LeftRight)
+		} else if tvv.colDrag == nil {
+			oswin.TheApp.Cursor(win.OSWin).PopIf(cursor.LeftRight)
+		}
+	})
+	sgh.ConnectEvent(oswin.MouseFocusEvent, gi.RegPri, func(recv, send ki.Ki, sig int64, d any) {
+		me := d.(*mouse.FocusEvent)
+		tvv := headerTableView(recv)
+		if tvv == nil || me.Action != mouse.Exit || tvv.colDrag != nil {
+			return
+		}
+		if win := tvv.ParentWindow(); win != nil {
+			oswin.TheApp.Cursor(win.OSWin).PopIf(cursor.LeftRight)
+		}
+	})
+}
+
+// headerTableView returns the TableView of given header
+func headerTableView(sgh ki.Ki) *TableView {
+	tvi := sgh.ParentByType(TypeTableView, ki.Embeds)
+	if tvi == nil {
+		return nil
+	}
+	return tvi.Embed(TypeTableView).(*TableView)
+}
+
+// ColsMenu adds actions to given menu for showing and hiding the columns,
+// and resetting them -- fldIdx is the visible field index of the column
+// the menu is for, if any, else -1
+func (tv *TableView) ColsMenu(m *gi.Menu, fldIdx int) {
+	if fldIdx >= 0 && fldIdx < tv.NVisFields {
+		fnm := tv.VisFields[fldIdx].Name
+		m.AddAction(gi.ActOpts{Label: "Hide " + fnm, Icon: icons.VisibilityOff, Data: fnm},
+			tv.This(), func(recv, send ki.Ki, sig int64, data any) {
+				tvv := recv.Embed(TypeTableView).(*TableView)
+				tvv.SetColHidden(data.(string), true)
+			})
+		if tv.Cols.Widths[fnm] > 0 {
+			m.AddAction(gi.ActOpts{Label: "Auto Width", Icon: icons.ViewColumn, Data: fnm, Tooltip: "size the column to fit its contents"},
+				tv.This(), func(recv, send ki.Ki, sig int64, data any) {
+					tvv := recv.Embed(TypeTableView).(*TableView)
+					tvv.SetColWidth(data.(string), 0)
+				})
+		}
+		m.AddSeparator("sep-col")
+	}
+	for _, fnm := range tv.Cols.Names(TableViewFields(tv.StruType, tv.IsDisabled())) {
+		ic := icons.CheckBox
+		if tv.Cols.Hidden[fnm] {
+			ic = icons.CheckBoxOutlineBlank
+		}
+		m.AddAction(gi.ActOpts{Label: fnm, Icon: ic, Data: fnm, Tooltip: "show or hide this column"},
+			tv.This(), func(recv, send ki.Ki, sig int64, data any) {
+				tvv := recv.Embed(TypeTableView).(*TableView)
+				nm := data.(string)
+				tvv.SetColHidden(nm, !tvv.Cols.Hidden[nm])
+			})
+	}
+	m.AddSeparator("sep-cols")
+	m.AddAction(gi.ActOpts{Label: "Reset Columns", Icon: icons.SettingsBackupRestore, Tooltip: "reset the order, widths and visibility of the columns to the defaults"},
+		tv.This(), func(recv, send ki.Ki, sig int64, data any) {
+			tvv := recv.Embed(TypeTableView).(*TableView)
+			tvv.ResetCols()
+		})
+}
+
+// HeaderCtxtMenu pulls up the column menu (see ColsMenu) for the header
+// at given window position
+func (tv *TableView) HeaderCtxtMenu(pos image.Point) {
+	fli, _ := tv.HeaderColAt(pos)
+	var men gi.Menu
+	tv.ColsMenu(&men, fli)
+	gi.PopupMenu(men, pos.X, pos.Y, tv.ViewportSafe(), tv.Nm+"-cols-menu")
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"goki.dev/gi/v2/gi"
+	"goki.dev/gi/v2/gitest"
+	"goki.dev/gi/v2/giv"
+	"goki.dev/gi/v2/oswin"
+)
+
+type tcRow struct {
+	Name string
+	Val  int
+}
+
+func TestTableViewColPrefs(t *testing.T) {
+	mgr := &giv.TableViewColsMgr
+	mgr.Init()
+	defer func(fnm string, dl time.Duration) { mgr.FileName, mgr.SaveDelay = fnm, dl }(mgr.FileName, mgr.SaveDelay)
+	mgr.FileName = "table_view_prefs_test"
+	mgr.SaveDelay = time.Hour // only saved by SavePending
+	pnm := filepath.Join(oswin.TheApp.AppPrefsDir(), mgr.FileName+".json")
+	os.Remove(pnm)
+	defer os.Remove(pnm)
+
+	sl := []tcRow{{"b", 1}, {"a", 2}}
+	win := gi.NewMainWindow("tv-colprefs", "tv-colprefs", 400, 300)
+	defer win.Close()
+	mfr := win.SetMainFrame()
+
+	// with a - name, nothing is saved
+	tv := giv.AddNewTableView(mfr, "none")
+	tv.ColPrefsName = "-"
+	tv.SetSlice(&sl)
+	tv.SetColWidth("Val", 40)
+	for _, nm := range []string{"", "-", "tcRow"} {
+		if _, ok := mgr.NameCols(nm); ok {
+			t.Errorf("column prefs saved under %q with a - ColPrefsName", nm)
+		}
+	}
+
+	// without a name, they are saved under the name of the struct type
+	tv = giv.AddNewTableView(mfr, "default")
+	tv.SetSlice(&sl)
+	if key := tv.ColPrefsKey(); key != "tcRow" {
+		t.Errorf("ColPrefsKey = %q, want tcRow", key)
+	}
+	tv.SetColWidth("Val", 30)
+	if tc, ok := mgr.NameCols("tcRow"); !ok || tc.Widths["Val"] != 30 {
+		t.Errorf("default column prefs = %v, %v, want Val width 30", tc, ok)
+	}
+
+	tv = giv.AddNewTableView(mfr, "saved")
+	tv.ColPrefsName = "tcrows"
+	tv.SetSlice(&sl)
+	tv.SetSortFieldName("Val:down")
+	tv.SetColWidth("Name", 80)
+	tv.SetColWidth("Val", 40)
+	tc, ok := mgr.NameCols("tcrows")
+	if !ok || tc.Widths["Name"] != 80 || tc.Widths["Val"] != 40 || tc.Sort != "Val:down" {
+		t.Errorf("saved column prefs = %v, %v, want widths 80, 40 and sort Val:down", tc, ok)
+	}
+	if _, err := os.Stat(pnm); err == nil {
+		t.Errorf("column prefs file written before SaveDelay")
+	}
+	if err := mgr.SavePending(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(pnm); err != nil {
+		t.Errorf("column prefs file not written by SavePending: %v", err)
+	}
+
+	// a new view with the same name opens the saved columns and sort
+	tv = giv.AddNewTableView(mfr, "opened")
+	tv.ColPrefsName = "tcrows"
+	tv.SetSlice(&sl)
+	if tv.Cols.Widths["Name"] != 80 {
+		t.Errorf("opened Name width = %v, want 80", tv.Cols.Widths["Name"])
+	}
+	if nm := tv.SortFieldName(); nm != "Val:down" {
+		t.Errorf("opened sort = %q, want Val:down", nm)
+	}
+
+	// the prefs are only opened once, so setting a new slice keeps the
+	// current columns
+	tv.Cols.Widths["Name"] = 60
+	sl2 := []tcRow{{"c", 3}}
+	tv.SetSlice(&sl2)
+	if tv.Cols.Widths["Name"] != 60 {
+		t.Errorf("Name width after new slice = %v, want 60", tv.Cols.Widths["Name"])
+	}
+
+	// a sort set by the app is not overridden by the saved sort
+	tv = giv.AddNewTableView(mfr, "appsort")
+	tv.SetSlice(&sl)
+	tv.SetSortFieldName("Name:up")
+	tv.ColPrefsName = "tcrows"
+	tv.OpenColPrefs()
+	if tv.Cols.Widths["Name"] != 80 {
+		t.Errorf("opened Name width = %v, want 80", tv.Cols.Widths["Name"])
+	}
+	if nm := tv.SortFieldName(); nm != "Name:up" {
+		t.Errorf("sort = %q, want the app sort Name:up", nm)
+	}
+}
+
+func TestTableViewColPrefsClose(t *testing.T) {
+	mgr := &giv.TableViewColsMgr
+	mgr.Init()
+	defer func(fnm string, dl time.Duration) { mgr.FileName, mgr.SaveDelay = fnm, dl }(mgr.FileName, mgr.SaveDelay)
+	mgr.FileName = "table_view_prefs_close_test"
+	mgr.SaveDelay = time.Hour
+	pnm := filepath.Join(oswin.TheApp.AppPrefsDir(), mgr.FileName+".json")
+	os.Remove(pnm)
+	defer os.Remove(pnm)
+
+	sl := []tcRow{{"b", 1}}
+	win := gi.NewMainWindow("tv-colprefs-close", "tv-colprefs-close", 400, 300)
+	vp := win.WinViewport2D()
+	updt := vp.UpdateStart()
+	mfr := win.SetMainFrame()
+	tv := giv.AddNewTableView(mfr, "tv")
+	tv.SetSlice(&sl)
+	vp.UpdateEndNoSig(updt)
+	win.GoStartEventLoop()
+	gitest.New(win, t).WaitIdle()
+	tv.SetColWidth("Name", 70)
+
+	// pending changes are saved when a window is closed
+	win.Close()
+	for i := 0; i < 500 && !win.IsClosed(); i++ {
+		time.Sleep(time.Millisecond)
+	}
+	if _, err := os.Stat(pnm); err != nil {
+		t.Errorf("column prefs file not written on window close: %v", err)
+	}
+}
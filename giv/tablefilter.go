@@ -0,0 +1,552 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"cmp"
+	"fmt"
+	"log"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"goki.dev/gi/v2/gi"
+	"goki.dev/gi/v2/icons"
+	"goki.dev/ki/v2/ki"
+	"goki.dev/ki/v2/kit"
+)
+
+// TableViewSortKey is a secondary sort key of a TableView, applied after
+// the primary SortIdx field for rows that are equal on all previous keys
+type TableViewSortKey struct {
+
+	// name of the field to sort by
+	Field string `desc:"name of the field to sort by"`
+
+	// whether to sort in descending order
+	Desc bool `desc:"whether to sort in descending order"`
+}
+
+// TableViewFilter is a parsed filter expression for a column of a
+// TableView.  The expression syntax is:
+//   - text: values containing the text, ignoring case
+//   - =text: values equal to the text, ignoring case
+//   - a|b|c: values equal to one of the alternatives, ignoring case
+//   - /regexp/: values matching the regular expression
+//   - >x, >=x, <x, <=x, =x: numeric values in the given range
+//   - a..b: numeric values between a and b inclusive (either may be omitted)
+//
+// For enum and bool fields, plain text matches equal values only, as
+// selected in the filter bar.
+type TableViewFilter struct {
+
+	// the filter expression
+	Expr string `desc:"the filter expression"`
+
+	// regular expression, for /regexp/ filters
+	re *regexp.Regexp
+
+	// values to match exactly, in lower case
+	vals []string
+
+	// text to match as a substring, in lower case
+	sub string
+
+	// numeric range
+	min, max float64
+
+	// which numeric range limits are used
+	hasMin, hasMax bool
+
+	// whether the numeric range limits are inclusive
+	minIncl, maxIncl bool
+}
+
+// ParseTableViewFilter parses given filter expression -- exact means that
+// plain text only matches equal values (for enum and bool fields)
+func ParseTableViewFilter(expr string, exact bool) (*TableViewFilter, error) {
+	fl := &TableViewFilter{Expr: expr}
+	ex := strings.TrimSpace(expr)
+	switch {
+	case len(ex) >= 2 && strings.HasPrefix(ex, "/") && strings.HasSuffix(ex, "/"):
+		re, err := regexp.Compile(ex[1 : len(ex)-1])
+		if err != nil {
+			return nil, fmt.Errorf("giv.TableViewFilter: invalid regexp in filter %q: %w", expr, err)
+		}
+		fl.re = re
+	case fl.parseRange(ex):
+	case strings.HasPrefix(ex, "="):
+		fl.vals = []string{strings.ToLower(strings.TrimSpace(ex[1:]))}
+	case exact || strings.Contains(ex, "|"):
+		for _, v := range strings.Split(ex, "|") {
+			fl.vals = append(fl.vals, strings.ToLower(strings.TrimSpace(v)))
+		}
+	default:
+		fl.sub = strings.ToLower(ex)
+	}
+	return fl, nil
+}
+
+// parseRange parses a numeric range expression, returning false if it is
+// not one
+func (fl *TableViewFilter) parseRange(ex string) bool {
+	if lo, hi, ok := strings.Cut(ex, ".."); ok {
+		lo, hi = strings.TrimSpace(lo), strings.TrimSpace(hi)
+		if lo == "" && hi == "" {
+			return false
+		}
+		var mn, mx float64
+		var err error
+		if lo != "" {
+			if mn, err = strconv.ParseFloat(lo, 64); err != nil {
+				return false
+			}
+		}
+		if hi != "" {
+			if mx, err = strconv.ParseFloat(hi, 64); err != nil {
+				return false
+			}
+		}
+		fl.min, fl.hasMin, fl.minIncl = mn, lo != "", true
+		fl.max, fl.hasMax, fl.maxIncl = mx, hi != "", true
+		return true
+	}
+	for _, op := range []string{">=", "<=", ">", "<", "="} {
+		if !strings.HasPrefix(ex, op) {
+			continue
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(ex[len(op):]), 64)
+		if err != nil {
+			return false
+		}
+		switch op {
+		case ">=", ">":
+			fl.min, fl.hasMin, fl.minIncl = v, true, op == ">="
+		case "<=", "<":
+			fl.max, fl.hasMax, fl.maxIncl = v, true, op == "<="
+		case "=":
+			fl.min, fl.hasMin, fl.minIncl = v, true, true
+			fl.max, fl.hasMax, fl.maxIncl = v, true, true
+		}
+		return true
+	}
+	return false
+}
+
+// Match returns true if given field value passes the filter
+func (fl *TableViewFilter) Match(val any) bool {
+	if fl.hasMin || fl.hasMax {
+		f, ok := kit.ToFloat(val)
+		if !ok {
+			return false
+		}
+		switch {
+		case fl.hasMin && (f < fl.min || (f == fl.min && !fl.minIncl)):
+			return false
+		case fl.hasMax && (f > fl.max || (f == fl.max && !fl.maxIncl)):
+			return false
+		}
+		return true
+	}
+	str := kit.ToString(val)
+	switch {
+	case fl.re != nil:
+		return fl.re.MatchString(str)
+	case fl.vals != nil:
+		lstr := strings.ToLower(str)
+		for _, v := range fl.vals {
+			if v == lstr {
+				return true
+			}
+		}
+		return false
+	}
+	return strings.Contains(strings.ToLower(str), fl.sub)
+}
+
+// TableViewFilterExact returns true if plain text filters on fields of
+// given type match equal values only (enums and bools)
+func TableViewFilterExact(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Bool || kit.Enums.TypeRegistered(kit.NonPtrType(typ))
+}
+
+/////////////////////////////////////////////////////////////////////////////
+//  TableView filtering and sorting
+
+// FieldVal returns the value of the visible field at given index for the
+// element at given slice index
+func (tv *TableView) FieldVal(sidx, fldIdx int) reflect.Value {
+	val := kit.OnePtrUnderlyingValue(tv.SliceNPVal.Index(sidx))
+	return val.Elem().FieldByIndex(tv.VisFields[fldIdx].Index)
+}
+
+// tableSortKey is a resolved sort key, by visible field index
+type tableSortKey struct {
+	fldIdx int
+	desc   bool
+}
+
+// sortKeys returns the current sort keys: SortIdx and then ThenSort
+func (tv *TableView) sortKeys() []tableSortKey {
+	if tv.SortIdx < 0 || tv.SortIdx >= tv.NVisFields {
+		return nil
+	}
+	keys := []tableSortKey{{tv.SortIdx, tv.SortDesc}}
+	for _, sk := range tv.ThenSort {
+		fli := tv.VisFieldIdx(sk.Field)
+		if fli < 0 || fli == tv.SortIdx {
+			continue
+		}
+		keys = append(keys, tableSortKey{fli, sk.Desc})
+	}
+	return keys
+}
+
+// VisFieldIdx returns the index of the visible field with given name, or -1
+func (tv *TableView) VisFieldIdx(fldName string) int {
+	for fli, fld := range tv.VisFields {
+		if fld.Name == fldName {
+			return fli
+		}
+	}
+	return -1
+}
+
+// UpdateViewIdxs applies the current Filters, Search and sort to set the
+// ViewIdxs of the rows shown, without modifying the slice -- the selection
+// is kept on the same elements where they are still shown
+func (tv *TableView) UpdateViewIdxs() {
+	if tv.Source != nil || kit.IfaceIsNil(tv.Slice) || !tv.SliceNPVal.IsValid() {
+		tv.SetViewIdxs(nil)
+		return
+	}
+	fls := make(map[int]*TableViewFilter)
+	for fli, fld := range tv.VisFields {
+		if expr := tv.Filters[fld.Name]; expr != "" {
+			if fl, err := ParseTableViewFilter(expr, TableViewFilterExact(fld.Type)); err == nil {
+				fls[fli] = fl
+			}
+		}
+	}
+	srch := strings.ToLower(strings.TrimSpace(tv.Search))
+	keys := tv.sortKeys()
+	n := tv.SliceNPVal.Len()
+	if n == 0 || (len(fls) == 0 && srch == "" && len(keys) == 0) {
+		tv.SetViewIdxs(nil)
+		return
+	}
+	idxs := make([]int, 0, n)
+	for si := 0; si < n; si++ {
+		if tv.RowMatches(si, fls, srch) {
+			idxs = append(idxs, si)
+		}
+	}
+	if len(keys) > 0 {
+		sort.SliceStable(idxs, func(i, j int) bool {
+			for _, k := range keys {
+				c := tableCompare(tv.FieldVal(idxs[i], k.fldIdx), tv.FieldVal(idxs[j], k.fldIdx))
+				if c == 0 {
+					continue
+				}
+				if k.desc {
+					return c > 0
+				}
+				return c < 0
+			}
+			return false
+		})
+	}
+	tv.SetViewIdxs(idxs)
+}
+
+// RowMatches returns true if the element at given slice index passes the
+// given filters, by visible field index, and contains the given lower-case
+// search text in one of its visible fields
+func (tv *TableView) RowMatches(sidx int, fls map[int]*TableViewFilter, srch string) bool {
+	for fli, fl := range fls {
+		if !fl.Match(tv.FieldVal(sidx, fli).Interface()) {
+			return false
+		}
+	}
+	if srch == "" {
+		return true
+	}
+	for fli := range tv.VisFields {
+		if strings.Contains(strings.ToLower(kit.ToString(tv.FieldVal(sidx, fli).Interface())), srch) {
+			return true
+		}
+	}
+	return false
+}
+
+// tableCompare compares two field values for sorting, returning -1, 0 or 1
+func tableCompare(a, b reflect.Value) int {
+	switch k := a.Kind(); {
+	case k >= reflect.Int && k <= reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case k >= reflect.Uint && k <= reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case k == reflect.Float32 || k == reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case k == reflect.Bool:
+		switch {
+		case a.Bool() == b.Bool():
+			return 0
+		case b.Bool():
+			return -1
+		}
+		return 1
+	case k == reflect.String:
+		return cmp.Compare(strings.ToLower(a.String()), strings.ToLower(b.String()))
+	}
+	if at, ok := a.Interface().(time.Time); ok {
+		return at.Compare(b.Interface().(time.Time))
+	}
+	return cmp.Compare(strings.ToLower(kit.ToString(a.Interface())), strings.ToLower(kit.ToString(b.Interface())))
+}
+
+// FilterSortAction re-applies the filters, search and sort, and updates
+// the display
+func (tv *TableView) FilterSortAction() {
+	if kit.IfaceIsNil(tv.Slice) || !tv.IsConfiged() {
+		return
+	}
+	wupdt := tv.TopUpdateStart()
+	defer tv.TopUpdateEnd(wupdt)
+
+	updt := tv.UpdateStart()
+	tv.UpdateViewIdxs()
+	tv.SetFullReRender()
+	tv.ScrollBar().SetFullReRender()
+	tv.This().(SliceViewer).LayoutSliceGrid()
+	tv.This().(SliceViewer).UpdateSliceGrid()
+	tv.UpdateEnd(updt)
+}
+
+// SetFilter sets the filter expression for the field of given name (see
+// TableViewFilter for the syntax), and updates the display -- an empty
+// expression removes the filter
+func (tv *TableView) SetFilter(fldName, expr string) {
+	if tv.Filters[fldName] == expr {
+		return
+	}
+	if expr == "" {
+		delete(tv.Filters, fldName)
+	} else {
+		fli := tv.VisFieldIdx(fldName)
+		if fli >= 0 {
+			if _, err := ParseTableViewFilter(expr, TableViewFilterExact(tv.VisFields[fli].Type)); err != nil {
+				log.Println(err)
+			}
+		}
+		if tv.Filters == nil {
+			tv.Filters = make(map[string]string)
+		}
+		tv.Filters[fldName] = expr
+	}
+	tv.FilterSortAction()
+}
+
+// ClearFilters removes all the filters and the search, and updates the
+// display
+func (tv *TableView) ClearFilters() {
+	tv.Filters = nil
+	tv.Search = ""
+	tv.ConfigFilterBar()
+	if sf, ok := tv.SearchField(); ok {
+		sf.SetText("")
+	}
+	tv.FilterSortAction()
+}
+
+// SetSearch sets the quick search text, showing only rows that contain it
+// (ignoring case) in one of their visible fields, and updates the display
+func (tv *TableView) SetSearch(srch string) {
+	if tv.Search == srch {
+		return
+	}
+	tv.Search = srch
+	tv.FilterSortAction()
+}
+
+// SortThenAction adds the field of given index as a secondary sort key
+// after the current ones, or toggles its direction if it is already one
+// (shift-click on the header)
+func (tv *TableView) SortThenAction(fldIdx int) {
+	if tv.Source != nil || fldIdx < 0 || fldIdx >= tv.NVisFields {
+		return
+	}
+	switch {
+	case tv.SortIdx < 0 || tv.SortIdx >= tv.NVisFields:
+		tv.SortIdx = fldIdx
+		tv.SortDesc = false
+	case tv.SortIdx == fldIdx:
+		tv.SortDesc = !tv.SortDesc
+	default:
+		nm := tv.VisFields[fldIdx].Name
+		found := false
+		for i := range tv.ThenSort {
+			if tv.ThenSort[i].Field == nm {
+				tv.ThenSort[i].Desc = !tv.ThenSort[i].Desc
+				found = true
+				break
+			}
+		}
+		if !found {
+			tv.ThenSort = append(tv.ThenSort, TableViewSortKey{Field: nm})
+		}
+	}
+	tv.UpdateSortHeaders()
+	tv.FilterSortAction()
+	tv.SaveColPrefs()
+}
+
+// UpdateSortHeaders updates the header actions to show the sort direction
+// of the sort keys, numbered in order when there is more than one
+func (tv *TableView) UpdateSortHeaders() {
+	if !tv.IsConfiged() {
+		return
+	}
+	sgh := tv.SliceHeader()
+	sgh.SetFullReRender()
+	_, idxOff := tv.RowWidgetNs()
+	keys := tv.sortKeys()
+	for fli := 0; fli < tv.NVisFields; fli++ {
+		hdr, ok := sgh.Child(idxOff + fli).(*gi.Action)
+		if !ok {
+			continue
+		}
+		txt := tv.VisFields[fli].Name
+		hdr.SetIcon("none")
+		for ri, k := range keys {
+			if k.fldIdx != fli {
+				continue
+			}
+			if k.desc {
+				hdr.SetIcon(icons.KeyboardArrowDown)
+			} else {
+				hdr.SetIcon(icons.KeyboardArrowUp)
+			}
+			if len(keys) > 1 {
+				txt += " " + strconv.Itoa(ri+1)
+			}
+		}
+		hdr.SetText(txt)
+	}
+}
+
+// FilterBar returns the filter bar below the header, and false if it is
+// not shown (see ShowFilters)
+func (tv *TableView) FilterBar() (*gi.ToolBar, bool) {
+	if !tv.IsConfiged() {
+		return nil, false
+	}
+	fb, ok := tv.SliceFrame().ChildByName("filters", 1).(*gi.ToolBar)
+	return fb, ok
+}
+
+// SearchField returns the quick search field in the toolbar, and false
+// if it is not shown
+func (tv *TableView) SearchField() (*gi.TextField, bool) {
+	if !tv.IsConfiged() {
+		return nil, false
+	}
+	sf, ok := tv.ToolBar().ChildByName("search", 1).(*gi.TextField)
+	return sf, ok
+}
+
+// SetShowFilters sets whether to show the filter bar, and updates the
+// display -- the filters apply whether or not the bar is shown
+func (tv *TableView) SetShowFilters(show bool) {
+	if tv.ShowFilters == show {
+		return
+	}
+	tv.ShowFilters = show
+	if !tv.IsConfiged() {
+		return
+	}
+	updt := tv.UpdateStart()
+	tv.SetFullReRender()
+	tv.ConfigSliceGrid()
+	tv.UpdateEnd(updt)
+	tv.Update()
+}
+
+// ConfigFilterBar configures the filter bar, with a filter widget for each
+// visible field, aligned with the header: a ComboBox selecting a value
+// for enum and bool fields, and otherwise a TextField taking a
+// TableViewFilter expression
+func (tv *TableView) ConfigFilterBar() {
+	fb, ok := tv.FilterBar()
+	if !ok {
+		return
+	}
+	_, idxOff := tv.RowWidgetNs()
+	config := kit.TypeAndNameList{}
+	if tv.ShowIndex {
+		config.Add(gi.TypeLabel, "filter-idx")
+	}
+	for _, fld := range tv.VisFields {
+		if TableViewFilterExact(fld.Type) {
+			config.Add(gi.TypeComboBox, "filter-"+fld.Name)
+		} else {
+			config.Add(gi.TypeTextField, "filter-"+fld.Name)
+		}
+	}
+	if !tv.IsDisabled() {
+		config.Add(gi.TypeLabel, "filter-add")
+		config.Add(gi.TypeLabel, "filter-del")
+	}
+	mods, updt := fb.ConfigChildren(config)
+	for fli, fld := range tv.VisFields {
+		fldName := fld.Name
+		expr := tv.Filters[fldName]
+		switch fw := fb.Child(idxOff + fli).(type) {
+		case *gi.TextField:
+			fw.Placeholder = "Filter"
+			fw.Tooltip = "filter " + fldName + " by: text, =text, a|b, /regexp/, >x, <=x, or a..b"
+			fw.SetText(expr)
+			fw.TextFieldSig.ConnectOnly(tv.This(), func(recv, send ki.Ki, sig int64, data any) {
+				tvv := recv.Embed(TypeTableView).(*TableView)
+				switch sig {
+				case int64(gi.TextFieldDone), int64(gi.TextFieldDeFocused):
+					tvv.SetFilter(fldName, send.(*gi.TextField).Text())
+				case int64(gi.TextFieldCleared):
+					tvv.SetFilter(fldName, "")
+				}
+			})
+		case *gi.ComboBox:
+			items := []string{"All"}
+			if fld.Type.Kind() == reflect.Bool {
+				items = append(items, "true", "false")
+			} else {
+				for _, ev := range kit.Enums.TypeValues(kit.NonPtrType(fld.Type), false) {
+					items = append(items, ev.Name)
+				}
+			}
+			fw.Tooltip = "show only rows with this " + fldName
+			fw.ItemsFromStringList(items, false, 0)
+			if expr == "" {
+				fw.SelectItem(0)
+			} else {
+				fw.SetCurVal(expr)
+			}
+			fw.ComboSig.ConnectOnly(tv.This(), func(recv, send ki.Ki, sig int64, data any) {
+				tvv := recv.Embed(TypeTableView).(*TableView)
+				expr := ""
+				if sig > 0 {
+					expr = kit.ToString(data)
+				}
+				tvv.SetFilter(fldName, expr)
+			})
+		}
+	}
+	if mods {
+		fb.UpdateEnd(updt)
+	}
+}
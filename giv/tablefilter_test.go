@@ -0,0 +1,98 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv_test
+
+import (
+	"reflect"
+	"testing"
+
+	"goki.dev/gi/v2/gi"
+	"goki.dev/gi/v2/giv"
+)
+
+func TestTableViewFilterMatch(t *testing.T) {
+	tests := []struct {
+		expr  string
+		exact bool
+		match []any
+		nomat []any
+	}{
+		{"ap", false, []any{"apple", "Grape", "AP"}, []any{"pear", ""}},
+		{"  ap ", false, []any{"apple"}, []any{"pear"}},
+		{"", false, []any{"anything", ""}, nil},
+		{"=apple", false, []any{"apple", "Apple"}, []any{"apples", "pineapple"}},
+		{"= apple", false, []any{"apple"}, []any{"apples"}},
+		{"apple|pear", false, []any{"Apple", "pear"}, []any{"apples", "grape"}},
+		{"true", true, []any{true, "True"}, []any{false, "untrue"}},
+		{"Horiz", true, []any{"horiz"}, []any{"HorizFlow"}},
+		{"/^a.*e$/", false, []any{"apple", "ae"}, []any{"Apple", "apples"}},
+		{"/[0-9]+/", false, []any{42, "a1"}, []any{"abc"}},
+		{">5", false, []any{6, 5.5, "10"}, []any{5, 4, "abc"}},
+		{">=5", false, []any{5, 6}, []any{4.99}},
+		{"<5", false, []any{4, -1, 4.99}, []any{5, 6}},
+		{"<=5", false, []any{5, 4}, []any{5.01}},
+		{"=5", false, []any{5, 5.0, int64(5)}, []any{4, 6}},
+		{"2..4", false, []any{2, 3, 4, 2.5}, []any{1, 5, 4.01}},
+		{"2..", false, []any{2, 100}, []any{1.99}},
+		{"..4", false, []any{-10, 4}, []any{4.5}},
+		{"a..b", false, []any{"a..b", "xa..by"}, []any{"ab"}},
+		{"..", false, []any{"..", "a..b"}, []any{"a.b"}},
+		{">x", false, []any{">x", "a>x"}, []any{"x"}},
+	}
+	for _, tt := range tests {
+		fl, err := giv.ParseTableViewFilter(tt.expr, tt.exact)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.expr, err)
+			continue
+		}
+		for _, v := range tt.match {
+			if !fl.Match(v) {
+				t.Errorf("%q: %v (%T) does not match", tt.expr, v, v)
+			}
+		}
+		for _, v := range tt.nomat {
+			if fl.Match(v) {
+				t.Errorf("%q: %v (%T) matches", tt.expr, v, v)
+			}
+		}
+	}
+}
+
+func TestTableViewFilterErrors(t *testing.T) {
+	for _, expr := range []string{"/[a/", "/(/", "/a**/"} {
+		if _, err := giv.ParseTableViewFilter(expr, false); err == nil {
+			t.Errorf("%q: expected error", expr)
+		}
+	}
+	// not a regexp, so taken as plain text
+	for _, expr := range []string{"/", "/a", "a/"} {
+		fl, err := giv.ParseTableViewFilter(expr, false)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", expr, err)
+			continue
+		}
+		if !fl.Match("x" + expr + "x") {
+			t.Errorf("%q: does not match itself as text", expr)
+		}
+	}
+}
+
+func TestTableViewFilterExact(t *testing.T) {
+	tests := []struct {
+		typ   reflect.Type
+		exact bool
+	}{
+		{reflect.TypeOf(true), true},
+		{reflect.TypeOf(gi.LayoutHoriz), true},
+		{reflect.TypeOf(""), false},
+		{reflect.TypeOf(0), false},
+		{reflect.TypeOf(0.0), false},
+	}
+	for _, tt := range tests {
+		if exact := giv.TableViewFilterExact(tt.typ); exact != tt.exact {
+			t.Errorf("%v: exact = %v, want %v", tt.typ, exact, tt.exact)
+		}
+	}
+}
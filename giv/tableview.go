@@ -5,6 +5,7 @@
 package giv
 
 import (
+	"errors"
 	"fmt"
 	"image"
 	"log"
@@ -18,6 +19,7 @@ import (
 	"goki.dev/gi/v2/icons"
 	"goki.dev/gi/v2/oswin"
 	"goki.dev/gi/v2/oswin/cursor"
+	"goki.dev/gi/v2/oswin/key"
 	"goki.dev/gi/v2/units"
 	"goki.dev/ki/v2/ints"
 	"goki.dev/ki/v2/ki"
@@ -25,9 +27,6 @@ import (
 	"goki.dev/mat32/v2"
 )
 
-// todo:
-// * search option, both as a search field and as simple type-to-search
-
 // TableView represents a slice-of-structs as a table, where the fields are
 // the columns, within an overall frame.  It has two modes, determined by
 // Inactive flag: if Inactive, it functions as a mutually-exclusive item
@@ -36,6 +35,11 @@ import (
 // used for closing dialogs).  If !Inactive, it is a full-featured editor with
 // multiple-selection, cut-and-paste, and drag-and-drop, reporting each action
 // taken using the TableViewSig signals
+// Rows can be filtered (Filters, Search) and sorted on multiple fields
+// (SortIdx, ThenSort), which is applied as a view index over the slice
+// (ViewIdxs) without modifying it.
+// Columns can be resized, moved and hidden using the header, and this
+// configuration (Cols) is saved in the app prefs (see ColPrefsName).
 // Automatically has a toolbar with Slice ToolBar props if defined
 // set prop toolbar = false to turn off
 type TableView struct {
@@ -53,6 +57,24 @@ type TableView struct {
 	// whether current sort order is descending
 	SortDesc bool `desc:"whether current sort order is descending"`
 
+	// secondary sort keys, applied in order after SortIdx -- shift-click on a header to add one
+	ThenSort []TableViewSortKey `desc:"secondary sort keys, applied in order after SortIdx -- shift-click on a header to add one"`
+
+	// filter expressions by field name -- see TableViewFilter for the syntax
+	Filters map[string]string `desc:"filter expressions by field name -- see TableViewFilter for the syntax"`
+
+	// quick search text: only rows containing it (ignoring case) in one of their visible fields are shown
+	Search string `desc:"quick search text: only rows containing it (ignoring case) in one of their visible fields are shown"`
+
+	// whether to show the filter bar, with a filter for each field, below the header
+	ShowFilters bool `desc:"whether to show the filter bar, with a filter for each field, below the header"`
+
+	// column configuration: order, widths and hidden set of the columns, and sort fields -- drag the header to move or resize columns, and right-click it to show or hide them
+	Cols TableViewCols `desc:"column configuration: order, widths and hidden set of the columns, and sort fields -- drag the header to move or resize columns, and right-click it to show or hide them"`
+
+	// name under which the column configuration (Cols) is saved in the app prefs, and opened from them when a slice is set -- empty (the default) for the name of the struct type, - for no saving
+	ColPrefsName string `desc:"name under which the column configuration (Cols) is saved in the app prefs, and opened from them when a slice is set -- empty (the default) for the name of the struct type, - for no saving"`
+
 	// [view: -] struct type for each row
 	StruType reflect.Type `copy:"-" view:"-" json:"-" xml:"-" desc:"struct type for each row"`
 
@@ -61,6 +83,16 @@ type TableView struct {
 
 	// [view: -] number of visible fields
 	NVisFields int `copy:"-" view:"-" json:"-" xml:"-" desc:"number of visible fields"`
+
+	// [view: -] if true, the struct type has validation tags or a Validate method -- rows are validated when shown or edited, with errors shown in their cells
+	HasValids bool `copy:"-" view:"-" json:"-" xml:"-" desc:"if true, the struct type has validation tags or a Validate method -- rows are validated when shown or edited, with errors shown in their cells"`
+
+	// column of the header being resized or moved with the mouse
+	colDrag *tableColDrag
+
+	// key and struct type that the column prefs were last opened for
+	colPrefsKey  string
+	colPrefsType reflect.Type
 }
 
 var TypeTableView = kit.Types.AddType(&TableView{}, TableViewProps)
@@ -101,7 +133,7 @@ func (tv *TableView) OnChildAdded(child ki.Ki) {
 				s.Margin.Set()
 				s.Padding.Set()
 			})
-		case "header": // slice header
+		case "header", "filters": // slice header and filter bar
 			sh := child.(*gi.ToolBar)
 			sh.Lay = gi.LayoutHoriz
 			sh.AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
@@ -150,6 +182,7 @@ func (tv *TableView) OnChildAdded(child ki.Ki) {
 // SetSlice sets the source slice that we are viewing -- rebuilds the children
 // to represent this slice (does Update if already viewing).
 func (tv *TableView) SetSlice(sl any) {
+	tv.ClearSourceFor(sl)
 	if kit.IfaceIsNil(sl) {
 		tv.Slice = nil
 		return
@@ -164,6 +197,10 @@ func (tv *TableView) SetSlice(sl any) {
 	tv.StartIdx = 0
 	tv.SortIdx = -1
 	tv.SortDesc = false
+	tv.ThenSort = nil
+	tv.Filters = nil
+	tv.Search = ""
+	tv.ViewIdxs = nil
 	slpTyp := reflect.TypeOf(sl)
 	if slpTyp.Kind() != reflect.Ptr {
 		log.Printf("TableView requires that you pass a pointer to a slice of struct elements -- type is not a Ptr: %v\n", slpTyp.String())
@@ -175,6 +212,10 @@ func (tv *TableView) SetSlice(sl any) {
 	}
 	tv.Slice = sl
 	tv.SliceNPVal = kit.NonPtrValue(reflect.ValueOf(tv.Slice))
+	tv.Undos = nil
+	if tv.Source == nil {
+		tv.Undos = NewViewUndo(sl)
+	}
 	struTyp := tv.StructType()
 	if struTyp.Kind() != reflect.Struct {
 		log.Printf("TableView requires that you pass a slice of struct elements -- type is not a Struct: %v\n", struTyp.String())
@@ -184,6 +225,7 @@ func (tv *TableView) SetSlice(sl any) {
 	tv.ResetSelectedIdxs()
 	tv.SelectMode = false
 	tv.SetFullReRender()
+	tv.OpenColPrefs()
 
 	tv.Config()
 	tv.UpdateEnd(updt)
@@ -200,11 +242,21 @@ func (tv *TableView) StructType() reflect.Type {
 	return tv.StruType
 }
 
-// CacheVisFields computes the number of visible fields in nVisFields and
-// caches those to skip in fieldSkip
+// CacheVisFields computes the visible fields in column order, according
+// to Cols, and their number in NVisFields
 func (tv *TableView) CacheVisFields() {
-	styp := tv.StructType()
-	tv.VisFields = make([]reflect.StructField, 0, 20)
+	tv.VisFields = tv.Cols.Fields(TableViewFields(tv.StructType(), tv.IsDisabled()))
+	tv.NVisFields = len(tv.VisFields)
+	tv.HasValids = TypeHasValidation(tv.StructType())
+}
+
+// TableViewFields returns the fields of given struct type that are shown
+// as columns of a TableView, which is inactive (select-only) or not:
+// exported fields, including those of embedded structs, except those
+// tagged view:"-" or tableview:"-", tableview:"-select" when inactive
+// and tableview:"-edit" when active
+func TableViewFields(styp reflect.Type, inactive bool) []reflect.StructField {
+	flds := make([]reflect.StructField, 0, 20)
 	kit.FlatFieldsTypeFunc(styp, func(typ reflect.Type, fld reflect.StructField) bool {
 		if !fld.IsExported() {
 			return true
@@ -214,9 +266,9 @@ func (tv *TableView) CacheVisFields() {
 		if tvtag != "" {
 			if tvtag == "-" {
 				add = false
-			} else if tvtag == "-select" && tv.IsDisabled() {
+			} else if tvtag == "-select" && inactive {
 				add = false
-			} else if tvtag == "-edit" && !tv.IsDisabled() {
+			} else if tvtag == "-edit" && !inactive {
 				add = false
 			}
 		}
@@ -228,17 +280,17 @@ func (tv *TableView) CacheVisFields() {
 			if typ != styp {
 				rfld, has := styp.FieldByName(fld.Name)
 				if has {
-					tv.VisFields = append(tv.VisFields, rfld)
+					flds = append(flds, rfld)
 				} else {
 					fmt.Printf("TableView: Field name: %v is ambiguous from base struct type: %v, cannot be used in view!\n", fld.Name, styp.String())
 				}
 			} else {
-				tv.VisFields = append(tv.VisFields, fld)
+				flds = append(flds, fld)
 			}
 		}
 		return true
 	})
-	tv.NVisFields = len(tv.VisFields)
+	return flds
 }
 
 // IsConfiged returns true if the widget is fully configured
@@ -324,6 +376,41 @@ func (tv *TableView) RowWidgetNs() (nWidgPerRow, idxOff int) {
 	return
 }
 
+// AccessInfo returns the accessibility info for the table view, which is
+// a Table of rows (see AccessKids)
+func (tv *TableView) AccessInfo() gi.AccessInfo {
+	return tv.AccessInfoBase(gi.AccessRoleTable, "")
+}
+
+// AccessKids returns the accessibility children of the table view: the
+// toolbar, a Row of ColumnHeader nodes for the visible fields, and a Row
+// for each visible row, with a Cell for each field (see AccessRows)
+func (tv *TableView) AccessKids() []*gi.AccessNode {
+	var kids []*gi.AccessNode
+	if tb := tv.ChildByName("toolbar", 0); tb != nil {
+		kids = gi.AccessNodes(tb)
+	}
+	if !tv.IsConfiged() {
+		return kids
+	}
+	sgh := tv.SliceHeader()
+	hn := gi.NewAccessNode(sgh.This(), gi.AccessInfo{Role: gi.AccessRoleRow})
+	cols := make([]string, tv.NVisFields)
+	for fli := 0; fli < tv.NVisFields; fli++ {
+		fld := tv.VisFields[fli]
+		hdr, ok := sgh.ChildByName("head-"+fld.Name, fli).(*gi.Action)
+		if !ok {
+			continue
+		}
+		ai := hdr.AccessInfo()
+		ai.Role = gi.AccessRoleColumnHeader
+		cols[fli] = ai.Name
+		hn.Kids = append(hn.Kids, gi.NewAccessNode(hdr.This(), ai))
+	}
+	kids = append(kids, hn)
+	return append(kids, tv.AccessRows(tv.NVisFields, cols)...)
+}
+
 // ConfigSliceGrid configures the SliceGrid for the current slice
 // this is only called by global Config and updates are guarded by that
 func (tv *TableView) ConfigSliceGrid() {
@@ -341,9 +428,10 @@ func (tv *TableView) ConfigSliceGrid() {
 	}
 
 	tv.CacheVisFields()
+	tv.UpdateViewIdxs()
 
 	sz := tv.This().(SliceViewer).UpdtSliceSize()
-	if sz == 0 {
+	if sz == 0 && tv.ViewIdxs == nil { // still show header if all filtered out
 		return
 	}
 
@@ -351,6 +439,9 @@ func (tv *TableView) ConfigSliceGrid() {
 
 	sgcfg := kit.TypeAndNameList{}
 	sgcfg.Add(gi.TypeToolBar, "header")
+	if tv.ShowFilters {
+		sgcfg.Add(gi.TypeToolBar, "filters")
+	}
 	sgcfg.Add(gi.TypeLayout, "grid-lay")
 	sg.ConfigChildren(sgcfg)
 
@@ -400,15 +491,8 @@ func (tv *TableView) ConfigSliceGrid() {
 		field := tv.VisFields[fli]
 		hdr := sgh.Child(idxOff + fli).(*gi.Action)
 		hdr.SetText(field.Name)
-		if fli == tv.SortIdx {
-			if tv.SortDesc {
-				hdr.SetIcon(icons.KeyboardArrowDown)
-			} else {
-				hdr.SetIcon(icons.KeyboardArrowUp)
-			}
-		}
 		hdr.Data = fli
-		hdr.Tooltip = field.Name + " (click to sort by)"
+		hdr.Tooltip = field.Name + " (click to sort by, shift-click to sort by next, drag to move, drag border to resize, right-click to show or hide columns)"
 		dsc := field.Tag.Get("desc")
 		if dsc != "" {
 			hdr.Tooltip += ": " + dsc
@@ -417,7 +501,11 @@ func (tv *TableView) ConfigSliceGrid() {
 			tvv := recv.Embed(TypeTableView).(*TableView)
 			act := send.(*gi.Action)
 			fldIdx := act.Data.(int)
-			tvv.SortSliceAction(fldIdx)
+			if em := tvv.EventMgr2D(); em != nil && key.HasAllModifierBits(em.LastModBits, key.Shift) {
+				tvv.SortThenAction(fldIdx)
+			} else {
+				tvv.SortSliceAction(fldIdx)
+			}
 		})
 
 		val := kit.OnePtrUnderlyingValue(tv.SliceNPVal.Index(0)) // deal with pointer lists
@@ -434,6 +522,7 @@ func (tv *TableView) ConfigSliceGrid() {
 		widg := ki.NewOfType(vtyp).(gi.Node2D)
 		sgf.SetChild(widg, cidx, valnm)
 		vv.ConfigWidget(widg)
+		tv.ColWidthStyler(widg, field.Name)
 	}
 
 	if !tv.IsDisabled() {
@@ -460,9 +549,8 @@ func (tv *TableView) ConfigSliceGrid() {
 		}
 	}
 
-	if tv.SortIdx >= 0 {
-		tv.SortSlice()
-	}
+	tv.UpdateSortHeaders()
+	tv.ConfigFilterBar()
 	tv.ConfigScroll()
 }
 
@@ -529,14 +617,22 @@ func (tv *TableView) LayoutSliceGrid() bool {
 
 // LayoutHeader updates the header layout based on field widths
 func (tv *TableView) LayoutHeader() {
+	tv.LayoutHeaderBar(tv.SliceHeader())
+	if fb, ok := tv.FilterBar(); ok {
+		tv.LayoutHeaderBar(fb)
+	}
+}
+
+// LayoutHeaderBar updates the layout of the given header or filter bar
+// based on field widths
+func (tv *TableView) LayoutHeaderBar(sgh *gi.ToolBar) {
 	// STYTODO: set these styles in stylers
 	_, idxOff := tv.RowWidgetNs()
 	nfld := tv.NVisFields + idxOff
-	sgh := tv.SliceHeader()
 	sgf := tv.SliceGrid()
 	spc := sgh.Spacing.Dots
 	gd := sgf.GridData[gi.Col]
-	if gd == nil {
+	if len(gd) < nfld || sgh.NumChildren() < nfld { // grid empty, e.g., all filtered out
 		return
 	}
 	sumwd := float32(0)
@@ -551,7 +647,7 @@ func (tv *TableView) LayoutHeader() {
 	}
 	if !tv.IsDisabled() {
 		mx := len(sgf.GridData[gi.Col])
-		for fli := nfld; fli < mx; fli++ {
+		for fli := nfld; fli < mx && fli < sgh.NumChildren(); fli++ {
 			lbl := sgh.Child(fli).(gi.Node2D).AsWidget()
 			wd := gd[fli].AllocSize - spc
 			lbl.SetFixedWidth(units.Dot(wd))
@@ -603,16 +699,18 @@ func (tv *TableView) UpdateSliceGrid() {
 	}
 
 	tv.UpdateStartIdx()
+	tv.FetchRows()
 
 	for i := 0; i < tv.DispRows; i++ {
 		ridx := i * nWidgPerRow
-		si := tv.StartIdx + i // slice idx
+		si := tv.StartIdx + i // view idx
+		sli := tv.SliceIdx(si)
 		issel := tv.IdxIsSelected(si)
-		val := kit.OnePtrUnderlyingValue(tv.SliceNPVal.Index(si)) // deal with pointer lists
+		val := kit.OnePtrUnderlyingValue(tv.RowVal(si)) // deal with pointer lists
 		stru := val.Interface()
 
 		itxt := strconv.Itoa(i)
-		sitxt := strconv.Itoa(si)
+		sitxt := strconv.Itoa(sli)
 		labnm := "index-" + itxt
 		if tv.ShowIndex {
 			var idxlab *gi.Label
@@ -635,12 +733,16 @@ func (tv *TableView) UpdateSliceGrid() {
 				})
 			}
 			idxlab.SetSelectedState(issel)
-			idxlab.SetText(sitxt)
+			if tv.RowLoading(si) {
+				idxlab.SetText("…")
+			} else {
+				idxlab.SetText(sitxt)
+			}
 		}
 
 		vpath := tv.ViewPath + "[" + sitxt + "]"
 		if lblr, ok := tv.Slice.(gi.SliceLabeler); ok {
-			slbl := lblr.ElemLabel(si)
+			slbl := lblr.ElemLabel(sli)
 			if slbl != "" {
 				vpath = tv.ViewPath + "[" + slbl + "]"
 			}
@@ -681,6 +783,12 @@ func (tv *TableView) UpdateSliceGrid() {
 				widg = ki.NewOfType(vtyp).(gi.Node2D)
 				sg.SetChild(widg, cidx, valnm)
 				vv.ConfigWidget(widg)
+				tv.ColWidthStyler(widg, field.Name)
+				widg.AsWidget().AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
+					if tv.HasValids && vvi < len(tv.Values) && tv.Values[vvi] != nil {
+						ValidErrStyle(tv.Values[vvi].AsValueViewBase().ValidErr, s)
+					}
+				})
 				wb := widg.AsWidget()
 				if wb != nil {
 					// totally not worth it now:
@@ -705,12 +813,28 @@ func (tv *TableView) UpdateSliceGrid() {
 					vvb.ViewSig.ConnectOnly(tv.This(), // todo: do we need this?
 						func(recv, send ki.Ki, sig int64, data any) {
 							tvv, _ := recv.Embed(TypeTableView).(*TableView)
+							vvb := send.(ValueView).AsValueViewBase()
+							if wb := vvb.Widget.AsWidget(); wb != nil {
+								if row, ok := wb.Prop("tv-row").(int); ok {
+									tvv.SetSourceRow(tvv.StartIdx + row)
+									if tvv.HasValids {
+										updt := tvv.UpdateStart()
+										tvv.ValidateRow(row)
+										tvv.UpdateEnd(updt)
+									}
+									tvv.Undos.Save(fmt.Sprintf("Edit %v in row %d", vvb.Field.Name, tvv.SliceIdx(tvv.StartIdx+row)))
+								}
+							}
 							tvv.SetChanged()
 						})
 				}
 			}
-			tv.This().(SliceViewer).StyleRow(tv.SliceNPVal, widg, si, fli, vv)
+			tv.UpdateRowLoading(widg, si)
+			if ri := tv.StyleRowIdx(si); ri >= 0 {
+				tv.This().(SliceViewer).StyleRow(tv.SliceNPVal, widg, ri, fli, vv)
+			}
 		}
+		tv.ValidateRow(i)
 
 		if !tv.IsDisabled() {
 			cidx := ridx + tv.NVisFields + idxOff
@@ -753,6 +877,7 @@ func (tv *TableView) UpdateSliceGrid() {
 
 	if tv.SelField != "" && tv.SelVal != nil {
 		tv.SelectedIdx, _ = StructSliceIdxByValue(tv.Slice, tv.SelField, tv.SelVal)
+		tv.SelectedIdx = tv.ViewIdx(tv.SelectedIdx)
 	}
 	if tv.IsDisabled() && tv.SelectedIdx >= 0 {
 		tv.SelectIdx(tv.SelectedIdx)
@@ -760,6 +885,71 @@ func (tv *TableView) UpdateSliceGrid() {
 	tv.UpdateScroll()
 }
 
+// ValidateRow validates the element shown at given display row (see
+// ValidateStruct), showing the errors for each field in its cell, and the
+// other errors, for the element as a whole or fields that are not shown,
+// in its first cell -- returns the error, nil if it is valid
+func (tv *TableView) ValidateRow(row int) error {
+	si := tv.StartIdx + row
+	if !tv.HasValids || row < 0 || row >= tv.DispRows || tv.RowLoading(si) {
+		return nil
+	}
+	nf := tv.NVisFields
+	if nf == 0 || len(tv.Values) < (row+1)*nf {
+		return nil
+	}
+	val := kit.OnePtrUnderlyingValue(tv.RowVal(si))
+	err := ValidateStruct(val.Interface())
+	ferrs, serr := SplitFieldErrors(err)
+	var oerrs []error
+	for _, fld := range TableViewFields(tv.StructType(), tv.IsDisabled()) {
+		if ferr, has := ferrs[fld.Name]; has && tv.VisFieldIdx(fld.Name) < 0 {
+			oerrs = append(oerrs, &FieldError{Field: fld.Name, Err: ferr})
+		}
+	}
+	if serr != nil {
+		oerrs = append(oerrs, serr)
+	}
+	for fli := 0; fli < nf; fli++ {
+		vv := tv.Values[row*nf+fli]
+		if vv == nil {
+			continue
+		}
+		ferr := ferrs[tv.VisFields[fli].Name]
+		if fli == 0 && len(oerrs) > 0 {
+			ferr = errors.Join(append([]error{ferr}, oerrs...)...)
+		}
+		vv.AsValueViewBase().SetValidErr(ferr)
+	}
+	return err
+}
+
+// Validate validates all the elements of the slice (see ValidateStruct),
+// returning the first error, with the index of its element, nil if they
+// are all valid -- elements of a Source are not validated
+func (tv *TableView) Validate() error {
+	if !tv.HasValids || kit.IfaceIsNil(tv.Slice) || tv.Source != nil {
+		return nil
+	}
+	for i := 0; i < tv.SliceNPVal.Len(); i++ {
+		val := kit.OnePtrUnderlyingValue(tv.SliceNPVal.Index(i))
+		if err := ValidateStruct(val.Interface()); err != nil {
+			return fmt.Errorf("%v %v: %w", tv.StructType().Name(), i, err)
+		}
+	}
+	return nil
+}
+
+// UpdtSliceSize updates and returns the number of rows shown, re-applying
+// the filters and sort if the slice has changed size other than through
+// the view
+func (tv *TableView) UpdtSliceSize() int {
+	if tv.ViewIdxsStale() {
+		tv.UpdateViewIdxs()
+	}
+	return tv.SliceViewBase.UpdtSliceSize()
+}
+
 func (tv *TableView) StyleRow(svnp reflect.Value, widg gi.Node2D, idx, fidx int, vv ValueView) {
 	if tv.StyleFunc != nil {
 		tv.StyleFunc(tv, svnp.Interface(), widg, idx, fidx, vv)
@@ -776,16 +966,20 @@ func (tv *TableView) SliceNewAt(idx int) {
 	defer tv.UpdateEnd(updt)
 
 	tv.SliceNewAtSel(idx)
+	vidx := idx
+	idx = tv.SliceInsertIdx(idx)
 	kit.SliceNewAt(tv.Slice, idx)
 	if idx < 0 {
-		idx = tv.SliceSize
+		idx = tv.SliceNPVal.Len() - 1
 	}
+	tv.viewIdxsInserted(vidx, idx, 1)
 
 	tv.This().(SliceViewer).UpdtSliceSize()
 
 	if tv.TmpSave != nil {
 		tv.TmpSave.SaveTmp()
 	}
+	tv.Undos.Save(fmt.Sprintf("Insert row %d", idx))
 	tv.SetChanged()
 	tv.SetFullReRender()
 	tv.ScrollBar().SetFullReRender()
@@ -809,13 +1003,19 @@ func (tv *TableView) SliceDeleteAt(idx int, doupdt bool) {
 
 	tv.SliceDeleteAtSel(idx)
 
+	vidx := idx
+	idx = tv.SliceIdx(idx)
 	kit.SliceDeleteAt(tv.Slice, idx)
+	tv.viewIdxsDeleted(vidx)
 
 	tv.This().(SliceViewer).UpdtSliceSize()
 
 	if tv.TmpSave != nil {
 		tv.TmpSave.SaveTmp()
 	}
+	if doupdt { // otherwise part of a larger action, e.g., DeleteIdxs
+		tv.Undos.Save(fmt.Sprintf("Delete row %d", idx))
+	}
 	tv.SetChanged()
 	if doupdt {
 		tv.SetFullReRender()
@@ -827,18 +1027,19 @@ func (tv *TableView) SliceDeleteAt(idx int, doupdt bool) {
 	tv.SliceViewSig.Emit(tv.This(), int64(SliceViewDeleted), idx)
 }
 
-// SortSlice sorts the slice according to current settings
+// SortSlice sorts the view according to current settings, along with
+// the filters, as a view index without modifying the slice -- a Source is
+// not sorted, as only its visible rows are available
 func (tv *TableView) SortSlice() {
-	if tv.SortIdx < 0 || tv.SortIdx >= len(tv.VisFields) {
-		return
-	}
-	rawIdx := tv.VisFields[tv.SortIdx].Index
-	kit.StructSliceSort(tv.Slice, rawIdx, !tv.SortDesc)
+	tv.UpdateViewIdxs()
 }
 
-// SortSliceAction sorts the slice for given field index -- toggles ascending
-// vs. descending if already sorting on this dimension
+// SortSliceAction sorts the view by given field index only -- toggles
+// ascending vs. descending if already sorting on this dimension
 func (tv *TableView) SortSliceAction(fldIdx int) {
+	if tv.Source != nil {
+		return
+	}
 	oswin.TheApp.Cursor(tv.ParentWindow().OSWin).Push(cursor.Wait)
 	defer oswin.TheApp.Cursor(tv.ParentWindow().OSWin).Pop()
 
@@ -846,35 +1047,18 @@ func (tv *TableView) SortSliceAction(fldIdx int) {
 	defer tv.TopUpdateEnd(wupdt)
 
 	updt := tv.UpdateStart()
-	sgh := tv.SliceHeader()
-	sgh.SetFullReRender()
-	_, idxOff := tv.RowWidgetNs()
-
-	ascending := true
-
-	for fli := 0; fli < tv.NVisFields; fli++ {
-		hdr := sgh.Child(idxOff + fli).(*gi.Action)
-		if fli == fldIdx {
-			if tv.SortIdx == fli {
-				tv.SortDesc = !tv.SortDesc
-				ascending = !tv.SortDesc
-			} else {
-				tv.SortDesc = false
-			}
-			if ascending {
-				hdr.SetIcon(icons.KeyboardArrowUp)
-			} else {
-				hdr.SetIcon(icons.KeyboardArrowDown)
-			}
-		} else {
-			hdr.SetIcon("none")
-		}
+	if tv.SortIdx == fldIdx {
+		tv.SortDesc = !tv.SortDesc
+	} else {
+		tv.SortDesc = false
 	}
-
 	tv.SortIdx = fldIdx
+	tv.ThenSort = nil
+	tv.UpdateSortHeaders()
 	tv.SortSlice()
 	tv.UpdateSliceGrid()
 	tv.UpdateEnd(updt)
+	tv.SaveColPrefs()
 }
 
 // ConfigToolbar configures the toolbar actions
@@ -890,24 +1074,54 @@ func (tv *TableView) ConfigToolbar() {
 		return
 	}
 	tb := tv.ToolBar()
-	ndef := 2 // number of default actions
-	if tv.isArray || tv.IsDisabled() || tv.NoAdd {
-		ndef = 1
+	hasAdd := !(tv.isArray || tv.IsDisabled() || tv.NoAdd)
+	ndef := 4 // number of default actions
+	if hasAdd {
+		ndef++
+	}
+	if !tv.IsDisabled() {
+		ndef++ // undo
 	}
 	if len(*tb.Children()) < ndef {
 		tb.SetStretchMaxWidth()
-		tb.AddAction(gi.ActOpts{Label: "UpdtView", Icon: icons.Refresh, Tooltip: "update this TableView to reflect current state of table"},
+		tb.AddAction(gi.ActOpts{Label: "UpdtView", Icon: icons.Refresh, Tooltip: "update this TableView to reflect current state of table, re-applying any filters and sorting"},
 			tv.This(), func(recv, send ki.Ki, sig int64, data any) {
 				tvv := recv.Embed(TypeTableView).(*TableView)
-				tvv.UpdateSliceGrid()
+				tvv.FilterSortAction()
 			})
-		if ndef > 1 {
+		if hasAdd {
 			tb.AddAction(gi.ActOpts{Label: "Add", Icon: icons.Add, Tooltip: "add a new element to the table"},
 				tv.This(), func(recv, send ki.Ki, sig int64, data any) {
 					tvv := recv.Embed(TypeTableView).(*TableView)
 					tvv.SliceNewAt(-1)
 				})
 		}
+		if !tv.IsDisabled() {
+			AddUndoAction(tb, tv)
+		}
+		tv.AddCSVAction(tb)
+		tb.AddAction(gi.ActOpts{Label: "Filter", Icon: icons.FilterList, Tooltip: "show or hide the filter bar, for filtering the rows by the values of each field"},
+			tv.This(), func(recv, send ki.Ki, sig int64, data any) {
+				tvv := recv.Embed(TypeTableView).(*TableView)
+				tvv.SetShowFilters(!tvv.ShowFilters)
+			})
+		sf := gi.AddNewTextField(tb, "search")
+		sf.Placeholder = "Search"
+		sf.LeadingIcon = icons.Search
+		sf.Tooltip = "show only rows containing this text in one of their fields"
+		sf.AddClearAction()
+		sf.TextFieldSig.Connect(tv.This(), func(recv, send ki.Ki, sig int64, data any) {
+			tvv := recv.Embed(TypeTableView).(*TableView)
+			switch sig {
+			case int64(gi.TextFieldDone), int64(gi.TextFieldDeFocused):
+				tvv.SetSearch(send.(*gi.TextField).Text())
+			case int64(gi.TextFieldCleared):
+				tvv.SetSearch("")
+			}
+		})
+	}
+	if sf, ok := tb.ChildByName("search", ndef-1).(*gi.TextField); ok {
+		sf.SetText(tv.Search)
 	}
 	sz := len(*tb.Children())
 	if sz > ndef {
@@ -923,42 +1137,54 @@ func (tv *TableView) ConfigToolbar() {
 }
 
 // SortFieldName returns the name of the field being sorted, along with :up or
-// :down depending on descending
+// :down depending on descending, followed by any ThenSort fields in the same
+// format, separated by commas
 func (tv *TableView) SortFieldName() string {
-	if tv.SortIdx >= 0 && tv.SortIdx < tv.NVisFields {
-		nm := tv.VisFields[tv.SortIdx].Name
-		if tv.SortDesc {
+	keys := tv.sortKeys()
+	nms := make([]string, len(keys))
+	for i, k := range keys {
+		nm := tv.VisFields[k.fldIdx].Name
+		if k.desc {
 			nm += ":down"
 		} else {
 			nm += ":up"
 		}
-		return nm
+		nms[i] = nm
 	}
-	return ""
+	return strings.Join(nms, ",")
 }
 
-// SetSortField sets sorting to happen on given field and direction -- see
-// SortFieldName for details
+// SetSortField sets sorting to happen on given field and direction, and
+// then on any further comma-separated fields -- see SortFieldName for details
 func (tv *TableView) SetSortFieldName(nm string) {
 	if nm == "" {
 		return
 	}
-	spnm := strings.Split(nm, ":")
-	for fli := 0; fli < tv.NVisFields; fli++ {
-		fld := tv.VisFields[fli]
-		if fld.Name == spnm[0] {
-			tv.SortIdx = fli
+	tv.ThenSort = nil
+	for i, fnm := range strings.Split(nm, ",") {
+		spnm := strings.Split(strings.TrimSpace(fnm), ":")
+		desc := len(spnm) == 2 && spnm[1] == "down"
+		if i > 0 {
+			tv.ThenSort = append(tv.ThenSort, TableViewSortKey{Field: spnm[0], Desc: desc})
+			continue
 		}
-	}
-	if len(spnm) == 2 {
-		if spnm[1] == "down" {
-			tv.SortDesc = true
-		} else {
-			tv.SortDesc = false
+		for fli := 0; fli < tv.NVisFields; fli++ {
+			fld := tv.VisFields[fli]
+			if fld.Name == spnm[0] {
+				tv.SortIdx = fli
+			}
+		}
+		if len(spnm) == 2 {
+			tv.SortDesc = desc
 		}
 	}
 }
 
+func (tv *TableView) ConnectEvents2D() {
+	tv.SliceViewBase.ConnectEvents2D()
+	tv.HeaderEvents()
+}
+
 func (tv *TableView) Layout2D(parBBox image.Rectangle, iter int) bool {
 	redo := tv.Frame.Layout2D(parBBox, iter)
 	if !tv.IsConfiged() {
@@ -1056,6 +1282,7 @@ func (tv *TableView) SelectFieldVal(fld, val string) bool {
 	tv.SelVal = val
 	if tv.SelField != "" && tv.SelVal != nil {
 		idx, _ := StructSliceIdxByValue(tv.Slice, tv.SelField, tv.SelVal)
+		idx = tv.ViewIdx(idx)
 		if idx >= 0 {
 			tv.ScrollToIdx(idx)
 			tv.UpdateSelectIdx(idx, true)
@@ -1092,9 +1319,11 @@ func StructSliceIdxByValue(struSlice any, fldName string, fldVal any) (int, erro
 }
 
 func (tv *TableView) EditIdx(idx int) {
-	val := kit.OnePtrUnderlyingValue(tv.SliceNPVal.Index(idx))
-	stru := val.Interface()
-	tynm := kit.NonPtrType(val.Type()).Name()
+	stru := tv.SliceVal(idx)
+	if stru == nil {
+		return
+	}
+	tynm := kit.NonPtrType(reflect.TypeOf(stru)).Name()
 	lbl := gi.ToLabel(stru)
 	if lbl != "" {
 		tynm += ": " + lbl
@@ -1102,6 +1331,16 @@ func (tv *TableView) EditIdx(idx int) {
 	StructViewDialog(tv.Viewport, stru, DlgOpts{Title: tynm}, nil, nil)
 }
 
+// ItemCtxtMenu pulls up the context menu for given slice index, or the
+// column menu when the mouse is over the header (see HeaderCtxtMenu)
+func (tv *TableView) ItemCtxtMenu(idx int) {
+	if em := tv.EventMgr2D(); em != nil && tv.IsConfiged() && tv.SliceHeader().PosInWinBBox(em.LastMousePos) {
+		tv.HeaderCtxtMenu(em.LastMousePos)
+		return
+	}
+	tv.SliceViewBase.ItemCtxtMenu(idx)
+}
+
 func (tv *TableView) StdCtxtMenu(m *gi.Menu, idx int) {
 	if tv.isArray {
 		return
@@ -220,10 +220,55 @@ func (tv *TextView) Disconnect() {
 	tv.LinkSig.DisconnectAll()
 }
 
+// AccessInfo returns the accessibility info for the text view, with the
+// full text of the buffer as the value
+func (tv *TextView) AccessInfo() gi.AccessInfo {
+	ai := tv.AccessInfoBase(gi.AccessRoleTextArea, "")
+	if tv.Buf != nil {
+		ai.Value = string(tv.Buf.Text())
+	}
+	ai.SetState(tv.IsDisabled(), gi.AccessReadOnly)
+	return ai
+}
+
 var TextViewProps = ki.Props{
 	ki.EnumTypeFlag: TypeTextViewFlags,
 }
 
+// TextViewKeyCmds are the named commands of the TextView key binding
+// layer (see gi.KeyCmds), in addition to the standard key functions --
+// none are bound by default: they can be bound to key chords in the
+// key-cmds property of a given TextView (see gi.SetKeyCmds), or in the
+// TextView scope of the key map preferences, e.g., using
+// TextViewStdKeyCmds.  They operate on the lines of the selection, or the
+// cursor line if there is no selection.
+var TextViewKeyCmds = map[string]func(tv *TextView){
+	"CommentRegion": func(tv *TextView) {
+		st, ed := tv.SelectedLines()
+		tv.Buf.CommentRegion(st, ed)
+	},
+	"AutoIndentRegion": func(tv *TextView) {
+		st, ed := tv.SelectedLines()
+		tv.Buf.AutoIndentRegion(st, ed)
+	},
+	"JoinParaLines": func(tv *TextView) {
+		st, ed := tv.SelectedLines()
+		tv.Buf.JoinParaLines(st, ed)
+	},
+	"TabsToSpaces": func(tv *TextView) {
+		st, ed := tv.SelectedLines()
+		tv.Buf.TabsToSpacesRegion(st, ed)
+	},
+}
+
+// TextViewStdKeyCmds are standard key bindings for TextViewKeyCmds, which
+// are not bound by default, as they would shadow the global key map and
+// alt-key text input -- opt in with gi.SetKeyCmds(tv, "", TextViewStdKeyCmds)
+var TextViewStdKeyCmds = gi.KeyCmds{
+	"Control+;": "CommentRegion",
+	"Alt+Q":     "JoinParaLines",
+}
+
 // TextViewSignals are signals that text view can send
 type TextViewSignals int64
 
@@ -2177,6 +2222,20 @@ func (tv *TextView) HasSelection() bool {
 	return false
 }
 
+// SelectedLines returns the range of lines covered by the selection, or
+// just the cursor line if there is no selection -- end is *exclusive*
+func (tv *TextView) SelectedLines() (st, ed int) {
+	if !tv.HasSelection() {
+		return tv.CursorPos.Ln, tv.CursorPos.Ln + 1
+	}
+	st = tv.SelectReg.Start.Ln
+	ed = tv.SelectReg.End.Ln
+	if tv.SelectReg.End.Ch > 0 || ed == st {
+		ed++
+	}
+	return
+}
+
 // Selection returns the currently selected text as a textbuf.Edit, which
 // captures start, end, and full lines in between -- nil if no selection
 func (tv *TextView) Selection() *textbuf.Edit {
@@ -2783,7 +2842,7 @@ func (tv *TextView) ISpellKeyInput(kt *key.ChordEvent) {
 	isDoc := tv.Buf.Info.Cat == filecat.Doc
 	tp := tv.CursorPos
 
-	kf := gi.KeyFun(kt.Chord())
+	kf := gi.KeyFunFor(tv.This(), kt.Chord())
 	switch kf {
 	case gi.KeyFunMoveUp:
 		if isDoc {
@@ -4140,7 +4199,7 @@ func (tv *TextView) KeyInput(kt *key.ChordEvent) {
 	if gi.KeyEventTrace {
 		fmt.Printf("TextView KeyInput: %v\n", tv.Path())
 	}
-	kf := gi.KeyFun(kt.Chord())
+	kf := gi.KeyFunFor(tv.This(), kt.Chord())
 	win := tv.ParentWindow()
 	tv.ClearScopelights()
 
@@ -4168,6 +4227,16 @@ func (tv *TextView) KeyInput(kt *key.ChordEvent) {
 		return
 	}
 
+	if cmd, _, ok := gi.KeyCmdFor(tv.This(), kt.Chord()); ok {
+		if fn, has := TextViewKeyCmds[cmd]; has {
+			kt.SetProcessed()
+			if !tv.IsDisabled() {
+				fn(tv)
+			}
+			return
+		}
+	}
+
 	// cancelAll cancels search, completer, and..
 	cancelAll := func() {
 		tv.CancelComplete()
@@ -0,0 +1,729 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"goki.dev/gi/v2/gi"
+	"goki.dev/gi/v2/gist"
+	"goki.dev/gi/v2/icons"
+	"goki.dev/gi/v2/units"
+	"goki.dev/ki/v2/ki"
+	"goki.dev/ki/v2/kit"
+)
+
+// TimeTagLayouts are the layouts tried, in order, when parsing min / max
+// struct tags for time values
+var TimeTagLayouts = []string{DefaultTimeFormat, time.RFC3339, "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+// ParseTimeTag parses a time value from a min / max struct tag, trying
+// each of the TimeTagLayouts in turn
+func ParseTimeTag(tag string) (time.Time, error) {
+	tag = strings.TrimSpace(tag)
+	for _, lay := range TimeTagLayouts {
+		if tm, err := time.ParseInLocation(lay, tag, time.Local); err == nil {
+			return tm, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("giv.ParseTimeTag: could not parse time: %q", tag)
+}
+
+// ClampTime returns tm constrained to lie within min and max -- a zero min
+// or max means no constraint on that end
+func ClampTime(tm, min, max time.Time) time.Time {
+	if !min.IsZero() && tm.Before(min) {
+		return min
+	}
+	if !max.IsZero() && tm.After(max) {
+		return max
+	}
+	return tm
+}
+
+// sundayRegions and saturdayRegions are the locale regions whose weeks
+// start on Sunday or Saturday -- all others start on Monday
+var (
+	sundayRegions   = map[string]bool{"US": true, "CA": true, "MX": true, "BR": true, "JP": true, "KR": true, "TW": true, "HK": true, "IL": true, "PH": true, "ZA": true, "IN": true, "SA": true, "PE": true, "CO": true}
+	saturdayRegions = map[string]bool{"AE": true, "AF": true, "BH": true, "DZ": true, "EG": true, "IQ": true, "IR": true, "JO": true, "KW": true, "LY": true, "OM": true, "QA": true, "SY": true}
+)
+
+// LocaleWeekStart returns the first day of the week for the current
+// locale, as given by the LC_ALL, LC_TIME or LANG environment variables
+// (e.g., en_US.UTF-8 starts on Sunday, and de_DE.UTF-8 on Monday)
+func LocaleWeekStart() time.Weekday {
+	loc := ""
+	for _, ev := range []string{"LC_ALL", "LC_TIME", "LANG"} {
+		if loc = os.Getenv(ev); loc != "" {
+			break
+		}
+	}
+	if i := strings.IndexAny(loc, ".@"); i >= 0 {
+		loc = loc[:i]
+	}
+	reg := ""
+	if i := strings.IndexAny(loc, "_-"); i >= 0 {
+		reg = strings.ToUpper(loc[i+1:])
+	}
+	switch {
+	case sundayRegions[reg]:
+		return time.Sunday
+	case saturdayRegions[reg]:
+		return time.Saturday
+	case reg == "" && (loc == "" || loc == "C" || loc == "POSIX"):
+		return time.Sunday
+	}
+	return time.Monday
+}
+
+/////////////////////////////////////////////////////////////////////////////
+//  DateView
+
+// DateView is a calendar month view for selecting a date, with buttons to
+// move between months, and optional Min and Max dates outside of which days
+// cannot be selected.  The time of day of Date is preserved when a new day
+// is selected.
+type DateView struct {
+	gi.Frame
+
+	// the currently selected date
+	Date time.Time `desc:"the currently selected date"`
+
+	// the earliest date that can be selected -- zero for no limit
+	Min time.Time `desc:"the earliest date that can be selected -- zero for no limit"`
+
+	// the latest date that can be selected -- zero for no limit
+	Max time.Time `desc:"the latest date that can be selected -- zero for no limit"`
+
+	// the first day of the week shown in the calendar -- defaults to LocaleWeekStart
+	WeekStart time.Weekday `desc:"the first day of the week shown in the calendar -- defaults to LocaleWeekStart"`
+
+	// [view: -] signal for date view -- only one signal sent when a new date has been selected
+	ViewSig ki.Signal `json:"-" xml:"-" view:"-" desc:"signal for date view -- only one signal sent when a new date has been selected"`
+
+	// first day of the month currently being shown
+	month time.Time
+}
+
+var TypeDateView = kit.Types.AddType(&DateView{}, DateViewProps)
+
+// AddNewDateView adds a new dateview to given parent node, with given name.
+func AddNewDateView(parent ki.Ki, name string) *DateView {
+	return parent.AddNewChild(TypeDateView, name).(*DateView)
+}
+
+func (dv *DateView) OnInit() {
+	dv.Lay = gi.LayoutVert
+	dv.WeekStart = LocaleWeekStart()
+	dv.AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
+		dv.Spacing = gi.StdDialogVSpaceUnits
+	})
+}
+
+func (dv *DateView) OnChildAdded(child ki.Ki) {
+	if w := gi.KiAsWidget(child); w != nil {
+		switch w.Name() {
+		case "header":
+			w.AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
+				s.AlignV = gist.AlignMiddle
+			})
+		case "month":
+			w.AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
+				s.AlignV = gist.AlignMiddle
+				s.MinWidth.SetCh(16)
+			})
+		case "grid":
+			w.AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
+				s.Columns = 7
+			})
+		}
+	}
+}
+
+func (dv *DateView) Disconnect() {
+	dv.Frame.Disconnect()
+	dv.ViewSig.DisconnectAll()
+}
+
+var DateViewProps = ki.Props{
+	ki.EnumTypeFlag: gi.TypeNodeFlags,
+}
+
+// SetDate sets the selected date, constrained to Min and Max, and shows
+// the month containing it
+func (dv *DateView) SetDate(tm time.Time) {
+	if tm.IsZero() {
+		tm = time.Now()
+	}
+	dv.Date = ClampTime(tm, dv.Min, dv.Max)
+	dv.month = time.Date(dv.Date.Year(), dv.Date.Month(), 1, 0, 0, 0, 0, dv.Date.Location())
+	dv.Config()
+	dv.ConfigGrid()
+}
+
+// SetMonth shows the month containing the given date, without changing
+// the selected date
+func (dv *DateView) SetMonth(tm time.Time) {
+	dv.month = time.Date(tm.Year(), tm.Month(), 1, 0, 0, 0, 0, tm.Location())
+	dv.ConfigGrid()
+}
+
+// DayInRange returns true if the given day lies within the Min and Max
+// dates, compared at the resolution of whole days
+func (dv *DateView) DayInRange(day time.Time) bool {
+	dst := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	if !dv.Max.IsZero() && dst.After(dv.Max) {
+		return false
+	}
+	if !dv.Min.IsZero() && !dst.AddDate(0, 0, 1).After(dv.Min) {
+		return false
+	}
+	return true
+}
+
+// SelectDay selects the given day, keeping the time of day of the current
+// Date, and emits ViewSig
+func (dv *DateView) SelectDay(day time.Time) {
+	d := dv.Date
+	nd := time.Date(day.Year(), day.Month(), day.Day(), d.Hour(), d.Minute(), d.Second(), d.Nanosecond(), d.Location())
+	dv.Date = ClampTime(nd, dv.Min, dv.Max)
+	dv.SetMonth(day)
+	dv.ViewSig.Emit(dv.This(), 0, dv.Date)
+}
+
+// Config configures a standard setup of entire view
+func (dv *DateView) Config() {
+	if dv.HasChildren() {
+		return
+	}
+	updt := dv.UpdateStart()
+	hdr := gi.AddNewLayout(dv, "header", gi.LayoutHoriz)
+	prev := gi.AddNewButton(hdr, "prev")
+	prev.Type = gi.ButtonText
+	prev.SetIcon(icons.NavigateBefore)
+	prev.Tooltip = "Previous month"
+	prev.ButtonSig.Connect(dv.This(), func(recv, send ki.Ki, sig int64, data any) {
+		if sig == int64(gi.ButtonClicked) {
+			dvv := recv.Embed(TypeDateView).(*DateView)
+			dvv.SetMonth(dvv.month.AddDate(0, -1, 0))
+		}
+	})
+	gi.AddNewLabel(hdr, "month", "").Type = gi.LabelTitleMedium
+	gi.AddNewStretch(hdr, "stretch")
+	today := gi.AddNewButton(hdr, "today")
+	today.Type = gi.ButtonText
+	today.SetIcon(icons.Today)
+	today.Tooltip = "Go to today"
+	today.ButtonSig.Connect(dv.This(), func(recv, send ki.Ki, sig int64, data any) {
+		if sig == int64(gi.ButtonClicked) {
+			dvv := recv.Embed(TypeDateView).(*DateView)
+			if now := time.Now(); dvv.DayInRange(now) {
+				dvv.SelectDay(now)
+			} else {
+				dvv.SetMonth(now)
+			}
+		}
+	})
+	next := gi.AddNewButton(hdr, "next")
+	next.Type = gi.ButtonText
+	next.SetIcon(icons.NavigateNext)
+	next.Tooltip = "Next month"
+	next.ButtonSig.Connect(dv.This(), func(recv, send ki.Ki, sig int64, data any) {
+		if sig == int64(gi.ButtonClicked) {
+			dvv := recv.Embed(TypeDateView).(*DateView)
+			dvv.SetMonth(dvv.month.AddDate(0, 1, 0))
+		}
+	})
+	gi.AddNewLayout(dv, "grid", gi.LayoutGrid)
+	dv.UpdateEnd(updt)
+}
+
+// ConfigGrid configures the calendar grid of days for the current month
+func (dv *DateView) ConfigGrid() {
+	hdr := dv.ChildByName("header", 0).(*gi.Layout)
+	grid := dv.ChildByName("grid", 1).(*gi.Layout)
+	if dv.month.IsZero() {
+		dv.SetMonth(time.Now())
+		return
+	}
+	updt := dv.UpdateStart()
+	hdr.ChildByName("month", 1).(*gi.Label).SetText(dv.month.Format("January 2006"))
+	grid.DeleteChildren(ki.DestroyKids)
+	for i := 0; i < 7; i++ {
+		wd := time.Weekday((int(dv.WeekStart) + i) % 7)
+		lb := gi.AddNewLabel(grid, "wd-"+strconv.Itoa(i), wd.String()[:2])
+		lb.Type = gi.LabelLabelMedium
+		lb.AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
+			s.Color = gi.ColorScheme.OnSurfaceVariant
+			s.Text.Align = gist.AlignCenter
+			s.MinWidth.SetEm(2.5)
+		})
+	}
+	off := (int(dv.month.Weekday()) - int(dv.WeekStart) + 7) % 7
+	st := dv.month.AddDate(0, 0, -off)
+	now := time.Now()
+	for i := 0; i < 42; i++ {
+		day := st.AddDate(0, 0, i)
+		inMonth := day.Month() == dv.month.Month()
+		sel := sameDay(day, dv.Date)
+		bt := gi.AddNewButton(grid, "day-"+strconv.Itoa(i))
+		bt.SetText(strconv.Itoa(day.Day()))
+		switch {
+		case sel:
+			bt.Type = gi.ButtonFilled
+		case sameDay(day, now):
+			bt.Type = gi.ButtonOutlined
+		default:
+			bt.Type = gi.ButtonText
+		}
+		bt.SetDisabledState(!dv.DayInRange(day))
+		bt.AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
+			s.MinWidth.SetEm(2.5)
+			s.Padding.Set(units.Px(4 * gi.Prefs.DensityMul()))
+			s.Border.Radius = gist.BorderRadiusFull
+			if !inMonth && !sel {
+				s.Color = gi.ColorScheme.OnSurfaceVariant
+			}
+		})
+		bt.ButtonSig.Connect(dv.This(), func(recv, send ki.Ki, sig int64, data any) {
+			if sig == int64(gi.ButtonClicked) {
+				dvv := recv.Embed(TypeDateView).(*DateView)
+				dvv.SelectDay(day)
+			}
+		})
+	}
+	dv.SetFullReRender()
+	dv.UpdateEnd(updt)
+}
+
+// sameDay returns true if a and b fall on the same calendar day
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+/////////////////////////////////////////////////////////////////////////////
+//  TimeView
+
+// TimeView is a time-of-day picker, with spin boxes for the hour, minute
+// and optionally second of Time -- the date part of Time is preserved.
+type TimeView struct {
+	gi.Frame
+
+	// the time that we view -- only the time of day is edited
+	Time time.Time `desc:"the time that we view -- only the time of day is edited"`
+
+	// whether to show and edit seconds in addition to hours and minutes
+	ShowSeconds bool `desc:"whether to show and edit seconds in addition to hours and minutes"`
+
+	// [view: -] signal for time view -- only one signal sent when the time has been changed
+	ViewSig ki.Signal `json:"-" xml:"-" view:"-" desc:"signal for time view -- only one signal sent when the time has been changed"`
+}
+
+var TypeTimeView = kit.Types.AddType(&TimeView{}, TimeViewProps)
+
+// AddNewTimeView adds a new timeview to given parent node, with given name.
+func AddNewTimeView(parent ki.Ki, name string) *TimeView {
+	return parent.AddNewChild(TypeTimeView, name).(*TimeView)
+}
+
+func (tv *TimeView) OnInit() {
+	tv.Lay = gi.LayoutHoriz
+	tv.AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
+		s.AlignV = gist.AlignMiddle
+	})
+}
+
+func (tv *TimeView) OnChildAdded(child ki.Ki) {
+	if w := gi.KiAsWidget(child); w != nil {
+		if _, ok := child.(*gi.Label); ok {
+			w.AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
+				s.AlignV = gist.AlignMiddle
+			})
+		}
+	}
+}
+
+func (tv *TimeView) Disconnect() {
+	tv.Frame.Disconnect()
+	tv.ViewSig.DisconnectAll()
+}
+
+var TimeViewProps = ki.Props{
+	ki.EnumTypeFlag: gi.TypeNodeFlags,
+}
+
+// SetTime sets the time to view
+func (tv *TimeView) SetTime(tm time.Time) {
+	tv.Time = tm
+	tv.Config()
+	tv.UpdateSpins()
+}
+
+// Config configures a standard setup of entire view
+func (tv *TimeView) Config() {
+	if tv.HasChildren() {
+		return
+	}
+	updt := tv.UpdateStart()
+	icn := gi.AddNewIcon(tv, "icon", icons.Schedule)
+	icn.Tooltip = "Time of day"
+	tv.addSpin("hour", 23)
+	gi.AddNewLabel(tv, "hm-sep", ":")
+	tv.addSpin("minute", 59)
+	if tv.ShowSeconds {
+		gi.AddNewLabel(tv, "ms-sep", ":")
+		tv.addSpin("second", 59)
+	}
+	tv.UpdateEnd(updt)
+}
+
+// addSpin adds a spin box for one component of the time of day
+func (tv *TimeView) addSpin(name string, max float32) {
+	sb := gi.AddNewSpinBox(tv, name)
+	sb.SetMin(0)
+	sb.SetMax(max)
+	sb.Step = 1
+	sb.PageStep = 10
+	sb.Format = "%02d"
+	sb.SpinBoxSig.ConnectOnly(tv.This(), func(recv, send ki.Ki, sig int64, data any) {
+		tvv := recv.Embed(TypeTimeView).(*TimeView)
+		tvv.SpinsToTime()
+		tvv.ViewSig.Emit(tvv.This(), 0, tvv.Time)
+	})
+}
+
+// spinVal returns the value of the given spin box, or -1 if not present
+func (tv *TimeView) spinVal(name string) int {
+	if sbk := tv.ChildByName(name, 0); sbk != nil {
+		return int(sbk.(*gi.SpinBox).Value)
+	}
+	return -1
+}
+
+// SpinsToTime sets the time of day of Time from the spin box values
+func (tv *TimeView) SpinsToTime() {
+	t := tv.Time
+	sec := tv.spinVal("second")
+	nsec := 0
+	if sec < 0 {
+		sec = t.Second()
+		nsec = t.Nanosecond()
+	}
+	tv.Time = time.Date(t.Year(), t.Month(), t.Day(), tv.spinVal("hour"), tv.spinVal("minute"), sec, nsec, t.Location())
+}
+
+// UpdateSpins updates the spin box values from Time
+func (tv *TimeView) UpdateSpins() {
+	vals := map[string]int{"hour": tv.Time.Hour(), "minute": tv.Time.Minute(), "second": tv.Time.Second()}
+	for nm, v := range vals {
+		if sbk := tv.ChildByName(nm, 0); sbk != nil {
+			sbk.(*gi.SpinBox).SetValue(float32(v))
+		}
+	}
+}
+
+/////////////////////////////////////////////////////////////////////////////
+//  Durations
+
+// FormatDuration formats a duration in a compact human-readable form,
+// e.g., "1h 30m", "2d 4h" or "1.5s" -- durations below a second use the
+// standard time.Duration format, e.g., "250ms"
+func FormatDuration(d time.Duration) string {
+	if d == 0 {
+		return "0s"
+	}
+	neg := d < 0
+	if neg {
+		d = -d
+	}
+	var str string
+	if d < time.Second {
+		str = d.String()
+	} else {
+		var parts []string
+		day := 24 * time.Hour
+		if d >= day {
+			parts = append(parts, fmt.Sprintf("%dd", d/day))
+			d %= day
+		}
+		if d >= time.Hour {
+			parts = append(parts, fmt.Sprintf("%dh", d/time.Hour))
+			d %= time.Hour
+		}
+		if d >= time.Minute {
+			parts = append(parts, fmt.Sprintf("%dm", d/time.Minute))
+			d %= time.Minute
+		}
+		if d > 0 {
+			parts = append(parts, strconv.FormatFloat(d.Seconds(), 'f', -1, 64)+"s")
+		}
+		str = strings.Join(parts, " ")
+	}
+	if neg {
+		return "-" + str
+	}
+	return str
+}
+
+// ParseDuration parses a duration as formatted by FormatDuration or
+// time.Duration.String -- spaces are ignored, "d" can be used for days,
+// and a plain number is taken to be in seconds
+func ParseDuration(s string) (time.Duration, error) {
+	str := strings.Join(strings.Fields(s), "")
+	if str == "" {
+		return 0, nil
+	}
+	if f, err := strconv.ParseFloat(str, 64); err == nil {
+		return time.Duration(f * float64(time.Second)), nil
+	}
+	neg := false
+	switch str[0] {
+	case '-':
+		neg = true
+		str = str[1:]
+	case '+':
+		str = str[1:]
+	}
+	var d time.Duration
+	if i := strings.Index(str, "d"); i >= 0 {
+		days, err := strconv.ParseFloat(str[:i], 64)
+		if err != nil {
+			return 0, fmt.Errorf("giv.ParseDuration: invalid duration: %q", s)
+		}
+		d = time.Duration(days * float64(24*time.Hour))
+		str = str[i+1:]
+	}
+	if str != "" {
+		rd, err := time.ParseDuration(str)
+		if err != nil || rd < 0 {
+			return 0, fmt.Errorf("giv.ParseDuration: invalid duration: %q", s)
+		}
+		d += rd
+	}
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+// DurationEdit is a text field for editing a time.Duration in a compact
+// human-readable form, e.g., "1h 30m" -- see FormatDuration and
+// ParseDuration.  Values are constrained to lie within Min and Max.
+type DurationEdit struct {
+	gi.TextField
+
+	// the current duration value
+	Dur time.Duration `desc:"the current duration value"`
+
+	// whether Min is used as a lower limit
+	HasMin bool `desc:"whether Min is used as a lower limit"`
+
+	// minimum duration
+	Min time.Duration `desc:"minimum duration"`
+
+	// whether Max is used as an upper limit
+	HasMax bool `desc:"whether Max is used as an upper limit"`
+
+	// maximum duration
+	Max time.Duration `desc:"maximum duration"`
+
+	// [view: -] signal -- only one event, when the duration has been edited
+	DurationSig ki.Signal `json:"-" xml:"-" view:"-" desc:"signal -- only one event, when the duration has been edited"`
+}
+
+var TypeDurationEdit = kit.Types.AddType(&DurationEdit{}, DurationEditProps)
+
+// AddNewDurationEdit adds a new duration edit to given parent node, with given name.
+func AddNewDurationEdit(parent ki.Ki, name string) *DurationEdit {
+	return parent.AddNewChild(TypeDurationEdit, name).(*DurationEdit)
+}
+
+func (de *DurationEdit) OnInit() {
+	de.TextField.OnInit()
+	de.Placeholder = "e.g., 1h 30m"
+	de.AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
+		s.MinWidth.SetCh(12)
+	})
+	de.TextFieldSig.Connect(de.This(), func(recv, send ki.Ki, sig int64, data any) {
+		if sig == int64(gi.TextFieldDone) || sig == int64(gi.TextFieldDeFocused) {
+			dee := recv.Embed(TypeDurationEdit).(*DurationEdit)
+			dee.TextToDuration()
+		}
+	})
+}
+
+func (de *DurationEdit) Disconnect() {
+	de.TextField.Disconnect()
+	de.DurationSig.DisconnectAll()
+}
+
+var DurationEditProps = ki.Props{
+	ki.EnumTypeFlag: gi.TypeNodeFlags,
+}
+
+// SetMin sets the minimum duration
+func (de *DurationEdit) SetMin(min time.Duration) {
+	de.HasMin = true
+	de.Min = min
+}
+
+// SetMax sets the maximum duration
+func (de *DurationEdit) SetMax(max time.Duration) {
+	de.HasMax = true
+	de.Max = max
+}
+
+// Clamp returns d constrained to the Min and Max of the edit
+func (de *DurationEdit) Clamp(d time.Duration) time.Duration {
+	if de.HasMin && d < de.Min {
+		d = de.Min
+	}
+	if de.HasMax && d > de.Max {
+		d = de.Max
+	}
+	return d
+}
+
+// SetDuration sets the duration, constrained to Min and Max, and updates
+// the text
+func (de *DurationEdit) SetDuration(d time.Duration) {
+	de.Dur = de.Clamp(d)
+	de.SetText(FormatDuration(de.Dur))
+}
+
+// TextToDuration parses the current text as a duration, sets it (clamped
+// to Min and Max) and emits DurationSig if it changed -- invalid text is
+// reverted to the current duration
+func (de *DurationEdit) TextToDuration() {
+	d, err := ParseDuration(de.Text())
+	if err != nil {
+		de.SetText(FormatDuration(de.Dur))
+		return
+	}
+	prv := de.Dur
+	de.SetDuration(d)
+	if de.Dur != prv {
+		de.DurationSig.Emit(de.This(), 0, de.Dur)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  DurationValueView
+
+// DurationValueView presents a DurationEdit for a time.Duration -- the min
+// and max tags, if present, are parsed with ParseDuration, e.g., max:"24h"
+type DurationValueView struct {
+	ValueViewBase
+}
+
+var TypeDurationValueView = kit.Types.AddType(&DurationValueView{}, nil)
+
+func (vv *DurationValueView) WidgetType() reflect.Type {
+	vv.WidgetTyp = TypeDurationEdit
+	return vv.WidgetTyp
+}
+
+func (vv *DurationValueView) UpdateWidget() {
+	if vv.Widget == nil {
+		return
+	}
+	de := vv.Widget.(*DurationEdit)
+	d, _ := kit.NonPtrValue(vv.Value).Interface().(time.Duration)
+	de.SetDuration(d)
+}
+
+func (vv *DurationValueView) ConfigWidget(widg gi.Node2D) {
+	vv.Widget = widg
+	vv.StdConfigWidget(widg)
+	de := vv.Widget.(*DurationEdit)
+	de.Tooltip, _ = vv.Tag("desc")
+	de.SetDisabledState(vv.This().(ValueView).IsInactive())
+	if mintag, ok := vv.Tag("min"); ok {
+		if d, err := ParseDuration(mintag); err == nil {
+			de.SetMin(d)
+		}
+	}
+	if maxtag, ok := vv.Tag("max"); ok {
+		if d, err := ParseDuration(maxtag); err == nil {
+			de.SetMax(d)
+		}
+	}
+	de.DurationSig.ConnectOnly(vv.This(), func(recv, send ki.Ki, sig int64, data any) {
+		vvv, _ := recv.Embed(TypeDurationValueView).(*DurationValueView)
+		dee := vvv.Widget.(*DurationEdit)
+		if vvv.SetValue(dee.Dur) {
+			vvv.UpdateWidget()
+		}
+	})
+	vv.UpdateWidget()
+}
+
+/////////////////////////////////////////////////////////////////////////////
+//  TimeViewDialog
+
+// TimeViewDialog opens a dialog for picking a date and time of day, using
+// a DateView and a TimeView -- min and max constrain the selectable
+// dates (zero for no limit).  Use TimeViewDialogValue to get the result.
+func TimeViewDialog(avp *gi.Viewport2D, tm, min, max time.Time, opts DlgOpts, recv ki.Ki, dlgFunc ki.RecvFunc) *gi.Dialog {
+	dlg := gi.NewStdDialog(opts.ToGiOpts(), gi.AddOk, gi.AddCancel)
+	dlg.SetName("time-view") // use a consistent name for consistent sizing / placement
+
+	frame := dlg.Frame()
+	_, prIdx := dlg.PromptWidget(frame)
+
+	if tm.IsZero() {
+		tm = time.Now()
+	}
+	tm = ClampTime(tm, min, max)
+	dv := frame.InsertNewChild(TypeDateView, prIdx+1, "date-view").(*DateView)
+	dv.Min = min
+	dv.Max = max
+	dv.SetDate(tm)
+	tv := frame.InsertNewChild(TypeTimeView, prIdx+2, "time-view").(*TimeView)
+	tv.ShowSeconds = tm.Second() != 0
+	tv.SetTime(tm)
+
+	dv.ViewSig.Connect(tv.This(), func(recv, send ki.Ki, sig int64, data any) {
+		tvv := recv.Embed(TypeTimeView).(*TimeView)
+		d := data.(time.Time)
+		t := tvv.Time
+		tvv.Time = time.Date(d.Year(), d.Month(), d.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	})
+	tv.ViewSig.Connect(dv.This(), func(recv, send ki.Ki, sig int64, data any) {
+		dvv := recv.Embed(TypeDateView).(*DateView)
+		dvv.Date = data.(time.Time)
+	})
+
+	if recv != nil && dlgFunc != nil {
+		dlg.DialogSig.Connect(recv, dlgFunc)
+	}
+
+	dlg.UpdateEndNoSig(true)
+	dlg.Open(0, 0, avp, nil)
+	return dlg
+}
+
+// TimeViewDialogValue gets the selected date and time from the dialog,
+// constrained to the min and max of the date view
+func TimeViewDialogValue(dlg *gi.Dialog) time.Time {
+	frame := dlg.Frame()
+	dvk := frame.ChildByName("date-view", 0)
+	tvk := frame.ChildByName("time-view", 0)
+	if dvk == nil || tvk == nil {
+		return time.Time{}
+	}
+	dv := dvk.(*DateView)
+	t := tvk.(*TimeView).Time
+	d := dv.Date
+	tm := time.Date(d.Year(), d.Month(), d.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), d.Location())
+	return ClampTime(tm, dv.Min, dv.Max)
+}
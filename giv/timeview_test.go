@@ -0,0 +1,169 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv_test
+
+import (
+	"testing"
+	"time"
+
+	"goki.dev/gi/v2/giv"
+)
+
+func TestDateViewDayInRange(t *testing.T) {
+	date := func(d, h int) time.Time {
+		return time.Date(2023, time.March, d, h, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name     string
+		min, max time.Time
+		day      time.Time
+		in       bool
+	}{
+		{"no limits", time.Time{}, time.Time{}, date(10, 0), true},
+		{"min same day", date(10, 15), time.Time{}, date(10, 0), true},
+		{"min day before", date(10, 15), time.Time{}, date(9, 23), false},
+		{"min at midnight", date(10, 0), time.Time{}, date(9, 12), false},
+		{"min at midnight same day", date(10, 0), time.Time{}, date(10, 12), true},
+		{"max same day", time.Time{}, date(10, 9), date(10, 23), true},
+		{"max day after", time.Time{}, date(10, 9), date(11, 0), false},
+		{"max at midnight", time.Time{}, date(10, 0), date(10, 12), true},
+		{"between", date(5, 12), date(15, 12), date(10, 0), true},
+	}
+	for _, tt := range tests {
+		dv := &giv.DateView{Min: tt.min, Max: tt.max}
+		if in := dv.DayInRange(tt.day); in != tt.in {
+			t.Errorf("%s: DayInRange = %v, want %v", tt.name, in, tt.in)
+		}
+	}
+}
+
+func TestFormatParseDuration(t *testing.T) {
+	tests := []struct {
+		d   time.Duration
+		str string
+	}{
+		{0, "0s"},
+		{250 * time.Millisecond, "250ms"},
+		{1500 * time.Millisecond, "1.5s"},
+		{90 * time.Minute, "1h 30m"},
+		{52 * time.Hour, "2d 4h"},
+		{26*time.Hour + 3*time.Minute + 4*time.Second, "1d 2h 3m 4s"},
+		{-45 * time.Second, "-45s"},
+		{-(25*time.Hour + 500*time.Millisecond), "-1d 1h 0.5s"},
+	}
+	for _, tt := range tests {
+		str := giv.FormatDuration(tt.d)
+		if str != tt.str {
+			t.Errorf("FormatDuration(%v) = %q, want %q", tt.d, str, tt.str)
+		}
+		d, err := giv.ParseDuration(str)
+		if err != nil || d != tt.d {
+			t.Errorf("ParseDuration(%q) = %v, %v, want %v", str, d, err, tt.d)
+		}
+		if d, err := giv.ParseDuration(tt.d.String()); err != nil || d != tt.d {
+			t.Errorf("ParseDuration(%q) = %v, %v, want %v", tt.d.String(), d, err, tt.d)
+		}
+	}
+
+	parses := []struct {
+		str string
+		d   time.Duration
+		ok  bool
+	}{
+		{"", 0, true},
+		{"  ", 0, true},
+		{"90", 90 * time.Second, true},
+		{"1.5", 1500 * time.Millisecond, true},
+		{"+1h", time.Hour, true},
+		{"1.5d", 36 * time.Hour, true},
+		{" 1 h 30 m ", 90 * time.Minute, true},
+		{"1x", 0, false},
+		{"d", 0, false},
+		{"1d-2h", 0, false},
+		{"h1", 0, false},
+	}
+	for _, tt := range parses {
+		d, err := giv.ParseDuration(tt.str)
+		if (err == nil) != tt.ok || d != tt.d {
+			t.Errorf("ParseDuration(%q) = %v, %v, want %v, ok = %v", tt.str, d, err, tt.d, tt.ok)
+		}
+	}
+}
+
+func TestParseTimeTag(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want time.Time
+		ok   bool
+	}{
+		{"2023-03-10", time.Date(2023, 3, 10, 0, 0, 0, 0, time.Local), true},
+		{" 2023-03-10 14:05 ", time.Date(2023, 3, 10, 14, 5, 0, 0, time.Local), true},
+		{"2023-03-10 14:05:30", time.Date(2023, 3, 10, 14, 5, 30, 0, time.Local), true},
+		{"2023-03-10T14:05:30Z", time.Date(2023, 3, 10, 14, 5, 30, 0, time.UTC), true},
+		{"", time.Time{}, false},
+		{"yesterday", time.Time{}, false},
+		{"2023-13-01", time.Time{}, false},
+		{"10/03/2023", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		tm, err := giv.ParseTimeTag(tt.tag)
+		if (err == nil) != tt.ok || !tm.Equal(tt.want) {
+			t.Errorf("ParseTimeTag(%q) = %v, %v, want %v, ok = %v", tt.tag, tm, err, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestLocaleWeekStart(t *testing.T) {
+	tests := []struct {
+		lcAll, lcTime, lang string
+		want                time.Weekday
+	}{
+		{"", "", "", time.Sunday},
+		{"", "", "C", time.Sunday},
+		{"", "", "POSIX", time.Sunday},
+		{"", "", "en_US.UTF-8", time.Sunday},
+		{"", "", "de_DE.UTF-8", time.Monday},
+		{"", "", "en_GB", time.Monday},
+		{"", "", "ar_EG.UTF-8", time.Saturday},
+		{"", "", "fr", time.Monday},
+		{"", "", "pt-br", time.Sunday},
+		{"", "", "sr_RS@latin", time.Monday},
+		{"", "de_DE.UTF-8", "en_US.UTF-8", time.Monday},
+		{"en_US.UTF-8", "de_DE.UTF-8", "ar_EG", time.Sunday},
+	}
+	for _, tt := range tests {
+		t.Setenv("LC_ALL", tt.lcAll)
+		t.Setenv("LC_TIME", tt.lcTime)
+		t.Setenv("LANG", tt.lang)
+		if got := giv.LocaleWeekStart(); got != tt.want {
+			t.Errorf("LC_ALL %q, LC_TIME %q, LANG %q: LocaleWeekStart = %v, want %v", tt.lcAll, tt.lcTime, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestClampTime(t *testing.T) {
+	date := func(d int) time.Time {
+		return time.Date(2023, time.March, d, 12, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name         string
+		tm, min, max time.Time
+		want         time.Time
+	}{
+		{"no limits", date(10), time.Time{}, time.Time{}, date(10)},
+		{"within", date(10), date(5), date(15), date(10)},
+		{"before min", date(1), date(5), date(15), date(5)},
+		{"after max", date(20), date(5), date(15), date(15)},
+		{"at min", date(5), date(5), date(15), date(5)},
+		{"only min", date(1), date(5), time.Time{}, date(5)},
+		{"only max", date(20), time.Time{}, date(15), date(15)},
+		{"zero time", time.Time{}, time.Time{}, date(15), time.Time{}},
+	}
+	for _, tt := range tests {
+		if got := giv.ClampTime(tt.tm, tt.min, tt.max); !got.Equal(tt.want) {
+			t.Errorf("%s: ClampTime = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,567 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"goki.dev/gi/v2/gi"
+	"goki.dev/gi/v2/gist"
+	"goki.dev/gi/v2/icons"
+	"goki.dev/gi/v2/units"
+	"goki.dev/ki/v2/ki"
+	"goki.dev/ki/v2/kit"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  TreeTableView
+
+// TreeTableView is a tree with columns: it shows a ki tree using
+// TreeTableRow nodes, which have all the selection, copy / paste and
+// drag-n-drop behavior of TreeView, plus a column for each of the visible
+// struct fields of the source nodes, with a header for sorting the rows
+// within each level of the tree, without changing the source tree.  Any
+// parent / children data can be shown using SetData, which wraps it in a
+// tree of TreeTableItem nodes.
+type TreeTableView struct {
+	gi.Frame
+
+	// root of the source tree that we are viewing
+	SrcRoot ki.Ki `copy:"-" json:"-" xml:"-" desc:"root of the source tree that we are viewing"`
+
+	// names of the struct fields to show as columns -- if empty, all visible fields of the struct type of the root node are shown
+	Cols []string `desc:"names of the struct fields to show as columns -- if empty, all visible fields of the struct type of the root node are shown"`
+
+	// label for the header of the tree column
+	TreeLabel string `desc:"label for the header of the tree column"`
+
+	// width of each field column, in Ch units
+	ColWidth float32 `desc:"width of each field column, in Ch units"`
+
+	// current sort column: -1 for none, 0 for the tree column (sorting by node label), and 1 + the index into VisFields for the field columns
+	SortIdx int `desc:"current sort column: -1 for none, 0 for the tree column (sorting by node label), and 1 + the index into VisFields for the field columns"`
+
+	// whether current sort order is descending
+	SortDesc bool `desc:"whether current sort order is descending"`
+
+	// [view: -] the fields shown as columns
+	VisFields []reflect.StructField `copy:"-" view:"-" json:"-" xml:"-" desc:"the fields shown as columns"`
+
+	// [view: -] signal for valueview -- only one signal sent when a column value has been edited
+	ViewSig ki.Signal `copy:"-" json:"-" xml:"-" view:"-" desc:"signal for valueview -- only one signal sent when a column value has been edited"`
+}
+
+var TypeTreeTableView = kit.Types.AddType(&TreeTableView{}, TreeTableViewProps)
+
+// AddNewTreeTableView adds a new treetableview to given parent node, with given name.
+func AddNewTreeTableView(parent ki.Ki, name string) *TreeTableView {
+	return parent.AddNewChild(TypeTreeTableView, name).(*TreeTableView)
+}
+
+func (tt *TreeTableView) OnInit() {
+	tt.Lay = gi.LayoutVert
+	tt.TreeLabel = "Name"
+	tt.ColWidth = 12
+	tt.SortIdx = -1
+	tt.AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
+		tt.Spacing.SetPx(0)
+		s.SetStretchMax()
+	})
+}
+
+func (tt *TreeTableView) OnChildAdded(child ki.Ki) {
+	if w := gi.KiAsWidget(child); w != nil {
+		switch w.Name() {
+		case "header":
+			hd := child.(*gi.Layout)
+			hd.AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
+				hd.Spacing.SetCh(0.5)
+				s.SetStretchMaxWidth()
+				s.Padding.Set(units.Px(4*gi.Prefs.DensityMul()), units.Px(6*gi.Prefs.DensityMul()))
+				s.BackgroundColor.SetSolid(gi.ColorScheme.SurfaceContainerHigh)
+				s.Overflow = gist.OverflowHidden
+			})
+		case "frame":
+			fr := child.(*gi.Frame)
+			fr.Lay = gi.LayoutVert
+			fr.AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
+				s.SetMinPrefWidth(units.Ch(20))
+				s.SetMinPrefHeight(units.Em(6))
+				s.Overflow = gist.OverflowScroll
+				s.SetStretchMax()
+				s.Border.Style.Set(gist.BorderNone)
+				s.Margin.Set()
+				s.Padding.Set()
+			})
+		}
+		if w.Parent().Name() == "header" && strings.HasPrefix(w.Name(), "col-") {
+			w.AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
+				s.MinWidth.SetCh(tt.ColWidth)
+				s.Width.SetCh(tt.ColWidth)
+				s.MaxWidth.SetCh(tt.ColWidth)
+				s.Padding.Set()
+				s.Margin.Set()
+			})
+		}
+	}
+}
+
+func (tt *TreeTableView) Disconnect() {
+	tt.Frame.Disconnect()
+	tt.ViewSig.DisconnectAll()
+}
+
+var TreeTableViewProps = ki.Props{
+	ki.EnumTypeFlag: gi.TypeNodeFlags,
+}
+
+// SetRootNode sets the root of the source tree to view, and builds the
+// view of the tree, with columns for its fields
+func (tt *TreeTableView) SetRootNode(sk ki.Ki) {
+	updt := tt.UpdateStart()
+	tt.SrcRoot = sk
+	tt.CacheVisFields()
+	tt.Config()
+	tt.ConfigHeader()
+	tr := tt.Tree()
+	tr.SetDisabledState(tt.IsDisabled())
+	tr.SetRootNode(sk)
+	tr.ReSync() // makes sure all rows pick up the new columns
+	tt.SetFullReRender()
+	tt.UpdateEnd(updt)
+}
+
+// SetData sets a generic parent / children data source to view, by making
+// a tree of TreeTableItem nodes for it -- the columns are the fields of the
+// root data item.  Returns the root item.
+func (tt *TreeTableView) SetData(data TreeTableData) *TreeTableItem {
+	root := NewTreeTableItems(data)
+	tt.SetRootNode(root)
+	return root
+}
+
+// Config configures the header and the tree frame, if not already done
+func (tt *TreeTableView) Config() {
+	if tt.HasChildren() {
+		return
+	}
+	updt := tt.UpdateStart()
+	gi.AddNewLayout(tt, "header", gi.LayoutHoriz)
+	fr := gi.AddNewFrame(tt, "frame", gi.LayoutVert)
+	tr := AddNewTreeTableRow(fr, "tree")
+	tr.TreeViewSig.Connect(tt.This(), func(recv, send ki.Ki, sig int64, data any) {
+		if sig == int64(TreeViewInserted) || sig == int64(TreeViewChanged) {
+			ttv := recv.Embed(TypeTreeTableView).(*TreeTableView)
+			ttv.ViewSig.Emit(ttv.This(), 0, nil)
+		}
+	})
+	tt.UpdateEnd(updt)
+}
+
+// Header returns the header layout, with the column sort buttons
+func (tt *TreeTableView) Header() *gi.Layout {
+	return tt.ChildByName("header", 0).(*gi.Layout)
+}
+
+// Tree returns the root TreeTableRow of the view
+func (tt *TreeTableView) Tree() *TreeTableRow {
+	return tt.ChildByName("frame", 1).Child(0).(*TreeTableRow)
+}
+
+// TreeTableStruct returns the struct that holds the column fields for the
+// given source node: the Data of a TreeTableItem, and otherwise the node
+// itself
+func TreeTableStruct(k ki.Ki) any {
+	if ti, ok := k.(*TreeTableItem); ok {
+		return ti.Data
+	}
+	return k
+}
+
+// CacheVisFields computes the fields shown as columns, from Cols if set,
+// and otherwise from the struct type of the root node
+func (tt *TreeTableView) CacheVisFields() {
+	tt.VisFields = nil
+	if tt.SrcRoot == nil {
+		return
+	}
+	styp := kit.NonPtrType(reflect.TypeOf(TreeTableStruct(tt.SrcRoot)))
+	if styp == nil || styp.Kind() != reflect.Struct {
+		return
+	}
+	if len(tt.Cols) > 0 {
+		for _, nm := range tt.Cols {
+			if fld, ok := styp.FieldByName(nm); ok {
+				tt.VisFields = append(tt.VisFields, fld)
+			} else {
+				fmt.Printf("TreeTableView: Field name: %v not found in type: %v\n", nm, styp.String())
+			}
+		}
+		return
+	}
+	kit.FlatFieldsTypeFunc(styp, func(typ reflect.Type, fld reflect.StructField) bool {
+		if typ == ki.KiT_Node || !fld.IsExported() {
+			return true // the tree already shows the node itself
+		}
+		if fld.Tag.Get("tableview") == "-" || fld.Tag.Get("view") == "-" {
+			return true
+		}
+		if rfld, has := styp.FieldByName(fld.Name); has {
+			tt.VisFields = append(tt.VisFields, rfld)
+		}
+		return true
+	})
+}
+
+// ColLabel returns the header label for the given field column index
+func (tt *TreeTableView) ColLabel(col int) string {
+	fld := tt.VisFields[col]
+	if lbl, ok := fld.Tag.Lookup("label"); ok {
+		return lbl
+	}
+	return fld.Name
+}
+
+// ConfigHeader configures the header sort buttons for the current columns
+func (tt *TreeTableView) ConfigHeader() {
+	hd := tt.Header()
+	config := kit.TypeAndNameList{}
+	config.Add(gi.TypeButton, "tree")
+	config.Add(gi.TypeStretch, "stretch")
+	for _, fld := range tt.VisFields {
+		config.Add(gi.TypeButton, "col-"+fld.Name)
+	}
+	mods, updt := hd.ConfigChildren(config)
+	if !mods {
+		updt = hd.UpdateStart()
+	}
+	for i := 0; i <= len(tt.VisFields); i++ {
+		ci := i
+		idx := i
+		if i > 0 {
+			idx++ // skip stretch
+		}
+		bt := hd.Child(idx).(*gi.Button)
+		bt.Type = gi.ButtonText
+		if i == 0 {
+			bt.SetText(tt.TreeLabel)
+			bt.Tooltip = "click to sort by node label within each level of the tree"
+		} else {
+			bt.SetText(tt.ColLabel(i - 1))
+			bt.Tooltip, _ = tt.VisFields[i-1].Tag.Lookup("desc")
+		}
+		switch {
+		case tt.SortIdx != ci:
+			bt.SetIcon(icons.None)
+		case tt.SortDesc:
+			bt.SetIcon(icons.KeyboardArrowDown)
+		default:
+			bt.SetIcon(icons.KeyboardArrowUp)
+		}
+		if mods {
+			bt.ButtonSig.Connect(tt.This(), func(recv, send ki.Ki, sig int64, data any) {
+				if sig == int64(gi.ButtonClicked) {
+					ttv := recv.Embed(TypeTreeTableView).(*TreeTableView)
+					ttv.SortAction(ci)
+				}
+			})
+		}
+	}
+	hd.SetFullReRender()
+	hd.UpdateEnd(updt)
+}
+
+// ColValue returns the value of the given field column for the given
+// source node, and false if the node does not have that field
+func (tt *TreeTableView) ColValue(k ki.Ki, col int) (reflect.Value, reflect.StructField, bool) {
+	stru := TreeTableStruct(k)
+	if kit.IfaceIsNil(stru) || col < 0 || col >= len(tt.VisFields) {
+		return reflect.Value{}, reflect.StructField{}, false
+	}
+	sv := kit.NonPtrValue(reflect.ValueOf(stru))
+	if sv.Kind() != reflect.Struct {
+		return reflect.Value{}, reflect.StructField{}, false
+	}
+	fld, ok := sv.Type().FieldByName(tt.VisFields[col].Name)
+	if !ok {
+		return reflect.Value{}, reflect.StructField{}, false
+	}
+	return sv.FieldByIndex(fld.Index), fld, true
+}
+
+// SortKey returns the value used for sorting the given source node on the
+// given sort column (see SortIdx)
+func (tt *TreeTableView) SortKey(k ki.Ki, sortIdx int) any {
+	if sortIdx == 0 {
+		if lbl, has := gi.ToLabeler(k); has {
+			return lbl
+		}
+		return k.Name()
+	}
+	fv, _, ok := tt.ColValue(k, sortIdx-1)
+	if !ok {
+		return nil
+	}
+	return fv.Interface()
+}
+
+// treeTableLess compares two sort keys, as times, numbers or strings
+func treeTableLess(a, b any) bool {
+	if a == nil || b == nil {
+		return a == nil && b != nil
+	}
+	if at, ok := a.(time.Time); ok {
+		if bt, ok := b.(time.Time); ok {
+			return at.Before(bt)
+		}
+	}
+	if _, isStr := a.(string); !isStr {
+		if af, ok := kit.ToFloat(a); ok {
+			if bf, ok := kit.ToFloat(b); ok {
+				return af < bf
+			}
+		}
+	}
+	return strings.ToLower(kit.ToString(a)) < strings.ToLower(kit.ToString(b))
+}
+
+// SortKids returns a copy of the given children of a source node, sorted
+// according to the current SortIdx and SortDesc settings, or the children
+// themselves if not sorting -- the source tree itself is never sorted
+func (tt *TreeTableView) SortKids(kids []ki.Ki) []ki.Ki {
+	if len(kids) < 2 || tt.SortIdx < 0 || tt.SortIdx > len(tt.VisFields) {
+		return kids
+	}
+	skids := make([]ki.Ki, len(kids))
+	copy(skids, kids)
+	sort.SliceStable(skids, func(i, j int) bool {
+		vi, vj := tt.SortKey(skids[i], tt.SortIdx), tt.SortKey(skids[j], tt.SortIdx)
+		if tt.SortDesc {
+			return treeTableLess(vj, vi)
+		}
+		return treeTableLess(vi, vj)
+	})
+	return skids
+}
+
+// SortAction sorts the rows within each level of the tree on the given
+// sort column (see SortIdx), without changing the order of the source
+// tree -- toggles ascending vs. descending if already sorting on this column
+func (tt *TreeTableView) SortAction(sortIdx int) {
+	if tt.SortIdx == sortIdx {
+		tt.SortDesc = !tt.SortDesc
+	} else {
+		tt.SortDesc = false
+	}
+	tt.SortIdx = sortIdx
+	updt := tt.UpdateStart()
+	tt.ConfigHeader()
+	tt.Tree().ReSync()
+	tt.UpdateEnd(updt)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  TreeTableRow
+
+// TreeTableRow is the TreeView node used in a TreeTableView, which adds a
+// value view for each of the columns of the table after the label.  The
+// columns are right-aligned, so they line up across rows at any depth.
+type TreeTableRow struct {
+	TreeView
+
+	// [view: -] value views for each of the columns of this row
+	ColViews []ValueView `copy:"-" json:"-" xml:"-" view:"-" desc:"value views for each of the columns of this row"`
+
+	// source node that the column views were made for
+	colsSrc ki.Ki
+}
+
+var TypeTreeTableRow = kit.Types.AddType(&TreeTableRow{}, nil)
+
+// exists for same reason as TreeView one (init cycle)
+func init() {
+	kit.Types.SetProps(TypeTreeTableRow, TreeViewProps)
+}
+
+// AddNewTreeTableRow adds a new treetablerow to given parent node, with given name.
+func AddNewTreeTableRow(parent ki.Ki, name string) *TreeTableRow {
+	tr := parent.AddNewChild(TypeTreeTableRow, name).(*TreeTableRow)
+	tr.OpenDepth = 4
+	return tr
+}
+
+func (tr *TreeTableRow) OnInit() {
+	tr.TreeView.OnInit()
+}
+
+func (tr *TreeTableRow) OnChildAdded(child ki.Ki) {
+	tr.TreeView.OnChildAdded(child)
+	w := gi.KiAsWidget(child)
+	if w == nil || child.Parent() != tr.Parts.This() || !strings.HasPrefix(w.Name(), "col-") {
+		return
+	}
+	w.AddStyler(func(w *gi.WidgetBase, s *gist.Style) {
+		cw := float32(12)
+		if tt := tr.Table(); tt != nil {
+			cw = tt.ColWidth
+		}
+		s.MinWidth.SetCh(cw)
+		s.Width.SetCh(cw)
+		s.MaxWidth.SetCh(cw)
+		s.Margin.Set()
+		s.AlignV = gist.AlignMiddle
+	})
+}
+
+// Table returns the TreeTableView that this row is in
+func (tr *TreeTableRow) Table() *TreeTableView {
+	if tr.RootView == nil {
+		return nil
+	}
+	if tt := tr.RootView.ParentByType(TypeTreeTableView, ki.Embeds); tt != nil {
+		return tt.Embed(TypeTreeTableView).(*TreeTableView)
+	}
+	return nil
+}
+
+// ExtraParts is the TreeViewParter interface, adding a stretch and then
+// the column value widgets after the label
+func (tr *TreeTableRow) ExtraParts(config *kit.TypeAndNameList) {
+	tt := tr.Table()
+	if tt == nil || tr.SrcNode == nil {
+		return
+	}
+	if tr.colsSrc != tr.SrcNode || len(tr.ColViews) != len(tt.VisFields) {
+		tr.ConfigColViews(tt)
+	}
+	config.Add(gi.TypeStretch, "stretch")
+	for i, vv := range tr.ColViews {
+		nm := "col-" + tt.VisFields[i].Name
+		if vv == nil {
+			config.Add(gi.TypeLabel, nm)
+		} else {
+			config.Add(vv.WidgetType(), nm)
+		}
+	}
+}
+
+// SortSrcKids is the TreeViewSorter interface, showing the children of the
+// source node in the sort order of the table (see TreeTableView.SortKids)
+func (tr *TreeTableRow) SortSrcKids(kids []ki.Ki) []ki.Ki {
+	tt := tr.Table()
+	if tt == nil {
+		return kids
+	}
+	return tt.SortKids(kids)
+}
+
+// ConfigColViews makes the value views for the columns of this row
+func (tr *TreeTableRow) ConfigColViews(tt *TreeTableView) {
+	tr.colsSrc = tr.SrcNode
+	tr.ColViews = make([]ValueView, len(tt.VisFields))
+	stru := TreeTableStruct(tr.SrcNode)
+	for i := range tt.VisFields {
+		fv, fld, ok := tt.ColValue(tr.SrcNode, i)
+		if !ok || !fv.CanAddr() {
+			continue
+		}
+		vv := FieldToValueView(stru, fld.Name, fv.Interface())
+		if vv == nil {
+			continue
+		}
+		vv.SetStructValue(fv.Addr(), stru, &fld, nil, "")
+		tr.ColViews[i] = vv
+	}
+}
+
+// ConfigExtraParts is the TreeViewParter interface, configuring the column
+// value widgets when they have been created, and otherwise updating them
+func (tr *TreeTableRow) ConfigExtraParts(mods bool) {
+	tt := tr.Table()
+	if tt == nil {
+		return
+	}
+	for i, vv := range tr.ColViews {
+		if vv == nil {
+			continue
+		}
+		widg, ok := tr.Parts.ChildByName("col-"+tt.VisFields[i].Name, 0).(gi.Node2D)
+		if !ok {
+			continue
+		}
+		vvb := vv.AsValueViewBase()
+		if !mods && vvb.Widget == widg {
+			vv.UpdateWidget()
+			continue
+		}
+		if tt.IsDisabled() {
+			vv.SetTag("inactive", "true")
+			widg.AsNode2D().SetDisabled()
+		}
+		vv.ConfigWidget(widg)
+		vvb.ViewSig.ConnectOnly(tr.This(), func(recv, send ki.Ki, sig int64, data any) {
+			trr := recv.Embed(TypeTreeTableRow).(*TreeTableRow)
+			trr.SetChanged()
+			if ttv := trr.Table(); ttv != nil {
+				ttv.ViewSig.Emit(ttv.This(), 0, nil)
+			}
+		})
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+//  TreeTableItem
+
+// TreeTableData is a generic parent / children data source that can be
+// shown in a TreeTableView using SetData -- the data items should be
+// pointers to structs, whose fields are shown as the columns
+type TreeTableData interface {
+	// TreeTableChildren returns the children of this data item
+	TreeTableChildren() []TreeTableData
+}
+
+// TreeTableItem is a ki node that wraps a generic TreeTableData item, so
+// that it can be shown in a TreeTableView -- note that editing the tree
+// structure (e.g., by drag-n-drop) changes the TreeTableItem tree, not the
+// underlying data
+type TreeTableItem struct {
+	ki.Node
+
+	// the data item for this node
+	Data TreeTableData `desc:"the data item for this node"`
+}
+
+var TypeTreeTableItem = kit.Types.AddType(&TreeTableItem{}, nil)
+
+// Label returns the label of the data item if it is a gi.Labeler with a
+// label, and otherwise the (unique) name of the node
+func (ti *TreeTableItem) Label() string {
+	if lbl, has := gi.ToLabeler(ti.Data); has && lbl != "" {
+		return lbl
+	}
+	return ti.Name()
+}
+
+// NewTreeTableItems returns a new tree of TreeTableItem nodes for the given
+// data item and all of its children
+func NewTreeTableItems(data TreeTableData) *TreeTableItem {
+	root := &TreeTableItem{Data: data}
+	root.InitName(root, "root")
+	root.addItems()
+	return root
+}
+
+// addItems adds child items for the children of our data item, recursively
+func (ti *TreeTableItem) addItems() {
+	for i, cd := range ti.Data.TreeTableChildren() {
+		kid := ti.AddNewChild(TypeTreeTableItem, fmt.Sprintf("item-%d", i)).(*TreeTableItem)
+		kid.Data = cd
+		kid.addItems()
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv_test
+
+import (
+	"fmt"
+	"testing"
+
+	"goki.dev/gi/v2/gi"
+	"goki.dev/gi/v2/giv"
+	"goki.dev/ki/v2/ki"
+)
+
+type ttFile struct {
+	Name  string
+	Size  int
+	Files []*ttFile `view:"-"`
+}
+
+func (f *ttFile) Label() string { return f.Name }
+
+func (f *ttFile) TreeTableChildren() []giv.TreeTableData {
+	kids := make([]giv.TreeTableData, len(f.Files))
+	for i, k := range f.Files {
+		kids[i] = k
+	}
+	return kids
+}
+
+// rowNames returns the source item labels of the rows under given row
+func rowNames(tr ki.Ki) []string {
+	var nms []string
+	for _, k := range *tr.Children() {
+		nms = append(nms, k.Embed(giv.TypeTreeTableRow).(*giv.TreeTableRow).SrcNode.(*giv.TreeTableItem).Label())
+	}
+	return nms
+}
+
+func kidNames(k ki.Ki) []string {
+	var nms []string
+	for _, kid := range *k.Children() {
+		nms = append(nms, kid.Name())
+	}
+	return nms
+}
+
+func TestTreeTableViewSort(t *testing.T) {
+	gi.Init()
+	data := &ttFile{Name: "root", Files: []*ttFile{
+		{Name: "b", Size: 1},
+		{Name: "c", Size: 3, Files: []*ttFile{{Name: "y", Size: 5}, {Name: "x", Size: 2}}},
+		{Name: "a", Size: 2},
+	}}
+	fr := &gi.Frame{}
+	fr.InitName(fr, "fr")
+	tt := giv.AddNewTreeTableView(fr, "tt")
+	root := tt.SetData(data)
+	tr := tt.Tree()
+	src := kidNames(root)
+	if fmt.Sprint(src) != "[item-0 item-1 item-2]" {
+		t.Errorf("item names %q, want item-0, item-1 and item-2", src)
+	}
+	csrc := kidNames(root.Child(1))
+
+	tests := []struct {
+		sortIdx   int
+		top, kids []string
+	}{
+		{0, []string{"a", "b", "c"}, []string{"x", "y"}},
+		{0, []string{"c", "b", "a"}, []string{"y", "x"}}, // descending
+		{2, []string{"b", "a", "c"}, []string{"x", "y"}}, // Size
+	}
+	for _, tst := range tests {
+		tt.SortAction(tst.sortIdx)
+		desc := fmt.Sprintf("sort %d desc %v", tt.SortIdx, tt.SortDesc)
+		if got := rowNames(tr); fmt.Sprint(got) != fmt.Sprint(tst.top) {
+			t.Errorf("%s: rows %q, want %q", desc, got, tst.top)
+		}
+		crow := tr.ChildByName("tv_item-1", 0)
+		if got := rowNames(crow); fmt.Sprint(got) != fmt.Sprint(tst.kids) {
+			t.Errorf("%s: rows of c %q, want %q", desc, got, tst.kids)
+		}
+		// the source tree is never sorted
+		if got := kidNames(root); fmt.Sprint(got) != fmt.Sprint(src) {
+			t.Errorf("%s: source order changed to %q, want %q", desc, got, src)
+		}
+		if got := kidNames(root.Child(1)); fmt.Sprint(got) != fmt.Sprint(csrc) {
+			t.Errorf("%s: source order of c changed to %q, want %q", desc, got, csrc)
+		}
+	}
+}
+
+func TestTreeTableItemNames(t *testing.T) {
+	// items with the same label, or none, keep unique names
+	data := &ttFile{Name: "root", Files: []*ttFile{{Name: "a"}, {Name: "a"}, {Name: ""}}}
+	root := giv.NewTreeTableItems(data)
+	if got := kidNames(root); fmt.Sprint(got) != "[item-0 item-1 item-2]" {
+		t.Errorf("item names %q, want item-0, item-1 and item-2", got)
+	}
+	for i, want := range []string{"a", "a", "item-2"} {
+		if lbl := root.Child(i).(*giv.TreeTableItem).Label(); lbl != want {
+			t.Errorf("item %d label %q, want %q", i, lbl, want)
+		}
+	}
+}
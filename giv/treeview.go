@@ -237,6 +237,9 @@ func (tv *TreeView) SyncToSrc(tvIdx *int, init bool, depth int) {
 		fldClosed = append(fldClosed, cls)
 		return true
 	})
+	if ts, ok := tv.This().(TreeViewSorter); ok {
+		skids = ts.SortSrcKids(skids)
+	}
 	for _, skid := range skids {
 		tnl.Add(typ, "tv_"+skid.Name())
 	}
@@ -254,7 +257,7 @@ func (tv *TreeView) SyncToSrc(tvIdx *int, init bool, depth int) {
 		}
 		idx++
 	}
-	for _, skid := range *sk.Children() {
+	for _, skid := range skids {
 		if len(tv.Kids) <= idx {
 			break
 		}
@@ -368,6 +371,26 @@ func (tv *TreeView) Label() string {
 	return tv.SrcNode.Name()
 }
 
+// AccessInfo returns the accessibility info for the tree view: the root is
+// the Tree, and the other nodes are TreeItems named by their label,
+// Expandable if they have children, and Expanded if they are open
+func (tv *TreeView) AccessInfo() gi.AccessInfo {
+	role := gi.AccessRoleTreeItem
+	if tv.RootView == tv {
+		role = gi.AccessRoleTree
+	}
+	nm := tv.Nm
+	if tv.SrcNode != nil {
+		nm = tv.Label()
+	}
+	ai := tv.AccessInfoBase(role, nm)
+	if tv.HasChildren() {
+		ai.SetState(true, gi.AccessExpandable)
+		ai.SetState(!tv.IsClosed(), gi.AccessExpanded)
+	}
+	return ai
+}
+
 // UpdateInactive updates the Inactive state based on SrcNode -- returns true if
 // inactive.  The inactivity of individual nodes only affects display properties
 // typically, and not overall functional behavior, which is controlled by
@@ -1727,7 +1750,7 @@ func (tv *TreeView) KeyInput(kt *key.ChordEvent) {
 	if gi.KeyEventTrace {
 		fmt.Printf("TreeView KeyInput: %v\n", tv.Path())
 	}
-	kf := gi.KeyFun(kt.Chord())
+	kf := gi.KeyFunFor(tv.This(), kt.Chord())
 	selMode := mouse.SelectModeBits(kt.Modifiers)
 
 	if selMode == mouse.SelectOne {
@@ -1911,6 +1934,27 @@ func (tv *TreeView) LabelPart() (*gi.Label, bool) {
 	return nil, false
 }
 
+// TreeViewParter is implemented by TreeView types that add their own parts
+// after the label of each node, e.g., the columns of a TreeTableRow
+type TreeViewParter interface {
+	// ExtraParts adds the types and names of the extra parts to the config
+	ExtraParts(config *kit.TypeAndNameList)
+
+	// ConfigExtraParts configures the extra parts once they have been
+	// created -- mods is true if any parts were added or removed
+	ConfigExtraParts(mods bool)
+}
+
+// TreeViewSorter is implemented by TreeView types that show the children
+// of each source node in a different order than the source tree, e.g., the
+// sorted rows of a TreeTableRow, without changing the source tree
+type TreeViewSorter interface {
+	// SortSrcKids returns the given children of the source node in the
+	// order to show them in -- it must not modify the given slice, which is
+	// the children of the source node
+	SortSrcKids(kids []ki.Ki) []ki.Ki
+}
+
 func (tv *TreeView) ConfigParts() {
 	tv.Parts.Lay = gi.LayoutHoriz
 	tv.Parts.Style.Template = "giv.TreeView.Parts"
@@ -1922,7 +1966,11 @@ func (tv *TreeView) ConfigParts() {
 		config.Add(gi.TypeIcon, "icon")
 	}
 	config.Add(gi.TypeLabel, "label")
-	_, updt := tv.Parts.ConfigChildren(config)
+	tp, hasExtra := tv.This().(TreeViewParter)
+	if hasExtra {
+		tp.ExtraParts(&config)
+	}
+	mods, updt := tv.Parts.ConfigChildren(config)
 	if tv.HasChildren() {
 		if wb, ok := tv.BranchPart(); ok {
 			if wb.Style.Template != "giv.TreeView.Branch" {
@@ -1950,6 +1998,9 @@ func (tv *TreeView) ConfigParts() {
 		tv.Style.Font.CopyNonDefaultProps(lbl.This()) // copy our properties to label
 		lbl.SetText(tv.Label())
 	}
+	if hasExtra {
+		tp.ConfigExtraParts(mods)
+	}
 	tv.Parts.UpdateEnd(updt)
 }
 
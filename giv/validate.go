@@ -0,0 +1,287 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"goki.dev/gi/v2/gi"
+	"goki.dev/gi/v2/gist"
+	"goki.dev/gi/v2/units"
+	"goki.dev/ki/v2/kit"
+)
+
+// Validator is implemented by structs that validate themselves as a whole,
+// beyond the validation tags of their fields (see ValidateValue) -- errors
+// for specific fields can be returned as FieldError values, several of which
+// can be combined using errors.Join.  StructView, StructViewInline and
+// TableView show these errors, and their dialogs cannot be accepted until
+// the struct is valid.
+type Validator interface {
+	Validate() error
+}
+
+// FieldError is a validation error for the field of a struct with the
+// given name
+type FieldError struct {
+
+	// name of the field
+	Field string
+
+	// the validation error
+	Err error
+}
+
+func (fe *FieldError) Error() string {
+	return fe.Field + ": " + fe.Err.Error()
+}
+
+func (fe *FieldError) Unwrap() error {
+	return fe.Err
+}
+
+var validatorType = reflect.TypeOf((*Validator)(nil)).Elem()
+
+// ValidTags are the struct field tags used for validation (see ValidateValue)
+var ValidTags = []string{"required", "min", "max", "pattern", "oneof"}
+
+// HasValidTags returns true if any of the ValidTags is present, using given
+// tag lookup function, e.g., reflect.StructTag.Lookup or ValueView.Tag
+func HasValidTags(tag func(string) (string, bool)) bool {
+	for _, vt := range ValidTags {
+		if _, has := tag(vt); has {
+			return true
+		}
+	}
+	return false
+}
+
+// TypeHasValidation returns true if given struct type (or pointer to it) has
+// any validation: fields with validation tags, including those of
+// view:"add-fields" sub-structs, or a Validate method
+func TypeHasValidation(typ reflect.Type) bool {
+	typ = kit.NonPtrType(typ)
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return false
+	}
+	if reflect.PointerTo(typ).Implements(validatorType) {
+		return true
+	}
+	has := false
+	kit.FlatFieldsTypeFunc(typ, func(typ reflect.Type, field reflect.StructField) bool {
+		vwtag := field.Tag.Get("view")
+		if vwtag == "-" {
+			return true
+		}
+		if HasValidTags(field.Tag.Lookup) || (vwtag == "add-fields" && TypeHasValidation(field.Type)) {
+			has = true
+			return false
+		}
+		return true
+	})
+	return has
+}
+
+// validPatternCache caches the compiled regular expressions of pattern
+// tags by pattern -- the error is stored for invalid patterns
+var validPatternCache sync.Map
+
+// validPattern is an entry in validPatternCache
+type validPattern struct {
+	re  *regexp.Regexp
+	err error
+}
+
+// validPatternRegexp returns the compiled regular expression that matches
+// given pattern in full, using a cache
+func validPatternRegexp(pat string) (*regexp.Regexp, error) {
+	if vp, ok := validPatternCache.Load(pat); ok {
+		return vp.(*validPattern).re, vp.(*validPattern).err
+	}
+	re, err := regexp.Compile("^(?:" + pat + ")$")
+	validPatternCache.Store(pat, &validPattern{re: re, err: err})
+	return re, err
+}
+
+// validEmpty returns true if given (non-pointer) value has not been
+// provided: it is invalid or a nil pointer or interface, or an empty string,
+// slice, array or map -- numbers and other values are always provided
+func validEmpty(v reflect.Value) bool {
+	if !v.IsValid() {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len() == 0
+	}
+	return false
+}
+
+// ValidateValue validates given value according to the validation tags
+// returned by given tag lookup function, e.g., reflect.StructTag.Lookup or
+// ValueView.Tag:
+//   - required: strings, slices and maps must not be empty, and pointers
+//     must not be nil
+//   - min, max: minimum and maximum for numbers, and minimum and maximum
+//     number of characters for strings, or elements for slices and maps
+//   - pattern: regular expression that a string must match in full
+//   - oneof: comma-separated list of allowed values
+//
+// Tags other than required do not apply to empty values, so that optional
+// values can be left empty -- numbers are always checked, including zero.
+func ValidateValue(val reflect.Value, tag func(string) (string, bool)) error {
+	v := kit.NonPtrValue(val)
+	if validEmpty(v) {
+		if _, has := tag("required"); has {
+			return errors.New("required")
+		}
+		return nil
+	}
+	kind := v.Kind()
+	num, isNum := float64(0), false
+	unit := ""
+	switch {
+	case kind == reflect.String:
+		num, unit = float64(utf8.RuneCountInString(v.String())), "characters"
+	case kind == reflect.Slice || kind == reflect.Array || kind == reflect.Map:
+		num, unit = float64(v.Len()), "items"
+	case kind >= reflect.Int && kind <= reflect.Float64:
+		num, isNum = kit.ToFloat(v.Interface())
+	}
+	if isNum || unit != "" {
+		if mins, has := tag("min"); has {
+			if min, err := strconv.ParseFloat(mins, 64); err == nil && num < min {
+				return fmt.Errorf("must be at least %v", strings.TrimSpace(mins+" "+unit))
+			}
+		}
+		if maxs, has := tag("max"); has {
+			if max, err := strconv.ParseFloat(maxs, 64); err == nil && num > max {
+				return fmt.Errorf("must be at most %v", strings.TrimSpace(maxs+" "+unit))
+			}
+		}
+	}
+	str := kit.ToString(v.Interface())
+	if pat, has := tag("pattern"); has && kind == reflect.String {
+		re, err := validPatternRegexp(pat)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pat, err)
+		}
+		if !re.MatchString(str) {
+			return fmt.Errorf("must match pattern: %v", pat)
+		}
+	}
+	if oneof, has := tag("oneof"); has {
+		opts := strings.Split(oneof, ",")
+		for i, opt := range opts {
+			opts[i] = strings.TrimSpace(opt)
+			if opts[i] == str {
+				return nil
+			}
+		}
+		return fmt.Errorf("must be one of: %v", strings.Join(opts, ", "))
+	}
+	return nil
+}
+
+// ValidateStruct validates the fields of given struct (pointer) according
+// to their tags (see ValidateValue), including those of view:"add-fields"
+// sub-structs, and then the struct itself if it is a Validator.  Errors for
+// fields are returned as FieldError values, combined with errors.Join --
+// see SplitFieldErrors.  Returns nil if the struct is valid.
+func ValidateStruct(stru any) error {
+	var errs []error
+	kit.FlatFieldsValueFunc(stru, func(fval any, typ reflect.Type, field reflect.StructField, fieldVal reflect.Value) bool {
+		vwtag := field.Tag.Get("view")
+		if vwtag == "-" {
+			return true
+		}
+		if vwtag == "add-fields" && field.Type.Kind() == reflect.Struct {
+			ferrs, serr := SplitFieldErrors(ValidateStruct(fieldVal.Addr().Interface()))
+			for fnm, ferr := range ferrs {
+				errs = append(errs, &FieldError{Field: fnm, Err: ferr})
+			}
+			if serr != nil {
+				errs = append(errs, &FieldError{Field: field.Name, Err: serr})
+			}
+			return true
+		}
+		if err := ValidateValue(fieldVal, field.Tag.Lookup); err != nil {
+			errs = append(errs, &FieldError{Field: field.Name, Err: err})
+		}
+		return true
+	})
+	if vl, ok := stru.(Validator); ok {
+		if err := vl.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SplitFieldErrors splits given validation error, which can combine several
+// errors using errors.Join, into the FieldError errors by field name (only
+// the first one for each field), and the remaining errors for the struct as
+// a whole, if any
+func SplitFieldErrors(err error) (map[string]error, error) {
+	if err == nil {
+		return nil, nil
+	}
+	var errs []error
+	if je, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = je.Unwrap()
+	} else {
+		errs = []error{err}
+	}
+	var ferrs map[string]error
+	var serrs []error
+	for _, e := range errs {
+		if fe, ok := e.(*FieldError); ok {
+			if ferrs == nil {
+				ferrs = make(map[string]error)
+			}
+			if _, has := ferrs[fe.Field]; !has {
+				ferrs[fe.Field] = fe.Err
+			}
+			continue
+		}
+		serrs = append(serrs, e)
+	}
+	return ferrs, errors.Join(serrs...)
+}
+
+// ValidErrStyle styles the widget of a value with given validation error,
+// if it is not nil, using an error-colored border
+func ValidErrStyle(err error, s *gist.Style) {
+	if err == nil {
+		return
+	}
+	s.Border.Style.Set(gist.BorderSolid)
+	s.Border.Width.Set(units.Px(2))
+	s.Border.Color.Set(gi.ColorScheme.Error)
+}
+
+// ValidMsgStyle styles a label showing validation error messages
+func ValidMsgStyle(s *gist.Style) {
+	s.Color = gi.ColorScheme.Error
+	s.Text.WhiteSpace = gist.WhiteSpacePreLine
+	s.AlignH = gist.AlignLeft
+}
+
+// errMsg returns the message of given error, empty if it is nil
+func errMsg(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
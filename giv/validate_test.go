@@ -0,0 +1,128 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"goki.dev/gi/v2/giv"
+)
+
+func TestValidateValue(t *testing.T) {
+	var nilp *int
+	one := 1
+	tests := []struct {
+		val any
+		tag reflect.StructTag
+		ok  bool
+	}{
+		{"", `required:"+"`, false},
+		{"a", `required:"+"`, true},
+		{[]int{}, `required:"+"`, false},
+		{[]int{0}, `required:"+"`, true},
+		{map[string]int{}, `required:"+"`, false},
+		{nilp, `required:"+"`, false},
+		{&one, `required:"+"`, true},
+		{0, `required:"+"`, true},
+		{false, `required:"+"`, true},
+		{"", `min:"2"`, true},
+		{"a", `min:"2"`, false},
+		{"ab", `min:"2" max:"3"`, true},
+		{"abcd", `max:"3"`, false},
+		{"äöü", `max:"3"`, true},
+		{[]int{1, 2}, `max:"1"`, false},
+		{0, `min:"1"`, false},
+		{0.0, `min:"0.5"`, false},
+		{-1, `max:"-2"`, false},
+		{5, `min:"1" max:"10"`, true},
+		{11, `min:"1" max:"10"`, false},
+		{nilp, `min:"1"`, true},
+		{&one, `min:"2"`, false},
+		{"", `pattern:"[a-z]+"`, true},
+		{"abc", `pattern:"[a-z]+"`, true},
+		{"abc1", `pattern:"[a-z]+"`, false},
+		{"a", `pattern:"a|b"`, true},
+		{"ab", `pattern:"a|b"`, false},
+		{"a", `pattern:"["`, false},
+		{"", `oneof:"a, b"`, true},
+		{"b", `oneof:"a, b"`, true},
+		{"c", `oneof:"a, b"`, false},
+		{0, `oneof:"1,2"`, false},
+		{2, `oneof:"1,2"`, true},
+	}
+	for _, tt := range tests {
+		err := giv.ValidateValue(reflect.ValueOf(tt.val), tt.tag.Lookup)
+		if (err == nil) != tt.ok {
+			t.Errorf("%#v with %s: error %v, want ok = %v", tt.val, tt.tag, err, tt.ok)
+		}
+	}
+	// invalid patterns are reported each time, from the cache
+	for i := 0; i < 2; i++ {
+		tag := reflect.StructTag(`pattern:"("`)
+		if err := giv.ValidateValue(reflect.ValueOf("a"), tag.Lookup); err == nil {
+			t.Errorf("invalid pattern: no error on call %d", i)
+		}
+	}
+}
+
+type validAddr struct {
+	City string `required:"+"`
+	Zip  string `pattern:"[0-9]{5}"`
+}
+
+type validPerson struct {
+	Name    string    `required:"+"`
+	Age     int       `min:"0" max:"150"`
+	Role    string    `oneof:"admin,user"`
+	Skip    string    `view:"-" required:"+"`
+	Addr    validAddr `view:"add-fields"`
+	Friends []string  `max:"2"`
+}
+
+func (vp *validPerson) Validate() error {
+	if vp.Role == "admin" && vp.Age < 18 {
+		return errors.New("admins must be adults")
+	}
+	return nil
+}
+
+func TestValidateStruct(t *testing.T) {
+	valid := validPerson{Name: "Ann", Age: 30, Role: "admin", Addr: validAddr{City: "Bern", Zip: "30000"}}
+	if err := giv.ValidateStruct(&valid); err != nil {
+		t.Errorf("valid struct: %v", err)
+	}
+
+	bad := validPerson{Age: -1, Role: "guest", Addr: validAddr{Zip: "x"}, Friends: []string{"a", "b", "c"}}
+	err := giv.ValidateStruct(&bad)
+	ferrs, serr := giv.SplitFieldErrors(err)
+	want := []string{"Name", "Age", "Role", "City", "Zip", "Friends"}
+	if len(ferrs) != len(want) {
+		t.Errorf("field errors = %v, want errors for %v", ferrs, want)
+	}
+	for _, fnm := range want {
+		if ferrs[fnm] == nil {
+			t.Errorf("no error for field %v", fnm)
+		}
+	}
+	if ferrs["Skip"] != nil {
+		t.Errorf(`view:"-" field validated: %v`, ferrs["Skip"])
+	}
+	if serr != nil {
+		t.Errorf("struct error = %v, want nil", serr)
+	}
+	var fe *giv.FieldError
+	if !errors.As(err, &fe) {
+		t.Errorf("error %v does not contain a FieldError", err)
+	}
+
+	minor := valid
+	minor.Age = 0
+	ferrs, serr = giv.SplitFieldErrors(giv.ValidateStruct(&minor))
+	if len(ferrs) != 0 || serr == nil || serr.Error() != "admins must be adults" {
+		t.Errorf("Validate: field errors %v and struct error %v, want only the struct error", ferrs, serr)
+	}
+}
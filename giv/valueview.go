@@ -69,6 +69,11 @@ func init() {
 		ki.InitNode(vv)
 		return vv
 	})
+	ValueViewMapAdd(kit.LongTypeName(reflect.TypeOf(time.Duration(0))), func() ValueView {
+		vv := &DurationValueView{}
+		ki.InitNode(vv)
+		return vv
+	})
 }
 
 // MapInlineLen is the number of map elements at or below which an inline
@@ -551,6 +556,9 @@ type ValueViewBase struct {
 
 	// value view that needs to have SaveTmp called on it whenever a change is made to one of the underlying values -- pass this down to any sub-views created from a parent
 	TmpSave ValueView `desc:"value view that needs to have SaveTmp called on it whenever a change is made to one of the underlying values -- pass this down to any sub-views created from a parent"`
+
+	// validation error for the current value, if any -- set by the view that contains this value, which shows it -- see SetValidErr
+	ValidErr error `json:"-" xml:"-" desc:"validation error for the current value, if any -- set by the view that contains this value, which shows it -- see SetValidErr"`
 }
 
 var TypeValueViewBase = kit.Types.AddType(&ValueViewBase{}, ValueViewBaseProps)
@@ -793,6 +801,33 @@ func (vv *ValueViewBase) SetTags(tags map[string]string) {
 	}
 }
 
+// SetValidErr sets the validation error for the current value, nil if it
+// is valid, showing it in the tooltip of the widget -- the view containing
+// the widget styles it using ValidErrStyle.  Returns true if the error
+// changed, in which case the widget is marked for re-styling.
+func (vv *ValueViewBase) SetValidErr(err error) bool {
+	if errMsg(vv.ValidErr) == errMsg(err) {
+		vv.ValidErr = err
+		return false
+	}
+	vv.ValidErr = err
+	if vv.Widget == nil {
+		return true
+	}
+	if wb := vv.Widget.AsWidget(); wb != nil {
+		desc, _ := vv.Tag("desc")
+		if err != nil && desc != "" {
+			wb.Tooltip = err.Error() + "\n" + desc
+		} else if err != nil {
+			wb.Tooltip = err.Error()
+		} else {
+			wb.Tooltip = desc
+		}
+	}
+	vv.Widget.AsNode2D().SetFullReRender()
+	return true
+}
+
 func (vv *ValueViewBase) SetTag(tag, value string) {
 	if vv.Tags == nil {
 		vv.Tags = make(map[string]string, 10)
@@ -1058,6 +1093,10 @@ func (vi *ViewIFace) PrefsDbgView(prefs *gi.PrefsDebug) {
 	PrefsDbgView(prefs)
 }
 
+func (vi *ViewIFace) SavePending() {
+	TableViewColsMgr.SavePending()
+}
+
 ////////////////////////////////////////////////////////////////////////////////////////
 //  VersCtrlValueView
 
@@ -0,0 +1,378 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"slices"
+	"strings"
+
+	"goki.dev/gi/v2/gi"
+	"goki.dev/gi/v2/icons"
+	"goki.dev/gi/v2/oswin/key"
+	"goki.dev/gi/v2/undo"
+	"goki.dev/ki/v2/ki"
+	"goki.dev/ki/v2/kit"
+)
+
+// ViewUndo records the edits made in a StructView, MapView, SliceView or
+// TableView as JSON snapshots of the value being viewed, in an undo.Mgr,
+// so that they can be undone and redone.  The state is only recorded for
+// the values that can be saved to JSON and fully restored from it -- see
+// NewViewUndo.  Fields that are not saved in JSON (e.g., json:"-") are
+// not affected by undo.
+type ViewUndo struct {
+
+	// the undo manager holding the snapshots and actions
+	Mgr undo.Mgr `desc:"the undo manager holding the snapshots and actions"`
+
+	// the value being viewed, a pointer for structs and slices
+	Val any `desc:"the value being viewed, a pointer for structs and slices"`
+
+	// snapshot of the value as of the last recorded edit, or undo / redo -- the state before the next edit
+	Last []string `desc:"snapshot of the value as of the last recorded edit, or undo / redo -- the state before the next edit"`
+}
+
+// NewViewUndo returns a new ViewUndo for given value being viewed, which
+// must be a pointer to a struct, slice or array, or a map, or nil if edits
+// of the value cannot be undone: if it is a Ki, has Ki or interface
+// elements, or cannot be saved to JSON and restored from it as it is.
+func NewViewUndo(val any) *ViewUndo {
+	if kit.IfaceIsNil(val) {
+		return nil
+	}
+	if _, ok := val.(ki.Ki); ok {
+		return nil
+	}
+	typ := reflect.TypeOf(val)
+	npt := kit.NonPtrType(typ)
+	switch npt.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array:
+		if typ.Kind() != reflect.Pointer {
+			return nil
+		}
+		if npt.Kind() != reflect.Struct && !undoElemOK(npt.Elem()) {
+			return nil
+		}
+	case reflect.Map:
+		if !undoElemOK(npt.Elem()) {
+			return nil
+		}
+	default:
+		return nil
+	}
+	vu := &ViewUndo{Val: val}
+	st, err := vu.State()
+	if err != nil {
+		return nil
+	}
+	// the state must be restored as it is into a new value
+	nv := reflect.New(npt)
+	if err := json.Unmarshal([]byte(strings.Join(st, "\n")), nv.Interface()); err != nil {
+		return nil
+	}
+	nb, err := json.MarshalIndent(nv.Interface(), "", "  ")
+	if err != nil || string(nb) != strings.Join(st, "\n") {
+		return nil
+	}
+	vu.Last = st
+	return vu
+}
+
+// undoElemOK returns true if values of given slice or map element type can
+// be restored by ViewUndo
+func undoElemOK(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Interface || ki.IsKi(typ) {
+		return false
+	}
+	return !ki.IsKi(kit.NonPtrType(typ))
+}
+
+// IsFor returns true if this is the ViewUndo for given value -- false if
+// it is nil
+func (vu *ViewUndo) IsFor(val any) bool {
+	if vu == nil || kit.IfaceIsNil(val) {
+		return false
+	}
+	cv, nv := reflect.ValueOf(vu.Val), reflect.ValueOf(val)
+	if cv.Type() != nv.Type() {
+		return false
+	}
+	if nv.Kind() == reflect.Pointer || nv.Kind() == reflect.Map {
+		return cv.Pointer() == nv.Pointer()
+	}
+	return false
+}
+
+// State returns the current state of the value, as the lines of its JSON
+func (vu *ViewUndo) State() ([]string, error) {
+	b, err := json.MarshalIndent(vu.Val, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(string(b), "\n"), nil
+}
+
+// SetState restores the value to given state, as returned by State, and
+// makes it the Last state.  The state is read into a new value, which then
+// replaces the elements of maps, slices and arrays, and the fields of
+// structs that are saved in JSON.
+func (vu *ViewUndo) SetState(st []string) error {
+	if st == nil {
+		return fmt.Errorf("giv.ViewUndo: no state to restore")
+	}
+	npv := kit.NonPtrValue(reflect.ValueOf(vu.Val))
+	nv := reflect.New(npv.Type())
+	if err := json.Unmarshal([]byte(strings.Join(st, "\n")), nv.Interface()); err != nil {
+		return err
+	}
+	switch npv.Kind() {
+	case reflect.Map: // the map itself is viewed, so its elements are replaced
+		npv.Clear()
+		it := nv.Elem().MapRange()
+		for it.Next() {
+			npv.SetMapIndex(it.Key(), it.Value())
+		}
+	case reflect.Struct:
+		setJSONFields(npv, nv.Elem())
+	default:
+		npv.Set(nv.Elem())
+	}
+	vu.Last = st
+	return nil
+}
+
+// setJSONFields sets the fields of given struct value that are saved in
+// JSON to those of given source struct value, leaving the others as they are
+func setJSONFields(dst, src reflect.Value) {
+	typ := dst.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		fld := typ.Field(i)
+		if fld.Tag.Get("json") == "-" {
+			continue
+		}
+		if fld.Anonymous && fld.Type.Kind() == reflect.Struct {
+			setJSONFields(dst.Field(i), src.Field(i))
+			continue
+		}
+		if fv := dst.Field(i); fv.CanSet() {
+			fv.Set(src.Field(i))
+		}
+	}
+}
+
+// Save records given action, if the value has changed since the Last state
+// -- call after each edit, with a description of it to show the user --
+// returns true if saved.  It is safe to call on a nil ViewUndo.
+func (vu *ViewUndo) Save(action string) bool {
+	if vu == nil {
+		return false
+	}
+	st, err := vu.State()
+	if err != nil || slices.Equal(st, vu.Last) {
+		return false
+	}
+	vu.Mgr.Save(action, "", vu.Last)
+	vu.Last = st
+	return true
+}
+
+// CanUndo returns true if there is an action to undo
+func (vu *ViewUndo) CanUndo() bool {
+	return vu != nil && vu.Mgr.HasUndoAvail()
+}
+
+// CanRedo returns true if there is an undone action to redo
+func (vu *ViewUndo) CanRedo() bool {
+	return vu != nil && vu.Mgr.HasRedoAvail()
+}
+
+// saveUndoStart saves the current state before the first undo, so that it
+// can be redone
+func (vu *ViewUndo) saveUndoStart() bool {
+	if !vu.Mgr.MustSaveUndoStart() {
+		return true
+	}
+	st, err := vu.State()
+	if err != nil {
+		return false
+	}
+	vu.Mgr.SaveUndoStart(st)
+	return true
+}
+
+// Undo restores the value to the state before the last action, returning
+// the action, and false if there was none or it could not be restored
+func (vu *ViewUndo) Undo() (string, bool) {
+	if !vu.CanUndo() || !vu.saveUndoStart() {
+		return "", false
+	}
+	act, _, st := vu.Mgr.Undo()
+	return act, vu.restore(act, st)
+}
+
+// UndoTo restores the value to the state before the action at given index
+// in the undo.Mgr records, undoing it and all the later actions
+func (vu *ViewUndo) UndoTo(idx int) (string, bool) {
+	if !vu.CanUndo() || !vu.saveUndoStart() {
+		return "", false
+	}
+	act, _, st := vu.Mgr.UndoTo(idx)
+	return act, vu.restore(act, st)
+}
+
+// Redo restores the value to the state after the last undone action,
+// returning the action, and false if there was none
+func (vu *ViewUndo) Redo() (string, bool) {
+	if !vu.CanRedo() {
+		return "", false
+	}
+	act, _, st := vu.Mgr.Redo()
+	return act, vu.restore(act, st)
+}
+
+// RedoTo restores the value to the state after the action at given index
+// in the undo.Mgr records, redoing it and all the undone actions before it
+func (vu *ViewUndo) RedoTo(idx int) (string, bool) {
+	if !vu.CanRedo() {
+		return "", false
+	}
+	act, _, st := vu.Mgr.RedoTo(idx)
+	return act, vu.restore(act, st)
+}
+
+// restore sets the state for given action, logging any error
+func (vu *ViewUndo) restore(act string, st []string) bool {
+	if st == nil {
+		return false
+	}
+	if err := vu.SetState(st); err != nil {
+		log.Printf("giv.ViewUndo: could not restore the state for action: %v: %v\n", act, err)
+		return false
+	}
+	return true
+}
+
+/////////////////////////////////////////////////////////////////////////
+//  UndoViewer
+
+// UndoViewer is the interface for views whose edits can be undone, using
+// a ViewUndo, which is used by the undo key functions and menus
+type UndoViewer interface {
+	// ViewUndos returns the undo records for the edits of the view -- nil
+	// if its edits cannot be undone
+	ViewUndos() *ViewUndo
+
+	// UndoUpdate updates the view after its value has been restored by an
+	// undo or redo of given action
+	UndoUpdate(action string)
+}
+
+// UndoAction undoes the last edit in given view
+func UndoAction(vw UndoViewer) bool {
+	act, ok := vw.ViewUndos().Undo()
+	if ok {
+		vw.UndoUpdate(act)
+	}
+	return ok
+}
+
+// RedoAction redoes the last undone edit in given view
+func RedoAction(vw UndoViewer) bool {
+	act, ok := vw.ViewUndos().Redo()
+	if ok {
+		vw.UndoUpdate(act)
+	}
+	return ok
+}
+
+// UndoKeyFun undoes or redoes the edits in given view for the KeyFunUndo
+// and KeyFunRedo key functions, setting the event as processed -- returns
+// true if the key function was one of these
+func UndoKeyFun(vw UndoViewer, kf gi.KeyFuns, kt *key.ChordEvent) bool {
+	switch kf {
+	case gi.KeyFunUndo:
+		UndoAction(vw)
+	case gi.KeyFunRedo:
+		RedoAction(vw)
+	default:
+		return false
+	}
+	kt.SetProcessed()
+	return true
+}
+
+// UndoMenu adds the Undo and Redo actions for given view to given menu,
+// along with Undo History and Redo History sub-menus of the actions that
+// can be undone and redone, to undo or redo several at once
+func UndoMenu(m *gi.Menu, vw UndoViewer) {
+	vu := vw.ViewUndos()
+	recv := vw.(ki.Ki).This()
+	var ul, rl []string
+	if vu != nil {
+		ul = vu.Mgr.UndoList()
+		rl = vu.Mgr.RedoList()
+	}
+	ulbl, rlbl := "Undo", "Redo"
+	if len(ul) > 0 {
+		ulbl += ": " + ul[0]
+	}
+	if len(rl) > 0 {
+		rlbl += ": " + rl[0]
+	}
+	ua := m.AddAction(gi.ActOpts{Name: "undo", Label: ulbl, Icon: icons.Undo, ShortcutKey: gi.KeyFunUndo},
+		recv, func(recv, send ki.Ki, sig int64, data any) {
+			UndoAction(recv.(UndoViewer))
+		})
+	ua.SetDisabledState(len(ul) == 0)
+	ra := m.AddAction(gi.ActOpts{Name: "redo", Label: rlbl, Icon: icons.Redo, ShortcutKey: gi.KeyFunRedo},
+		recv, func(recv, send ki.Ki, sig int64, data any) {
+			RedoAction(recv.(UndoViewer))
+		})
+	ra.SetDisabledState(len(rl) == 0)
+	if len(ul) == 0 && len(rl) == 0 {
+		return
+	}
+	m.AddSeparator("sep-undo")
+	uh := m.AddAction(gi.ActOpts{Name: "undo-history", Label: "Undo History", Icon: icons.History}, nil, nil)
+	uh.SetDisabledState(len(ul) == 0)
+	for i, act := range ul { // most recent first
+		uh.Menu.AddAction(gi.ActOpts{Name: fmt.Sprintf("undo-%d", i), Label: act, Data: vu.Mgr.Idx - i},
+			recv, func(recv, send ki.Ki, sig int64, data any) {
+				uvw := recv.(UndoViewer)
+				if uact, ok := uvw.ViewUndos().UndoTo(data.(int)); ok {
+					uvw.UndoUpdate(uact)
+				}
+			})
+	}
+	rh := m.AddAction(gi.ActOpts{Name: "redo-history", Label: "Redo History", Icon: icons.History}, nil, nil)
+	rh.SetDisabledState(len(rl) == 0)
+	for i, act := range rl { // next first
+		rh.Menu.AddAction(gi.ActOpts{Name: fmt.Sprintf("redo-%d", i), Label: act, Data: vu.Mgr.Idx + 1 + i},
+			recv, func(recv, send ki.Ki, sig int64, data any) {
+				uvw := recv.(UndoViewer)
+				if uact, ok := uvw.ViewUndos().RedoTo(data.(int)); ok {
+					uvw.UndoUpdate(uact)
+				}
+			})
+	}
+}
+
+// AddUndoAction adds an action to given toolbar with the UndoMenu of
+// given view, which is disabled when there is nothing to undo or redo
+func AddUndoAction(tb *gi.ToolBar, vw UndoViewer) {
+	ac := tb.AddAction(gi.ActOpts{Name: "undo", Label: "Undo", Icon: icons.Undo, Tooltip: "undo or redo the edits made in this view, one at a time or several at once from the history",
+		UpdateFunc: func(act *gi.Action) {
+			vu := vw.ViewUndos()
+			act.SetDisabledState(!vu.CanUndo() && !vu.CanRedo())
+		}}, nil, nil)
+	ac.MakeMenuFunc = func(obj ki.Ki, m *gi.Menu) {
+		*m = (*m)[:0]
+		UndoMenu(m, vw)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"goki.dev/gi/v2/giv"
+)
+
+type vuItem struct {
+	Name string
+	Tags map[string]int
+	Note string `json:"-"`
+}
+
+func TestViewUndo(t *testing.T) {
+	stru := &vuItem{Name: "a", Note: "kept"}
+	mp := map[string]int{"x": 1}
+	sl := &[]vuItem{{Name: "a"}}
+	tests := []struct {
+		name  string
+		val   any
+		edits []func()
+	}{
+		{"struct", stru, []func(){
+			func() { stru.Name = "b"; stru.Tags = map[string]int{"t": 1} },
+			func() { stru.Tags["u"] = 2 },
+		}},
+		{"map", mp, []func(){
+			func() { mp["y"] = 2 },
+			func() { delete(mp, "x"); mp["y"] = 3 },
+		}},
+		{"slice", sl, []func(){
+			func() { *sl = append(*sl, vuItem{Name: "b", Tags: map[string]int{"t": 1}}) },
+			func() { (*sl)[1].Tags["u"] = 2; (*sl)[0].Name = "c" },
+		}},
+	}
+	for _, tt := range tests {
+		snap := func() string {
+			b, _ := json.Marshal(tt.val)
+			return string(b)
+		}
+		vu := giv.NewViewUndo(tt.val)
+		if vu == nil {
+			t.Fatalf("%s: no ViewUndo", tt.name)
+		}
+		if vu.Save("none") {
+			t.Errorf("%s: saved without a change", tt.name)
+		}
+		snaps := []string{snap()}
+		for i, ed := range tt.edits {
+			ed()
+			if !vu.Save("edit") {
+				t.Errorf("%s: edit %d not saved", tt.name, i)
+			}
+			snaps = append(snaps, snap())
+		}
+		check := func(op string, ok bool, want int) {
+			t.Helper()
+			if !ok {
+				t.Errorf("%s: %s failed", tt.name, op)
+			}
+			if got := snap(); got != snaps[want] {
+				t.Errorf("%s: after %s: %s, want %s", tt.name, op, got, snaps[want])
+			}
+		}
+		_, ok := vu.Undo()
+		check("Undo", ok, 1)
+		_, ok = vu.Undo()
+		check("second Undo", ok, 0)
+		if vu.CanUndo() {
+			t.Errorf("%s: can undo after undoing all", tt.name)
+		}
+		_, ok = vu.Redo()
+		check("Redo", ok, 1)
+		_, ok = vu.Redo()
+		check("second Redo", ok, 2)
+		if vu.CanRedo() {
+			t.Errorf("%s: can redo after redoing all", tt.name)
+		}
+		_, ok = vu.UndoTo(0)
+		check("UndoTo(0)", ok, 0)
+		_, ok = vu.RedoTo(0)
+		check("RedoTo(0)", ok, 1)
+		_, ok = vu.RedoTo(1)
+		check("RedoTo(1)", ok, 2)
+	}
+	if stru.Note != "kept" {
+		t.Errorf("field not saved in JSON changed by undo: %q", stru.Note)
+	}
+}
+
+func TestNewViewUndoUnsupported(t *testing.T) {
+	var nilp *vuItem
+	tests := []struct {
+		name string
+		val  any
+	}{
+		{"nil", nil},
+		{"nil pointer", nilp},
+		{"struct value", vuItem{}},
+		{"slice value", []int{1}},
+		{"interface elements", &[]any{1}},
+		{"int", new(int)},
+	}
+	for _, tt := range tests {
+		if vu := giv.NewViewUndo(tt.val); vu != nil {
+			t.Errorf("%s: got a ViewUndo, want nil", tt.name)
+		}
+	}
+}
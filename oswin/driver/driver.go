@@ -10,7 +10,21 @@
 // Package driver provides the default driver for accessing a screen.
 package driver
 
-import "goki.dev/gi/v2/oswin"
+import (
+	"os"
+
+	"goki.dev/gi/v2/oswin"
+	"goki.dev/gi/v2/oswin/driver/headless"
+)
+
+// Headless selects the in-memory headless driver instead of the default
+// platform driver, for running without a display or GPU (e.g., in CI).
+// It must be set prior to calling Main.  The headless driver is also
+// selected if the GOGI_DRIVER environment variable is set to "headless",
+// and it is the only driver when building with the headless build tag
+// (go build -tags headless), which leaves out the platform drivers, so
+// that they do not need to be linked.
+var Headless = false
 
 // TODO: figure out what to say about the responsibility for users of this
 // package to check any implicit dependencies' LICENSEs. For example, the
@@ -24,5 +38,9 @@ import "goki.dev/gi/v2/oswin"
 // specific libraries require being on 'the main thread'. It returns when f
 // returns.
 func Main(f func(oswin.App)) {
+	if Headless || os.Getenv("GOGI_DRIVER") == "headless" {
+		headless.Main(f)
+		return
+	}
 	driverMain(f)
 }
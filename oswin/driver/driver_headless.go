@@ -0,0 +1,18 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build headless
+
+package driver
+
+import (
+	"goki.dev/gi/v2/oswin"
+	"goki.dev/gi/v2/oswin/driver/headless"
+)
+
+// with the headless build tag, the headless driver is the only one, so
+// that the platform drivers and their system libraries are not linked
+func driverMain(f func(oswin.App)) {
+	headless.Main(f)
+}
@@ -0,0 +1,494 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package headless implements the oswin interfaces entirely in memory,
+// without any display server or GPU, so that gi apps can run in CI and
+// batch jobs.  Windows render into an in-memory image.RGBA frame
+// (see oswin.OffscreenWindow), and all events are delivered through the
+// usual event deque, so gi.Window event processing works unchanged.
+package headless
+
+import (
+	"image"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"goki.dev/gi/v2/oswin"
+	"goki.dev/gi/v2/oswin/clip"
+	"goki.dev/gi/v2/oswin/cursor"
+	"goki.dev/gi/v2/oswin/window"
+)
+
+var (
+	// ScreenSize is the size of the single virtual screen, in pixels.
+	// Must be set prior to calling Main.
+	ScreenSize = image.Point{1920, 1080}
+
+	// ScreenDPI is the physical DPI of the virtual screen.  The default
+	// of 96 results in standard pixel units being the same as raw pixels.
+	// Must be set prior to calling Main.
+	ScreenDPI = float32(96)
+
+	// PrefsDir is the preferences directory used by the app.  If empty,
+	// a new temporary directory is used, so that headless runs never read
+	// or modify the user's actual preferences -- it is removed when the
+	// app quits.
+	PrefsDir = ""
+)
+
+var theApp = &appImpl{
+	winlist:      make([]*windowImpl, 0),
+	screens:      make([]*oswin.Screen, 0),
+	name:         "GoGi",
+	quitCloseCnt: make(chan struct{}),
+}
+
+var _ oswin.App = theApp
+
+type appImpl struct {
+	mu            sync.Mutex
+	mainQueue     chan funcRun
+	stopFunc      func() // stops the current main loop, see stopMain
+	winlist       []*windowImpl
+	screens       []*oswin.Screen
+	ctxtwin       *windowImpl // context window, dynamically set, for e.g., pointer and other methods
+	name          string
+	about         string
+	openFiles     []string
+	prefsDir      string
+	prefsTemp     bool          // prefsDir is a temporary directory, removed on quit
+	quitting      bool          // set to true when quitting and closing windows
+	quitCloseCnt  chan struct{} // counts windows to make sure all are closed before done
+	quitReqFunc   func()
+	quitCleanFunc func()
+}
+
+var mainCallback func(oswin.App)
+
+// Main is called from main thread when it is time to start running the
+// main loop.  When function f returns, the app ends automatically.
+func Main(f func(oswin.App)) {
+	mainCallback = f
+	theApp.GetScreens()
+	oswin.TheApp = theApp
+	theApp.mainQueue = make(chan funcRun)
+	// each run has its own stop function, so that a late stop of an
+	// earlier run has no effect on a later one
+	done := make(chan struct{})
+	once := &sync.Once{}
+	stop := func() {
+		once.Do(func() {
+			theApp.removePrefsDir()
+			close(done)
+		})
+	}
+	theApp.mu.Lock()
+	theApp.stopFunc = stop
+	theApp.quitting = false
+	theApp.mu.Unlock()
+	go func() {
+		f(theApp)
+		stop()
+	}()
+	theApp.mainLoop(done)
+}
+
+type funcRun struct {
+	f    func()
+	done chan bool
+}
+
+// RunOnMain runs given function on main thread
+func (app *appImpl) RunOnMain(f func()) {
+	if app.mainQueue == nil {
+		f()
+		return
+	}
+	done := make(chan bool)
+	app.mainQueue <- funcRun{f: f, done: done}
+	<-done
+}
+
+// GoRunOnMain runs given function on main thread and returns immediately
+func (app *appImpl) GoRunOnMain(f func()) {
+	go func() {
+		app.mainQueue <- funcRun{f: f, done: nil}
+	}()
+}
+
+// SendEmptyEvent sends an empty, blank event to global event processing
+// system, which has the effect of pushing the system along during cases when
+// the event loop needs to be "pinged" to get things moving along..
+func (app *appImpl) SendEmptyEvent() {
+	if win := app.WindowInFocus(); win != nil {
+		win.SendEmptyEvent()
+	}
+}
+
+// PollEvents tells the main event loop to check for any gui events right now.
+// There are no external events in headless mode, so this just processes any
+// pending functions on the main thread.
+func (app *appImpl) PollEvents() {
+outer:
+	for {
+		select {
+		case f := <-app.mainQueue:
+			f.f()
+			if f.done != nil {
+				f.done <- true
+			}
+		default:
+			break outer
+		}
+	}
+}
+
+// mainLoop runs functions on the main thread until given channel is
+// closed by stopMain.
+func (app *appImpl) mainLoop(done chan struct{}) {
+	for {
+		select {
+		case <-done:
+			return
+		case f := <-app.mainQueue:
+			f.f()
+			if f.done != nil {
+				f.done <- true
+			}
+		}
+	}
+}
+
+// stopMain stops the main loop and thus terminates the app, and removes
+// the temporary prefs directory -- it is called by Quit and when the main
+// function returns, and only has an effect the first time
+func (app *appImpl) stopMain() {
+	app.mu.Lock()
+	stop := app.stopFunc
+	app.mu.Unlock()
+	if stop != nil {
+		stop()
+	}
+}
+
+////////////////////////////////////////////////////////
+//  Screens
+
+// GetScreens configures the single virtual screen, based on
+// ScreenSize and ScreenDPI.
+func (app *appImpl) GetScreens() {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	if len(app.screens) == 0 {
+		app.screens = append(app.screens, &oswin.Screen{})
+	}
+	sc := app.screens[0]
+	sc.ScreenNumber = 0
+	sc.Name = "Headless"
+	sc.Geometry = image.Rectangle{Max: ScreenSize}
+	sc.DevicePixelRatio = 1
+	sc.PixSize = ScreenSize
+	sc.PhysicalDPI = ScreenDPI
+	sc.PhysicalSize.X = int(25.4 * float32(ScreenSize.X) / ScreenDPI)
+	sc.PhysicalSize.Y = int(25.4 * float32(ScreenSize.Y) / ScreenDPI)
+	sc.Depth = 24
+	sc.RefreshRate = 60
+	sc.UpdateLogicalDPI()
+}
+
+func (app *appImpl) NScreens() int {
+	return len(app.screens)
+}
+
+func (app *appImpl) Screen(scrN int) *oswin.Screen {
+	sz := len(app.screens)
+	if scrN < sz {
+		return app.screens[scrN]
+	}
+	return nil
+}
+
+func (app *appImpl) ScreenByName(name string) *oswin.Screen {
+	for _, sc := range app.screens {
+		if sc.Name == name {
+			return sc
+		}
+	}
+	return nil
+}
+
+func (app *appImpl) NoScreens() bool {
+	return false
+}
+
+////////////////////////////////////////////////////////
+//  Window
+
+func (app *appImpl) NewWindow(opts *oswin.NewWindowOptions) (oswin.Window, error) {
+	if len(app.winlist) == 0 && oswin.InitScreenLogicalDPIFunc != nil {
+		oswin.InitScreenLogicalDPIFunc()
+	}
+
+	sc := app.screens[0]
+
+	if opts == nil {
+		opts = &oswin.NewWindowOptions{}
+	}
+	opts.Fixup()
+
+	w := &windowImpl{
+		app:      app,
+		runQueue: make(chan funcRun),
+		winClose: make(chan struct{}),
+		WindowBase: oswin.WindowBase{
+			Titl:        opts.GetTitle(),
+			Flag:        opts.Flags,
+			Pos:         opts.Pos,
+			WnSize:      sc.WinSizeFmPix(opts.Size),
+			PxSize:      opts.Size,
+			DevPixRatio: sc.DevicePixelRatio,
+			PhysDPI:     sc.PhysicalDPI,
+			LogDPI:      sc.LogicalDPI,
+		},
+	}
+
+	app.mu.Lock()
+	for _, ow := range app.winlist {
+		ow.focus(false)
+	}
+	app.winlist = append(app.winlist, w)
+	app.mu.Unlock()
+
+	go w.winLoop() // start window's own dedicated run loop
+
+	// same sequence of initial events as a real window, without any delay
+	w.focus(true)
+	w.sendWindowEvent(window.Paint)
+	w.sendWindowEvent(window.Show)
+	return w, nil
+}
+
+func (app *appImpl) DeleteWin(w *windowImpl) {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	for i, wl := range app.winlist {
+		if wl == w {
+			app.winlist = append(app.winlist[:i], app.winlist[i+1:]...)
+			break
+		}
+	}
+	if app.ctxtwin == w {
+		app.ctxtwin = nil
+	}
+}
+
+func (app *appImpl) NWindows() int {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	return len(app.winlist)
+}
+
+func (app *appImpl) Window(win int) oswin.Window {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	sz := len(app.winlist)
+	if win < sz {
+		return app.winlist[win]
+	}
+	return nil
+}
+
+func (app *appImpl) WindowByName(name string) oswin.Window {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	for _, win := range app.winlist {
+		if win.Name() == name {
+			return win
+		}
+	}
+	return nil
+}
+
+func (app *appImpl) WindowInFocus() oswin.Window {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	for _, win := range app.winlist {
+		if win.IsFocus() {
+			return win
+		}
+	}
+	return nil
+}
+
+func (app *appImpl) ContextWindow() oswin.Window {
+	app.mu.Lock()
+	cw := app.ctxtwin
+	app.mu.Unlock()
+	return cw
+}
+
+////////////////////////////////////////////////////////
+//  App info
+
+func (app *appImpl) Platform() oswin.Platforms {
+	switch runtime.GOOS {
+	case "darwin":
+		return oswin.MacOS
+	case "windows":
+		return oswin.Windows
+	}
+	return oswin.LinuxX11
+}
+
+func (app *appImpl) Name() string {
+	return app.name
+}
+
+func (app *appImpl) SetName(name string) {
+	app.name = name
+}
+
+func (app *appImpl) About() string {
+	return app.about
+}
+
+func (app *appImpl) SetAbout(about string) {
+	app.about = about
+}
+
+func (app *appImpl) OpenFiles() []string {
+	return app.openFiles
+}
+
+// PrefsDir returns a temporary directory that is unique to this process,
+// so that headless runs never read or clobber the user's actual preferences.
+func (app *appImpl) PrefsDir() string {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	if app.prefsDir == "" {
+		if PrefsDir != "" {
+			app.prefsDir = PrefsDir
+			return app.prefsDir
+		}
+		pdir, err := os.MkdirTemp("", "gogi-headless-")
+		if err != nil {
+			pdir = os.TempDir()
+		} else {
+			app.prefsTemp = true
+		}
+		app.prefsDir = pdir
+	}
+	return app.prefsDir
+}
+
+// removePrefsDir removes the prefs directory if it is a temporary one
+// created by PrefsDir
+func (app *appImpl) removePrefsDir() {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	if !app.prefsTemp {
+		return
+	}
+	os.RemoveAll(app.prefsDir)
+	app.prefsDir = ""
+	app.prefsTemp = false
+}
+
+func (app *appImpl) GoGiPrefsDir() string {
+	pdir := filepath.Join(app.PrefsDir(), "GoGi")
+	os.MkdirAll(pdir, 0755)
+	return pdir
+}
+
+func (app *appImpl) AppPrefsDir() string {
+	pdir := filepath.Join(app.PrefsDir(), app.Name())
+	os.MkdirAll(pdir, 0755)
+	return pdir
+}
+
+func (app *appImpl) FontPaths() []string {
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{"/System/Library/Fonts", "/Library/Fonts"}
+	case "windows":
+		return []string{"C:\\Windows\\Fonts"}
+	}
+	return []string{"/usr/share/fonts/truetype"}
+}
+
+func (app *appImpl) OpenURL(url string) {
+	// no-op
+}
+
+func (app *appImpl) ClipBoard(win oswin.Window) clip.Board {
+	app.mu.Lock()
+	app.ctxtwin = win.(*windowImpl)
+	app.mu.Unlock()
+	return &theClip
+}
+
+func (app *appImpl) Cursor(win oswin.Window) cursor.Cursor {
+	app.mu.Lock()
+	app.ctxtwin = win.(*windowImpl)
+	app.mu.Unlock()
+	return &theCursor
+}
+
+func (app *appImpl) SetQuitReqFunc(fun func()) {
+	app.quitReqFunc = fun
+}
+
+func (app *appImpl) SetQuitCleanFunc(fun func()) {
+	app.quitCleanFunc = fun
+}
+
+func (app *appImpl) QuitReq() {
+	if app.quitting {
+		return
+	}
+	if app.quitReqFunc != nil {
+		app.quitReqFunc()
+	} else {
+		app.Quit()
+	}
+}
+
+func (app *appImpl) IsQuitting() bool {
+	return app.quitting
+}
+
+func (app *appImpl) QuitClean() {
+	app.quitting = true
+	if app.quitCleanFunc != nil {
+		app.quitCleanFunc()
+	}
+	app.mu.Lock()
+	nwin := len(app.winlist)
+	for i := nwin - 1; i >= 0; i-- {
+		win := app.winlist[i]
+		go win.Close()
+	}
+	app.mu.Unlock()
+	for i := 0; i < nwin; i++ {
+		<-app.quitCloseCnt
+	}
+}
+
+func (app *appImpl) Quit() {
+	if app.quitting {
+		return
+	}
+	app.QuitClean()
+	app.stopMain()
+}
+
+func (app *appImpl) ShowVirtualKeyboard(typ oswin.VirtualKeyboardTypes) {
+	// no-op
+}
+
+func (app *appImpl) HideVirtualKeyboard() {
+	// no-op
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package headless
+
+import (
+	"image"
+	"image/color"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"goki.dev/gi/v2/oswin"
+	"goki.dev/gi/v2/oswin/window"
+)
+
+// runMain runs Main with given function, failing if Main or the function
+// do not return within a few seconds (Main can return first, on Quit)
+func runMain(t *testing.T, f func(app oswin.App)) {
+	t.Helper()
+	done, fdone := make(chan struct{}), make(chan struct{})
+	go func() {
+		Main(func(app oswin.App) {
+			defer close(fdone)
+			f(app)
+		})
+		close(done)
+	}()
+	for _, ch := range []chan struct{}{done, fdone} {
+		select {
+		case <-ch:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Main did not return")
+		}
+	}
+}
+
+// waitReturn reports an error if given function does not return within a
+// few seconds -- it can be called from the Main function
+func waitReturn(t *testing.T, what string, f func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		f()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Errorf("%s did not return", what)
+	}
+}
+
+// winEvents returns the actions of the window events pending for given window
+func winEvents(w oswin.Window) []window.Actions {
+	var acts []window.Actions
+	for {
+		ev, ok := w.(*windowImpl).PollEvent()
+		if !ok {
+			return acts
+		}
+		if we, ok := ev.(*window.Event); ok {
+			acts = append(acts, we.Action)
+		}
+	}
+}
+
+func TestMainReturn(t *testing.T) {
+	var pdir string
+	runMain(t, func(app oswin.App) {
+		pdir = app.PrefsDir()
+		if _, err := os.Stat(app.AppPrefsDir()); err != nil {
+			t.Errorf("app prefs dir: %v", err)
+		}
+	})
+	if _, err := os.Stat(pdir); !os.IsNotExist(err) {
+		t.Errorf("temporary prefs dir %v not removed: %v", pdir, err)
+	}
+	// quitting after the main function returned must not block
+	waitReturn(t, "Quit after Main", theApp.Quit)
+}
+
+func TestMainQuit(t *testing.T) {
+	var closed int32
+	runMain(t, func(app oswin.App) {
+		for i := 0; i < 2; i++ {
+			w, err := app.NewWindow(&oswin.NewWindowOptions{Title: "win", Size: image.Pt(200, 100)})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			w.SetCloseCleanFunc(func(win oswin.Window) { atomic.AddInt32(&closed, 1) })
+		}
+		ran := false
+		app.RunOnMain(func() { ran = true })
+		if !ran {
+			t.Errorf("RunOnMain did not run")
+		}
+		waitReturn(t, "Quit", app.Quit)
+		if !app.IsQuitting() {
+			t.Errorf("not quitting after Quit")
+		}
+	})
+	if closed != 2 {
+		t.Errorf("%d windows closed on quit, want 2", closed)
+	}
+	if n := theApp.NWindows(); n != 0 {
+		t.Errorf("%d windows left after quit", n)
+	}
+}
+
+func TestPrefsDirSet(t *testing.T) {
+	defer func(pd string) { PrefsDir = pd }(PrefsDir)
+	PrefsDir = t.TempDir()
+	runMain(t, func(app oswin.App) {
+		if pd := app.PrefsDir(); pd != PrefsDir {
+			t.Errorf("PrefsDir = %v, want %v", pd, PrefsDir)
+		}
+	})
+	if _, err := os.Stat(PrefsDir); err != nil {
+		t.Errorf("given prefs dir removed: %v", err)
+	}
+	theApp.prefsDir = ""
+}
+
+func TestWindow(t *testing.T) {
+	runMain(t, func(app oswin.App) {
+		w1, _ := app.NewWindow(&oswin.NewWindowOptions{Title: "one", Size: image.Pt(200, 100)})
+		w2, _ := app.NewWindow(&oswin.NewWindowOptions{Title: "two", Size: image.Pt(300, 150)})
+		defer w1.Close()
+		defer w2.Close()
+		if acts := winEvents(w1); len(acts) != 4 || acts[0] != window.Focus || acts[3] != window.DeFocus {
+			t.Errorf("first window events = %v, want focus, paint, show, defocus", acts)
+		}
+		winEvents(w2)
+		if app.WindowInFocus() != w2 {
+			t.Errorf("new window is not in focus")
+		}
+		if sz := w2.Size(); sz != image.Pt(300, 150) {
+			t.Errorf("size = %v, want 300x150", sz)
+		}
+		w1.Raise()
+		if app.WindowInFocus() != w1 {
+			t.Errorf("raised window is not in focus")
+		}
+		if acts := winEvents(w2); len(acts) != 1 || acts[0] != window.DeFocus {
+			t.Errorf("other window events on raise = %v, want defocus", acts)
+		}
+		w1.SetSize(image.Pt(120, 80))
+		if sz := w1.Size(); sz != image.Pt(120, 80) {
+			t.Errorf("size after SetSize = %v, want 120x80", sz)
+		}
+
+		ow := w1.(oswin.OffscreenWindow)
+		if ow.Image() != nil {
+			t.Errorf("image before publishing")
+		}
+		img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+		img.SetRGBA(1, 1, color.RGBA{255, 0, 0, 255})
+		ow.PublishImage(img)
+		cp := ow.Image()
+		img.SetRGBA(1, 1, color.RGBA{})
+		if cp == nil || cp.RGBAAt(1, 1) != (color.RGBA{255, 0, 0, 255}) {
+			t.Errorf("Image is not a copy of the published image")
+		}
+
+		w2.Close()
+		if !w2.IsClosed() || app.NWindows() != 1 {
+			t.Errorf("closed = %v with %d windows, want true with 1", w2.IsClosed(), app.NWindows())
+		}
+	})
+}
@@ -0,0 +1,334 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package headless
+
+import (
+	"image"
+	"sync"
+
+	"goki.dev/gi/v2/gist"
+	"goki.dev/gi/v2/oswin"
+	"goki.dev/gi/v2/oswin/driver/internal/event"
+	"goki.dev/gi/v2/oswin/window"
+	"goki.dev/ki/v2/bitflag"
+	"goki.dev/vgpu/v2/vdraw"
+)
+
+type windowImpl struct {
+	oswin.WindowBase
+	event.Deque
+	app            *appImpl
+	img            *image.RGBA // last published image
+	closed         bool
+	runQueue       chan funcRun
+	winClose       chan struct{}
+	mu             sync.Mutex
+	imgMu          sync.Mutex
+	closeReqFunc   func(win oswin.Window)
+	closeCleanFunc func(win oswin.Window)
+}
+
+var _ oswin.OffscreenWindow = &windowImpl{}
+
+// Handle returns the driver-specific handle for this window,
+// which is just the window itself in headless mode.
+func (w *windowImpl) Handle() any {
+	return w
+}
+
+func (w *windowImpl) OSHandle() uintptr {
+	return 0
+}
+
+// Drawer returns nil, as there is no GPU surface for a headless window:
+// see PublishImage for how the window image is updated.
+func (w *windowImpl) Drawer() *vdraw.Drawer {
+	return nil
+}
+
+func (w *windowImpl) MainMenu() oswin.MainMenu {
+	return nil
+}
+
+// PublishImage sets the current full-window image.
+func (w *windowImpl) PublishImage(img *image.RGBA) {
+	w.imgMu.Lock()
+	w.img = img
+	w.imgMu.Unlock()
+}
+
+// Image returns a copy of the most recently published window image.
+func (w *windowImpl) Image() *image.RGBA {
+	w.imgMu.Lock()
+	defer w.imgMu.Unlock()
+	if w.img == nil {
+		return nil
+	}
+	cp := image.NewRGBA(w.img.Rect)
+	copy(cp.Pix, w.img.Pix)
+	return cp
+}
+
+func (w *windowImpl) IsClosed() bool {
+	if w == nil {
+		return true
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.closed
+}
+
+func (w *windowImpl) IsVisible() bool {
+	if w == nil {
+		return false
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return !w.closed && !w.IsMinimized()
+}
+
+// for sending window.Event's
+func (w *windowImpl) sendWindowEvent(act window.Actions) {
+	winEv := window.Event{
+		Action: act,
+	}
+	winEv.Init()
+	w.Send(&winEv)
+}
+
+// winLoop is the window's own locked processing loop.
+func (w *windowImpl) winLoop() {
+	for {
+		select {
+		case <-w.winClose:
+			return
+		case f := <-w.runQueue:
+			f.f()
+			if f.done != nil {
+				f.done <- true
+			}
+		}
+	}
+}
+
+// RunOnWin runs given function on the window's unique locked thread.
+func (w *windowImpl) RunOnWin(f func()) {
+	if w.IsClosed() {
+		return
+	}
+	done := make(chan bool)
+	w.runQueue <- funcRun{f: f, done: done}
+	<-done
+}
+
+// GoRunOnWin runs given function on window's unique locked thread and returns immediately
+func (w *windowImpl) GoRunOnWin(f func()) {
+	if w.IsClosed() {
+		return
+	}
+	go func() {
+		w.runQueue <- funcRun{f: f, done: nil}
+	}()
+}
+
+// SendEmptyEvent sends an empty, blank event to this window, which just has
+// the effect of pushing the system along during cases when the window
+// event loop needs to be "pinged" to get things moving along..
+func (w *windowImpl) SendEmptyEvent() {
+	if w.IsClosed() {
+		return
+	}
+	oswin.SendCustomEvent(w, nil)
+}
+
+////////////////////////////////////////////////////////////
+//  Geom etc
+
+func (w *windowImpl) Screen() *oswin.Screen {
+	return w.app.screens[0]
+}
+
+func (w *windowImpl) Size() image.Point {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.PxSize
+}
+
+func (w *windowImpl) WinSize() image.Point {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.WnSize
+}
+
+func (w *windowImpl) Position() image.Point {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.Pos
+}
+
+func (w *windowImpl) Insets() gist.SideFloats {
+	return gist.NewSideFloats()
+}
+
+func (w *windowImpl) PhysicalDPI() float32 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.PhysDPI
+}
+
+func (w *windowImpl) LogicalDPI() float32 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.LogDPI
+}
+
+func (w *windowImpl) SetLogicalDPI(dpi float32) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.LogDPI = dpi
+}
+
+func (w *windowImpl) SetTitle(title string) {
+	w.Titl = title
+}
+
+func (w *windowImpl) SetWinSize(sz image.Point) {
+	if w.IsClosed() {
+		return
+	}
+	sc := w.Screen()
+	w.mu.Lock()
+	w.WnSize = sz
+	w.PxSize = sc.WinSizeToPix(sz)
+	w.mu.Unlock()
+	w.sendWindowEvent(window.Resize)
+}
+
+func (w *windowImpl) SetSize(sz image.Point) {
+	if w.IsClosed() {
+		return
+	}
+	sc := w.Screen()
+	w.SetWinSize(sc.WinSizeFmPix(sz))
+}
+
+func (w *windowImpl) SetPos(pos image.Point) {
+	if w.IsClosed() {
+		return
+	}
+	w.mu.Lock()
+	w.Pos = pos
+	w.mu.Unlock()
+	w.sendWindowEvent(window.Move)
+}
+
+func (w *windowImpl) SetGeom(pos image.Point, sz image.Point) {
+	if w.IsClosed() {
+		return
+	}
+	w.SetPos(pos)
+	w.SetSize(sz)
+}
+
+func (w *windowImpl) Raise() {
+	if w.IsClosed() {
+		return
+	}
+	w.app.mu.Lock()
+	for _, ow := range w.app.winlist {
+		if ow != w {
+			ow.focus(false)
+		}
+	}
+	w.app.mu.Unlock()
+	if bitflag.HasAtomic(&w.Flag, int(oswin.Minimized)) {
+		bitflag.ClearAtomic(&w.Flag, int(oswin.Minimized))
+		w.sendWindowEvent(window.Minimize)
+	}
+	w.focus(true)
+}
+
+func (w *windowImpl) Minimize() {
+	if w.IsClosed() {
+		return
+	}
+	bitflag.SetAtomic(&w.Flag, int(oswin.Minimized))
+	bitflag.ClearAtomic(&w.Flag, int(oswin.Focus))
+	w.sendWindowEvent(window.Minimize)
+}
+
+// focus sets the focus state of the window, sending the corresponding
+// window event if it changes.
+func (w *windowImpl) focus(focused bool) {
+	if focused == w.IsFocus() {
+		return
+	}
+	if focused {
+		bitflag.ClearAtomic(&w.Flag, int(oswin.Minimized))
+		bitflag.SetAtomic(&w.Flag, int(oswin.Focus))
+		w.sendWindowEvent(window.Focus)
+	} else {
+		bitflag.ClearAtomic(&w.Flag, int(oswin.Focus))
+		w.sendWindowEvent(window.DeFocus)
+	}
+}
+
+func (w *windowImpl) SetCloseReqFunc(fun func(win oswin.Window)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closeReqFunc = fun
+}
+
+func (w *windowImpl) SetCloseCleanFunc(fun func(win oswin.Window)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closeCleanFunc = fun
+}
+
+func (w *windowImpl) CloseReq() {
+	if theApp.quitting {
+		w.Close()
+		return
+	}
+	if w.closeReqFunc != nil {
+		w.closeReqFunc(w)
+	} else {
+		w.Close()
+	}
+}
+
+func (w *windowImpl) CloseClean() {
+	if w.closeCleanFunc != nil {
+		w.closeCleanFunc(w)
+	}
+}
+
+func (w *windowImpl) Close() {
+	// this is actually the final common pathway for closing here
+	if w.IsClosed() {
+		return
+	}
+	w.winClose <- struct{}{} // break out of run loop
+	w.CloseClean()
+	w.sendWindowEvent(window.Close)
+	theApp.DeleteWin(w)
+	w.mu.Lock()
+	w.closed = true
+	w.mu.Unlock()
+	if w.DestroyGPUfunc != nil {
+		w.DestroyGPUfunc()
+	}
+	if theApp.quitting {
+		theApp.quitCloseCnt <- struct{}{}
+	}
+}
+
+func (w *windowImpl) SetMousePos(x, y float64) {
+	// no-op
+}
+
+func (w *windowImpl) SetCursorEnabled(enabled, raw bool) {
+	// no-op
+}
@@ -72,6 +72,20 @@ type Event struct {
 // generally appropriate for most uses
 type ChordEvent struct {
 	Event
+
+	// Seq is the full multi-key chord sequence (e.g., "Control+X Control+S")
+	// completed by this chord, if any -- set by the window dispatching the
+	// event, and returned by Chord in place of the final chord alone
+	Seq Chord `json:"-"`
+}
+
+// Chord returns the chord of this event, or the full multi-key chord
+// sequence that it completes, if any (see Seq)
+func (ev *ChordEvent) Chord() Chord {
+	if ev.Seq != "" {
+		return ev.Seq
+	}
+	return ev.Event.Chord()
 }
 
 func (ev *Event) String() string {
@@ -162,6 +176,29 @@ func (ch Chord) Decode() (r rune, mods int32, err error) {
 	return
 }
 
+// DecodeCode decodes a chord string into rune, key code and modifiers (set
+// as bit flags), handling both single printable runes and the code names
+// (without the "Code" prefix) that Event.Chord generates for non-printable
+// keys, e.g., "Control+ReturnEnter".  The rune for a code name is taken from
+// CodeRuneMap, and is 0 for keys without a rune.
+func (ch Chord) DecodeCode() (r rune, code Codes, mods int32, err error) {
+	cs := string(ch)
+	mods, cs = ModsFmString(cs)
+	rs := ([]rune)(cs)
+	if len(rs) == 1 {
+		r = rs[0]
+		code = RuneCode(r)
+		return
+	}
+	code = CodeFromString(cs)
+	if code == CodeUnknown {
+		err = fmt.Errorf("gi.oswin.key.DecodeCode: unknown key code name: %v from chord: %v\n", cs, ch)
+		return
+	}
+	r = CodeRuneMap[code]
+	return
+}
+
 // Shortcut transforms chord string into short form suitable for display to users
 func (ch Chord) Shortcut() string {
 	cs := strings.Replace(string(ch), "Control+", "^", -1) // ⌃ doesn't look as good
@@ -192,6 +229,52 @@ func (ch Chord) OSShortcut() Chord {
 	return Chord(sc)
 }
 
+// SeqSep separates the individual chords of a multi-key chord sequence,
+// e.g., "Control+X Control+S" -- an unmodified space key within a
+// sequence is written as "Spacebar" so it cannot be confused with the separator
+const SeqSep = " "
+
+// IsSeq returns true if this chord is a multi-key sequence of chords
+func (ch Chord) IsSeq() bool {
+	return len(ch) > 1 && strings.Contains(string(ch), SeqSep)
+}
+
+// Chords splits a chord sequence into its individual chords -- a single
+// chord returns a slice of just itself
+func (ch Chord) Chords() []Chord {
+	if !ch.IsSeq() {
+		return []Chord{ch}
+	}
+	fs := strings.Fields(string(ch))
+	chs := make([]Chord, len(fs))
+	for i, f := range fs {
+		if f == "Spacebar" {
+			f = " "
+		}
+		chs[i] = Chord(f)
+	}
+	return chs
+}
+
+// Seq joins the given chords into a multi-key chord sequence, the
+// inverse of Chords
+func Seq(chs ...Chord) Chord {
+	if len(chs) == 1 {
+		return chs[0]
+	}
+	ss := make([]string, 0, len(chs))
+	for _, c := range chs {
+		if c == "" {
+			continue
+		}
+		if c == " " {
+			c = "Spacebar"
+		}
+		ss = append(ss, string(c))
+	}
+	return Chord(strings.Join(ss, SeqSep))
+}
+
 // CodeIsModifier returns true if given code is a modifier key
 func CodeIsModifier(c Codes) bool {
 	if c >= CodeLeftControl && c <= CodeRightMeta {
@@ -498,3 +581,52 @@ var CodeRuneMap = map[Codes]rune{
 	CodeKeypadFullStop:    '.',
 	CodeKeypadEqualSign:   '=',
 }
+
+// codeNameMap maps from code names (without "Code" prefix) to Codes
+var codeNameMap = func() map[string]Codes {
+	nm := make(map[string]Codes)
+	for c := CodeA; c <= CodeRightMeta; c++ {
+		cs := c.String()
+		if !strings.HasPrefix(cs, "Codes(") {
+			nm[strings.TrimPrefix(cs, "Code")] = c
+		}
+	}
+	nm["Compose"] = CodeCompose
+	return nm
+}()
+
+// CodeFromString returns the Code for given code name, with or without the
+// "Code" prefix (e.g., "ReturnEnter" or "CodeReturnEnter").
+// Returns CodeUnknown if not found.
+func CodeFromString(s string) Codes {
+	if c, ok := codeNameMap[strings.TrimPrefix(s, "Code")]; ok {
+		return c
+	}
+	return CodeUnknown
+}
+
+// runeCodeMap is the inverse of CodeRuneMap for the main keyboard keys
+// (before the keypad), built in code order so that the lowest code wins
+// for any rune generated by more than one key
+var runeCodeMap = func() map[rune]Codes {
+	rm := make(map[rune]Codes)
+	for c := CodeA; c < CodeKeypadNumLock; c++ {
+		if r, ok := CodeRuneMap[c]; ok {
+			if _, has := rm[r]; !has {
+				rm[r] = c
+			}
+		}
+	}
+	return rm
+}()
+
+// RuneCode returns the Code for the key that generates given rune on a
+// standard US keyboard, using the inverse of CodeRuneMap for the main
+// keyboard keys (letters are matched regardless of case).
+// Returns CodeUnknown if none.
+func RuneCode(r rune) Codes {
+	if c, ok := runeCodeMap[unicode.ToUpper(r)]; ok {
+		return c
+	}
+	return CodeUnknown
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package key
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeCode(t *testing.T) {
+	ctrl := int32(1 << uint32(Control))
+	tests := []struct {
+		chord Chord
+		r     rune
+		code  Codes
+		mods  int32
+		err   bool
+	}{
+		{"a", 'a', CodeA, 0, false},
+		{"Control+S", 'S', CodeS, ctrl, false},
+		{"Shift+Control+P", 'P', CodeP, ctrl | 1<<uint32(Shift), false},
+		{"Control+ReturnEnter", 0, CodeReturnEnter, ctrl, false},
+		{"Control+Spacebar", ' ', CodeSpacebar, ctrl, false},
+		{"Control+NotAKey", 0, CodeUnknown, ctrl, true},
+	}
+	for _, tt := range tests {
+		r, code, mods, err := tt.chord.DecodeCode()
+		if (err != nil) != tt.err {
+			t.Errorf("%q: error %v, want error %v", tt.chord, err, tt.err)
+		}
+		if err != nil {
+			continue
+		}
+		if r != tt.r || code != tt.code || mods != tt.mods {
+			t.Errorf("%q: got %q %v %b, want %q %v %b", tt.chord, r, code, mods, tt.r, tt.code, tt.mods)
+		}
+		// round trip through the event chord
+		ev := &Event{Rune: r, Code: code, Modifiers: mods}
+		if ch := ev.Chord(); ch != tt.chord {
+			t.Errorf("%q: event chord %q", tt.chord, ch)
+		}
+	}
+}
+
+func TestRuneCode(t *testing.T) {
+	tests := []struct {
+		r    rune
+		code Codes
+	}{
+		{'a', CodeA},
+		{'Z', CodeZ},
+		{'0', Code0},
+		{' ', CodeSpacebar},
+		{'\t', CodeTab},
+		{'-', CodeHyphenMinus},
+		{'/', CodeSlash},
+		{'.', CodeFullStop},
+		{'=', CodeEqualSign},
+		{'*', CodeUnknown}, // keypad only
+		{'+', CodeUnknown},
+		{'é', CodeUnknown},
+	}
+	// the same rune must give the same code every time
+	for i := 0; i < 20; i++ {
+		for _, tt := range tests {
+			if code := RuneCode(tt.r); code != tt.code {
+				t.Fatalf("%q: got %v, want %v", tt.r, code, tt.code)
+			}
+		}
+	}
+	for c, r := range CodeRuneMap {
+		if c < CodeKeypadNumLock && RuneCode(r) != c {
+			t.Errorf("%q: got %v, want %v", r, RuneCode(r), c)
+		}
+	}
+}
+
+func TestChordSeq(t *testing.T) {
+	tests := []struct {
+		seq    Chord
+		chords []Chord
+	}{
+		{"Control+X", []Chord{"Control+X"}},
+		{" ", []Chord{" "}},
+		{"Control+X Control+S", []Chord{"Control+X", "Control+S"}},
+		{"Control+X Spacebar", []Chord{"Control+X", " "}},
+		{"Control+C Control+X K", []Chord{"Control+C", "Control+X", "K"}},
+	}
+	for _, tt := range tests {
+		if isSeq := len(tt.chords) > 1; tt.seq.IsSeq() != isSeq {
+			t.Errorf("%q: IsSeq = %v, want %v", tt.seq, !isSeq, isSeq)
+		}
+		chs := tt.seq.Chords()
+		if !reflect.DeepEqual(chs, tt.chords) {
+			t.Errorf("%q: Chords = %q, want %q", tt.seq, chs, tt.chords)
+		}
+		if seq := Seq(chs...); seq != tt.seq {
+			t.Errorf("%q: Seq of its chords = %q", tt.seq, seq)
+		}
+	}
+	if seq := Seq("", "Control+X", "", "Control+S"); seq != "Control+X Control+S" {
+		t.Errorf("Seq skipping empty chords = %q", seq)
+	}
+}
+
+func TestChordEventSeq(t *testing.T) {
+	ev := &ChordEvent{Event: Event{Rune: 's', Code: CodeS, Modifiers: 1 << uint32(Control)}}
+	if ch := ev.Chord(); ch != "Control+S" {
+		t.Errorf("Chord = %q, want %q", ch, "Control+S")
+	}
+	ev.Seq = "Control+X Control+S"
+	if ch := ev.Chord(); ch != ev.Seq {
+		t.Errorf("Chord with Seq = %q, want %q", ch, ev.Seq)
+	}
+	if ch := ev.Event.Chord(); ch != "Control+S" {
+		t.Errorf("Event.Chord with Seq = %q, want %q", ch, "Control+S")
+	}
+}
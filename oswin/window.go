@@ -227,6 +227,22 @@ type Window interface {
 	EventDeque
 }
 
+// OffscreenWindow is implemented by Windows that have no GPU surface,
+// such as those from the headless driver, where Drawer() returns nil.
+// In this case, the gi.Window composites its final image in software
+// and publishes it to the window via PublishImage.
+type OffscreenWindow interface {
+	Window
+
+	// PublishImage sets the current full-window image -- the window
+	// retains the image, so a copy should be passed if it will be modified.
+	PublishImage(img *image.RGBA)
+
+	// Image returns a copy of the most recently published window image,
+	// or nil if nothing has been published yet.
+	Image() *image.RGBA
+}
+
 // WindowBase provides a base-level implementation of the generic data aspects
 // of the window, including maintaining the current window size and dpi
 type WindowBase struct {
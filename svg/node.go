@@ -8,7 +8,6 @@ import (
 	"fmt"
 	"image"
 	"reflect"
-	"strings"
 
 	"goki.dev/gi/v2/gi"
 	"goki.dev/gi/v2/girl"
@@ -337,10 +336,6 @@ func StyleSVG(gii gi.Node2D) {
 // ApplyCSSSVG applies css styles to given node, using key to select sub-props
 // from overall properties list
 func ApplyCSSSVG(node gi.Node2D, key string, css ki.Props) bool {
-	pntr, ok := node.(gist.Painter)
-	if !ok {
-		return false
-	}
 	pp, got := css[key]
 	if !got {
 		return false
@@ -349,6 +344,16 @@ func ApplyCSSSVG(node gi.Node2D, key string, css ki.Props) bool {
 	if !ok {
 		return false
 	}
+	return ApplyPropsSVG(node, pmap)
+}
+
+// ApplyPropsSVG applies given style properties to given node,
+// returning false if it is not a Painter
+func ApplyPropsSVG(node gi.Node2D, pmap ki.Props) bool {
+	pntr, ok := node.(gist.Painter)
+	if !ok {
+		return false
+	}
 	nb := node.AsNode2D()
 	pc := pntr.Paint()
 
@@ -364,15 +369,12 @@ func ApplyCSSSVG(node gi.Node2D, key string, css ki.Props) bool {
 	return true
 }
 
-// StyleCSS applies css style properties to given SVG node, parsing
-// out type, .class, and #name selectors
+// StyleCSS applies css style properties to given SVG node, for all of the
+// selectors that match the node, in cascade order (see gi.CSSMatchProps)
 func StyleCSS(node gi.Node2D, css ki.Props) {
-	tyn := strings.ToLower(ki.Type(node).Name()) // type is most general, first
-	ApplyCSSSVG(node, tyn, css)
-	cln := "." + strings.ToLower(node.AsNode2D().Class) // then class
-	ApplyCSSSVG(node, cln, css)
-	idnm := "#" + strings.ToLower(node.Name()) // then name
-	ApplyCSSSVG(node, idnm, css)
+	for _, pmap := range gi.CSSMatchProps(css, node) {
+		ApplyPropsSVG(node, pmap)
+	}
 }
 
 func (g *NodeBase) Style2D() {
@@ -28,7 +28,7 @@ import (
 	"strings"
 	"sync"
 
-	"goki.dev/gi/v2/giv"
+	"github.com/c2h5oh/datasize"
 	"goki.dev/gi/v2/giv/textbuf"
 )
 
@@ -192,7 +192,7 @@ func (um *Mgr) SaveState(nr *Rec, idx int, state []string) {
 // This does NOT get the lock -- may rarely be inaccurate but is used for
 // gui enabling so not such a big deal.
 func (um *Mgr) HasUndoAvail() bool {
-	return um.Idx >= 0
+	return um.Idx >= 0 && len(um.Recs) > 0
 }
 
 // HasRedoAvail returns true if there is at least one redo record available.
@@ -263,7 +263,7 @@ func (um *Mgr) Redo() (action, data string, state []string) {
 // returning nil if already at end of saved records.
 func (um *Mgr) RedoTo(idx int) (action, data string, state []string) {
 	um.Mu.Lock()
-	if idx >= len(um.Recs)-1 || idx <= 0 {
+	if idx >= len(um.Recs)-1 || idx < 0 {
 		um.Mu.Unlock()
 		return
 	}
@@ -287,7 +287,10 @@ func (um *Mgr) Reset() {
 // UndoList returns the list actions in order from the most recent back in time
 // suitable for a menu of actions to undo.
 func (um *Mgr) UndoList() []string {
-	al := make([]string, um.Idx)
+	if um.Idx < 0 || um.Idx >= len(um.Recs) {
+		return nil
+	}
+	al := make([]string, um.Idx+1)
 	for i := um.Idx; i >= 0; i-- {
 		al[um.Idx-i] = um.Recs[i].Action
 	}
@@ -336,9 +339,9 @@ func (um *Mgr) MemStats(details bool) string {
 		mem := r.MemUsed()
 		sum += mem
 		if details {
-			sb.WriteString(fmt.Sprintf("%d\t%s\tmem:%s\n", i, r.Action, giv.FileSize(mem).String()))
+			sb.WriteString(fmt.Sprintf("%d\t%s\tmem:%s\n", i, r.Action, datasize.ByteSize(mem).HumanReadable()))
 		}
 	}
-	sb.WriteString(fmt.Sprintf("Total: %s\n", giv.FileSize(sum).String()))
+	sb.WriteString(fmt.Sprintf("Total: %s\n", datasize.ByteSize(sum).HumanReadable()))
 	return sb.String()
 }
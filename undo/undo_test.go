@@ -0,0 +1,74 @@
+// Copyright (c) 2023, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package undo
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMgr(t *testing.T) {
+	um := &Mgr{}
+	if um.HasUndoAvail() || um.HasRedoAvail() {
+		t.Errorf("new Mgr: undo %v, redo %v available", um.HasUndoAvail(), um.HasRedoAvail())
+	}
+	if ul := um.UndoList(); len(ul) != 0 {
+		t.Errorf("new Mgr: UndoList = %v, want none", ul)
+	}
+	um.Save("a", "", []string{"0"})
+	if !um.HasUndoAvail() {
+		t.Errorf("no undo available after Save")
+	}
+	if ul := um.UndoList(); !slices.Equal(ul, []string{"a"}) {
+		t.Errorf("UndoList = %v, want [a]", ul)
+	}
+	um.Save("b", "", []string{"0", "1"})
+	um.Save("c", "", []string{"2"})
+	if ul := um.UndoList(); !slices.Equal(ul, []string{"c", "b", "a"}) {
+		t.Errorf("UndoList = %v, want [c b a]", ul)
+	}
+
+	if !um.MustSaveUndoStart() {
+		t.Fatalf("MustSaveUndoStart = false at the end of the records")
+	}
+	um.SaveUndoStart([]string{"3"})
+	act, _, st := um.Undo()
+	if act != "c" || !slices.Equal(st, []string{"2"}) {
+		t.Errorf("Undo = %v, %v, want c, [2]", act, st)
+	}
+	if ul, rl := um.UndoList(), um.RedoList(); !slices.Equal(ul, []string{"b", "a"}) || !slices.Equal(rl, []string{"c"}) {
+		t.Errorf("after Undo: UndoList = %v, RedoList = %v, want [b a], [c]", ul, rl)
+	}
+
+	act, _, st = um.UndoTo(0)
+	if act != "a" || !slices.Equal(st, []string{"0"}) {
+		t.Errorf("UndoTo(0) = %v, %v, want a, [0]", act, st)
+	}
+	if um.HasUndoAvail() || !um.HasRedoAvail() {
+		t.Errorf("after UndoTo(0): undo %v, redo %v available, want false, true", um.HasUndoAvail(), um.HasRedoAvail())
+	}
+	if ul, rl := um.UndoList(), um.RedoList(); len(ul) != 0 || !slices.Equal(rl, []string{"a", "b", "c"}) {
+		t.Errorf("after UndoTo(0): UndoList = %v, RedoList = %v, want [], [a b c]", ul, rl)
+	}
+
+	act, _, st = um.RedoTo(0)
+	if act != "a" || !slices.Equal(st, []string{"0", "1"}) {
+		t.Errorf("RedoTo(0) = %v, %v, want a, [0 1]", act, st)
+	}
+	act, _, st = um.Redo()
+	if act != "b" || !slices.Equal(st, []string{"2"}) {
+		t.Errorf("Redo = %v, %v, want b, [2]", act, st)
+	}
+	act, _, st = um.RedoTo(2)
+	if act != "c" || !slices.Equal(st, []string{"3"}) {
+		t.Errorf("RedoTo(2) = %v, %v, want c, [3]", act, st)
+	}
+	if um.HasRedoAvail() {
+		t.Errorf("redo available after redoing all")
+	}
+	if act, _, st = um.RedoTo(3); act != "" || st != nil {
+		t.Errorf("RedoTo past the end = %v, %v, want nothing", act, st)
+	}
+}